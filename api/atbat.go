@@ -0,0 +1,165 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/helpers"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/scoring"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"net/http"
+)
+
+// RecordPitchRequestBody represents one pitch to the batter due up.
+type RecordPitchRequestBody struct {
+	Type string `json:"type" binding:"required,oneof=ball called_strike swinging_strike foul in_play hit_by_pitch"`
+}
+
+// GetAtbatRequest addresses one at-bat in a game.
+type GetAtbatRequest struct {
+	ID      string `uri:"id" binding:"required,uuid"`
+	AtbatID string `uri:"atbat_id" binding:"required,uuid"`
+}
+
+// AtbatResponse represents an at-bat with its pitches and runner movement in order.
+type AtbatResponse struct {
+	db.Atbat
+	PitchList []db.Pitch       `json:"pitch_list"`
+	Runners   []db.AtbatRunner `json:"runners"`
+}
+
+// RecordPitch records a pitch event in a game in progress. The batter and pitcher are whoever the
+// play-by-play has up and on the mound. The count is kept from the events, and ball four, strike three
+// or a hit batter ends the plate appearance; a ball in play waits for the play to be recorded.
+// Only coaches and admins may score games.
+func (s *Server) RecordPitch(context *gin.Context) {
+	var req GetGameRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var body RecordPitchRequestBody
+	if err := context.ShouldBindJSON(&body); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	s.recordGameEvent(context, uuid.MustParse(req.ID), scoring.Event{
+		Type:  scoring.EventPitch,
+		Pitch: &scoring.Pitch{Type: util.PitchType(body.Type)},
+	})
+}
+
+// ListGameAtbats lists a game's at-bats in the order they happened, leaving out those of batters
+// who hide their stats from the caller.
+func (s *Server) ListGameAtbats(context *gin.Context) {
+	var req GetGameRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	game, err := s.store.GetGame(context, uuid.MustParse(req.ID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	atbats, err := s.store.ListGameAtbats(context, game.ID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	hidden, err := s.hiddenParticipants(context, game.ID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+	visible := make([]db.ListGameAtbatsRow, 0, len(atbats))
+	for _, atbat := range atbats {
+		if !hidden[atbat.BatterID] {
+			visible = append(visible, atbat)
+		}
+	}
+
+	context.JSON(http.StatusOK, visible)
+}
+
+// GetAtbat gets one at-bat with every pitch thrown in it and every runner who moved during it.
+// The at-bats of batters who hide their stats from the caller are forbidden.
+func (s *Server) GetAtbat(context *gin.Context) {
+	var req GetAtbatRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	atbat, err := s.store.GetAtbat(context, uuid.MustParse(req.AtbatID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+	if atbat.GameID != uuid.MustParse(req.ID) {
+		context.JSON(http.StatusNotFound, helpers.ErrorResponse(sql.ErrNoRows))
+		return
+	}
+
+	hidden, err := s.hiddenParticipants(context, atbat.GameID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+	if hidden[atbat.BatterID] {
+		context.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	pitches, err := s.store.ListPitches(context, atbat.ID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	runners, err := s.store.ListAtbatRunners(context, atbat.ID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, AtbatResponse{Atbat: atbat, PitchList: pitches, Runners: runners})
+}
+
+// hiddenParticipants returns which of a game's participants hide their stats from the caller
+func (s *Server) hiddenParticipants(context *gin.Context, gameID uuid.UUID) (map[uuid.UUID]bool, error) {
+	participants, err := s.store.ListGameParticipants(context, gameID)
+	if err != nil {
+		return nil, err
+	}
+	playerIDs := make([]uuid.UUID, len(participants))
+	for i, participant := range participants {
+		playerIDs[i] = participant.PlayerID
+	}
+	players, err := s.hiddenStats(context, middleware.GetAuthorizationPayload(context), playerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	hidden := make(map[uuid.UUID]bool, len(participants))
+	for _, participant := range participants {
+		hidden[participant.ID] = players[participant.PlayerID]
+	}
+	return hidden, nil
+}
@@ -0,0 +1,465 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	mockdb "github.com/kwalter26/scoreit-api-go/db/mock"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/scoring"
+	"github.com/kwalter26/scoreit-api-go/security"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestServer_RecordPitch(t *testing.T) {
+	user, _ := createRandomUser(t)
+	game := db.Game{ID: uuid.New(), HomeTeamID: uuid.New(), AwayTeamID: uuid.New(), Status: string(util.GameInProgress)}
+	scheduled := game
+	scheduled.Status = string(util.GameScheduled)
+	final := game
+	final.Status = string(util.GameFinal)
+
+	participants := randomStartingNines(game.ID)
+	batter, pitcher := participants[9], participants[8]
+	threeBalls := []db.GameEvent{
+		recordedEvent(t, game.ID, 1, pitchEvent(util.PitchBall)),
+		recordedEvent(t, game.ID, 2, pitchEvent(util.PitchBall)),
+		recordedEvent(t, game.ID, 3, pitchEvent(util.PitchBall)),
+	}
+
+	expectReplay := func(store *mockdb.MockStore, events []db.GameEvent) {
+		store.EXPECT().
+			GetGame(gomock.Any(), gomock.Eq(game.ID)).
+			Times(1).
+			Return(game, nil)
+		store.EXPECT().
+			ListGameParticipants(gomock.Any(), gomock.Eq(game.ID)).
+			Times(1).
+			Return(participants, nil)
+		store.EXPECT().
+			ListGameEvents(gomock.Any(), gomock.Eq(game.ID)).
+			Times(1).
+			Return(events, nil)
+	}
+
+	testCases := []struct {
+		name          string
+		roles         []security.Role
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "FirstPitch",
+			roles: coachRoles,
+			body:  gin.H{"type": util.PitchBall},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectReplay(store, nil)
+				store.EXPECT().
+					RecordGameEventTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.RecordGameEventTxParams) (db.RecordGameEventTxResult, error) {
+						require.Equal(t, int64(1), arg.Sequence)
+						require.Equal(t, string(scoring.EventPitch), arg.Event.Type)
+						require.Equal(t, user.ID, arg.CreatedBy)
+						require.Len(t, arg.Projection.Innings, 1)
+						require.Len(t, arg.Projection.Atbats, 1)
+
+						atbat := arg.Projection.Atbats[0]
+						require.Equal(t, batter.ID, atbat.BatterID)
+						require.Equal(t, pitcher.ID, atbat.PitcherID)
+						require.Equal(t, string(util.HalfTop), atbat.Half)
+						require.Equal(t, int64(1), atbat.Balls)
+						require.Empty(t, atbat.Result)
+						require.Equal(t, []db.PitchProjection{{Type: string(util.PitchBall), Balls: 1, PitcherID: pitcher.ID, CreatedBy: user.ID}}, atbat.Pitches)
+						return db.RecordGameEventTxResult{Event: db.GameEvent{GameID: game.ID, Sequence: 1}}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp RecordGameEventResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, int64(1), rsp.Event.Sequence)
+				require.Equal(t, int64(1), rsp.State.Balls)
+				require.Equal(t, batter.ID.String(), rsp.State.Batter)
+			},
+		},
+		{
+			name:  "Walk",
+			roles: coachRoles,
+			body:  gin.H{"type": util.PitchBall},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectReplay(store, threeBalls)
+				store.EXPECT().
+					RecordGameEventTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.RecordGameEventTxParams) (db.RecordGameEventTxResult, error) {
+						require.Equal(t, int64(4), arg.Sequence)
+						atbat := arg.Projection.Atbats[0]
+						require.Equal(t, string(util.AtBatWalk), atbat.Result)
+						require.Equal(t, int64(1), atbat.InitBases)
+						require.Len(t, atbat.Pitches, 4)
+						require.Equal(t, threeBalls[0].CreatedBy, atbat.Pitches[0].CreatedBy)
+						require.Equal(t, user.ID, atbat.Pitches[3].CreatedBy)
+						return db.RecordGameEventTxResult{}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp RecordGameEventResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, batter.ID.String(), rsp.State.Bases[0])
+				require.Equal(t, int64(0), rsp.State.Balls)
+				require.Equal(t, participants[10].ID.String(), rsp.State.Batter)
+			},
+		},
+		{
+			name:  "NotInProgress",
+			roles: coachRoles,
+			body:  gin.H{"type": util.PitchBall},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(scheduled, nil)
+				store.EXPECT().
+					ListGameEvents(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+				require.Contains(t, recorder.Body.String(), errGameNotScoring.Error())
+			},
+		},
+		{
+			name:  "Final",
+			roles: coachRoles,
+			body:  gin.H{"type": util.PitchBall},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(final, nil)
+				store.EXPECT().
+					RecordGameEventTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+				require.Contains(t, recorder.Body.String(), errGameFinal.Error())
+			},
+		},
+		{
+			name:  "RecordedConcurrently",
+			roles: coachRoles,
+			body:  gin.H{"type": util.PitchBall},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectReplay(store, threeBalls)
+				store.EXPECT().
+					RecordGameEventTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RecordGameEventTxResult{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+				require.Contains(t, recorder.Body.String(), errGameChanged.Error())
+			},
+		},
+		{
+			name:  "InvalidType",
+			roles: coachRoles,
+			body:  gin.H{"type": "balk"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "NotCoach",
+			roles: security.UserRoles,
+			body:  gin.H{"type": util.PitchBall},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectTeamCoach(store, user.ID)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			buf, err := buildJsonRequest(t, tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/games/%s/pitches", game.ID)
+			request, err := http.NewRequest(http.MethodPost, url, &buf)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, tc.roles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_GetAtbat(t *testing.T) {
+	user, _ := createRandomUser(t)
+	atbat := db.Atbat{ID: uuid.New(), GameID: uuid.New(), BatterID: uuid.New(), Balls: 1, Strikes: 0, Pitches: 1}
+	batter := db.ListGameParticipantsRow{ID: atbat.BatterID, GameID: atbat.GameID, PlayerID: uuid.New()}
+
+	testCases := []struct {
+		name          string
+		gameID        uuid.UUID
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			gameID: atbat.GameID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAtbat(gomock.Any(), gomock.Eq(atbat.ID)).
+					Times(1).
+					Return(atbat, nil)
+				store.EXPECT().
+					ListGameParticipants(gomock.Any(), gomock.Eq(atbat.GameID)).
+					Times(1).
+					Return([]db.ListGameParticipantsRow{batter}, nil)
+				expectStatVisibility(store)
+				store.EXPECT().
+					ListPitches(gomock.Any(), gomock.Eq(atbat.ID)).
+					Times(1).
+					Return([]db.Pitch{{AtbatID: atbat.ID, Number: 1, Type: string(util.PitchBall), Balls: 1}}, nil)
+				store.EXPECT().
+					ListAtbatRunners(gomock.Any(), gomock.Eq(atbat.ID)).
+					Times(1).
+					Return([]db.AtbatRunner{{AtbatID: atbat.ID, Number: 1, RunnerID: uuid.New(), FromBase: 1, ToBase: 2, Reason: string(scoring.ReasonStolenBase)}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp AtbatResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, atbat.ID, rsp.ID)
+				require.Len(t, rsp.PitchList, 1)
+				require.Len(t, rsp.Runners, 1)
+			},
+		},
+		{
+			name:   "BatterHidesStats",
+			gameID: atbat.GameID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAtbat(gomock.Any(), gomock.Eq(atbat.ID)).
+					Times(1).
+					Return(atbat, nil)
+				store.EXPECT().
+					ListGameParticipants(gomock.Any(), gomock.Eq(atbat.GameID)).
+					Times(1).
+					Return([]db.ListGameParticipantsRow{batter}, nil)
+				expectStatVisibility(store, batter.PlayerID)
+				store.EXPECT().
+					ListPitches(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "OtherGame",
+			gameID: uuid.New(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAtbat(gomock.Any(), gomock.Eq(atbat.ID)).
+					Times(1).
+					Return(atbat, nil)
+				store.EXPECT().
+					ListPitches(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					ListAtbatRunners(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "NotFound",
+			gameID: atbat.GameID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAtbat(gomock.Any(), gomock.Eq(atbat.ID)).
+					Times(1).
+					Return(db.Atbat{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/games/%s/atbats/%s", tc.gameID, atbat.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_ListGameAtbats(t *testing.T) {
+	user, _ := createRandomUser(t)
+	game := db.Game{ID: uuid.New(), HomeTeamID: uuid.New(), AwayTeamID: uuid.New(), Status: string(util.GameInProgress)}
+	participants := []db.ListGameParticipantsRow{
+		{ID: uuid.New(), GameID: game.ID, PlayerID: uuid.New()},
+		{ID: uuid.New(), GameID: game.ID, PlayerID: uuid.New()},
+	}
+	atbats := []db.ListGameAtbatsRow{
+		{ID: uuid.New(), GameID: game.ID, Number: 1, BatterID: participants[0].ID, PitcherID: participants[1].ID},
+		{ID: uuid.New(), GameID: game.ID, Number: 2, BatterID: participants[1].ID, PitcherID: participants[0].ID},
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					ListGameAtbats(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(atbats, nil)
+				store.EXPECT().
+					ListGameParticipants(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(participants, nil)
+				expectStatVisibility(store)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp []db.ListGameAtbatsRow
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Len(t, rsp, 2)
+			},
+		},
+		{
+			name: "BatterHidesStats",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					ListGameAtbats(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(atbats, nil)
+				store.EXPECT().
+					ListGameParticipants(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(participants, nil)
+				expectStatVisibility(store, participants[0].PlayerID)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp []db.ListGameAtbatsRow
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Len(t, rsp, 1)
+				require.Equal(t, atbats[1].ID, rsp[0].ID)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(db.Game{}, sql.ErrNoRows)
+				store.EXPECT().
+					ListGameAtbats(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/games/%s/atbats", game.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/helpers"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"net/http"
+	"time"
+)
+
+var errNotOnGameTeams = errors.New("player is not on either team in this game")
+
+// PlayerAvailabilityRequest represents a request scoped to one player's availability for a game.
+type PlayerAvailabilityRequest struct {
+	GameID string `uri:"id" binding:"required,uuid"`
+	UserID string `uri:"user_id" binding:"required,uuid"`
+}
+
+// SetAvailabilityRequestBody represents the body of a request to RSVP for a game.
+type SetAvailabilityRequestBody struct {
+	Response string `json:"response" binding:"required,oneof=yes no maybe"`
+	Note     string `json:"note" binding:"max=500"`
+}
+
+// AvailabilityResponse represents a player's RSVP for a game.
+type AvailabilityResponse struct {
+	GameID      uuid.UUID `json:"game_id"`
+	TeamID      uuid.UUID `json:"team_id"`
+	UserID      uuid.UUID `json:"user_id"`
+	Response    string    `json:"response"`
+	Note        string    `json:"note,omitempty"`
+	RespondedBy uuid.UUID `json:"responded_by"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// NewAvailabilityResponse creates a new AvailabilityResponse from a db.GameAvailability.
+func NewAvailabilityResponse(availability db.GameAvailability) AvailabilityResponse {
+	return AvailabilityResponse{
+		GameID:      availability.GameID,
+		TeamID:      availability.TeamID,
+		UserID:      availability.UserID,
+		Response:    availability.Response,
+		Note:        availability.Note,
+		RespondedBy: availability.RespondedBy,
+		UpdatedAt:   availability.UpdatedAt,
+	}
+}
+
+// SetAvailability records whether a player can play in a game.
+// Only the player, their approved guardians and admins may respond for them.
+func (s *Server) SetAvailability(context *gin.Context) {
+	var req PlayerAvailabilityRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var body SetAvailabilityRequestBody
+	if err := context.ShouldBindJSON(&body); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	gameID := uuid.MustParse(req.GameID)
+	userID := uuid.MustParse(req.UserID)
+
+	if !s.canActForPlayer(payload, userID) {
+		context.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	teamID, err := s.store.GetPlayerGameTeam(context, db.GetPlayerGameTeamParams{ID: gameID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(errNotOnGameTeams))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	availability, err := s.store.SetGameAvailability(context, db.SetGameAvailabilityParams{
+		GameID:      gameID,
+		TeamID:      teamID,
+		UserID:      userID,
+		Response:    body.Response,
+		Note:        body.Note,
+		RespondedBy: payload.UserID,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, NewAvailabilityResponse(availability))
+}
+
+// GetAvailability gets a player's RSVP for a game.
+// The player, their guardians, admins and the coaches of the player's team in the game may see it.
+func (s *Server) GetAvailability(context *gin.Context) {
+	var req PlayerAvailabilityRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	gameID := uuid.MustParse(req.GameID)
+	userID := uuid.MustParse(req.UserID)
+
+	if !s.canActForPlayer(payload, userID) {
+		if !isCoachOrAdmin(payload) {
+			context.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+		teamID, err := s.store.GetPlayerGameTeam(context, db.GetPlayerGameTeamParams{ID: gameID, UserID: userID})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				context.JSON(http.StatusNotFound, helpers.ErrorResponse(errNotOnGameTeams))
+				return
+			}
+			context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+			return
+		}
+		if !s.authorizeTeamCoach(context, teamID) {
+			return
+		}
+	}
+
+	availability, err := s.store.GetGameAvailability(context, db.GetGameAvailabilityParams{
+		GameID: gameID,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, NewAvailabilityResponse(availability))
+}
+
+// PlayerAvailability is one rostered player's RSVP in an availability summary.
+// Response is empty when the player has not answered yet.
+type PlayerAvailability struct {
+	UserID    uuid.UUID  `json:"user_id"`
+	FirstName string     `json:"first_name"`
+	LastName  string     `json:"last_name"`
+	Number    int64      `json:"number"`
+	Response  string     `json:"response,omitempty"`
+	Note      string     `json:"note,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// TeamAvailability summarizes the RSVPs of one team's roster for a game.
+type TeamAvailability struct {
+	TeamID     uuid.UUID            `json:"team_id"`
+	Yes        int                  `json:"yes"`
+	No         int                  `json:"no"`
+	Maybe      int                  `json:"maybe"`
+	NoResponse int                  `json:"no_response"`
+	Players    []PlayerAvailability `json:"players"`
+}
+
+// GameAvailabilityResponse groups the RSVPs for a game by team.
+// A team is left out when the caller does not coach it.
+type GameAvailabilityResponse struct {
+	GameID uuid.UUID         `json:"game_id"`
+	Home   *TeamAvailability `json:"home,omitempty"`
+	Away   *TeamAvailability `json:"away,omitempty"`
+}
+
+// add counts a player's response and appends them to the team's summary.
+func (t *TeamAvailability) add(player PlayerAvailability) {
+	switch util.Availability(player.Response) {
+	case util.AvailabilityYes:
+		t.Yes++
+	case util.AvailabilityNo:
+		t.No++
+	case util.AvailabilityMaybe:
+		t.Maybe++
+	default:
+		t.NoResponse++
+	}
+	t.Players = append(t.Players, player)
+}
+
+// GetGameAvailability summarizes who is coming to a game, grouped by team.
+// Every rostered player is listed, including those who have not answered. Coaches see only the teams
+// they coach in the game; admins see both.
+func (s *Server) GetGameAvailability(context *gin.Context) {
+	var req GetGameRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	if !isCoachOrAdmin(payload) {
+		context.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	game, err := s.store.GetGame(context, uuid.MustParse(req.ID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+	if !s.authorizeGameCoach(context, game) {
+		return
+	}
+
+	homeCoach, err := s.isTeamCoach(context, payload, game.HomeTeamID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+	awayCoach, err := s.isTeamCoach(context, payload, game.AwayTeamID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	rsp := GameAvailabilityResponse{GameID: game.ID}
+	if homeCoach {
+		rsp.Home = &TeamAvailability{TeamID: game.HomeTeamID, Players: []PlayerAvailability{}}
+	}
+	if awayCoach {
+		rsp.Away = &TeamAvailability{TeamID: game.AwayTeamID, Players: []PlayerAvailability{}}
+	}
+
+	rows, err := s.store.ListGameAvailability(context, game.ID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	for _, row := range rows {
+		player := PlayerAvailability{
+			UserID:    row.UserID,
+			FirstName: row.FirstName,
+			LastName:  row.LastName,
+			Number:    row.Number,
+			Response:  row.Response.String,
+			Note:      row.Note.String,
+		}
+		if row.UpdatedAt.Valid {
+			player.UpdatedAt = &row.UpdatedAt.Time
+		}
+		team := rsp.Away
+		if row.TeamID == game.HomeTeamID {
+			team = rsp.Home
+		}
+		if team != nil {
+			team.add(player)
+		}
+	}
+
+	context.JSON(http.StatusOK, rsp)
+}
@@ -0,0 +1,467 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	mockdb "github.com/kwalter26/scoreit-api-go/db/mock"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/security"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestServer_SetAvailability(t *testing.T) {
+	player, _ := createRandomUser(t)
+	guardian, _ := createRandomUser(t)
+	stranger, _ := createRandomUser(t)
+	gameID := uuid.New()
+	teamID := uuid.New()
+
+	availability := db.GameAvailability{
+		ID:          uuid.New(),
+		GameID:      gameID,
+		TeamID:      teamID,
+		UserID:      player.ID,
+		Response:    string(util.AvailabilityNo),
+		Note:        "out of town",
+		RespondedBy: guardian.ID,
+		UpdatedAt:   time.Now(),
+	}
+
+	testCases := []struct {
+		name          string
+		callerID      uuid.UUID
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "Guardian",
+			callerID: guardian.ID,
+			body:     gin.H{"response": util.AvailabilityNo, "note": "out of town"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetPlayerGameTeam(gomock.Any(), gomock.Eq(db.GetPlayerGameTeamParams{ID: gameID, UserID: player.ID})).
+					Times(1).
+					Return(teamID, nil)
+				arg := db.SetGameAvailabilityParams{
+					GameID:      gameID,
+					TeamID:      teamID,
+					UserID:      player.ID,
+					Response:    string(util.AvailabilityNo),
+					Note:        "out of town",
+					RespondedBy: guardian.ID,
+				}
+				store.EXPECT().
+					SetGameAvailability(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(availability, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp AvailabilityResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, string(util.AvailabilityNo), rsp.Response)
+				require.Equal(t, guardian.ID, rsp.RespondedBy)
+			},
+		},
+		{
+			name:     "Player",
+			callerID: player.ID,
+			body:     gin.H{"response": util.AvailabilityYes},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetPlayerGameTeam(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(teamID, nil)
+				store.EXPECT().
+					SetGameAvailability(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(availability, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "Stranger",
+			callerID: stranger.ID,
+			body:     gin.H{"response": util.AvailabilityYes},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetGameAvailability(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "NotOnEitherTeam",
+			callerID: player.ID,
+			body:     gin.H{"response": util.AvailabilityYes},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetPlayerGameTeam(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(uuid.Nil, sql.ErrNoRows)
+				store.EXPECT().
+					SetGameAvailability(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:     "InvalidResponse",
+			callerID: player.ID,
+			body:     gin.H{"response": "probably"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetGameAvailability(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			loadGuardianLinks(t, server, store, randomGuardian(guardian.ID, player.ID))
+			recorder := httptest.NewRecorder()
+
+			buf, err := buildJsonRequest(t, tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/games/%s/availability/%s", gameID, player.ID)
+			request, err := http.NewRequest(http.MethodPut, url, &buf)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, tc.callerID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_GetGameAvailability(t *testing.T) {
+	user, _ := createRandomUser(t)
+	game := db.Game{ID: uuid.New(), HomeTeamID: uuid.New(), AwayTeamID: uuid.New()}
+
+	rows := []db.ListGameAvailabilityRow{
+		{TeamID: game.HomeTeamID, UserID: uuid.New(), Number: 1, Response: sql.NullString{String: string(util.AvailabilityYes), Valid: true}, UpdatedAt: sql.NullTime{Time: time.Now(), Valid: true}},
+		{TeamID: game.HomeTeamID, UserID: uuid.New(), Number: 2, Response: sql.NullString{String: string(util.AvailabilityNo), Valid: true}, UpdatedAt: sql.NullTime{Time: time.Now(), Valid: true}},
+		{TeamID: game.HomeTeamID, UserID: uuid.New(), Number: 3},
+		{TeamID: game.AwayTeamID, UserID: uuid.New(), Number: 7, Response: sql.NullString{String: string(util.AvailabilityMaybe), Valid: true}, UpdatedAt: sql.NullTime{Time: time.Now(), Valid: true}},
+	}
+
+	// expectCoaching stubs looking up the caller on each team, finding them a coach of those in coached
+	expectCoaching := func(store *mockdb.MockStore, coached ...uuid.UUID) {
+		for _, teamID := range []uuid.UUID{game.HomeTeamID, game.AwayTeamID} {
+			member, err := db.TeamMember{}, sql.ErrNoRows
+			for _, id := range coached {
+				if id == teamID {
+					member, err = db.TeamMember{TeamID: teamID, UserID: user.ID, Role: string(util.TeamRoleCoach)}, nil
+				}
+			}
+			store.EXPECT().
+				GetTeamMember(gomock.Any(), gomock.Eq(db.GetTeamMemberParams{TeamID: teamID, UserID: user.ID})).
+				AnyTimes().
+				Return(member, err)
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		roles         []security.Role
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "HomeCoach",
+			roles: coachRoles,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				expectCoaching(store, game.HomeTeamID)
+				store.EXPECT().
+					ListGameAvailability(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(rows, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp GameAvailabilityResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, game.HomeTeamID, rsp.Home.TeamID)
+				require.Len(t, rsp.Home.Players, 3)
+				require.Equal(t, 1, rsp.Home.Yes)
+				require.Equal(t, 1, rsp.Home.No)
+				require.Equal(t, 1, rsp.Home.NoResponse)
+				require.Empty(t, rsp.Home.Players[2].Response)
+				require.Nil(t, rsp.Home.Players[2].UpdatedAt)
+				require.Nil(t, rsp.Away)
+			},
+		},
+		{
+			name:  "Admin",
+			roles: adminUserRoles,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					ListGameAvailability(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(rows, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp GameAvailabilityResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Len(t, rsp.Home.Players, 3)
+				require.Len(t, rsp.Away.Players, 1)
+				require.Equal(t, 1, rsp.Away.Maybe)
+			},
+		},
+		{
+			name:  "OtherTeamsCoach",
+			roles: coachRoles,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				expectCoaching(store)
+				store.EXPECT().
+					ListGameAvailability(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "NotCoach",
+			roles: security.UserRoles,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListGameAvailability(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "GameNotFound",
+			roles: coachRoles,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(db.Game{}, sql.ErrNoRows)
+				store.EXPECT().
+					ListGameAvailability(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/games/%s/availability", game.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, tc.roles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_GetAvailability(t *testing.T) {
+	player, _ := createRandomUser(t)
+	coach, _ := createRandomUser(t)
+	gameID := uuid.New()
+	teamID := uuid.New()
+
+	availability := db.GameAvailability{
+		ID:          uuid.New(),
+		GameID:      gameID,
+		TeamID:      teamID,
+		UserID:      player.ID,
+		Response:    string(util.AvailabilityYes),
+		RespondedBy: player.ID,
+		UpdatedAt:   time.Now(),
+	}
+	gameTeam := db.GetPlayerGameTeamParams{ID: gameID, UserID: player.ID}
+
+	testCases := []struct {
+		name          string
+		roles         []security.Role
+		callerID      uuid.UUID
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "Player",
+			roles:    security.UserRoles,
+			callerID: player.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGameAvailability(gomock.Any(), gomock.Eq(db.GetGameAvailabilityParams{GameID: gameID, UserID: player.ID})).
+					Times(1).
+					Return(availability, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "TeamCoach",
+			roles:    coachRoles,
+			callerID: coach.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetPlayerGameTeam(gomock.Any(), gomock.Eq(gameTeam)).
+					Times(1).
+					Return(teamID, nil)
+				store.EXPECT().
+					GetTeamMember(gomock.Any(), gomock.Eq(db.GetTeamMemberParams{TeamID: teamID, UserID: coach.ID})).
+					Times(1).
+					Return(db.TeamMember{TeamID: teamID, UserID: coach.ID, Role: string(util.TeamRoleCoach)}, nil)
+				store.EXPECT().
+					GetGameAvailability(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(availability, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp AvailabilityResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, availability.Response, rsp.Response)
+			},
+		},
+		{
+			name:     "OtherTeamCoach",
+			roles:    coachRoles,
+			callerID: coach.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetPlayerGameTeam(gomock.Any(), gomock.Eq(gameTeam)).
+					Times(1).
+					Return(teamID, nil)
+				store.EXPECT().
+					GetTeamMember(gomock.Any(), gomock.Eq(db.GetTeamMemberParams{TeamID: teamID, UserID: coach.ID})).
+					Times(1).
+					Return(db.TeamMember{}, sql.ErrNoRows)
+				store.EXPECT().
+					GetGameAvailability(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "NotOnGameTeams",
+			roles:    coachRoles,
+			callerID: coach.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetPlayerGameTeam(gomock.Any(), gomock.Eq(gameTeam)).
+					Times(1).
+					Return(uuid.Nil, sql.ErrNoRows)
+				store.EXPECT().
+					GetGameAvailability(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:     "Stranger",
+			roles:    security.UserRoles,
+			callerID: coach.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetPlayerGameTeam(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					GetGameAvailability(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/games/%s/availability/%s", gameID, player.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, tc.roles, middleware.AuthorizationTypeBearer, tc.callerID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
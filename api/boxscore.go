@@ -0,0 +1,574 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/helpers"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/scoring"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"net/http"
+	"strings"
+)
+
+// GetBoxScoreRequest represents the format of a box score: JSON or plain text.
+type GetBoxScoreRequest struct {
+	Format string `form:"format" binding:"omitempty,oneof=json text"`
+}
+
+// BattingLine represents one player's batting in one batting slot. Positions lists every position
+// they played there, starting with "ph" or "pr" when they came in to pinch hit or run. The lines of
+// players who hide their stats from the caller are blank and marked Hidden.
+type BattingLine struct {
+	PlayerID   uuid.UUID `json:"player_id"`
+	FirstName  string    `json:"first_name"`
+	LastName   string    `json:"last_name"`
+	Positions  []string  `json:"positions"`
+	Substitute bool      `json:"substitute"`
+	AB         int64     `json:"ab"`
+	R          int64     `json:"r"`
+	H          int64     `json:"h"`
+	RBI        int64     `json:"rbi"`
+	BB         int64     `json:"bb"`
+	SO         int64     `json:"so"`
+	LOB        int64     `json:"lob"`
+	Hidden     bool      `json:"hidden,omitempty"`
+}
+
+// BattingSlot represents a spot in the batting order: the starter followed by everyone who replaced them.
+type BattingSlot struct {
+	BatPosition int64         `json:"bat_position"`
+	Players     []BattingLine `json:"players"`
+}
+
+// BattingTotals represents a team's batting totals. LOB is the runners stranded at the end of each half inning.
+type BattingTotals struct {
+	AB  int64 `json:"ab"`
+	R   int64 `json:"r"`
+	H   int64 `json:"h"`
+	RBI int64 `json:"rbi"`
+	BB  int64 `json:"bb"`
+	SO  int64 `json:"so"`
+	LOB int64 `json:"lob"`
+}
+
+// PitchingLine represents one pitcher's line. IP is innings pitched in thirds, as in "6.2".
+// The lines of players who hide their stats from the caller are blank and marked Hidden.
+type PitchingLine struct {
+	PlayerID  uuid.UUID `json:"player_id"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	Outs      int64     `json:"outs"`
+	IP        string    `json:"ip"`
+	H         int64     `json:"h"`
+	R         int64     `json:"r"`
+	ER        int64     `json:"er"`
+	BB        int64     `json:"bb"`
+	SO        int64     `json:"so"`
+	HR        int64     `json:"hr"`
+	Pitches   int64     `json:"pitches"`
+	Hidden    bool      `json:"hidden,omitempty"`
+}
+
+// BoxScoreNote represents one line of a team's game notes, such as its doubles or errors, with how many each player had.
+type BoxScoreNote struct {
+	Type    util.GameStatType  `json:"type"`
+	Label   string             `json:"label"`
+	Players []BoxScoreNoteItem `json:"players"`
+}
+
+// BoxScoreNoteItem represents a player named in a game note.
+type BoxScoreNoteItem struct {
+	PlayerID uuid.UUID `json:"player_id"`
+	LastName string    `json:"last_name"`
+	Count    int64     `json:"count"`
+}
+
+// TeamBoxScore represents one team's half of a box score.
+type TeamBoxScore struct {
+	TeamID   uuid.UUID      `json:"team_id"`
+	Name     string         `json:"name"`
+	Batting  []BattingSlot  `json:"batting"`
+	Totals   BattingTotals  `json:"totals"`
+	Pitching []PitchingLine `json:"pitching"`
+	Notes    []BoxScoreNote `json:"notes"`
+}
+
+// BoxScoreResponse represents a game's box score.
+type BoxScoreResponse struct {
+	GameID    uuid.UUID    `json:"game_id"`
+	Status    string       `json:"status"`
+	AwayScore int64        `json:"away_score"`
+	HomeScore int64        `json:"home_score"`
+	Away      TeamBoxScore `json:"away"`
+	Home      TeamBoxScore `json:"home"`
+}
+
+// noteLabels are the abbreviations box scores use for game stat types
+var noteLabels = map[util.GameStatType]string{
+	util.StatDouble:         "2B",
+	util.StatTriple:         "3B",
+	util.StatHomeRun:        "HR",
+	util.StatStolenBase:     "SB",
+	util.StatCaughtStealing: "CS",
+	util.StatError:          "E",
+}
+
+// positionLabels are the abbreviations box scores use for positions
+var positionLabels = map[util.BaseballPosition]string{
+	util.Pitcher:          "p",
+	util.Catcher:          "c",
+	util.FirstBase:        "1b",
+	util.SecondBase:       "2b",
+	util.ThirdBase:        "3b",
+	util.ShortStop:        "ss",
+	util.LeftField:        "lf",
+	util.CenterField:      "cf",
+	util.RightField:       "rf",
+	util.DesignatedHitter: "dh",
+	util.RightCenterField: "rcf",
+	util.LeftCenterField:  "lcf",
+}
+
+// GetBoxScore gets a game's box score: batting lines by batting order with substitutes under the players
+// they replaced, pitching lines, team totals and notes. It is built from the game's participants, at-bats,
+// runner movement and game stats, and is returned as JSON or, with format=text, as newspaper-style plain text.
+// Players who hide their stats from the caller get blank lines and are left out of the notes, though the
+// team totals still count them.
+func (s *Server) GetBoxScore(context *gin.Context) {
+	var req GetGameRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var query GetBoxScoreRequest
+	if err := context.ShouldBindQuery(&query); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	game, err := s.store.GetGame(context, uuid.MustParse(req.ID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	home, err := s.store.GetTeam(context, game.HomeTeamID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+	away, err := s.store.GetTeam(context, game.AwayTeamID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	participants, err := s.store.ListGameParticipants(context, game.ID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+	atbats, err := s.store.ListGameAtbats(context, game.ID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+	runners, err := s.store.ListGameRunners(context, game.ID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+	stats, err := s.store.ListGameStats(context, game.ID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+	pitches, err := s.store.ListGameRecordedPitches(context, game.ID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	playerIDs := make([]uuid.UUID, len(participants))
+	for i, participant := range participants {
+		playerIDs[i] = participant.PlayerID
+	}
+	hidden, err := s.hiddenStats(context, middleware.GetAuthorizationPayload(context), playerIDs)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	box := boxScore(game, home, away, participants, atbats, runners, stats, pitches)
+	box.hideStats(hidden)
+	if query.Format == "text" {
+		context.String(http.StatusOK, box.text())
+		return
+	}
+	context.JSON(http.StatusOK, box)
+}
+
+// boxScoreBuilder accumulates a box score, keeping track of which line each participant's stats go to
+type boxScoreBuilder struct {
+	participants map[uuid.UUID]db.ListGameParticipantsRow
+	// batting maps a participant to their batting line, shared by every stint a player has in one slot
+	batting map[uuid.UUID]*BattingLine
+	// pitching maps a player to their pitching line
+	pitching map[uuid.UUID]*PitchingLine
+	box      BoxScoreResponse
+}
+
+func (b *boxScoreBuilder) team(home bool) *TeamBoxScore {
+	if home {
+		return &b.box.Home
+	}
+	return &b.box.Away
+}
+
+// boxScore builds a box score from a game's participants, at-bats in order, runner movement, game stats
+// and the pitches each pitcher threw. Pitches are counted for whoever threw them, so a pitcher relieved
+// in the middle of an at-bat is credited with the pitches they threw in it.
+func boxScore(game db.Game, home, away db.Team, participants []db.ListGameParticipantsRow, atbats []db.ListGameAtbatsRow,
+	runners []db.AtbatRunner, stats []db.ListGameStatsRow, pitches []db.ListGameRecordedPitchesRow) BoxScoreResponse {
+	b := boxScoreBuilder{
+		participants: make(map[uuid.UUID]db.ListGameParticipantsRow, len(participants)),
+		batting:      make(map[uuid.UUID]*BattingLine),
+		pitching:     make(map[uuid.UUID]*PitchingLine),
+		box: BoxScoreResponse{
+			GameID:    game.ID,
+			Status:    game.Status,
+			AwayScore: game.AwayScore,
+			HomeScore: game.HomeScore,
+			Away:      TeamBoxScore{TeamID: away.ID, Name: away.Name, Batting: []BattingSlot{}, Pitching: []PitchingLine{}, Notes: []BoxScoreNote{}},
+			Home:      TeamBoxScore{TeamID: home.ID, Name: home.Name, Batting: []BattingSlot{}, Pitching: []PitchingLine{}, Notes: []BoxScoreNote{}},
+		},
+	}
+
+	// participants come ordered by team, batting slot and when they entered. slotOf records where each
+	// participant's line is, so the lines can be pointed to once every slot is built.
+	slotOf := make(map[uuid.UUID][2]int)
+	appearance := make(map[uuid.UUID]uuid.UUID)
+	for _, participant := range participants {
+		b.participants[participant.ID] = participant
+		if _, ok := appearance[participant.PlayerID]; !ok {
+			appearance[participant.PlayerID] = participant.ID
+		}
+		if participant.BatPosition == 0 {
+			continue
+		}
+		team := b.team(participant.HomeTeam)
+		if n := len(team.Batting); n == 0 || team.Batting[n-1].BatPosition != participant.BatPosition {
+			team.Batting = append(team.Batting, BattingSlot{BatPosition: participant.BatPosition})
+		}
+		slot := &team.Batting[len(team.Batting)-1]
+
+		// a player who changes position stays on the same line
+		index := -1
+		for i, line := range slot.Players {
+			if line.PlayerID == participant.PlayerID {
+				index = i
+			}
+		}
+		if index < 0 {
+			index = len(slot.Players)
+			slot.Players = append(slot.Players, BattingLine{
+				PlayerID:   participant.PlayerID,
+				FirstName:  participant.FirstName,
+				LastName:   participant.LastName,
+				Positions:  []string{},
+				Substitute: util.ParticipantEntry(participant.Entry) != util.EntryStarter,
+			})
+		}
+		slotOf[participant.ID] = [2]int{len(team.Batting) - 1, index}
+
+		line := &slot.Players[index]
+		switch util.ParticipantEntry(participant.Entry) {
+		case util.EntryPinchHitter:
+			line.Positions = append(line.Positions, "ph")
+		case util.EntryPinchRunner:
+			line.Positions = append(line.Positions, "pr")
+		default:
+			position := positionLabels[util.BaseballPosition(participant.Position)]
+			if n := len(line.Positions); position != "" && (n == 0 || line.Positions[n-1] != position) {
+				line.Positions = append(line.Positions, position)
+			}
+		}
+	}
+	for id, at := range slotOf {
+		team := b.team(b.participants[id].HomeTeam)
+		b.batting[id] = &team.Batting[at[0]].Players[at[1]]
+	}
+
+	// pitchers are listed in the order they first pitched. As with batting lines, the lines are
+	// pointed to only once all are added, since appending may move them.
+	pitched := make(map[uuid.UUID]bool)
+	addPitcher := func(id uuid.UUID) {
+		participant := b.participants[id]
+		if pitched[participant.PlayerID] {
+			return
+		}
+		pitched[participant.PlayerID] = true
+		team := b.team(participant.HomeTeam)
+		team.Pitching = append(team.Pitching, PitchingLine{
+			PlayerID:  participant.PlayerID,
+			FirstName: participant.FirstName,
+			LastName:  participant.LastName,
+		})
+	}
+	for _, thrown := range pitches {
+		if id, ok := appearance[thrown.PlayerID]; ok {
+			addPitcher(id)
+		}
+	}
+	for _, atbat := range atbats {
+		addPitcher(atbat.PitcherID)
+	}
+	for _, move := range runners {
+		if move.Scored && move.PitcherID.Valid {
+			addPitcher(move.PitcherID.UUID)
+		}
+	}
+	for _, team := range []*TeamBoxScore{&b.box.Away, &b.box.Home} {
+		for i := range team.Pitching {
+			b.pitching[team.Pitching[i].PlayerID] = &team.Pitching[i]
+		}
+	}
+	for _, thrown := range pitches {
+		if line := b.pitching[thrown.PlayerID]; line != nil {
+			line.Pitches = thrown.Pitches
+		}
+	}
+
+	moves := make(map[uuid.UUID][]db.AtbatRunner)
+	for _, move := range runners {
+		moves[move.AtbatID] = append(moves[move.AtbatID], move)
+		if move.Scored {
+			if line := b.batting[move.RunnerID]; line != nil {
+				line.R++
+			}
+			if move.PitcherID.Valid {
+				pitcher := b.pitching[b.participants[move.PitcherID.UUID].PlayerID]
+				pitcher.R++
+				if move.Earned {
+					pitcher.ER++
+				}
+			}
+		}
+	}
+
+	for i, atbat := range atbats {
+		bases := endBases(atbat, moves[atbat.ID])
+		pitcher := b.pitching[b.participants[atbat.PitcherID].PlayerID]
+		if atbat.Out {
+			pitcher.Outs++
+		}
+		for _, move := range moves[atbat.ID] {
+			if move.Out && move.FromBase != int64(scoring.Batter) {
+				pitcher.Outs++
+			}
+		}
+
+		// the runners left on base when a half inning ends are charged to the team
+		last := i == len(atbats)-1
+		if last && util.GameStatus(game.Status) == util.GameFinal ||
+			!last && (atbats[i+1].Inning != atbat.Inning || atbats[i+1].Half != atbat.Half) {
+			b.team(util.BattingSide(util.InningHalf(atbat.Half))).Totals.LOB += bases
+		}
+
+		line := b.batting[atbat.BatterID]
+		if line == nil || !atbat.Result.Valid {
+			continue
+		}
+		line.RBI += atbat.Rbi
+		if atbat.Out {
+			line.LOB += bases
+		}
+		switch result := atbat.Result.String; result {
+		case string(util.AtBatWalk):
+			line.BB++
+			pitcher.BB++
+		case string(util.AtBatStrikeout):
+			line.AB++
+			line.SO++
+			pitcher.SO++
+		case string(scoring.PlaySingle), string(scoring.PlayDouble), string(scoring.PlayTriple), string(scoring.PlayHomeRun):
+			line.AB++
+			line.H++
+			pitcher.H++
+			if result == string(scoring.PlayHomeRun) {
+				pitcher.HR++
+			}
+		case string(scoring.PlayOut), string(scoring.PlayError), string(scoring.PlayFieldersChoice):
+			line.AB++
+		}
+	}
+
+	for _, team := range []*TeamBoxScore{&b.box.Away, &b.box.Home} {
+		for _, slot := range team.Batting {
+			for _, line := range slot.Players {
+				team.Totals.AB += line.AB
+				team.Totals.R += line.R
+				team.Totals.H += line.H
+				team.Totals.RBI += line.RBI
+				team.Totals.BB += line.BB
+				team.Totals.SO += line.SO
+			}
+		}
+		for i := range team.Pitching {
+			team.Pitching[i].IP = fmt.Sprintf("%d.%d", team.Pitching[i].Outs/3, team.Pitching[i].Outs%3)
+		}
+	}
+
+	b.notes(stats)
+	return b.box
+}
+
+// notes adds each team's game notes, by type in the usual order and by player in the order they first appear
+func (b *boxScoreBuilder) notes(stats []db.ListGameStatsRow) {
+	for _, statType := range util.GameStatTypes {
+		for _, home := range []bool{false, true} {
+			note := BoxScoreNote{Type: statType, Label: noteLabels[statType]}
+			for _, stat := range stats {
+				participant, ok := b.participants[stat.ParticipantID.UUID]
+				if !ok || stat.Type.String != string(statType) || participant.HomeTeam != home {
+					continue
+				}
+				found := false
+				for i := range note.Players {
+					if note.Players[i].PlayerID == participant.PlayerID {
+						note.Players[i].Count++
+						found = true
+					}
+				}
+				if !found {
+					note.Players = append(note.Players, BoxScoreNoteItem{PlayerID: participant.PlayerID, LastName: participant.LastName, Count: 1})
+				}
+			}
+			if len(note.Players) > 0 {
+				team := b.team(home)
+				team.Notes = append(team.Notes, note)
+			}
+		}
+	}
+}
+
+// hideStats blanks the lines of hidden players and leaves them out of the notes
+func (box *BoxScoreResponse) hideStats(hidden map[uuid.UUID]bool) {
+	for _, team := range []*TeamBoxScore{&box.Away, &box.Home} {
+		for i := range team.Batting {
+			for j, line := range team.Batting[i].Players {
+				if hidden[line.PlayerID] {
+					team.Batting[i].Players[j] = BattingLine{
+						PlayerID:   line.PlayerID,
+						FirstName:  line.FirstName,
+						LastName:   line.LastName,
+						Positions:  line.Positions,
+						Substitute: line.Substitute,
+						Hidden:     true,
+					}
+				}
+			}
+		}
+		for i, line := range team.Pitching {
+			if hidden[line.PlayerID] {
+				team.Pitching[i] = PitchingLine{PlayerID: line.PlayerID, FirstName: line.FirstName, LastName: line.LastName, Hidden: true}
+			}
+		}
+
+		notes := []BoxScoreNote{}
+		for _, note := range team.Notes {
+			players := []BoxScoreNoteItem{}
+			for _, player := range note.Players {
+				if !hidden[player.PlayerID] {
+					players = append(players, player)
+				}
+			}
+			if len(players) > 0 {
+				note.Players = players
+				notes = append(notes, note)
+			}
+		}
+		team.Notes = notes
+	}
+}
+
+// endBases returns how many runners were on base when an at-bat ended, from who was on when it began
+// and every runner's movement during it
+func endBases(atbat db.ListGameAtbatsRow, moves []db.AtbatRunner) int64 {
+	bases := [3]uuid.NullUUID{atbat.RunnerOnFirst, atbat.RunnerOnSecond, atbat.RunnerOnThird}
+	for _, move := range moves {
+		if move.FromBase >= int64(scoring.First) && move.FromBase <= int64(scoring.Third) && bases[move.FromBase-1].UUID == move.RunnerID {
+			bases[move.FromBase-1] = uuid.NullUUID{}
+		}
+		if !move.Out && move.ToBase >= int64(scoring.First) && move.ToBase <= int64(scoring.Third) {
+			bases[move.ToBase-1] = uuid.NullUUID{UUID: move.RunnerID, Valid: true}
+		}
+	}
+
+	var on int64
+	for _, base := range bases {
+		if base.Valid {
+			on++
+		}
+	}
+	return on
+}
+
+// text renders the box score in the plain-text layout newspapers use
+func (box BoxScoreResponse) text() string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "%s %d, %s %d\n", box.Away.Name, box.AwayScore, box.Home.Name, box.HomeScore)
+
+	for _, team := range []TeamBoxScore{box.Away, box.Home} {
+		fmt.Fprintf(&out, "\n%-24s %3s %3s %3s %3s %3s %3s %3s\n", team.Name, "AB", "R", "H", "RBI", "BB", "SO", "LOB")
+		for _, slot := range team.Batting {
+			for _, line := range slot.Players {
+				name := line.LastName + " " + strings.Join(line.Positions, "-")
+				if line.Substitute {
+					name = "  " + name
+				}
+				if line.Hidden {
+					fmt.Fprintf(&out, "%-24s %3s %3s %3s %3s %3s %3s %3s\n", name, "-", "-", "-", "-", "-", "-", "-")
+					continue
+				}
+				fmt.Fprintf(&out, "%-24s %3d %3d %3d %3d %3d %3d %3d\n", name, line.AB, line.R, line.H, line.RBI, line.BB, line.SO, line.LOB)
+			}
+		}
+		t := team.Totals
+		fmt.Fprintf(&out, "%-24s %3d %3d %3d %3d %3d %3d %3d\n", "Totals", t.AB, t.R, t.H, t.RBI, t.BB, t.SO, t.LOB)
+
+		for _, note := range team.Notes {
+			items := make([]string, 0, len(note.Players))
+			for _, player := range note.Players {
+				if player.Count > 1 {
+					items = append(items, fmt.Sprintf("%s %d", player.LastName, player.Count))
+				} else {
+					items = append(items, player.LastName)
+				}
+			}
+			fmt.Fprintf(&out, "%s: %s.\n", note.Label, strings.Join(items, ", "))
+		}
+	}
+
+	for _, team := range []TeamBoxScore{box.Away, box.Home} {
+		fmt.Fprintf(&out, "\n%-24s %4s %3s %3s %3s %3s %3s %3s %3s\n", team.Name, "IP", "H", "R", "ER", "BB", "SO", "HR", "NP")
+		for _, line := range team.Pitching {
+			if line.Hidden {
+				fmt.Fprintf(&out, "%-24s %4s %3s %3s %3s %3s %3s %3s %3s\n", line.LastName, "-", "-", "-", "-", "-", "-", "-", "-")
+				continue
+			}
+			fmt.Fprintf(&out, "%-24s %4s %3d %3d %3d %3d %3d %3d %3d\n", line.LastName, line.IP, line.H, line.R, line.ER, line.BB, line.SO, line.HR, line.Pitches)
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,425 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	mockdb "github.com/kwalter26/scoreit-api-go/db/mock"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/scoring"
+	"github.com/kwalter26/scoreit-api-go/security"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// boxScoreGame is a game one inning in: the away team scores three in the top of the first
+// and the home team has made one out in the bottom
+type boxScoreGame struct {
+	game         db.Game
+	home, away   db.Team
+	participants []db.ListGameParticipantsRow
+	atbats       []db.ListGameAtbatsRow
+	runners      []db.AtbatRunner
+	stats        []db.ListGameStatsRow
+	pitches      []db.ListGameRecordedPitchesRow
+	awayStarters []db.ListGameParticipantsRow
+	homeStarters []db.ListGameParticipantsRow
+	pinchHitter  db.ListGameParticipantsRow
+}
+
+func newBoxScoreGame() boxScoreGame {
+	g := boxScoreGame{
+		game: db.Game{ID: uuid.New(), HomeTeamID: uuid.New(), AwayTeamID: uuid.New(), Status: string(util.GameInProgress), AwayScore: 3},
+	}
+	g.home = db.Team{ID: g.game.HomeTeamID, Name: "Hawks"}
+	g.away = db.Team{ID: g.game.AwayTeamID, Name: "Owls"}
+
+	g.homeStarters = randomParticipants(g.game.ID)
+	g.awayStarters = randomParticipants(g.game.ID)
+	for i := range g.awayStarters {
+		g.awayStarters[i].HomeTeam = false
+		g.awayStarters[i].LastName = fmt.Sprintf("Away%d", i+1)
+		g.homeStarters[i].LastName = fmt.Sprintf("Home%d", i+1)
+	}
+	g.pinchHitter = db.ListGameParticipantsRow{
+		ID:            uuid.New(),
+		GameID:        g.game.ID,
+		PlayerID:      uuid.New(),
+		BatPosition:   9,
+		Position:      string(util.Pitcher),
+		Entry:         string(util.EntryPinchHitter),
+		EnteredInning: 2,
+		ReplacedID:    uuid.NullUUID{UUID: g.awayStarters[8].ID, Valid: true},
+		LastName:      "Bench",
+	}
+	g.participants = append(append(append([]db.ListGameParticipantsRow{}, g.awayStarters...), g.pinchHitter), g.homeStarters...)
+
+	homePitcher := g.homeStarters[8].ID
+	on := func(participant db.ListGameParticipantsRow) uuid.NullUUID {
+		return uuid.NullUUID{UUID: participant.ID, Valid: true}
+	}
+	atbat := func(batter db.ListGameParticipantsRow, half util.InningHalf, pitcher uuid.UUID, result string, out bool) db.ListGameAtbatsRow {
+		return db.ListGameAtbatsRow{
+			ID:        uuid.New(),
+			GameID:    g.game.ID,
+			Number:    int64(len(g.atbats) + 1),
+			Inning:    1,
+			Half:      string(half),
+			BatterID:  batter.ID,
+			PitcherID: pitcher,
+			Pitches:   4,
+			Result:    sql.NullString{String: result, Valid: true},
+			Out:       out,
+		}
+	}
+	move := func(atbat db.ListGameAtbatsRow, runner db.ListGameParticipantsRow, from, to scoring.Base, reason string) db.AtbatRunner {
+		return db.AtbatRunner{ID: uuid.New(), AtbatID: atbat.ID, RunnerID: runner.ID, FromBase: int64(from), ToBase: int64(to), Reason: reason}
+	}
+	stat := func(atbat db.ListGameAtbatsRow, participant db.ListGameParticipantsRow, statType util.GameStatType) db.ListGameStatsRow {
+		return db.ListGameStatsRow{
+			ID:            uuid.New(),
+			AtbatID:       uuid.NullUUID{UUID: atbat.ID, Valid: true},
+			Type:          sql.NullString{String: string(statType), Valid: true},
+			ParticipantID: on(participant),
+			Inning:        1,
+		}
+	}
+	a := g.awayStarters
+
+	double := atbat(a[0], util.HalfTop, homePitcher, string(scoring.PlayDouble), false)
+	walk := atbat(a[1], util.HalfTop, homePitcher, string(util.AtBatWalk), false)
+	walk.RunnerOnSecond = on(a[0])
+	homer := atbat(a[2], util.HalfTop, homePitcher, string(scoring.PlayHomeRun), false)
+	homer.Rbi = 3
+	homer.RunnerOnFirst, homer.RunnerOnSecond = on(a[1]), on(a[0])
+	strikeout := atbat(a[3], util.HalfTop, homePitcher, string(util.AtBatStrikeout), true)
+	single := atbat(a[4], util.HalfTop, homePitcher, string(scoring.PlaySingle), false)
+	groundOut := atbat(a[5], util.HalfTop, homePitcher, string(scoring.PlayOut), true)
+	groundOut.RunnerOnFirst = on(a[4])
+	reached := atbat(a[6], util.HalfTop, homePitcher, string(scoring.PlayError), false)
+	reached.RunnerOnSecond = on(a[4])
+	flyOut := atbat(a[7], util.HalfTop, homePitcher, string(scoring.PlayOut), true)
+	flyOut.RunnerOnFirst, flyOut.RunnerOnSecond = on(a[6]), on(a[4])
+	bottom := atbat(g.homeStarters[0], util.HalfBottom, a[8].ID, string(util.AtBatStrikeout), true)
+	g.atbats = []db.ListGameAtbatsRow{double, walk, homer, strikeout, single, groundOut, reached, flyOut, bottom}
+
+	scored := func(runner db.AtbatRunner) db.AtbatRunner {
+		runner.Scored, runner.Rbi, runner.Earned = true, true, true
+		runner.PitcherID = uuid.NullUUID{UUID: homePitcher, Valid: true}
+		return runner
+	}
+	stolen := move(groundOut, a[4], scoring.First, scoring.Second, "stolen_base")
+	batterOut := move(groundOut, a[5], scoring.Batter, scoring.First, "batted_ball")
+	batterOut.Out = true
+	g.runners = []db.AtbatRunner{
+		move(double, a[0], scoring.Batter, scoring.Second, "batted_ball"),
+		move(walk, a[1], scoring.Batter, scoring.First, "walk"),
+		scored(move(homer, a[0], scoring.Second, scoring.Home, "batted_ball")),
+		scored(move(homer, a[1], scoring.First, scoring.Home, "batted_ball")),
+		scored(move(homer, a[2], scoring.Batter, scoring.Home, "batted_ball")),
+		move(single, a[4], scoring.Batter, scoring.First, "batted_ball"),
+		stolen,
+		batterOut,
+		move(reached, a[6], scoring.Batter, scoring.First, "error"),
+	}
+
+	g.stats = []db.ListGameStatsRow{
+		stat(double, a[0], util.StatDouble),
+		stat(homer, a[2], util.StatHomeRun),
+		stat(groundOut, a[4], util.StatStolenBase),
+		stat(reached, g.homeStarters[5], util.StatError),
+	}
+	g.pitches = []db.ListGameRecordedPitchesRow{
+		{PlayerID: g.homeStarters[8].PlayerID, Pitches: 32},
+		{PlayerID: a[8].PlayerID, Pitches: 4},
+	}
+	return g
+}
+
+func (g boxScoreGame) buildStubs(store *mockdb.MockStore) {
+	expectStatVisibility(store)
+	g.buildGameStubs(store)
+}
+
+// buildGameStubs stubs reading the game, leaving the players' stat visibility to the caller
+func (g boxScoreGame) buildGameStubs(store *mockdb.MockStore) {
+	store.EXPECT().
+		GetGame(gomock.Any(), gomock.Eq(g.game.ID)).
+		Times(1).
+		Return(g.game, nil)
+	store.EXPECT().
+		GetTeam(gomock.Any(), gomock.Eq(g.home.ID)).
+		Times(1).
+		Return(g.home, nil)
+	store.EXPECT().
+		GetTeam(gomock.Any(), gomock.Eq(g.away.ID)).
+		Times(1).
+		Return(g.away, nil)
+	store.EXPECT().
+		ListGameParticipants(gomock.Any(), gomock.Eq(g.game.ID)).
+		Times(1).
+		Return(g.participants, nil)
+	store.EXPECT().
+		ListGameAtbats(gomock.Any(), gomock.Eq(g.game.ID)).
+		Times(1).
+		Return(g.atbats, nil)
+	store.EXPECT().
+		ListGameRunners(gomock.Any(), gomock.Eq(g.game.ID)).
+		Times(1).
+		Return(g.runners, nil)
+	store.EXPECT().
+		ListGameStats(gomock.Any(), gomock.Eq(g.game.ID)).
+		Times(1).
+		Return(g.stats, nil)
+	store.EXPECT().
+		ListGameRecordedPitches(gomock.Any(), gomock.Eq(g.game.ID)).
+		Times(1).
+		Return(g.pitches, nil)
+}
+
+func TestServer_GetBoxScore(t *testing.T) {
+	user, _ := createRandomUser(t)
+	g := newBoxScoreGame()
+
+	// the home starter is relieved two pitches into the last at-bat of the top of the first
+	relieved := g
+	reliever := db.ListGameParticipantsRow{
+		ID:          uuid.New(),
+		GameID:      g.game.ID,
+		PlayerID:    uuid.New(),
+		HomeTeam:    true,
+		BatPosition: 9,
+		Position:    string(util.Pitcher),
+		Entry:       string(util.EntryPitchingChange),
+		ReplacedID:  uuid.NullUUID{UUID: g.homeStarters[8].ID, Valid: true},
+		LastName:    "Relief",
+	}
+	relieved.participants = append(append([]db.ListGameParticipantsRow{}, g.participants...), reliever)
+	relieved.atbats = append([]db.ListGameAtbatsRow{}, g.atbats...)
+	relieved.atbats[7].PitcherID = reliever.ID
+	relieved.pitches = []db.ListGameRecordedPitchesRow{
+		{PlayerID: g.homeStarters[8].PlayerID, Pitches: 30},
+		{PlayerID: reliever.PlayerID, Pitches: 2},
+		{PlayerID: g.awayStarters[8].PlayerID, Pitches: 4},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		roles         []security.Role
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "OK",
+			buildStubs: g.buildStubs,
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp BoxScoreResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, "Owls", rsp.Away.Name)
+				require.Len(t, rsp.Away.Batting, 9)
+				require.Len(t, rsp.Home.Batting, 9)
+
+				leadoff := rsp.Away.Batting[0].Players[0]
+				require.Equal(t, []string{"cf"}, leadoff.Positions)
+				require.Equal(t, BattingLine{PlayerID: leadoff.PlayerID, LastName: "Away1", Positions: []string{"cf"}, AB: 1, R: 1, H: 1}, leadoff)
+
+				slugger := rsp.Away.Batting[2].Players[0]
+				require.Equal(t, int64(3), slugger.RBI)
+				require.Equal(t, int64(1), slugger.R)
+				require.Equal(t, int64(1), rsp.Away.Batting[1].Players[0].BB)
+				require.Equal(t, int64(0), rsp.Away.Batting[1].Players[0].AB)
+				require.Equal(t, int64(1), rsp.Away.Batting[5].Players[0].LOB)
+				require.Equal(t, int64(2), rsp.Away.Batting[7].Players[0].LOB)
+
+				ninth := rsp.Away.Batting[8]
+				require.Len(t, ninth.Players, 2)
+				require.Equal(t, g.pinchHitter.PlayerID, ninth.Players[1].PlayerID)
+				require.Equal(t, []string{"ph"}, ninth.Players[1].Positions)
+				require.True(t, ninth.Players[1].Substitute)
+
+				require.Equal(t, BattingTotals{AB: 7, R: 3, H: 3, RBI: 3, BB: 1, SO: 1, LOB: 2}, rsp.Away.Totals)
+				// the bottom of the first is still being played, so no one is left on base yet
+				require.Equal(t, BattingTotals{AB: 1, SO: 1}, rsp.Home.Totals)
+
+				require.Len(t, rsp.Home.Pitching, 1)
+				pitcher := rsp.Home.Pitching[0]
+				require.Equal(t, g.homeStarters[8].PlayerID, pitcher.PlayerID)
+				require.Equal(t, "1.0", pitcher.IP)
+				require.Equal(t, PitchingLine{PlayerID: pitcher.PlayerID, LastName: "Home9", Outs: 3, IP: "1.0", H: 3, R: 3, ER: 3, BB: 1, SO: 1, HR: 1, Pitches: 32}, pitcher)
+				require.Len(t, rsp.Away.Pitching, 1)
+				require.Equal(t, "0.1", rsp.Away.Pitching[0].IP)
+
+				require.Equal(t, []BoxScoreNote{
+					{Type: util.StatDouble, Label: "2B", Players: []BoxScoreNoteItem{{PlayerID: g.awayStarters[0].PlayerID, LastName: "Away1", Count: 1}}},
+					{Type: util.StatHomeRun, Label: "HR", Players: []BoxScoreNoteItem{{PlayerID: g.awayStarters[2].PlayerID, LastName: "Away3", Count: 1}}},
+					{Type: util.StatStolenBase, Label: "SB", Players: []BoxScoreNoteItem{{PlayerID: g.awayStarters[4].PlayerID, LastName: "Away5", Count: 1}}},
+				}, rsp.Away.Notes)
+				require.Equal(t, []BoxScoreNote{
+					{Type: util.StatError, Label: "E", Players: []BoxScoreNoteItem{{PlayerID: g.homeStarters[5].PlayerID, LastName: "Home6", Count: 1}}},
+				}, rsp.Home.Notes)
+			},
+		},
+		{
+			name:       "Text",
+			query:      "?format=text",
+			buildStubs: g.buildStubs,
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Header().Get("Content-Type"), "text/plain")
+
+				body := recorder.Body.String()
+				require.Contains(t, body, "Owls 3, Hawks 0\n")
+				require.Contains(t, body, "  Bench ph")
+				require.Contains(t, body, "Totals                     7   3   3   3   1   1   2\n")
+				require.Contains(t, body, "2B: Away1.\n")
+				require.Contains(t, body, "E: Home6.\n")
+				require.Contains(t, body, "Home9                     1.0   3   3   3   1   1   1  32\n")
+			},
+		},
+		{
+			name: "HiddenStats",
+			buildStubs: func(store *mockdb.MockStore) {
+				expectStatVisibility(store, g.awayStarters[2].PlayerID, g.homeStarters[8].PlayerID)
+				store.EXPECT().
+					CreateAuditLog(gomock.Any(), gomock.Any()).
+					Times(0)
+				g.buildGameStubs(store)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp BoxScoreResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				slugger := rsp.Away.Batting[2].Players[0]
+				require.Equal(t, BattingLine{PlayerID: slugger.PlayerID, LastName: "Away3", Positions: slugger.Positions, Hidden: true}, slugger)
+				require.Equal(t, PitchingLine{PlayerID: g.homeStarters[8].PlayerID, LastName: "Home9", Hidden: true}, rsp.Home.Pitching[0])
+				require.False(t, rsp.Away.Batting[0].Players[0].Hidden)
+				require.Equal(t, int64(1), rsp.Away.Batting[0].Players[0].H)
+
+				// the team still gets credit for the hidden player's hits
+				require.Equal(t, int64(3), rsp.Away.Totals.H)
+				for _, note := range rsp.Away.Notes {
+					require.NotEqual(t, util.StatHomeRun, note.Type)
+				}
+			},
+		},
+		{
+			name:  "HiddenStatsText",
+			query: "?format=text",
+			buildStubs: func(store *mockdb.MockStore) {
+				expectStatVisibility(store, g.homeStarters[8].PlayerID)
+				g.buildGameStubs(store)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "Home9                       -   -   -   -   -   -   -   -\n")
+			},
+		},
+		{
+			name:  "AdminSeesHiddenStatsAudited",
+			roles: adminUserRoles,
+			buildStubs: func(store *mockdb.MockStore) {
+				expectStatVisibility(store, g.homeStarters[8].PlayerID)
+				store.EXPECT().
+					CreateAuditLog(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.CreateAuditLogParams) (db.AuditLog, error) {
+						require.Equal(t, string(util.AuditPrivacyBypass), arg.Action)
+						require.Equal(t, uuid.NullUUID{UUID: g.homeStarters[8].PlayerID, Valid: true}, arg.SubjectID)
+						return db.AuditLog{}, nil
+					})
+				g.buildGameStubs(store)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp BoxScoreResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.False(t, rsp.Home.Pitching[0].Hidden)
+				require.Equal(t, int64(32), rsp.Home.Pitching[0].Pitches)
+			},
+		},
+		{
+			name:  "InvalidFormat",
+			query: "?format=xml",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "PitchingChangeMidAtbat",
+			buildStubs: relieved.buildStubs,
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp BoxScoreResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Len(t, rsp.Home.Pitching, 2)
+				starter, relief := rsp.Home.Pitching[0], rsp.Home.Pitching[1]
+				require.Equal(t, g.homeStarters[8].PlayerID, starter.PlayerID)
+				require.Equal(t, int64(30), starter.Pitches)
+				require.Equal(t, int64(2), starter.Outs)
+				require.Equal(t, reliever.PlayerID, relief.PlayerID)
+				require.Equal(t, int64(2), relief.Pitches)
+				require.Equal(t, int64(1), relief.Outs)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(g.game.ID)).
+					Times(1).
+					Return(db.Game{}, sql.ErrNoRows)
+				store.EXPECT().
+					ListGameAtbats(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/games/%s/boxscore%s", g.game.ID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			roles := security.UserRoles
+			if tc.roles != nil {
+				roles = tc.roles
+			}
+			addAuthorization(t, request, server.tokenMaker, roles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
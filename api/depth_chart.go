@@ -0,0 +1,236 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/helpers"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"net/http"
+)
+
+var (
+	errDuplicatePosition = errors.New("positions must not repeat")
+	errDuplicatePlayer   = errors.New("user_ids must not repeat")
+)
+
+// errNotEligible is returned when a player is placed at a position they are not listed for.
+func errNotEligible(userID uuid.UUID, position string) error {
+	return fmt.Errorf("player %s is not eligible to play %s", userID, position)
+}
+
+// isEligibleForPosition reports whether a team member may play a position,
+// either as their primary position or as one of their secondary positions.
+func (s *Server) isEligibleForPosition(context *gin.Context, teamID uuid.UUID, userID uuid.UUID, position string) (bool, error) {
+	return s.store.IsEligibleForPosition(context, db.IsEligibleForPositionParams{
+		TeamID:   teamID,
+		UserID:   userID,
+		Position: position,
+	})
+}
+
+// ListPlayerPositions lists the secondary positions of a team member, best first.
+func (s *Server) ListPlayerPositions(context *gin.Context) {
+	var req AddTeamMemberRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	positions, err := s.store.ListPlayerPositions(context, db.ListPlayerPositionsParams{
+		TeamID: uuid.MustParse(req.TeamID),
+		UserID: uuid.MustParse(req.UserID),
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, positions)
+}
+
+// SetPlayerPositionsRequestBody represents the body of a request to set a member's positions.
+type SetPlayerPositionsRequestBody struct {
+	Positions []string `json:"positions" binding:"required,max=12"`
+}
+
+// SetPlayerPositions replaces every secondary position a team member can play.
+// The order of the positions is their rank. Only the team's coaches and admins may change it.
+func (s *Server) SetPlayerPositions(context *gin.Context) {
+	var req AddTeamMemberRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var body SetPlayerPositionsRequestBody
+	if err := context.ShouldBindJSON(&body); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	seen := make(map[string]bool, len(body.Positions))
+	for _, position := range body.Positions {
+		if !util.IsBaseballPosition(position) {
+			context.JSON(http.StatusBadRequest, helpers.ErrorResponse(fmt.Errorf("%s is not a valid baseball position", position)))
+			return
+		}
+		if seen[position] {
+			context.JSON(http.StatusBadRequest, helpers.ErrorResponse(errDuplicatePosition))
+			return
+		}
+		seen[position] = true
+	}
+
+	if !s.authorizeTeamCoach(context, uuid.MustParse(req.TeamID)) {
+		return
+	}
+
+	positions, err := s.store.SetPlayerPositionsTx(context, db.SetPlayerPositionsTxParams{
+		TeamID:    uuid.MustParse(req.TeamID),
+		UserID:    uuid.MustParse(req.UserID),
+		Positions: body.Positions,
+	})
+	if err != nil {
+		rosterError(context, err)
+		return
+	}
+
+	context.JSON(http.StatusOK, positions)
+}
+
+// DepthChartPlayer is a player listed on a team's depth chart.
+type DepthChartPlayer struct {
+	UserID    uuid.UUID `json:"user_id"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	Number    int64     `json:"number"`
+	Rank      int64     `json:"rank"`
+}
+
+// NewDepthChartResponse groups depth chart rows by position, keeping each position ordered by rank.
+func NewDepthChartResponse(rows []db.ListDepthChartRow) map[string][]DepthChartPlayer {
+	rsp := make(map[string][]DepthChartPlayer)
+	for _, row := range rows {
+		rsp[row.Position] = append(rsp[row.Position], DepthChartPlayer{
+			UserID:    row.UserID,
+			FirstName: row.FirstName,
+			LastName:  row.LastName,
+			Number:    row.Number,
+			Rank:      row.Rank,
+		})
+	}
+	return rsp
+}
+
+// GetDepthChart returns a team's depth chart, keyed by position.
+// Players whose profiles are hidden from the caller are left out.
+func (s *Server) GetDepthChart(context *gin.Context) {
+	var req GetTeamRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	arg := db.ListDepthChartParams{
+		TeamID:         uuid.MustParse(req.ID),
+		IncludePrivate: isAdmin(payload),
+		ViewerID:       payload.UserID,
+	}
+
+	rows, err := s.store.ListDepthChart(context, arg)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	if arg.IncludePrivate {
+		hidden := false
+		for _, row := range rows {
+			hidden = hidden || row.Hidden
+		}
+		if hidden {
+			if err := s.auditPrivacyBypass(context, payload, uuid.NullUUID{}); err != nil {
+				context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+				return
+			}
+		}
+	}
+
+	context.JSON(http.StatusOK, NewDepthChartResponse(rows))
+}
+
+// SetDepthChartRequest represents a request to change one position of a depth chart.
+type SetDepthChartRequest struct {
+	TeamID   string `uri:"id" binding:"required,uuid"`
+	Position string `uri:"position" binding:"required"`
+}
+
+// SetDepthChartRequestBody represents the players at a position, best first.
+type SetDepthChartRequestBody struct {
+	UserIDs []string `json:"user_ids" binding:"required,max=50,dive,uuid"`
+}
+
+// SetDepthChart replaces the ordered list of players at one position.
+// Every player must be able to play the position. Only the team's coaches and admins may change it.
+func (s *Server) SetDepthChart(context *gin.Context) {
+	var req SetDepthChartRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	if !util.IsBaseballPosition(req.Position) {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(fmt.Errorf("%s is not a valid baseball position", req.Position)))
+		return
+	}
+
+	var body SetDepthChartRequestBody
+	if err := context.ShouldBindJSON(&body); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	if !s.authorizeTeamCoach(context, uuid.MustParse(req.TeamID)) {
+		return
+	}
+
+	teamID := uuid.MustParse(req.TeamID)
+	userIDs := make([]uuid.UUID, 0, len(body.UserIDs))
+	seen := make(map[uuid.UUID]bool, len(body.UserIDs))
+	for _, id := range body.UserIDs {
+		userID := uuid.MustParse(id)
+		if seen[userID] {
+			context.JSON(http.StatusBadRequest, helpers.ErrorResponse(errDuplicatePlayer))
+			return
+		}
+		seen[userID] = true
+
+		eligible, err := s.isEligibleForPosition(context, teamID, userID, req.Position)
+		if err != nil {
+			context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+			return
+		}
+		if !eligible {
+			context.JSON(http.StatusBadRequest, helpers.ErrorResponse(errNotEligible(userID, req.Position)))
+			return
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	entries, err := s.store.SetDepthChartTx(context, db.SetDepthChartTxParams{
+		TeamID:   teamID,
+		Position: req.Position,
+		UserIDs:  userIDs,
+	})
+	if err != nil {
+		rosterError(context, err)
+		return
+	}
+
+	context.JSON(http.StatusOK, entries)
+}
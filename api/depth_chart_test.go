@@ -0,0 +1,460 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	mockdb "github.com/kwalter26/scoreit-api-go/db/mock"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/security"
+	"github.com/kwalter26/scoreit-api-go/security/token"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestServer_SetPlayerPositions(t *testing.T) {
+	user, _ := createRandomUser(t)
+	team := randomTeam()
+	positions := []string{string(util.ShortStop), string(util.SecondBase)}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"positions": positions},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.SetPlayerPositionsTxParams{
+					TeamID:    team.ID,
+					UserID:    user.ID,
+					Positions: positions,
+				}
+				store.EXPECT().
+					SetPlayerPositionsTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.PlayerPosition{
+						{ID: uuid.New(), TeamID: team.ID, UserID: user.ID, Position: positions[0], Rank: 1},
+						{ID: uuid.New(), TeamID: team.ID, UserID: user.ID, Position: positions[1], Rank: 2},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []db.PlayerPosition
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got, 2)
+				require.Equal(t, positions[0], got[0].Position)
+				require.Equal(t, int64(1), got[0].Rank)
+			},
+		},
+		{
+			name: "Clear",
+			body: gin.H{"positions": []string{}},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetPlayerPositionsTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.PlayerPosition{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidPosition",
+			body: gin.H{"positions": []string{"GOALIE"}},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetPlayerPositionsTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "DuplicatePosition",
+			body: gin.H{"positions": []string{positions[0], positions[0]}},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetPlayerPositionsTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), errDuplicatePosition.Error())
+			},
+		},
+		{
+			name: "NotMember",
+			body: gin.H{"positions": positions},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetPlayerPositionsTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, &pq.Error{Code: "23503"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "NotCoach",
+			body: gin.H{"positions": positions},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetPlayerPositionsTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectTeamCoach(store, user.ID)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			buf, err := buildJsonRequest(t, tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/teams/%s/members/%s/positions", team.ID, user.ID)
+			request, err := http.NewRequest(http.MethodPut, url, &buf)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_GetDepthChart(t *testing.T) {
+	user, _ := createRandomUser(t)
+	team := randomTeam()
+	rows := []db.ListDepthChartRow{
+		{Position: string(util.Catcher), Rank: 1, UserID: uuid.New(), FirstName: util.RandomName(), LastName: util.RandomName(), Number: 2},
+		{Position: string(util.Pitcher), Rank: 1, UserID: uuid.New(), FirstName: util.RandomName(), LastName: util.RandomName(), Number: 11},
+		{Position: string(util.Pitcher), Rank: 2, UserID: uuid.New(), FirstName: util.RandomName(), LastName: util.RandomName(), Number: 21},
+	}
+	// the admin sees one player who has hidden their profile
+	hiddenRows := append([]db.ListDepthChartRow{}, rows...)
+	hiddenRows[2].Hidden = true
+
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListDepthChartParams{
+					TeamID:   team.ID,
+					ViewerID: user.ID,
+				}
+				store.EXPECT().
+					ListDepthChart(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(rows, nil)
+				store.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got map[string][]DepthChartPlayer
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got, 2)
+				require.Len(t, got[string(util.Pitcher)], 2)
+				require.Equal(t, rows[1].UserID, got[string(util.Pitcher)][0].UserID)
+				require.Equal(t, rows[2].UserID, got[string(util.Pitcher)][1].UserID)
+			},
+		},
+		{
+			name: "AdminBypassAudited",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, adminUserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Times(1)
+				store.EXPECT().
+					ListDepthChart(gomock.Any(), gomock.Eq(db.ListDepthChartParams{
+						TeamID:         team.ID,
+						IncludePrivate: true,
+						ViewerID:       user.ID,
+					})).
+					Times(1).
+					Return(hiddenRows, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "AdminNothingHidden",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, adminUserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().
+					ListDepthChart(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(rows, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/teams/%s/depth-chart", team.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_SetDepthChart(t *testing.T) {
+	coach, _ := createRandomUser(t)
+	team := randomTeam()
+	starter := uuid.New()
+	backup := uuid.New()
+	position := string(util.ShortStop)
+
+	testCases := []struct {
+		name          string
+		position      string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			position: position,
+			body:     gin.H{"user_ids": []string{starter.String(), backup.String()}},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, coach.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					IsEligibleForPosition(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(true, nil)
+				arg := db.SetDepthChartTxParams{
+					TeamID:   team.ID,
+					Position: position,
+					UserIDs:  []uuid.UUID{starter, backup},
+				}
+				store.EXPECT().
+					SetDepthChartTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.DepthChartEntry{
+						{ID: uuid.New(), TeamID: team.ID, Position: position, UserID: starter, Rank: 1},
+						{ID: uuid.New(), TeamID: team.ID, Position: position, UserID: backup, Rank: 2},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "NotEligible",
+			position: position,
+			body:     gin.H{"user_ids": []string{starter.String(), backup.String()}},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, coach.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					IsEligibleForPosition(gomock.Any(), gomock.Eq(db.IsEligibleForPositionParams{
+						TeamID:   team.ID,
+						UserID:   starter,
+						Position: position,
+					})).
+					Times(1).
+					Return(true, nil)
+				store.EXPECT().
+					IsEligibleForPosition(gomock.Any(), gomock.Eq(db.IsEligibleForPositionParams{
+						TeamID:   team.ID,
+						UserID:   backup,
+						Position: position,
+					})).
+					Times(1).
+					Return(false, nil)
+				store.EXPECT().
+					SetDepthChartTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), errNotEligible(backup, position).Error())
+			},
+		},
+		{
+			name:     "InvalidPosition",
+			position: "GOALIE",
+			body:     gin.H{"user_ids": []string{starter.String()}},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, coach.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					IsEligibleForPosition(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					SetDepthChartTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "DuplicatePlayer",
+			position: position,
+			body:     gin.H{"user_ids": []string{starter.String(), starter.String()}},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, coach.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					IsEligibleForPosition(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(true, nil)
+				store.EXPECT().
+					SetDepthChartTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), errDuplicatePlayer.Error())
+			},
+		},
+		{
+			name:     "InvalidUserID",
+			position: position,
+			body:     gin.H{"user_ids": []string{"not-a-uuid"}},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, coach.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetDepthChartTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "NotCoach",
+			position: position,
+			body:     gin.H{"user_ids": []string{starter.String()}},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, coach.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					IsEligibleForPosition(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					SetDepthChartTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectTeamCoach(store, coach.ID)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			buf, err := buildJsonRequest(t, tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/teams/%s/depth-chart/%s", team.ID, tc.position)
+			request, err := http.NewRequest(http.MethodPut, url, &buf)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -2,30 +2,52 @@ package api
 
 import (
 	"database/sql"
+	"encoding/json"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/kwalter26/scoreit-api-go/api/helpers"
 	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/util"
 	"github.com/lib/pq"
-	"net/http"
+	"time"
 )
 
 // CreateGameRequest defines the request body for NewGameHandler.
 type CreateGameRequest struct {
-	HomeTeamID string `json:"home_team_id" binding:"required,uuid"`
-	AwayTeamID string `json:"away_team_id" binding:"required,uuid"`
+	HomeTeamID  string     `json:"home_team_id" binding:"required,uuid"`
+	AwayTeamID  string     `json:"away_team_id" binding:"required,uuid"`
+	ScheduledAt *time.Time `json:"scheduled_at"`
+	TimeZone    string     `json:"time_zone"`
+	VenueID     string     `json:"venue_id" binding:"omitempty,uuid"`
+	// FieldID places the game on a field; the venue is taken from the field
+	FieldID string `json:"field_id" binding:"omitempty,uuid"`
+	// ReentryRule sets whether substituted players may come back in; it defaults to none
+	ReentryRule string `json:"reentry_rule" binding:"omitempty,oneof=none starter_once unlimited"`
+	// RulePreset plays the game under a standard rule set, and Rules under a league's own.
+	// A game with neither is scored without a run cap, mercy rule, tiebreaker runner or designated hitter.
+	RulePreset string        `json:"rule_preset" binding:"omitempty,oneof=mlb nfhs little_league slow_pitch"`
+	Rules      *util.RuleSet `json:"rules"`
 }
 
 // CreateGameResponse defines the response body for NewGameHandler.
 type CreateGameResponse struct {
-	ID         string `json:"id"`
-	HomeTeamID string `json:"home_team_id"`
-	AwayTeamID string `json:"away_team_id"`
-	HomeScore  int64  `json:"home_score"`
-	AwayScore  int64  `json:"away_score"`
+	ID          string       `json:"id"`
+	HomeTeamID  string       `json:"home_team_id"`
+	AwayTeamID  string       `json:"away_team_id"`
+	HomeScore   int64        `json:"home_score"`
+	AwayScore   int64        `json:"away_score"`
+	Status      string       `json:"status"`
+	ScheduledAt *time.Time   `json:"scheduled_at,omitempty"`
+	TimeZone    string       `json:"time_zone"`
+	VenueID     *string      `json:"venue_id,omitempty"`
+	FieldID     *string      `json:"field_id,omitempty"`
+	ReentryRule string       `json:"reentry_rule"`
+	Rules       util.RuleSet `json:"rules"`
 }
 
-// CreateGame creates a new game.
+// CreateGame creates a new game. A game with a start time is rejected with 409
+// if either team or its field already has a game within the conflict window,
+// or if the field is not available then.
 func (s *Server) CreateGame(context *gin.Context) {
 	var req CreateGameRequest
 	if err := context.ShouldBindJSON(&req); err != nil {
@@ -33,33 +55,89 @@ func (s *Server) CreateGame(context *gin.Context) {
 		return
 	}
 
+	if req.TimeZone == "" {
+		req.TimeZone = "UTC"
+	}
+	if req.ReentryRule == "" {
+		req.ReentryRule = string(util.ReentryNone)
+	}
+	if !util.IsValidTimeZone(req.TimeZone) {
+		context.JSON(400, helpers.ErrorResponse(errInvalidTimeZone))
+		return
+	}
+	rules, err := newGameRules(req.RulePreset, req.Rules)
+	if err != nil {
+		context.JSON(400, helpers.ErrorResponse(err))
+		return
+	}
+	encodedRules, err := json.Marshal(rules)
+	if err != nil {
+		context.JSON(500, helpers.ErrorResponse(err))
+		return
+	}
+
 	homeID := uuid.MustParse(req.HomeTeamID)
 	awayID := uuid.MustParse(req.AwayTeamID)
+	venueID, fieldID, ok := s.resolveGameVenue(context, optionalUUID(req.VenueID), optionalUUID(req.FieldID))
+	if !ok {
+		return
+	}
 
-	game, err := s.store.CreateGame(context, db.CreateGameParams{
-		HomeTeamID: homeID,
-		AwayTeamID: awayID,
-		HomeScore:  0,
-		AwayScore:  0,
+	var scheduledAt sql.NullTime
+	if req.ScheduledAt != nil {
+		scheduledAt = sql.NullTime{Time: *req.ScheduledAt, Valid: true}
+		if s.respondFieldUnavailable(context, *req.ScheduledAt, fieldID) {
+			return
+		}
+	}
+
+	result, err := s.store.CreateGameTx(context, db.CreateGameTxParams{
+		CreateGameParams: db.CreateGameParams{
+			HomeTeamID:  homeID,
+			AwayTeamID:  awayID,
+			HomeScore:   0,
+			AwayScore:   0,
+			ScheduledAt: scheduledAt,
+			TimeZone:    req.TimeZone,
+			VenueID:     venueID,
+			FieldID:     fieldID,
+			ReentryRule: req.ReentryRule,
+			Rules:       encodedRules,
+		},
+		ConflictWindow: s.gameConflictWindow(),
 	})
 	if err != nil {
+		if respondScheduleConflict(context, err) {
+			return
+		}
 		if pgErr, err := err.(*pq.Error); err {
 			switch pgErr.Code.Name() {
 			case "unique_violation":
 				context.JSON(400, helpers.ErrorResponse(pgErr))
 				return
+			case "foreign_key_violation":
+				context.JSON(404, helpers.ErrorResponse(pgErr))
+				return
 			}
 		}
 		context.JSON(500, helpers.ErrorResponse(err))
 		return
 	}
+	game := result.Game
 
 	context.JSON(200, CreateGameResponse{
-		ID:         game.ID.String(),
-		HomeTeamID: game.HomeTeamID.String(),
-		AwayTeamID: game.AwayTeamID.String(),
-		HomeScore:  game.HomeScore,
-		AwayScore:  game.AwayScore,
+		ID:          game.ID.String(),
+		HomeTeamID:  game.HomeTeamID.String(),
+		AwayTeamID:  game.AwayTeamID.String(),
+		HomeScore:   game.HomeScore,
+		AwayScore:   game.AwayScore,
+		Status:      game.Status,
+		ScheduledAt: localScheduledAt(game),
+		TimeZone:    game.TimeZone,
+		VenueID:     nullUUIDString(game.VenueID),
+		FieldID:     nullUUIDString(game.FieldID),
+		ReentryRule: game.ReentryRule,
+		Rules:       rules,
 	})
 }
 
@@ -67,11 +145,20 @@ func (s *Server) CreateGame(context *gin.Context) {
 type ListGamesRequest struct {
 	HomeTeamID string `form:"home_team_id" binding:"omitempty,uuid"`
 	AwayTeamID string `form:"away_team_id" binding:"omitempty,uuid"`
-	PageSize   int32  `form:"page_size" binding:"omitempty,number,min=1,max=10"`
-	PageID     int32  `form:"page_id" binding:"omitempty,number,min=1"`
+	Status     string `form:"status" binding:"omitempty,oneof=scheduled in_progress final postponed suspended cancelled forfeit"`
+	// TeamID matches games where the team plays at home or away
+	TeamID   string    `form:"team_id" binding:"omitempty,uuid"`
+	VenueID  string    `form:"venue_id" binding:"omitempty,uuid"`
+	FieldID  string    `form:"field_id" binding:"omitempty,uuid"`
+	From     time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To       time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Sort     string    `form:"sort" binding:"omitempty,oneof=scheduled_at -scheduled_at"`
+	PageSize int32     `form:"page_size" binding:"omitempty,number,min=1,max=10"`
+	PageID   int32     `form:"page_id" binding:"omitempty,number,min=1"`
 }
 
-// ListGames lists all games.
+// ListGames lists games, newest first unless sorted by scheduled time.
+// The from and to filters apply to the scheduled start time.
 func (s *Server) ListGames(context *gin.Context) {
 	var req ListGamesRequest
 	if err := context.ShouldBindQuery(&req); err != nil {
@@ -79,6 +166,11 @@ func (s *Server) ListGames(context *gin.Context) {
 		return
 	}
 
+	if !req.From.IsZero() && !req.To.IsZero() && !req.To.After(req.From) {
+		context.JSON(400, helpers.ErrorResponse(errInvalidDateRange))
+		return
+	}
+
 	homeID := uuid.Nil
 	awayID := uuid.Nil
 	var err error
@@ -94,6 +186,13 @@ func (s *Server) ListGames(context *gin.Context) {
 		Offset:     (req.PageID - 1) * req.PageSize,
 		HomeTeamID: uuid.NullUUID{UUID: homeID, Valid: homeID != uuid.Nil},
 		AwayTeamID: uuid.NullUUID{UUID: awayID, Valid: awayID != uuid.Nil},
+		Status:     sql.NullString{String: req.Status, Valid: req.Status != ""},
+		TeamID:     optionalUUID(req.TeamID),
+		VenueID:    optionalUUID(req.VenueID),
+		FieldID:    optionalUUID(req.FieldID),
+		FromTime:   sql.NullTime{Time: req.From, Valid: !req.From.IsZero()},
+		ToTime:     sql.NullTime{Time: req.To, Valid: !req.To.IsZero()},
+		Sort:       sql.NullString{String: req.Sort, Valid: req.Sort != ""},
 	})
 	if err != nil {
 		context.JSON(500, helpers.ErrorResponse(err))
@@ -110,11 +209,18 @@ type GetGameRequest struct {
 
 // GetGameResponse defines the response body for GetGameHandler.
 type GetGameResponse struct {
-	ID         string `json:"id"`
-	HomeTeamID string `json:"home_team_id"`
-	AwayTeamID string `json:"away_team_id"`
-	HomeScore  int64  `json:"home_score"`
-	AwayScore  int64  `json:"away_score"`
+	ID          string       `json:"id"`
+	HomeTeamID  string       `json:"home_team_id"`
+	AwayTeamID  string       `json:"away_team_id"`
+	HomeScore   int64        `json:"home_score"`
+	AwayScore   int64        `json:"away_score"`
+	Status      string       `json:"status"`
+	ScheduledAt *time.Time   `json:"scheduled_at,omitempty"`
+	TimeZone    string       `json:"time_zone"`
+	VenueID     *string      `json:"venue_id,omitempty"`
+	FieldID     *string      `json:"field_id,omitempty"`
+	ReentryRule string       `json:"reentry_rule"`
+	Rules       util.RuleSet `json:"rules"`
 }
 
 // GetGame gets a game by ID.
@@ -136,56 +242,24 @@ func (s *Server) GetGame(context *gin.Context) {
 		context.JSON(500, helpers.ErrorResponse(err))
 		return
 	}
-
-	context.JSON(200, GetGameResponse{
-		ID:         game.ID.String(),
-		HomeTeamID: game.HomeTeamID.String(),
-		AwayTeamID: game.AwayTeamID.String(),
-		HomeScore:  game.HomeScore,
-		AwayScore:  game.AwayScore,
-	})
-}
-
-// UpdateGameRequest defines the request body for UpdateGameHandler.
-type UpdateGameRequest struct {
-	ID string `uri:"id" binding:"required,uuid"`
-}
-
-// UpdateGameBody defines the request body for UpdateGameHandler.
-type UpdateGameBody struct {
-	HomeScore int64 `json:"home_score"`
-	AwayScore int64 `json:"away_score"`
-}
-
-// UpdateGame updates a game by ID.
-func (s *Server) UpdateGame(context *gin.Context) {
-	var req UpdateGameRequest
-	if err := context.ShouldBindUri(&req); err != nil {
-		context.JSON(400, helpers.ErrorResponse(err))
-		return
-	}
-
-	var body UpdateGameBody
-	if err := context.ShouldBindJSON(&body); err != nil {
-		context.JSON(400, helpers.ErrorResponse(err))
-		return
-	}
-
-	id := uuid.MustParse(req.ID)
-
-	game, err := s.store.UpdateGame(context, db.UpdateGameParams{
-		ID:        id,
-		HomeScore: body.HomeScore,
-		AwayScore: body.AwayScore,
-	})
+	rules, err := gameRules(game)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
-			return
-		}
-		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		context.JSON(500, helpers.ErrorResponse(err))
 		return
 	}
 
-	context.JSON(http.StatusOK, game)
+	context.JSON(200, GetGameResponse{
+		ID:          game.ID.String(),
+		HomeTeamID:  game.HomeTeamID.String(),
+		AwayTeamID:  game.AwayTeamID.String(),
+		HomeScore:   game.HomeScore,
+		AwayScore:   game.AwayScore,
+		Status:      game.Status,
+		ScheduledAt: localScheduledAt(game),
+		TimeZone:    game.TimeZone,
+		VenueID:     nullUUIDString(game.VenueID),
+		FieldID:     nullUUIDString(game.FieldID),
+		ReentryRule: game.ReentryRule,
+		Rules:       rules,
+	})
 }
@@ -0,0 +1,658 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/helpers"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/scoring"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/lib/pq"
+	"net/http"
+)
+
+// RecordGameEventRequestBody represents one event in a game's play-by-play. Substitutions are
+// recorded through the lineup substitution endpoint, which also updates the game's participants.
+// Games end by themselves once decided; a game_end event ends one early with a reason, or calls a tie.
+type RecordGameEventRequestBody struct {
+	Type    string           `json:"type" binding:"required,oneof=pitch play inning_end game_end"`
+	Pitch   *scoring.Pitch   `json:"pitch"`
+	Play    *scoring.Play    `json:"play"`
+	GameEnd *scoring.GameEnd `json:"game_end"`
+}
+
+// GameStateResponse represents a game as it stands after replaying its events.
+type GameStateResponse struct {
+	GameID uuid.UUID `json:"game_id"`
+	// Batter is the participant due up
+	Batter string `json:"batter"`
+	*scoring.State
+}
+
+// RecordGameEventResponse represents a recorded event and the state of the game after it.
+type RecordGameEventResponse struct {
+	Event db.GameEvent      `json:"event"`
+	State GameStateResponse `json:"state"`
+}
+
+// UndoGameEventsRequestBody represents a request to undo the last events of a game.
+type UndoGameEventsRequestBody struct {
+	Count int64 `json:"count" binding:"required,min=1"`
+}
+
+// UndoGameEventsResponse represents the undone events and the state of the game without them.
+type UndoGameEventsResponse struct {
+	Events []db.GameEvent    `json:"events"`
+	State  GameStateResponse `json:"state"`
+}
+
+// CorrectGameEventRequest represents a request for one event in a game's play-by-play.
+type CorrectGameEventRequest struct {
+	ID       string `uri:"id" binding:"required,uuid"`
+	Sequence int64  `uri:"sequence" binding:"required,min=1"`
+}
+
+// CorrectGameEventResponse represents a corrected event, the original it replaced and the state of the game after it.
+type CorrectGameEventResponse struct {
+	Original db.GameEvent      `json:"original"`
+	Event    db.GameEvent      `json:"event"`
+	State    GameStateResponse `json:"state"`
+}
+
+// mercyRuleReason is logged as the reason a game the mercy rule ended went final
+const mercyRuleReason = "ended by the mercy rule"
+
+// gameEndReasons are logged as the reasons games ended early by a game_end event went final
+var gameEndReasons = map[scoring.GameEndReason]string{
+	scoring.EndSuspended: "suspended and not resumed",
+	scoring.EndForfeit:   "forfeited",
+	scoring.EndTimeLimit: "ended by the time limit",
+}
+
+var errSubstitutionNotCorrectable = errors.New("substitutions cannot be corrected; undo them or make another substitution instead")
+
+// RecordGameEvent appends an event to the play-by-play of a game in progress. The event is checked
+// against the game as replayed from its earlier events and rejected if it could not have happened;
+// the score, innings and at-bats are then rebuilt from the result. Only coaches and admins may score games.
+func (s *Server) RecordGameEvent(context *gin.Context) {
+	var req GetGameRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var body RecordGameEventRequestBody
+	if err := context.ShouldBindJSON(&body); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	s.recordGameEvent(context, uuid.MustParse(req.ID), scoring.Event{
+		Type:    scoring.EventType(body.Type),
+		Pitch:   body.Pitch,
+		Play:    body.Play,
+		GameEnd: body.GameEnd,
+	})
+}
+
+// UndoGameEvents undoes the last events of a game in progress. The undone events are kept in the
+// game's history, and the score, innings and at-bats are rebuilt from the events that remain. Undone
+// substitutions take their players back out and return the players they replaced to the game.
+func (s *Server) UndoGameEvents(context *gin.Context) {
+	var req GetGameRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var body UndoGameEventsRequestBody
+	if err := context.ShouldBindJSON(&body); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	if !isCoachOrAdmin(payload) {
+		context.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	game, ok := s.scoringGame(context, uuid.MustParse(req.ID))
+	if !ok {
+		return
+	}
+
+	log, err := s.gameLog(context, game)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+	if body.Count > int64(len(log.events)) {
+		err := fmt.Errorf("there are only %d events to undo", len(log.events))
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+	kept := len(log.events) - int(body.Count)
+	substitutions, err := undoneSubstitutions(log.decoded[kept:])
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	state, err := scoring.Replay(log.rules, log.home, log.away, log.decoded[:kept])
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	result, err := s.store.UndoGameEventsTx(context, db.UndoGameEventsTxParams{
+		GameID:        game.ID,
+		FromSequence:  log.events[kept].Sequence,
+		LastSequence:  log.events[len(log.events)-1].Sequence,
+		VoidedBy:      payload.UserID,
+		Projection:    gameProjection(state, 0, 1, log.scorers()),
+		Substitutions: substitutions,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusConflict, helpers.ErrorResponse(errGameChanged))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, UndoGameEventsResponse{
+		Events: result.Events,
+		State:  GameStateResponse{GameID: game.ID, Batter: state.Batter(), State: state},
+	})
+}
+
+// undoneSubstitutions lists the changes made by the substitutions among undone events, latest first,
+// so that a participant brought in and replaced again is taken out in the reverse order they went in.
+func undoneSubstitutions(undone []scoring.Event) ([]db.UndoSubstitutionParams, error) {
+	var substitutions []db.UndoSubstitutionParams
+	for i := len(undone) - 1; i >= 0; i-- {
+		if undone[i].Type != scoring.EventSubstitution {
+			continue
+		}
+		changes := undone[i].Substitution.Changes
+		for j := len(changes) - 1; j >= 0; j-- {
+			entered, err := uuid.Parse(changes[j].InID)
+			if err != nil {
+				return nil, err
+			}
+			replaced, err := uuid.Parse(changes[j].ReplacesID)
+			if err != nil {
+				return nil, err
+			}
+			substitutions = append(substitutions, db.UndoSubstitutionParams{EnteredID: entered, ReplacedID: replaced})
+		}
+	}
+	return substitutions, nil
+}
+
+// CorrectGameEvent replaces an earlier event of a game in progress, such as changing a hit to an error.
+// The corrected log is replayed from the start and rejected if any later event could no longer have
+// happened; the original event is kept in the game's history with who corrected it.
+func (s *Server) CorrectGameEvent(context *gin.Context) {
+	var req CorrectGameEventRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var body RecordGameEventRequestBody
+	if err := context.ShouldBindJSON(&body); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	if !isCoachOrAdmin(payload) {
+		context.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	game, ok := s.scoringGame(context, uuid.MustParse(req.ID))
+	if !ok {
+		return
+	}
+
+	log, err := s.gameLog(context, game)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+	index := -1
+	for i, event := range log.events {
+		if event.Sequence == req.Sequence {
+			index = i
+		}
+	}
+	if index < 0 {
+		err := fmt.Errorf("event %d not found", req.Sequence)
+		context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+		return
+	}
+	if log.decoded[index].Type == scoring.EventSubstitution {
+		context.JSON(http.StatusConflict, helpers.ErrorResponse(errSubstitutionNotCorrectable))
+		return
+	}
+
+	event := scoring.Event{Type: scoring.EventType(body.Type), Pitch: body.Pitch, Play: body.Play, GameEnd: body.GameEnd}
+	corrected := append([]scoring.Event{}, log.decoded...)
+	corrected[index] = event
+	state, err := scoring.Replay(log.rules, log.home, log.away, corrected)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	encoded, err := scoring.Encode(event)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	scorers := log.scorers()
+	scorers[req.Sequence] = payload.UserID
+
+	result, err := s.store.CorrectGameEventTx(context, db.CorrectGameEventTxParams{
+		GameID:       game.ID,
+		Sequence:     req.Sequence,
+		LastSequence: log.events[len(log.events)-1].Sequence,
+		Event:        db.GameEventParams{Type: string(event.Type), Payload: encoded},
+		CreatedBy:    payload.UserID,
+		Projection:   gameProjection(state, 0, 1, scorers),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusConflict, helpers.ErrorResponse(errGameChanged))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, CorrectGameEventResponse{
+		Original: result.Original,
+		Event:    result.Event,
+		State:    GameStateResponse{GameID: game.ID, Batter: state.Batter(), State: state},
+	})
+}
+
+// ListGameEventHistory lists every event ever recorded for a game, including those undone or
+// replaced by a correction, with who voided them.
+func (s *Server) ListGameEventHistory(context *gin.Context) {
+	var req GetGameRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	game, err := s.store.GetGame(context, uuid.MustParse(req.ID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	events, err := s.store.ListGameEventHistory(context, game.ID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, events)
+}
+
+// ListGameEvents lists a game's play-by-play events in order, leaving out those undone or replaced by a correction.
+func (s *Server) ListGameEvents(context *gin.Context) {
+	var req GetGameRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	game, err := s.store.GetGame(context, uuid.MustParse(req.ID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	events, err := s.store.ListGameEvents(context, game.ID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, events)
+}
+
+// GetGameState replays a game's events and returns where the game stands: the count, outs,
+// runners, score, who is due up and every plate appearance so far.
+func (s *Server) GetGameState(context *gin.Context) {
+	var req GetGameRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	game, err := s.store.GetGame(context, uuid.MustParse(req.ID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	state, _, err := s.replayGame(context, game)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, GameStateResponse{GameID: game.ID, Batter: state.Batter(), State: state})
+}
+
+// recordGameEvent checks an event against the game's replayed state, then appends it and writes
+// the projection. It writes the response itself.
+func (s *Server) recordGameEvent(context *gin.Context, gameID uuid.UUID, event scoring.Event) {
+	payload := middleware.GetAuthorizationPayload(context)
+	if !isCoachOrAdmin(payload) {
+		context.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	game, ok := s.scoringGame(context, gameID)
+	if !ok {
+		return
+	}
+
+	state, events, err := s.replayGame(context, game)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	fromAtbat, fromInning := len(state.PlateAppearances), state.Inning
+	if err := state.Apply(event); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	encoded, err := scoring.Encode(event)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	scorers := gameEventLog{events: events}.scorers()
+	scorers[state.Sequence] = payload.UserID
+
+	result, err := s.store.RecordGameEventTx(context, db.RecordGameEventTxParams{
+		GameID:     game.ID,
+		Sequence:   state.Sequence,
+		Event:      db.GameEventParams{Type: string(event.Type), Payload: encoded},
+		CreatedBy:  payload.UserID,
+		Projection: gameProjection(state, fromAtbat, fromInning, scorers),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusConflict, helpers.ErrorResponse(errGameChanged))
+			return
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			context.JSON(http.StatusConflict, helpers.ErrorResponse(errGameChanged))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, RecordGameEventResponse{
+		Event: result.Event,
+		State: GameStateResponse{GameID: game.ID, Batter: state.Batter(), State: state},
+	})
+}
+
+// scoringGame gets a game whose events the caller may change: one in progress that they coach a team in.
+// It writes the response itself when the game cannot be scored.
+func (s *Server) scoringGame(context *gin.Context, gameID uuid.UUID) (db.Game, bool) {
+	game, err := s.store.GetGame(context, gameID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return game, false
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return game, false
+	}
+	if !s.authorizeGameCoach(context, game) {
+		return game, false
+	}
+	switch util.GameStatus(game.Status) {
+	case util.GameInProgress:
+		return game, true
+	case util.GameFinal:
+		context.JSON(http.StatusConflict, helpers.ErrorResponse(errGameFinal))
+	default:
+		context.JSON(http.StatusConflict, helpers.ErrorResponse(errGameNotScoring))
+	}
+	return game, false
+}
+
+// gameEventLog is what a game is replayed from: its rules, its starting lineups and its events, stored and decoded
+type gameEventLog struct {
+	rules      util.RuleSet
+	home, away []scoring.LineupEntry
+	events     []db.GameEvent
+	decoded    []scoring.Event
+}
+
+// scorers maps event sequences to the users who recorded them
+func (log gameEventLog) scorers() map[int64]uuid.UUID {
+	scorers := make(map[int64]uuid.UUID, len(log.events)+1)
+	for _, event := range log.events {
+		scorers[event.Sequence] = event.CreatedBy
+	}
+	return scorers
+}
+
+// replayGame rebuilds a game's state from its rules, starting lineups and event log
+func (s *Server) replayGame(context *gin.Context, game db.Game) (*scoring.State, []db.GameEvent, error) {
+	log, err := s.gameLog(context, game)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	state, err := scoring.Replay(log.rules, log.home, log.away, log.decoded)
+	return state, log.events, err
+}
+
+// gameLog loads a game's rules and starting lineups and decodes the events in its log
+func (s *Server) gameLog(context *gin.Context, game db.Game) (gameEventLog, error) {
+	var log gameEventLog
+	rules, err := gameRules(game)
+	if err != nil {
+		return log, err
+	}
+	log.rules = rules
+
+	participants, err := s.store.ListGameParticipants(context, game.ID)
+	if err != nil {
+		return log, err
+	}
+	for _, participant := range participants {
+		if util.ParticipantEntry(participant.Entry) != util.EntryStarter {
+			continue
+		}
+		entry := scoring.LineupEntry{
+			ID:          participant.ID.String(),
+			BatPosition: participant.BatPosition,
+			Position:    util.BaseballPosition(participant.Position),
+		}
+		if participant.HomeTeam {
+			log.home = append(log.home, entry)
+		} else {
+			log.away = append(log.away, entry)
+		}
+	}
+
+	log.events, err = s.store.ListGameEvents(context, game.ID)
+	if err != nil {
+		return log, err
+	}
+	log.decoded = make([]scoring.Event, len(log.events))
+	for i, event := range log.events {
+		log.decoded[i], err = scoring.Decode(event.Payload)
+		if err != nil {
+			return log, fmt.Errorf("event %d: %w", event.Sequence, err)
+		}
+	}
+	return log, nil
+}
+
+// gameProjection returns the part of the state an event may have changed: the score, the plate appearance
+// that was in progress before it and everything after, and the innings they fall in. scorers maps event
+// sequences to the users who recorded them. A game that is over goes final with it.
+func gameProjection(state *scoring.State, fromAtbat int, fromInning int64, scorers map[int64]uuid.UUID) db.GameProjection {
+	projection := db.GameProjection{
+		HomeScore:  state.HomeScore,
+		AwayScore:  state.AwayScore,
+		LastInning: state.Inning,
+		LastAtbat:  int64(len(state.PlateAppearances)),
+		Final:      state.Final,
+	}
+	if state.Mercy {
+		projection.FinalReason = mercyRuleReason
+	}
+	if state.EndReason != "" {
+		projection.FinalReason = gameEndReasons[state.EndReason]
+	}
+	// a forfeited game ends as a forfeit rather than final
+	if state.EndReason == scoring.EndForfeit {
+		projection.FinalStatus = string(util.GameForfeit)
+	}
+
+	if fromAtbat > 0 {
+		fromAtbat--
+	}
+	appearances := state.PlateAppearances[fromAtbat:]
+	if len(appearances) > 0 && appearances[0].Inning < fromInning {
+		fromInning = appearances[0].Inning
+	}
+
+	for _, line := range state.Innings {
+		if line.Number < fromInning {
+			continue
+		}
+		projection.Innings = append(projection.Innings, db.ProjectInningParams{
+			Number:      line.Number,
+			HomeRuns:    line.HomeRuns,
+			HomeHits:    line.HomeHits,
+			HomeErrors:  line.HomeErrors,
+			HomeLastBat: participantID(line.HomeLastBat),
+			AwayRuns:    line.AwayRuns,
+			AwayHits:    line.AwayHits,
+			AwayErrors:  line.AwayErrors,
+			AwayLastBat: participantID(line.AwayLastBat),
+		})
+	}
+
+	for _, pa := range appearances {
+		atbat := db.AtbatProjection{
+			Number:         pa.Number,
+			Inning:         pa.Inning,
+			Half:           string(pa.Half),
+			BatterID:       uuid.MustParse(pa.BatterID),
+			PitcherID:      uuid.MustParse(pa.PitcherID),
+			Balls:          pa.Balls,
+			Strikes:        pa.Strikes,
+			Result:         pa.Result,
+			Out:            pa.Out,
+			InitBases:      pa.InitBases,
+			TotalBases:     pa.TotalBases,
+			RunnerOnFirst:  participantID(pa.StartBases[0]),
+			RunnerOnSecond: participantID(pa.StartBases[1]),
+			RunnerOnThird:  participantID(pa.StartBases[2]),
+			RBI:            pa.RBI,
+		}
+		for _, pitch := range pa.Pitches {
+			atbat.Pitches = append(atbat.Pitches, db.PitchProjection{
+				Type:      string(pitch.Type),
+				Balls:     pitch.Balls,
+				Strikes:   pitch.Strikes,
+				PitcherID: uuid.MustParse(pitch.PitcherID),
+				CreatedBy: scorers[pitch.Sequence],
+			})
+		}
+		for _, move := range pa.Runners {
+			atbat.Runners = append(atbat.Runners, db.RunnerProjection{
+				RunnerID:  uuid.MustParse(move.RunnerID),
+				FromBase:  int64(move.From),
+				ToBase:    int64(move.To),
+				Out:       move.Out,
+				Reason:    string(move.Reason),
+				Scored:    move.Scored,
+				RBI:       move.RBI,
+				Earned:    move.Earned,
+				PitcherID: participantID(move.PitcherID),
+			})
+		}
+		atbat.Stats = atbatStats(pa)
+		projection.Atbats = append(projection.Atbats, atbat)
+	}
+	return projection
+}
+
+// atbatStats returns the extra-base hits, stolen bases, times caught stealing and errors of a plate appearance
+func atbatStats(pa scoring.PlateAppearance) []db.StatProjection {
+	var stats []db.StatProjection
+	add := func(statType util.GameStatType, participant string) {
+		stats = append(stats, db.StatProjection{Type: string(statType), ParticipantID: uuid.MustParse(participant)})
+	}
+
+	switch scoring.PlayKind(pa.Result) {
+	case scoring.PlayDouble:
+		add(util.StatDouble, pa.BatterID)
+	case scoring.PlayTriple:
+		add(util.StatTriple, pa.BatterID)
+	case scoring.PlayHomeRun:
+		add(util.StatHomeRun, pa.BatterID)
+	}
+	for _, move := range pa.Runners {
+		switch {
+		case move.Reason == scoring.ReasonStolenBase && !move.Out:
+			add(util.StatStolenBase, move.RunnerID)
+		case move.Reason == scoring.ReasonCaughtStealing && move.Out:
+			add(util.StatCaughtStealing, move.RunnerID)
+		}
+	}
+	for _, fielder := range pa.Errors {
+		add(util.StatError, fielder)
+	}
+	return stats
+}
+
+// participantID converts a participant ID from the scoring engine, where "" means nobody
+func participantID(id string) uuid.NullUUID {
+	if id == "" {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: uuid.MustParse(id), Valid: true}
+}
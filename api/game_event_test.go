@@ -0,0 +1,857 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	mockdb "github.com/kwalter26/scoreit-api-go/db/mock"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/scoring"
+	"github.com/kwalter26/scoreit-api-go/security"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// randomStartingNines returns the home starters followed by the away starters, each with the pitcher batting last.
+func randomStartingNines(gameID uuid.UUID) []db.ListGameParticipantsRow {
+	home := randomParticipants(gameID)
+	away := randomParticipants(gameID)
+	for i := range away {
+		away[i].HomeTeam = false
+	}
+	return append(home, away...)
+}
+
+func pitchEvent(pitchType util.PitchType) scoring.Event {
+	return scoring.Event{Type: scoring.EventPitch, Pitch: &scoring.Pitch{Type: pitchType}}
+}
+
+// recordedEvent returns an event as stored in a game's log, recorded by a random user
+func recordedEvent(t *testing.T, gameID uuid.UUID, sequence int64, event scoring.Event) db.GameEvent {
+	payload, err := scoring.Encode(event)
+	require.NoError(t, err)
+	return db.GameEvent{
+		ID:        uuid.New(),
+		GameID:    gameID,
+		Sequence:  sequence,
+		Type:      string(event.Type),
+		Payload:   payload,
+		CreatedBy: uuid.New(),
+	}
+}
+
+func TestServer_RecordGameEvent(t *testing.T) {
+	user, _ := createRandomUser(t)
+	game := db.Game{ID: uuid.New(), HomeTeamID: uuid.New(), AwayTeamID: uuid.New(), Status: string(util.GameInProgress)}
+
+	participants := randomStartingNines(game.ID)
+	inPlay := []db.GameEvent{recordedEvent(t, game.ID, 1, pitchEvent(util.PitchInPlay))}
+
+	expectReplay := func(store *mockdb.MockStore, events []db.GameEvent) {
+		store.EXPECT().
+			GetGame(gomock.Any(), gomock.Eq(game.ID)).
+			Times(1).
+			Return(game, nil)
+		store.EXPECT().
+			ListGameParticipants(gomock.Any(), gomock.Eq(game.ID)).
+			Times(1).
+			Return(participants, nil)
+		store.EXPECT().
+			ListGameEvents(gomock.Any(), gomock.Eq(game.ID)).
+			Times(1).
+			Return(events, nil)
+	}
+
+	testCases := []struct {
+		name          string
+		roles         []security.Role
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "HomeRun",
+			roles: coachRoles,
+			body:  gin.H{"type": scoring.EventPlay, "play": gin.H{"kind": scoring.PlayHomeRun}},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectReplay(store, inPlay)
+				store.EXPECT().
+					RecordGameEventTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.RecordGameEventTxParams) (db.RecordGameEventTxResult, error) {
+						require.Equal(t, int64(2), arg.Sequence)
+						require.Equal(t, int64(1), arg.Projection.AwayScore)
+						require.Equal(t, int64(1), arg.Projection.Innings[0].AwayRuns)
+						require.Equal(t, int64(1), arg.Projection.Innings[0].AwayHits)
+
+						atbat := arg.Projection.Atbats[0]
+						require.Equal(t, string(scoring.PlayHomeRun), atbat.Result)
+						require.Equal(t, int64(4), atbat.TotalBases)
+						require.Equal(t, inPlay[0].CreatedBy, atbat.Pitches[0].CreatedBy)
+						require.Equal(t, int64(1), atbat.RBI)
+						require.Len(t, atbat.Runners, 1)
+						require.True(t, atbat.Runners[0].Scored)
+						require.True(t, atbat.Runners[0].Earned)
+						require.Equal(t, participants[8].ID, atbat.Runners[0].PitcherID.UUID)
+						require.Equal(t, []db.StatProjection{{Type: string(util.StatHomeRun), ParticipantID: participants[9].ID}}, atbat.Stats)
+
+						event, err := scoring.Decode(arg.Event.Payload)
+						require.NoError(t, err)
+						require.Equal(t, scoring.PlayHomeRun, event.Play.Kind)
+						return db.RecordGameEventTxResult{Event: db.GameEvent{Sequence: 2}}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp RecordGameEventResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, int64(1), rsp.State.AwayScore)
+				require.False(t, rsp.State.AwaitingPlay)
+				require.Len(t, rsp.State.PlateAppearances, 1)
+			},
+		},
+		{
+			name:  "ImpossiblePlay",
+			roles: coachRoles,
+			body: gin.H{"type": scoring.EventPlay, "play": gin.H{
+				"kind":     scoring.PlayStolenBase,
+				"advances": []gin.H{{"from": scoring.First, "to": scoring.Second}},
+			}},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectReplay(store, nil)
+				store.EXPECT().
+					RecordGameEventTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "there is no runner on 1")
+			},
+		},
+		{
+			name:  "RunCapEndsHalf",
+			roles: coachRoles,
+			body:  gin.H{"type": scoring.EventPitch, "pitch": gin.H{"type": util.PitchBall}},
+			buildStubs: func(store *mockdb.MockStore) {
+				capped := game
+				capped.Rules = encodeRules(util.RuleSet{MaxRunsPerInning: 1})
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(capped, nil)
+				store.EXPECT().
+					ListGameParticipants(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(participants, nil)
+				store.EXPECT().
+					ListGameEvents(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(append(inPlay, recordedEvent(t, game.ID, 2, playEvent(scoring.PlayHomeRun))), nil)
+				store.EXPECT().
+					RecordGameEventTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.RecordGameEventTxParams) (db.RecordGameEventTxResult, error) {
+						// the home run reached the cap, so the pitch is to the home team's leadoff hitter
+						atbat := arg.Projection.Atbats[len(arg.Projection.Atbats)-1]
+						require.Equal(t, string(util.HalfBottom), atbat.Half)
+						require.Equal(t, participants[0].ID, atbat.BatterID)
+						require.False(t, arg.Projection.Final)
+						return db.RecordGameEventTxResult{Event: db.GameEvent{Sequence: 3}}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "GameEndGoesFinal",
+			roles: coachRoles,
+			body:  gin.H{"type": scoring.EventGameEnd},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectReplay(store, append(inPlay, recordedEvent(t, game.ID, 2, playEvent(scoring.PlayHomeRun))))
+				store.EXPECT().
+					RecordGameEventTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.RecordGameEventTxParams) (db.RecordGameEventTxResult, error) {
+						require.True(t, arg.Projection.Final)
+						require.Empty(t, arg.Projection.FinalReason)
+						return db.RecordGameEventTxResult{Event: db.GameEvent{Sequence: 3}}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "ForfeitEndsAsForfeit",
+			roles: coachRoles,
+			body:  gin.H{"type": scoring.EventGameEnd, "game_end": gin.H{"reason": scoring.EndForfeit}},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectReplay(store, nil)
+				store.EXPECT().
+					RecordGameEventTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.RecordGameEventTxParams) (db.RecordGameEventTxResult, error) {
+						require.True(t, arg.Projection.Final)
+						require.Equal(t, string(util.GameForfeit), arg.Projection.FinalStatus)
+						require.Equal(t, "forfeited", arg.Projection.FinalReason)
+						return db.RecordGameEventTxResult{Event: db.GameEvent{Sequence: 1}}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "GameEndBeforeRegulation",
+			roles: coachRoles,
+			body:  gin.H{"type": scoring.EventGameEnd},
+			buildStubs: func(store *mockdb.MockStore) {
+				regulation := game
+				regulation.Rules = encodeRules(util.RuleSet{RegulationInnings: 7})
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(regulation, nil)
+				store.EXPECT().
+					ListGameParticipants(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(participants, nil)
+				store.EXPECT().
+					ListGameEvents(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(nil, nil)
+				store.EXPECT().
+					RecordGameEventTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "the game has not played its 7 regulation innings")
+			},
+		},
+		{
+			name:  "MercyGoesFinal",
+			roles: coachRoles,
+			body:  gin.H{"type": scoring.EventPlay, "play": gin.H{"kind": scoring.PlayHomeRun}},
+			buildStubs: func(store *mockdb.MockStore) {
+				mercy := game
+				mercy.Rules = encodeRules(util.RuleSet{MercyRules: []util.MercyRule{{Inning: 1, Runs: 1}}})
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(mercy, nil)
+				store.EXPECT().
+					ListGameParticipants(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(participants, nil)
+
+				// the visitors strike out in order and the home leadoff hitter puts the first pitch in play
+				var events []db.GameEvent
+				for i := 0; i < 9; i++ {
+					events = append(events, recordedEvent(t, game.ID, int64(len(events)+1), pitchEvent(util.PitchCalledStrike)))
+				}
+				events = append(events, recordedEvent(t, game.ID, int64(len(events)+1), scoring.Event{Type: scoring.EventInningEnd}))
+				events = append(events, recordedEvent(t, game.ID, int64(len(events)+1), pitchEvent(util.PitchInPlay)))
+				store.EXPECT().
+					ListGameEvents(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(events, nil)
+				store.EXPECT().
+					RecordGameEventTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.RecordGameEventTxParams) (db.RecordGameEventTxResult, error) {
+						require.True(t, arg.Projection.Final)
+						require.Equal(t, mercyRuleReason, arg.Projection.FinalReason)
+						require.Equal(t, int64(1), arg.Projection.HomeScore)
+						return db.RecordGameEventTxResult{Event: db.GameEvent{Sequence: 12}}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "MissingPlay",
+			roles: coachRoles,
+			body:  gin.H{"type": scoring.EventPlay},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectReplay(store, inPlay)
+				store.EXPECT().
+					RecordGameEventTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "SubstitutionNotAllowed",
+			roles: coachRoles,
+			body:  gin.H{"type": scoring.EventSubstitution},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "CorruptLog",
+			roles: coachRoles,
+			body:  gin.H{"type": scoring.EventInningEnd},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectReplay(store, []db.GameEvent{{Sequence: 1, Type: "timeout", Payload: json.RawMessage(`{"type":"timeout"}`)}})
+				store.EXPECT().
+					RecordGameEventTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:  "NotCoach",
+			roles: security.UserRoles,
+			body:  gin.H{"type": scoring.EventInningEnd},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectTeamCoach(store, user.ID)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			buf, err := buildJsonRequest(t, tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/games/%s/events", game.ID)
+			request, err := http.NewRequest(http.MethodPost, url, &buf)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, tc.roles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_GetGameState(t *testing.T) {
+	user, _ := createRandomUser(t)
+	game := db.Game{ID: uuid.New(), HomeTeamID: uuid.New(), AwayTeamID: uuid.New(), Status: string(util.GameInProgress)}
+	participants := randomStartingNines(game.ID)
+
+	// the leadoff hitter walks, then is replaced by a pinch runner
+	events := []db.GameEvent{
+		recordedEvent(t, game.ID, 1, pitchEvent(util.PitchBall)),
+		recordedEvent(t, game.ID, 2, pitchEvent(util.PitchBall)),
+		recordedEvent(t, game.ID, 3, pitchEvent(util.PitchBall)),
+		recordedEvent(t, game.ID, 4, pitchEvent(util.PitchBall)),
+	}
+	runner := uuid.New()
+	events = append(events, recordedEvent(t, game.ID, 5, scoring.Event{
+		Type: scoring.EventSubstitution,
+		Substitution: &scoring.Substitution{Changes: []scoring.Change{{
+			ReplacesID:  participants[9].ID.String(),
+			InID:        runner.String(),
+			BatPosition: 1,
+			Position:    util.CenterField,
+		}}},
+	}))
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					ListGameParticipants(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(participants, nil)
+				store.EXPECT().
+					ListGameEvents(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(events, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp GameStateResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, game.ID, rsp.GameID)
+				require.Equal(t, int64(5), rsp.Sequence)
+				require.Equal(t, runner.String(), rsp.Bases[0])
+				require.Equal(t, participants[10].ID.String(), rsp.Batter)
+				require.Equal(t, participants[8].ID.String(), rsp.Home.Pitcher)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(db.Game{}, sql.ErrNoRows)
+				store.EXPECT().
+					ListGameEvents(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/games/%s/state", game.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func playEvent(kind scoring.PlayKind, advances ...scoring.Advance) scoring.Event {
+	return scoring.Event{Type: scoring.EventPlay, Play: &scoring.Play{Kind: kind, Advances: advances}}
+}
+
+func TestServer_UndoGameEvents(t *testing.T) {
+	user, _ := createRandomUser(t)
+	game := db.Game{ID: uuid.New(), HomeTeamID: uuid.New(), AwayTeamID: uuid.New(), Status: string(util.GameInProgress)}
+	participants := randomStartingNines(game.ID)
+
+	// three balls, then a fourth recorded by mistake and a pitch put in play
+	var events []db.GameEvent
+	for i := int64(1); i <= 4; i++ {
+		events = append(events, recordedEvent(t, game.ID, i, pitchEvent(util.PitchBall)))
+	}
+	events = append(events, recordedEvent(t, game.ID, 5, pitchEvent(util.PitchInPlay)))
+
+	// a pinch hitter comes in after the fourth ball and is replaced again by another
+	pinchHitter, secondPinchHitter := uuid.New(), uuid.New()
+	substituted := append([]db.GameEvent{}, events[:4]...)
+	substituted = append(substituted, recordedEvent(t, game.ID, 5, scoring.Event{
+		Type: scoring.EventSubstitution,
+		Substitution: &scoring.Substitution{Changes: []scoring.Change{{
+			ReplacesID:  participants[9].ID.String(),
+			InID:        pinchHitter.String(),
+			BatPosition: 1,
+			Position:    util.CenterField,
+		}}},
+	}))
+	substituted = append(substituted, recordedEvent(t, game.ID, 6, scoring.Event{
+		Type: scoring.EventSubstitution,
+		Substitution: &scoring.Substitution{Changes: []scoring.Change{{
+			ReplacesID:  pinchHitter.String(),
+			InID:        secondPinchHitter.String(),
+			BatPosition: 1,
+			Position:    util.CenterField,
+		}}},
+	}))
+
+	expectReplay := func(store *mockdb.MockStore, events []db.GameEvent) {
+		store.EXPECT().
+			GetGame(gomock.Any(), gomock.Eq(game.ID)).
+			Times(1).
+			Return(game, nil)
+		store.EXPECT().
+			ListGameParticipants(gomock.Any(), gomock.Eq(game.ID)).
+			Times(1).
+			Return(participants, nil)
+		store.EXPECT().
+			ListGameEvents(gomock.Any(), gomock.Eq(game.ID)).
+			Times(1).
+			Return(events, nil)
+	}
+
+	testCases := []struct {
+		name          string
+		roles         []security.Role
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			roles: coachRoles,
+			body:  gin.H{"count": 2},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectReplay(store, events)
+				store.EXPECT().
+					UndoGameEventsTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.UndoGameEventsTxParams) (db.UndoGameEventsTxResult, error) {
+						require.Equal(t, int64(4), arg.FromSequence)
+						require.Equal(t, int64(5), arg.LastSequence)
+						require.Equal(t, user.ID, arg.VoidedBy)
+						require.Equal(t, int64(1), arg.Projection.LastAtbat)
+						require.Equal(t, int64(1), arg.Projection.LastInning)
+						require.Len(t, arg.Projection.Atbats, 1)
+						require.Equal(t, int64(3), arg.Projection.Atbats[0].Balls)
+						require.Empty(t, arg.Projection.Atbats[0].Result)
+						require.Len(t, arg.Projection.Atbats[0].Pitches, 3)
+						return db.UndoGameEventsTxResult{Events: events[3:]}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp UndoGameEventsResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Len(t, rsp.Events, 2)
+				require.Equal(t, int64(3), rsp.State.Balls)
+				require.Equal(t, int64(3), rsp.State.Sequence)
+				require.False(t, rsp.State.AwaitingPlay)
+			},
+		},
+		{
+			name:  "TooMany",
+			roles: coachRoles,
+			body:  gin.H{"count": 6},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectReplay(store, events)
+				store.EXPECT().
+					UndoGameEventsTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Substitutions",
+			roles: coachRoles,
+			body:  gin.H{"count": 3},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectReplay(store, substituted)
+				store.EXPECT().
+					UndoGameEventsTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.UndoGameEventsTxParams) (db.UndoGameEventsTxResult, error) {
+						require.Equal(t, int64(4), arg.FromSequence)
+						require.Equal(t, int64(6), arg.LastSequence)
+						// the latest substitution is taken back first
+						require.Equal(t, []db.UndoSubstitutionParams{
+							{EnteredID: secondPinchHitter, ReplacedID: pinchHitter},
+							{EnteredID: pinchHitter, ReplacedID: participants[9].ID},
+						}, arg.Substitutions)
+						return db.UndoGameEventsTxResult{Events: substituted[3:]}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp UndoGameEventsResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, participants[9].ID.String(), rsp.State.Away.Order[0])
+			},
+		},
+		{
+			name:  "Changed",
+			roles: coachRoles,
+			body:  gin.H{"count": 1},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectReplay(store, events)
+				store.EXPECT().
+					UndoGameEventsTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UndoGameEventsTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidCount",
+			roles: coachRoles,
+			body:  gin.H{"count": 0},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "NotCoach",
+			roles: security.UserRoles,
+			body:  gin.H{"count": 1},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectTeamCoach(store, user.ID)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			buf, err := buildJsonRequest(t, tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/games/%s/events/undo", game.ID)
+			request, err := http.NewRequest(http.MethodPost, url, &buf)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, tc.roles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_CorrectGameEvent(t *testing.T) {
+	user, _ := createRandomUser(t)
+	game := db.Game{ID: uuid.New(), HomeTeamID: uuid.New(), AwayTeamID: uuid.New(), Status: string(util.GameInProgress)}
+	participants := randomStartingNines(game.ID)
+
+	// the leadoff hitter singles and steals second, then is replaced by a pinch runner
+	events := []db.GameEvent{
+		recordedEvent(t, game.ID, 1, pitchEvent(util.PitchInPlay)),
+		recordedEvent(t, game.ID, 2, playEvent(scoring.PlaySingle)),
+		recordedEvent(t, game.ID, 3, playEvent(scoring.PlayStolenBase, scoring.Advance{From: scoring.First, To: scoring.Second})),
+		recordedEvent(t, game.ID, 4, scoring.Event{
+			Type: scoring.EventSubstitution,
+			Substitution: &scoring.Substitution{Changes: []scoring.Change{{
+				ReplacesID:  participants[9].ID.String(),
+				InID:        uuid.New().String(),
+				BatPosition: 1,
+				Position:    util.CenterField,
+			}}},
+		}),
+	}
+
+	expectReplay := func(store *mockdb.MockStore) {
+		store.EXPECT().
+			GetGame(gomock.Any(), gomock.Eq(game.ID)).
+			Times(1).
+			Return(game, nil)
+		store.EXPECT().
+			ListGameParticipants(gomock.Any(), gomock.Eq(game.ID)).
+			Times(1).
+			Return(participants, nil)
+		store.EXPECT().
+			ListGameEvents(gomock.Any(), gomock.Eq(game.ID)).
+			Times(1).
+			Return(events, nil)
+	}
+
+	testCases := []struct {
+		name          string
+		roles         []security.Role
+		sequence      int64
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "HitToError",
+			roles:    coachRoles,
+			sequence: 2,
+			body:     gin.H{"type": scoring.EventPlay, "play": gin.H{"kind": scoring.PlayError}},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectReplay(store)
+				store.EXPECT().
+					CorrectGameEventTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CorrectGameEventTxParams) (db.CorrectGameEventTxResult, error) {
+						require.Equal(t, int64(2), arg.Sequence)
+						require.Equal(t, int64(4), arg.LastSequence)
+						require.Equal(t, user.ID, arg.CreatedBy)
+
+						event, err := scoring.Decode(arg.Event.Payload)
+						require.NoError(t, err)
+						require.Equal(t, scoring.PlayError, event.Play.Kind)
+
+						inning := arg.Projection.Innings[0]
+						require.Equal(t, int64(0), inning.AwayHits)
+						require.Equal(t, int64(1), inning.HomeErrors)
+						require.Equal(t, string(scoring.PlayError), arg.Projection.Atbats[0].Result)
+						return db.CorrectGameEventTxResult{
+							Original: events[1],
+							Event:    db.GameEvent{Sequence: 2, CorrectsID: uuid.NullUUID{UUID: events[1].ID, Valid: true}},
+						}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp CorrectGameEventResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, events[1].ID, rsp.Original.ID)
+				require.Equal(t, events[1].ID, rsp.Event.CorrectsID.UUID)
+				require.Equal(t, int64(0), rsp.State.Innings[0].AwayHits)
+			},
+		},
+		{
+			name:     "LaterEventImpossible",
+			roles:    coachRoles,
+			sequence: 2,
+			body:     gin.H{"type": scoring.EventPlay, "play": gin.H{"kind": scoring.PlayOut}},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectReplay(store)
+				store.EXPECT().
+					CorrectGameEventTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "event 3")
+			},
+		},
+		{
+			name:     "Substitution",
+			roles:    coachRoles,
+			sequence: 4,
+			body:     gin.H{"type": scoring.EventInningEnd},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectReplay(store)
+				store.EXPECT().
+					CorrectGameEventTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:     "EventNotFound",
+			roles:    coachRoles,
+			sequence: 9,
+			body:     gin.H{"type": scoring.EventInningEnd},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectReplay(store)
+				store.EXPECT().
+					CorrectGameEventTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:     "Changed",
+			roles:    coachRoles,
+			sequence: 2,
+			body:     gin.H{"type": scoring.EventPlay, "play": gin.H{"kind": scoring.PlayError}},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectReplay(store)
+				store.EXPECT().
+					CorrectGameEventTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CorrectGameEventTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:     "NotCoach",
+			roles:    security.UserRoles,
+			sequence: 2,
+			body:     gin.H{"type": scoring.EventPlay, "play": gin.H{"kind": scoring.PlayError}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectTeamCoach(store, user.ID)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			buf, err := buildJsonRequest(t, tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/games/%s/events/%d", game.ID, tc.sequence)
+			request, err := http.NewRequest(http.MethodPut, url, &buf)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, tc.roles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
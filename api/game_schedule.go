@@ -0,0 +1,260 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/helpers"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/lib/pq"
+	"net/http"
+	"time"
+)
+
+// defaultGameConflictWindow is used when GAME_CONFLICT_WINDOW is not configured.
+const defaultGameConflictWindow = 3 * time.Hour
+
+var (
+	errInvalidTimeZone      = errors.New("time_zone must be an IANA time zone such as America/Chicago")
+	errScheduleConflict     = errors.New("a team or the venue already has a game at that time")
+	errGameNotReschedulable = errors.New("only scheduled or postponed games can be rescheduled")
+	errFieldNotAtVenue      = errors.New("field is not at that venue")
+	errFieldUnavailable     = errors.New("field is not available at that time")
+)
+
+// ScheduleConflictResponse is returned when a game would double-book a team or field.
+type ScheduleConflictResponse struct {
+	Error     string    `json:"error"`
+	Conflicts []db.Game `json:"conflicts"`
+}
+
+// RescheduleGameRequestBody represents the body of a request to move a game to a new time, venue or field.
+// A time zone or venue left empty keeps the game's current one. Moving to another venue without
+// naming a field clears the field.
+type RescheduleGameRequestBody struct {
+	ScheduledAt time.Time `json:"scheduled_at" binding:"required"`
+	TimeZone    string    `json:"time_zone"`
+	VenueID     string    `json:"venue_id" binding:"omitempty,uuid"`
+	FieldID     string    `json:"field_id" binding:"omitempty,uuid"`
+	Reason      string    `json:"reason" binding:"max=500"`
+}
+
+// RescheduleGameResponse represents the outcome of rescheduling a game.
+type RescheduleGameResponse struct {
+	Game         db.Game               `json:"game"`
+	Change       db.GameScheduleChange `json:"change"`
+	StatusChange *db.GameStatusChange  `json:"status_change,omitempty"`
+}
+
+// RescheduleGame moves a scheduled or postponed game to a new start time, time zone or venue.
+// A postponed game goes back to scheduled. Every change is kept in the game's schedule history.
+func (s *Server) RescheduleGame(context *gin.Context) {
+	var req GetGameRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var body RescheduleGameRequestBody
+	if err := context.ShouldBindJSON(&body); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+	if body.TimeZone != "" && !util.IsValidTimeZone(body.TimeZone) {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(errInvalidTimeZone))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	if !isCoachOrAdmin(payload) {
+		context.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	game, err := s.store.GetGame(context, uuid.MustParse(req.ID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+	if !s.authorizeGameCoach(context, game) {
+		return
+	}
+
+	status := util.GameStatus(game.Status)
+	if status != util.GameScheduled && status != util.GamePostponed {
+		context.JSON(http.StatusConflict, helpers.ErrorResponse(errGameNotReschedulable))
+		return
+	}
+
+	venueID, fieldID, ok := s.resolveGameVenue(context, optionalUUID(body.VenueID), optionalUUID(body.FieldID))
+	if !ok {
+		return
+	}
+	if !venueID.Valid {
+		venueID, fieldID = game.VenueID, game.FieldID
+	} else if !fieldID.Valid && venueID == game.VenueID {
+		fieldID = game.FieldID
+	}
+
+	if s.respondFieldUnavailable(context, body.ScheduledAt, fieldID) {
+		return
+	}
+
+	result, err := s.store.RescheduleGameTx(context, db.RescheduleGameTxParams{
+		Game:           game,
+		ScheduledAt:    body.ScheduledAt,
+		TimeZone:       sql.NullString{String: body.TimeZone, Valid: body.TimeZone != ""},
+		VenueID:        venueID,
+		FieldID:        fieldID,
+		Reason:         body.Reason,
+		ChangedBy:      payload.UserID,
+		ConflictWindow: s.gameConflictWindow(),
+	})
+	if err != nil {
+		if respondScheduleConflict(context, err) {
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusConflict, helpers.ErrorResponse(errGameChanged))
+			return
+		}
+		if pgErr, ok := err.(*pq.Error); ok {
+			switch pgErr.Code.Name() {
+			case "foreign_key_violation":
+				context.JSON(http.StatusNotFound, helpers.ErrorResponse(pgErr))
+				return
+			}
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, RescheduleGameResponse{
+		Game:         result.Game,
+		Change:       result.Change,
+		StatusChange: result.StatusChange,
+	})
+}
+
+// ListGameScheduleChanges lists every reschedule of a game, oldest first.
+func (s *Server) ListGameScheduleChanges(context *gin.Context) {
+	var req GetGameRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	changes, err := s.store.ListGameScheduleChanges(context, uuid.MustParse(req.ID))
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, changes)
+}
+
+// resolveGameVenue checks the venue and field a game is placed at. A field implies its venue.
+// It writes a response and returns false if the field does not exist or is at another venue.
+func (s *Server) resolveGameVenue(context *gin.Context, venueID, fieldID uuid.NullUUID) (uuid.NullUUID, uuid.NullUUID, bool) {
+	if !fieldID.Valid {
+		return venueID, fieldID, true
+	}
+
+	field, err := s.store.GetField(context, fieldID.UUID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return venueID, fieldID, false
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return venueID, fieldID, false
+	}
+	if venueID.Valid && venueID.UUID != field.VenueID {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(errFieldNotAtVenue))
+		return venueID, fieldID, false
+	}
+	return uuid.NullUUID{UUID: field.VenueID, Valid: true}, fieldID, true
+}
+
+// gameConflictWindow is how close to the other games of its teams and venue a game may start.
+// A game is taken to hold its field for the window.
+func (s *Server) gameConflictWindow() time.Duration {
+	if s.config.GameConflictWindow <= 0 {
+		return defaultGameConflictWindow
+	}
+	return s.config.GameConflictWindow
+}
+
+// respondFieldUnavailable checks that a game starting at start fits its conflict window inside one of
+// the field's availability windows, if it has any. It writes a response and returns true if it does not.
+func (s *Server) respondFieldUnavailable(context *gin.Context, start time.Time, fieldID uuid.NullUUID) bool {
+	if !fieldID.Valid {
+		return false
+	}
+
+	available, err := s.store.IsFieldAvailable(context, db.IsFieldAvailableParams{
+		FieldID:  fieldID.UUID,
+		StartsAt: start,
+		EndsAt:   start.Add(s.gameConflictWindow()),
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return true
+	}
+	if !available {
+		context.JSON(http.StatusConflict, helpers.ErrorResponse(errFieldUnavailable))
+		return true
+	}
+	return false
+}
+
+// respondScheduleConflict writes a 409 listing the conflicting games and returns true when err says
+// a game conflicts with other games of either team, or on the same field. Games at a venue without
+// a field conflict with every game there.
+func respondScheduleConflict(context *gin.Context, err error) bool {
+	var conflict *db.ScheduleConflictError
+	if !errors.As(err, &conflict) {
+		return false
+	}
+	context.JSON(http.StatusConflict, ScheduleConflictResponse{
+		Error:     errScheduleConflict.Error(),
+		Conflicts: conflict.Conflicts,
+	})
+	return true
+}
+
+// localScheduledAt returns the game's start time in its own time zone, or nil if it has none.
+func localScheduledAt(game db.Game) *time.Time {
+	if !game.ScheduledAt.Valid {
+		return nil
+	}
+	start := game.ScheduledAt.Time
+	if location, err := time.LoadLocation(game.TimeZone); err == nil {
+		start = start.In(location)
+	}
+	return &start
+}
+
+// optionalUUID converts an already validated, possibly empty UUID string to a uuid.NullUUID.
+func optionalUUID(id string) uuid.NullUUID {
+	if id == "" {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: uuid.MustParse(id), Valid: true}
+}
+
+// nullUUIDString renders a uuid.NullUUID as a string pointer, nil when it is not set.
+func nullUUIDString(id uuid.NullUUID) *string {
+	if !id.Valid {
+		return nil
+	}
+	value := id.UUID.String()
+	return &value
+}
@@ -0,0 +1,320 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	mockdb "github.com/kwalter26/scoreit-api-go/db/mock"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/security"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestServer_RescheduleGame(t *testing.T) {
+	user, _ := createRandomUser(t)
+	venueID := uuid.New()
+	scheduled := db.Game{
+		ID:         uuid.New(),
+		HomeTeamID: uuid.New(),
+		AwayTeamID: uuid.New(),
+		Status:     string(util.GameScheduled),
+		TimeZone:   "UTC",
+		VenueID:    uuid.NullUUID{UUID: venueID, Valid: true},
+	}
+	postponed := scheduled
+	postponed.Status = string(util.GamePostponed)
+	started := scheduled
+	started.Status = string(util.GameInProgress)
+	field := db.Field{ID: uuid.New(), VenueID: venueID, Name: "Diamond 2"}
+	start := time.Date(2026, time.June, 6, 13, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		roles         []security.Role
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			roles: coachRoles,
+			body:  gin.H{"scheduled_at": start, "time_zone": "America/New_York", "reason": "field closed"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(scheduled.ID)).
+					Times(1).
+					Return(scheduled, nil)
+				arg := db.RescheduleGameTxParams{
+					Game:           scheduled,
+					ScheduledAt:    start,
+					TimeZone:       sql.NullString{String: "America/New_York", Valid: true},
+					VenueID:        scheduled.VenueID,
+					Reason:         "field closed",
+					ChangedBy:      user.ID,
+					ConflictWindow: defaultGameConflictWindow,
+				}
+				moved := scheduled
+				moved.ScheduledAt = sql.NullTime{Time: start, Valid: true}
+				moved.TimeZone = "America/New_York"
+				store.EXPECT().
+					RescheduleGameTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.RescheduleGameTxResult{
+						Game:   moved,
+						Change: db.GameScheduleChange{ID: uuid.New(), GameID: scheduled.ID, NewScheduledAt: start, ChangedBy: user.ID},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp RescheduleGameResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, "America/New_York", rsp.Game.TimeZone)
+				require.Equal(t, user.ID, rsp.Change.ChangedBy)
+				require.Nil(t, rsp.StatusChange)
+			},
+		},
+		{
+			name:  "ToField",
+			roles: coachRoles,
+			body:  gin.H{"scheduled_at": start, "field_id": field.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(scheduled.ID)).
+					Times(1).
+					Return(scheduled, nil)
+				store.EXPECT().
+					GetField(gomock.Any(), gomock.Eq(field.ID)).
+					Times(1).
+					Return(field, nil)
+				store.EXPECT().
+					IsFieldAvailable(gomock.Any(), gomock.Eq(db.IsFieldAvailableParams{
+						FieldID:  field.ID,
+						StartsAt: start,
+						EndsAt:   start.Add(defaultGameConflictWindow),
+					})).
+					Times(1).
+					Return(true, nil)
+				arg := db.RescheduleGameTxParams{
+					Game:           scheduled,
+					ScheduledAt:    start,
+					VenueID:        uuid.NullUUID{UUID: venueID, Valid: true},
+					FieldID:        uuid.NullUUID{UUID: field.ID, Valid: true},
+					ChangedBy:      user.ID,
+					ConflictWindow: defaultGameConflictWindow,
+				}
+				store.EXPECT().
+					RescheduleGameTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.RescheduleGameTxResult{Game: scheduled}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "FieldUnavailable",
+			roles: coachRoles,
+			body:  gin.H{"scheduled_at": start, "field_id": field.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(scheduled.ID)).
+					Times(1).
+					Return(scheduled, nil)
+				store.EXPECT().
+					GetField(gomock.Any(), gomock.Eq(field.ID)).
+					Times(1).
+					Return(field, nil)
+				store.EXPECT().
+					IsFieldAvailable(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(false, nil)
+				store.EXPECT().
+					RescheduleGameTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:  "FieldAtOtherVenue",
+			roles: coachRoles,
+			body:  gin.H{"scheduled_at": start, "field_id": field.ID, "venue_id": uuid.New()},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(scheduled.ID)).
+					Times(1).
+					Return(scheduled, nil)
+				store.EXPECT().
+					GetField(gomock.Any(), gomock.Eq(field.ID)).
+					Times(1).
+					Return(field, nil)
+				store.EXPECT().
+					RescheduleGameTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Postponed",
+			roles: coachRoles,
+			body:  gin.H{"scheduled_at": start},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(scheduled.ID)).
+					Times(1).
+					Return(postponed, nil)
+				store.EXPECT().
+					RescheduleGameTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RescheduleGameTxResult{
+						Game:         scheduled,
+						StatusChange: &db.GameStatusChange{FromStatus: string(util.GamePostponed), ToStatus: string(util.GameScheduled)},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp RescheduleGameResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.NotNil(t, rsp.StatusChange)
+				require.Equal(t, string(util.GameScheduled), rsp.StatusChange.ToStatus)
+			},
+		},
+		{
+			name:  "DoubleBooked",
+			roles: coachRoles,
+			body:  gin.H{"scheduled_at": start},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(scheduled.ID)).
+					Times(1).
+					Return(scheduled, nil)
+				store.EXPECT().
+					RescheduleGameTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RescheduleGameTxResult{}, &db.ScheduleConflictError{Conflicts: []db.Game{{ID: uuid.New(), VenueID: scheduled.VenueID}}})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+
+				var rsp ScheduleConflictResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Len(t, rsp.Conflicts, 1)
+			},
+		},
+		{
+			name:  "AlreadyStarted",
+			roles: coachRoles,
+			body:  gin.H{"scheduled_at": start},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(scheduled.ID)).
+					Times(1).
+					Return(started, nil)
+				store.EXPECT().
+					RescheduleGameTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:  "ChangedConcurrently",
+			roles: coachRoles,
+			body:  gin.H{"scheduled_at": start},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(scheduled.ID)).
+					Times(1).
+					Return(scheduled, nil)
+				store.EXPECT().
+					RescheduleGameTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RescheduleGameTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:  "NotCoach",
+			roles: security.UserRoles,
+			body:  gin.H{"scheduled_at": start},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidTimeZone",
+			roles: coachRoles,
+			body:  gin.H{"scheduled_at": start, "time_zone": "Local"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "MissingTime",
+			roles: coachRoles,
+			body:  gin.H{"reason": "rain"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectTeamCoach(store, user.ID)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			buf, err := buildJsonRequest(t, tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/games/%s/reschedule", scheduled.ID)
+			request, err := http.NewRequest(http.MethodPost, url, &buf)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, tc.roles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
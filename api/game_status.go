@@ -0,0 +1,118 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/helpers"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"net/http"
+)
+
+var (
+	errGameFinal      = errors.New("game is final; an admin must reopen it before the score can change")
+	errGameNotScoring = errors.New("only games in progress can be scored")
+	errGameChanged    = errors.New("game was changed by another request; reload and try again")
+)
+
+// SetGameStatusRequestBody represents the body of a request to move a game to a new status.
+type SetGameStatusRequestBody struct {
+	Status string `json:"status" binding:"required,oneof=scheduled in_progress final postponed suspended cancelled forfeit"`
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// SetGameStatusResponse represents the outcome of a game status change.
+type SetGameStatusResponse struct {
+	Game   db.Game             `json:"game"`
+	Change db.GameStatusChange `json:"change"`
+}
+
+// SetGameStatus moves a game through its lifecycle: scheduled, in_progress and final,
+// plus postponed, suspended, cancelled and forfeit. Illegal transitions are rejected with 409.
+// The coaches of either team and admins may change the status; only admins may reopen a final game.
+func (s *Server) SetGameStatus(context *gin.Context) {
+	var req GetGameRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var body SetGameStatusRequestBody
+	if err := context.ShouldBindJSON(&body); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	if !isCoachOrAdmin(payload) {
+		context.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	game, err := s.store.GetGame(context, uuid.MustParse(req.ID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+	if !s.authorizeGameCoach(context, game) {
+		return
+	}
+
+	from := util.GameStatus(game.Status)
+	to := util.GameStatus(body.Status)
+	if !util.CanTransitionGame(from, to) {
+		err := fmt.Errorf("cannot move a game from %s to %s", from, to)
+		context.JSON(http.StatusConflict, helpers.ErrorResponse(err))
+		return
+	}
+	if from == util.GameFinal && !isAdmin(payload) {
+		context.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	result, err := s.store.GameStatusTx(context, db.GameStatusTxParams{
+		GameID:     game.ID,
+		FromStatus: game.Status,
+		ToStatus:   body.Status,
+		Reason:     body.Reason,
+		ChangedBy:  payload.UserID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusConflict, helpers.ErrorResponse(errGameChanged))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, SetGameStatusResponse{
+		Game:   result.Game,
+		Change: result.Change,
+	})
+}
+
+// ListGameStatusChanges lists every status change of a game, oldest first.
+func (s *Server) ListGameStatusChanges(context *gin.Context) {
+	var req GetGameRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	changes, err := s.store.ListGameStatusChanges(context, uuid.MustParse(req.ID))
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, changes)
+}
@@ -0,0 +1,242 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	mockdb "github.com/kwalter26/scoreit-api-go/db/mock"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/security"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestServer_SetGameStatus(t *testing.T) {
+	user, _ := createRandomUser(t)
+	otherCoach, _ := createRandomUser(t)
+	scheduled := db.Game{ID: uuid.New(), HomeTeamID: uuid.New(), AwayTeamID: uuid.New(), Status: string(util.GameScheduled)}
+	final := scheduled
+	final.Status = string(util.GameFinal)
+
+	testCases := []struct {
+		name          string
+		roles         []security.Role
+		callerID      uuid.UUID
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "CoachOfNeitherTeam",
+			roles:    coachRoles,
+			callerID: otherCoach.ID,
+			body:     gin.H{"status": util.GameInProgress},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(scheduled.ID)).
+					Times(1).
+					Return(scheduled, nil)
+				store.EXPECT().
+					GetTeamMember(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(db.TeamMember{}, sql.ErrNoRows)
+				store.EXPECT().
+					GameStatusTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "Start",
+			roles: coachRoles,
+			body:  gin.H{"status": util.GameInProgress},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(scheduled.ID)).
+					Times(1).
+					Return(scheduled, nil)
+				arg := db.GameStatusTxParams{
+					GameID:     scheduled.ID,
+					FromStatus: string(util.GameScheduled),
+					ToStatus:   string(util.GameInProgress),
+					ChangedBy:  user.ID,
+				}
+				started := scheduled
+				started.Status = string(util.GameInProgress)
+				store.EXPECT().
+					GameStatusTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.GameStatusTxResult{
+						Game:   started,
+						Change: db.GameStatusChange{ID: uuid.New(), GameID: scheduled.ID, FromStatus: arg.FromStatus, ToStatus: arg.ToStatus, ChangedBy: user.ID},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp SetGameStatusResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, string(util.GameInProgress), rsp.Game.Status)
+				require.Equal(t, user.ID, rsp.Change.ChangedBy)
+			},
+		},
+		{
+			name:  "IllegalTransition",
+			roles: coachRoles,
+			body:  gin.H{"status": util.GameFinal},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(scheduled.ID)).
+					Times(1).
+					Return(scheduled, nil)
+				store.EXPECT().
+					GameStatusTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:  "ReopenByCoach",
+			roles: coachRoles,
+			body:  gin.H{"status": util.GameInProgress},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(scheduled.ID)).
+					Times(1).
+					Return(final, nil)
+				store.EXPECT().
+					GameStatusTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "ReopenByAdmin",
+			roles: adminUserRoles,
+			body:  gin.H{"status": util.GameInProgress, "reason": "scoring correction"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(scheduled.ID)).
+					Times(1).
+					Return(final, nil)
+				arg := db.GameStatusTxParams{
+					GameID:     scheduled.ID,
+					FromStatus: string(util.GameFinal),
+					ToStatus:   string(util.GameInProgress),
+					Reason:     "scoring correction",
+					ChangedBy:  user.ID,
+				}
+				store.EXPECT().
+					GameStatusTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.GameStatusTxResult{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "ChangedConcurrently",
+			roles: coachRoles,
+			body:  gin.H{"status": util.GamePostponed},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(scheduled.ID)).
+					Times(1).
+					Return(scheduled, nil)
+				store.EXPECT().
+					GameStatusTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.GameStatusTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:  "NotCoach",
+			roles: security.UserRoles,
+			body:  gin.H{"status": util.GameInProgress},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "NotFound",
+			roles: coachRoles,
+			body:  gin.H{"status": util.GameInProgress},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Game{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidStatus",
+			roles: coachRoles,
+			body:  gin.H{"status": "halftime"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectTeamCoach(store, user.ID)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			buf, err := buildJsonRequest(t, tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/games/%s/status", scheduled.ID)
+			request, err := http.NewRequest(http.MethodPost, url, &buf)
+			require.NoError(t, err)
+
+			callerID := user.ID
+			if tc.callerID != uuid.Nil {
+				callerID = tc.callerID
+			}
+			addAuthorization(t, request, server.tokenMaker, tc.roles, middleware.AuthorizationTypeBearer, callerID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
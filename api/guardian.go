@@ -0,0 +1,201 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/helpers"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/lib/pq"
+	"net/http"
+	"time"
+)
+
+// GuardianPlayerRequest represents a request scoped to a player's guardians.
+type GuardianPlayerRequest struct {
+	PlayerID string `uri:"id" binding:"required,uuid"`
+}
+
+// GuardianLinkRequest represents a request scoped to a single guardian of a player.
+type GuardianLinkRequest struct {
+	PlayerID   string `uri:"id" binding:"required,uuid"`
+	GuardianID string `uri:"guardian_id" binding:"required,uuid"`
+}
+
+// GuardianResponse represents a guardian link in a response.
+type GuardianResponse struct {
+	GuardianID uuid.UUID  `json:"guardian_id"`
+	PlayerID   uuid.UUID  `json:"player_id"`
+	Status     string     `json:"status"`
+	ApprovedAt *time.Time `json:"approved_at,omitempty"`
+}
+
+// NewGuardianResponse creates a new GuardianResponse from a db.Guardian.
+func NewGuardianResponse(guardian db.Guardian) GuardianResponse {
+	rsp := GuardianResponse{
+		GuardianID: guardian.GuardianID,
+		PlayerID:   guardian.PlayerID,
+		Status:     guardian.Status,
+	}
+	if guardian.ApprovedAt.Valid {
+		rsp.ApprovedAt = &guardian.ApprovedAt.Time
+	}
+	return rsp
+}
+
+// RequestGuardian registers the caller as a pending guardian of a player.
+// The link has no effect until an admin or coach approves it.
+func (s *Server) RequestGuardian(context *gin.Context) {
+	var req GuardianPlayerRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	playerID := uuid.MustParse(req.PlayerID)
+
+	if payload.UserID == playerID {
+		err := errors.New("a player cannot be their own guardian")
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	guardian, err := s.store.CreateGuardian(context, db.CreateGuardianParams{
+		GuardianID: payload.UserID,
+		PlayerID:   playerID,
+	})
+	if err != nil {
+		if pgErr, ok := err.(*pq.Error); ok {
+			switch pgErr.Code.Name() {
+			case "unique_violation":
+				context.JSON(http.StatusConflict, helpers.ErrorResponse(pgErr))
+				return
+			case "foreign_key_violation":
+				context.JSON(http.StatusNotFound, helpers.ErrorResponse(pgErr))
+				return
+			}
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, NewGuardianResponse(guardian))
+}
+
+// ListGuardians lists the guardians of a player.
+// Only the player, their guardians, coaches and admins may see the list.
+func (s *Server) ListGuardians(context *gin.Context) {
+	var req GuardianPlayerRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	playerID := uuid.MustParse(req.PlayerID)
+
+	if !s.canActForPlayer(payload, playerID) && !isCoachOrAdmin(payload) {
+		context.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	guardians, err := s.store.ListGuardiansOfPlayer(context, playerID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, guardians)
+}
+
+// UpdateGuardianRequestBody represents the body of a request to approve or reject a guardian.
+type UpdateGuardianRequestBody struct {
+	Status string `json:"status" binding:"required,oneof=approved rejected"`
+}
+
+// UpdateGuardian approves or rejects a guardian link. Only admins and coaches of a team the player is on may decide.
+// Approval grants the guardian delegated rights on the player's routes.
+func (s *Server) UpdateGuardian(context *gin.Context) {
+	var req GuardianLinkRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var body UpdateGuardianRequestBody
+	if err := context.ShouldBindJSON(&body); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	arg := db.UpdateGuardianStatusParams{
+		Status:     body.Status,
+		GuardianID: uuid.MustParse(req.GuardianID),
+		PlayerID:   uuid.MustParse(req.PlayerID),
+	}
+	if !s.authorizePlayerCoach(context, arg.PlayerID) {
+		return
+	}
+
+	var guardian db.Guardian
+	var err error
+	if util.GuardianStatus(body.Status) == util.GuardianApproved {
+		arg.ApprovedBy = uuid.NullUUID{UUID: payload.UserID, Valid: true}
+		arg.ApprovedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+		var result db.ApproveGuardianTxResult
+		result, err = s.store.ApproveGuardianTx(context, db.ApproveGuardianTxParams{
+			UpdateGuardianStatusParams: arg,
+		})
+		guardian = result.Guardian
+	} else {
+		guardian, err = s.store.UpdateGuardianStatus(context, arg)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+	s.reloadGuardianPolicies()
+
+	context.JSON(http.StatusOK, NewGuardianResponse(guardian))
+}
+
+// RemoveGuardian deletes a guardian link and revokes the guardian's delegated rights.
+// Admins, coaches of a team the player is on and the guardian themselves may remove a link.
+func (s *Server) RemoveGuardian(context *gin.Context) {
+	var req GuardianLinkRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	guardianID := uuid.MustParse(req.GuardianID)
+	playerID := uuid.MustParse(req.PlayerID)
+
+	if payload.UserID != guardianID && !s.authorizePlayerCoach(context, playerID) {
+		return
+	}
+
+	err := s.store.DeleteGuardian(context, db.DeleteGuardianParams{
+		GuardianID: guardianID,
+		PlayerID:   playerID,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	s.reloadGuardianPolicies()
+
+	context.JSON(http.StatusOK, nil)
+}
@@ -0,0 +1,614 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	mockdb "github.com/kwalter26/scoreit-api-go/db/mock"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/security"
+	"github.com/kwalter26/scoreit-api-go/security/token"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var coachRoles = []security.Role{security.UserRole, security.CoachRole}
+
+func TestServer_RequestGuardian(t *testing.T) {
+	guardian, _ := createRandomUser(t)
+	player, _ := createRandomUser(t)
+	link := randomGuardian(guardian.ID, player.ID)
+
+	testCases := []struct {
+		name          string
+		playerID      string
+		buildStubs    func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			playerID: player.ID.String(),
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateGuardianParams{GuardianID: guardian.ID, PlayerID: player.ID}
+				store.EXPECT().
+					CreateGuardian(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(link, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, guardian.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "OwnGuardian",
+			playerID: player.ID.String(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateGuardian(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, player.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "AlreadyRequested",
+			playerID: player.ID.String(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateGuardian(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Guardian{}, &pq.Error{Code: "23505"})
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, guardian.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:     "PlayerNotFound",
+			playerID: player.ID.String(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateGuardian(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Guardian{}, &pq.Error{Code: "23503"})
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, guardian.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:     "InvalidID",
+			playerID: "invalid",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateGuardian(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, guardian.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "InternalError",
+			playerID: player.ID.String(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateGuardian(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Guardian{}, sql.ErrConnDone)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, guardian.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/players/%s/guardians", tc.playerID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_UpdateGuardian(t *testing.T) {
+	coach, _ := createRandomUser(t)
+	otherCoach, _ := createRandomUser(t)
+	guardian, _ := createRandomUser(t)
+	player, _ := createRandomUser(t)
+	teamID := uuid.New()
+	link := randomGuardian(guardian.ID, player.ID)
+	approved := link
+	approved.Status = string(util.GuardianApproved)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		before        []db.Guardian
+		after         []db.Guardian
+		buildStubs    func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "Approve",
+			body:  gin.H{"status": "approved"},
+			after: []db.Guardian{approved},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ApproveGuardianTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.ApproveGuardianTxParams) (db.ApproveGuardianTxResult, error) {
+						require.Equal(t, string(util.GuardianApproved), arg.UpdateGuardianStatusParams.Status)
+						require.Equal(t, coach.ID, arg.UpdateGuardianStatusParams.ApprovedBy.UUID)
+						return db.ApproveGuardianTxResult{Guardian: approved}, nil
+					})
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, coach.ID, time.Minute)
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.True(t, security.IsGuardianOf(server.enforcer, guardian.ID.String(), player.ID.String()))
+			},
+		},
+		{
+			name: "CommitFailed",
+			body: gin.H{"status": "approved"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ApproveGuardianTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ApproveGuardianTxResult{}, sql.ErrTxDone)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, coach.ID, time.Minute)
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.False(t, security.IsGuardianOf(server.enforcer, guardian.ID.String(), player.ID.String()))
+			},
+		},
+		{
+			name:   "Reject",
+			body:   gin.H{"status": "rejected"},
+			before: []db.Guardian{approved},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateGuardianStatusParams{
+					Status:     string(util.GuardianRejected),
+					GuardianID: guardian.ID,
+					PlayerID:   player.ID,
+				}
+				store.EXPECT().
+					UpdateGuardianStatus(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(link, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, coach.ID, time.Minute)
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.False(t, security.IsGuardianOf(server.enforcer, guardian.ID.String(), player.ID.String()))
+			},
+		},
+		{
+			name:  "Admin",
+			body:  gin.H{"status": "approved"},
+			after: []db.Guardian{approved},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ApproveGuardianTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ApproveGuardianTxResult{Guardian: approved}, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, adminUserRoles, middleware.AuthorizationTypeBearer, otherCoach.ID, time.Minute)
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.True(t, security.IsGuardianOf(server.enforcer, guardian.ID.String(), player.ID.String()))
+			},
+		},
+		{
+			name: "OtherTeamCoach",
+			body: gin.H{"status": "approved"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTeamMember(gomock.Any(), gomock.Eq(db.GetTeamMemberParams{TeamID: teamID, UserID: otherCoach.ID})).
+					Times(1).
+					Return(db.TeamMember{}, sql.ErrNoRows)
+				store.EXPECT().
+					ApproveGuardianTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, otherCoach.ID, time.Minute)
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+				require.False(t, security.IsGuardianOf(server.enforcer, guardian.ID.String(), player.ID.String()))
+			},
+		},
+		{
+			name: "NotCoach",
+			body: gin.H{"status": "approved"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ApproveGuardianTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, guardian.ID, time.Minute)
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidStatus",
+			body: gin.H{"status": "maybe"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ApproveGuardianTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, coach.ID, time.Minute)
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			body: gin.H{"status": "approved"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ApproveGuardianTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ApproveGuardianTxResult{}, sql.ErrNoRows)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, coach.ID, time.Minute)
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+				require.False(t, security.IsGuardianOf(server.enforcer, guardian.ID.String(), player.ID.String()))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+			store.EXPECT().
+				ListTeamIDsOfUser(gomock.Any(), gomock.Eq(player.ID)).
+				AnyTimes().
+				Return([]uuid.UUID{teamID}, nil)
+			expectTeamCoach(store, coach.ID)
+
+			// the links are listed once when the server starts and again on every reload after a change
+			store.EXPECT().
+				ListApprovedGuardians(gomock.Any()).
+				Times(1).
+				Return(tc.before, nil)
+			store.EXPECT().
+				ListApprovedGuardians(gomock.Any()).
+				AnyTimes().
+				Return(tc.after, nil)
+
+			server := newTestServer(t, store)
+			require.NoError(t, server.LoadGuardianPolicies())
+			recorder := httptest.NewRecorder()
+
+			data, err := buildJsonRequest(t, tc.body)
+			url := fmt.Sprintf("/api/v1/players/%s/guardians/%s", player.ID, guardian.ID)
+			request, err := http.NewRequest(http.MethodPut, url, &data)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, server, recorder)
+		})
+	}
+}
+
+func TestServer_RemoveGuardian(t *testing.T) {
+	coach, _ := createRandomUser(t)
+	otherCoach, _ := createRandomUser(t)
+	guardian, _ := createRandomUser(t)
+	player, _ := createRandomUser(t)
+	teamID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Guardian",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.DeleteGuardianParams{GuardianID: guardian.ID, PlayerID: player.ID}
+				store.EXPECT().
+					DeleteGuardian(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, guardian.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Coach",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteGuardian(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, coach.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "OtherTeamCoach",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTeamMember(gomock.Any(), gomock.Eq(db.GetTeamMemberParams{TeamID: teamID, UserID: otherCoach.ID})).
+					Times(1).
+					Return(db.TeamMember{}, sql.ErrNoRows)
+				store.EXPECT().
+					DeleteGuardian(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, otherCoach.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteGuardian(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, player.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteGuardian(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(sql.ErrConnDone)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, guardian.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+			store.EXPECT().
+				ListTeamIDsOfUser(gomock.Any(), gomock.Eq(player.ID)).
+				AnyTimes().
+				Return([]uuid.UUID{teamID}, nil)
+			expectTeamCoach(store, coach.ID)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/players/%s/guardians/%s", player.ID, guardian.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_ListGuardians(t *testing.T) {
+	guardian, _ := createRandomUser(t)
+	player, _ := createRandomUser(t)
+	stranger, _ := createRandomUser(t)
+	rows := []db.ListGuardiansOfPlayerRow{
+		{
+			ID:         uuid.New(),
+			GuardianID: guardian.ID,
+			FirstName:  guardian.FirstName,
+			LastName:   guardian.LastName,
+			Email:      guardian.Email,
+			Status:     string(util.GuardianPending),
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Player",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListGuardiansOfPlayer(gomock.Any(), gomock.Eq(player.ID)).
+					Times(1).
+					Return(rows, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, player.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Coach",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListGuardiansOfPlayer(gomock.Any(), gomock.Eq(player.ID)).
+					Times(1).
+					Return(rows, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, stranger.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Stranger",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListGuardiansOfPlayer(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, stranger.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListGuardiansOfPlayer(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, player.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/players/%s/guardians", player.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+// loadGuardianLinks has the server load links as the approved guardian links.
+func loadGuardianLinks(t *testing.T, server *Server, store *mockdb.MockStore, links ...db.Guardian) {
+	store.EXPECT().
+		ListApprovedGuardians(gomock.Any()).
+		AnyTimes().
+		Return(links, nil)
+	require.NoError(t, server.LoadGuardianPolicies())
+}
+
+func randomGuardian(guardianID uuid.UUID, playerID uuid.UUID) db.Guardian {
+	return db.Guardian{
+		ID:         uuid.New(),
+		GuardianID: guardianID,
+		PlayerID:   playerID,
+		Status:     string(util.GuardianPending),
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+}
@@ -0,0 +1,454 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/helpers"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/security"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/lib/pq"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// defaultInvitationLifetime is how long an invitation stays open when the coach doesn't say.
+const defaultInvitationLifetime = 7 * 24 * time.Hour
+
+var (
+	errInvitationNotFound  = errors.New("invitation not found")
+	errInvitationClosed    = errors.New("invitation has already been used or revoked")
+	errInvitationExpired   = errors.New("invitation has expired")
+	errInvitationEmail     = errors.New("invitation was sent to a different email address")
+	errJoinRequestPending  = errors.New("you already have a pending request to join this team")
+	errJoinRequestNotFound = errors.New("no pending join request found")
+	errNoPendingInvitation = errors.New("no pending invitation found")
+	errInvitationProposal  = errors.New("an invitation sent to an email needs a number and primary position")
+	errJoinCodeProposal    = errors.New("players choose their own number and position when they use a join code")
+	errJoinCodeChoice      = errors.New("choose a number and primary position to join with this code")
+)
+
+const joinRequestPendingConstraint = "join_requests_pending_idx"
+
+// CreateTeamInvitationRequestBody represents the body of a request to invite a player to a team.
+type CreateTeamInvitationRequestBody struct {
+	Email           string `json:"email" binding:"omitempty,email"`
+	Number          *int64 `json:"number" binding:"omitempty,min=0,max=99"`
+	PrimaryPosition string `json:"primary_position"`
+	ExpiresInHours  int64  `json:"expires_in_hours" binding:"omitempty,min=1,max=720"`
+}
+
+// CreateTeamInvitation invites a player to a team. An invitation sent to an email proposes a number and
+// position and can only be accepted once, by the user with that email. Without an email it is a join code
+// that any number of players holding it can use until it expires or is revoked, each choosing their own
+// number and position. Only the team's coaches and admins may invite.
+func (s *Server) CreateTeamInvitation(context *gin.Context) {
+	var req GetTeamRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var body CreateTeamInvitationRequestBody
+	if err := context.ShouldBindJSON(&body); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	if body.Email == "" && (body.Number != nil || body.PrimaryPosition != "") {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(errJoinCodeProposal))
+		return
+	}
+	if body.Email != "" && (body.Number == nil || body.PrimaryPosition == "") {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(errInvitationProposal))
+		return
+	}
+	if body.PrimaryPosition != "" && !util.IsBaseballPosition(body.PrimaryPosition) {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(errInvalidPosition))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	if !s.authorizeTeamCoach(context, uuid.MustParse(req.ID)) {
+		return
+	}
+
+	code, err := security.NewJoinCode()
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	lifetime := defaultInvitationLifetime
+	if body.ExpiresInHours > 0 {
+		lifetime = time.Duration(body.ExpiresInHours) * time.Hour
+	}
+
+	var number sql.NullInt64
+	if body.Number != nil {
+		number = sql.NullInt64{Int64: *body.Number, Valid: true}
+	}
+
+	invitation, err := s.store.CreateTeamInvitation(context, db.CreateTeamInvitationParams{
+		TeamID:          uuid.MustParse(req.ID),
+		Email:           sql.NullString{String: strings.ToLower(body.Email), Valid: body.Email != ""},
+		Code:            code,
+		Number:          number,
+		PrimaryPosition: sql.NullString{String: body.PrimaryPosition, Valid: body.PrimaryPosition != ""},
+		InvitedBy:       payload.UserID,
+		ExpiresAt:       time.Now().Add(lifetime),
+	})
+	if err != nil {
+		rosterError(context, err)
+		return
+	}
+
+	context.JSON(http.StatusOK, invitation)
+}
+
+// ListTeamInvitations lists a team's invitations, newest first. Only the team's coaches and admins may see them.
+func (s *Server) ListTeamInvitations(context *gin.Context) {
+	var req GetTeamRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var query ListTeamMembersRequestQuery
+	if err := context.ShouldBindQuery(&query); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	if !s.authorizeTeamCoach(context, uuid.MustParse(req.ID)) {
+		return
+	}
+
+	invitations, err := s.store.ListTeamInvitations(context, db.ListTeamInvitationsParams{
+		TeamID: uuid.MustParse(req.ID),
+		Limit:  query.PageSize,
+		Offset: (query.PageId - 1) * query.PageSize,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, invitations)
+}
+
+// TeamInvitationRequest represents a request for one of a team's invitations.
+type TeamInvitationRequest struct {
+	TeamID       string `uri:"id" binding:"required,uuid"`
+	InvitationID string `uri:"invitation_id" binding:"required,uuid"`
+}
+
+// RevokeTeamInvitation revokes a pending invitation. Only the team's coaches and admins may revoke.
+func (s *Server) RevokeTeamInvitation(context *gin.Context) {
+	var req TeamInvitationRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	if !s.authorizeTeamCoach(context, uuid.MustParse(req.TeamID)) {
+		return
+	}
+
+	invitation, err := s.store.RevokeTeamInvitation(context, db.RevokeTeamInvitationParams{
+		ID:     uuid.MustParse(req.InvitationID),
+		TeamID: uuid.MustParse(req.TeamID),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(errNoPendingInvitation))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, invitation)
+}
+
+// AcceptTeamInvitationRequest represents a request to accept an invitation by its join code.
+type AcceptTeamInvitationRequest struct {
+	Code string `uri:"code" binding:"required"`
+}
+
+// AcceptTeamInvitationRequestBody represents the number and position a player chooses when using a join code.
+type AcceptTeamInvitationRequestBody struct {
+	Number          *int64 `json:"number" binding:"omitempty,min=0,max=99"`
+	PrimaryPosition string `json:"primary_position"`
+}
+
+// AcceptTeamInvitation puts the caller on the invitation's team. An invitation sent to an email signs them
+// with the number and position it proposed; a join code signs them with the ones in the body, which must not
+// already be taken on the team.
+func (s *Server) AcceptTeamInvitation(context *gin.Context) {
+	var req AcceptTeamInvitationRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	invitation, err := s.store.GetTeamInvitationByCode(context, strings.ToUpper(req.Code))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(errInvitationNotFound))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	if invitation.Status != string(util.InvitationPending) {
+		context.JSON(http.StatusGone, helpers.ErrorResponse(errInvitationClosed))
+		return
+	}
+	if time.Now().After(invitation.ExpiresAt) {
+		context.JSON(http.StatusGone, helpers.ErrorResponse(errInvitationExpired))
+		return
+	}
+
+	arg := db.AcceptInvitationTxParams{
+		Invitation: invitation,
+		UserID:     middleware.GetAuthorizationPayload(context).UserID,
+	}
+	if invitation.Email.Valid {
+		user, err := s.store.GetUser(context, arg.UserID)
+		if err != nil {
+			context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+			return
+		}
+		if !strings.EqualFold(user.Email, invitation.Email.String) {
+			context.JSON(http.StatusForbidden, helpers.ErrorResponse(errInvitationEmail))
+			return
+		}
+	} else {
+		var body AcceptTeamInvitationRequestBody
+		if err := context.ShouldBindJSON(&body); err != nil {
+			context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+			return
+		}
+		if body.Number == nil || body.PrimaryPosition == "" {
+			context.JSON(http.StatusBadRequest, helpers.ErrorResponse(errJoinCodeChoice))
+			return
+		}
+		if !util.IsBaseballPosition(body.PrimaryPosition) {
+			context.JSON(http.StatusBadRequest, helpers.ErrorResponse(errInvalidPosition))
+			return
+		}
+		arg.Number = sql.NullInt64{Int64: *body.Number, Valid: true}
+		arg.PrimaryPosition = sql.NullString{String: body.PrimaryPosition, Valid: true}
+	}
+
+	result, err := s.store.AcceptInvitationTx(context, arg)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusGone, helpers.ErrorResponse(errInvitationClosed))
+			return
+		}
+		rosterError(context, err)
+		return
+	}
+
+	context.JSON(http.StatusOK, result.Member)
+}
+
+// CreateJoinRequestRequestBody represents the body of a request to join a team.
+type CreateJoinRequestRequestBody struct {
+	Number          *int64 `json:"number" binding:"required,min=0,max=99"`
+	PrimaryPosition string `json:"primary_position" binding:"required"`
+	Message         string `json:"message" binding:"max=500"`
+}
+
+// CreateJoinRequest asks a team's coaches to add the caller with the given number and position.
+func (s *Server) CreateJoinRequest(context *gin.Context) {
+	var req GetTeamRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var body CreateJoinRequestRequestBody
+	if err := context.ShouldBindJSON(&body); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	if !util.IsBaseballPosition(body.PrimaryPosition) {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(errInvalidPosition))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	teamID := uuid.MustParse(req.ID)
+
+	_, err := s.store.GetTeamMember(context, db.GetTeamMemberParams{TeamID: teamID, UserID: payload.UserID})
+	if err == nil {
+		context.JSON(http.StatusConflict, helpers.ErrorResponse(errAlreadyOnTeam))
+		return
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	joinRequest, err := s.store.CreateJoinRequest(context, db.CreateJoinRequestParams{
+		TeamID:          teamID,
+		UserID:          payload.UserID,
+		Number:          *body.Number,
+		PrimaryPosition: body.PrimaryPosition,
+		Message:         body.Message,
+	})
+	if err != nil {
+		if pgErr, ok := err.(*pq.Error); ok && pgErr.Constraint == joinRequestPendingConstraint {
+			context.JSON(http.StatusConflict, helpers.ErrorResponse(errJoinRequestPending))
+			return
+		}
+		rosterError(context, err)
+		return
+	}
+
+	context.JSON(http.StatusOK, joinRequest)
+}
+
+// ListJoinRequestsRequestQuery represents a request to list a team's join requests.
+type ListJoinRequestsRequestQuery struct {
+	Status   string `form:"status,default=pending" binding:"oneof=pending approved rejected"`
+	PageSize int32  `form:"page_size,default=5" binding:"max=100,min=1"`
+	PageId   int32  `form:"page_id,default=1" binding:"min=1"`
+}
+
+// ListJoinRequests lists a team's join requests, oldest first. Only the team's coaches and admins may see them.
+func (s *Server) ListJoinRequests(context *gin.Context) {
+	var req GetTeamRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var query ListJoinRequestsRequestQuery
+	if err := context.ShouldBindQuery(&query); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	if !s.authorizeTeamCoach(context, uuid.MustParse(req.ID)) {
+		return
+	}
+
+	joinRequests, err := s.store.ListJoinRequests(context, db.ListJoinRequestsParams{
+		TeamID: uuid.MustParse(req.ID),
+		Status: query.Status,
+		Limit:  query.PageSize,
+		Offset: (query.PageId - 1) * query.PageSize,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, joinRequests)
+}
+
+// JoinRequestRequest represents a request for one of a team's join requests.
+type JoinRequestRequest struct {
+	TeamID    string `uri:"id" binding:"required,uuid"`
+	RequestID string `uri:"request_id" binding:"required,uuid"`
+}
+
+// ApproveJoinRequestRequestBody optionally overrides the number and position the player asked for.
+type ApproveJoinRequestRequestBody struct {
+	Number          *int64 `json:"number" binding:"omitempty,min=0,max=99"`
+	PrimaryPosition string `json:"primary_position"`
+}
+
+// ApproveJoinRequest approves a pending join request and adds the player to the team.
+// Only the team's coaches and admins may approve.
+func (s *Server) ApproveJoinRequest(context *gin.Context) {
+	var req JoinRequestRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var body ApproveJoinRequestRequestBody
+	if context.Request.ContentLength > 0 {
+		if err := context.ShouldBindJSON(&body); err != nil {
+			context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+			return
+		}
+	}
+
+	if body.PrimaryPosition != "" && !util.IsBaseballPosition(body.PrimaryPosition) {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(errInvalidPosition))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	if !s.authorizeTeamCoach(context, uuid.MustParse(req.TeamID)) {
+		return
+	}
+
+	arg := db.ApproveJoinRequestTxParams{
+		DecideJoinRequestParams: db.DecideJoinRequestParams{
+			Status:    string(util.JoinRequestApproved),
+			DecidedBy: payload.UserID,
+			ID:        uuid.MustParse(req.RequestID),
+			TeamID:    uuid.MustParse(req.TeamID),
+		},
+		PrimaryPosition: sql.NullString{String: body.PrimaryPosition, Valid: body.PrimaryPosition != ""},
+	}
+	if body.Number != nil {
+		arg.Number = sql.NullInt64{Int64: *body.Number, Valid: true}
+	}
+
+	result, err := s.store.ApproveJoinRequestTx(context, arg)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(errJoinRequestNotFound))
+			return
+		}
+		rosterError(context, err)
+		return
+	}
+
+	context.JSON(http.StatusOK, result.Member)
+}
+
+// RejectJoinRequest rejects a pending join request. Only the team's coaches and admins may reject.
+func (s *Server) RejectJoinRequest(context *gin.Context) {
+	var req JoinRequestRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	if !s.authorizeTeamCoach(context, uuid.MustParse(req.TeamID)) {
+		return
+	}
+
+	joinRequest, err := s.store.DecideJoinRequest(context, db.DecideJoinRequestParams{
+		Status:    string(util.JoinRequestRejected),
+		DecidedBy: payload.UserID,
+		ID:        uuid.MustParse(req.RequestID),
+		TeamID:    uuid.MustParse(req.TeamID),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(errJoinRequestNotFound))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, joinRequest)
+}
@@ -0,0 +1,671 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	mockdb "github.com/kwalter26/scoreit-api-go/db/mock"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/security"
+	"github.com/kwalter26/scoreit-api-go/security/token"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func randomInvitation(team db.Team, coach db.User) db.TeamInvitation {
+	code, _ := security.NewJoinCode()
+	return db.TeamInvitation{
+		ID:        uuid.New(),
+		TeamID:    team.ID,
+		Code:      code,
+		Status:    string(util.InvitationPending),
+		InvitedBy: coach.ID,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+}
+
+func randomEmailInvitation(team db.Team, coach db.User, email string) db.TeamInvitation {
+	invitation := randomInvitation(team, coach)
+	invitation.Email = sql.NullString{String: email, Valid: true}
+	invitation.Number = sql.NullInt64{Int64: util.RandomInt(0, 99), Valid: true}
+	invitation.PrimaryPosition = sql.NullString{String: string(util.RandomBaseballPosition()), Valid: true}
+	return invitation
+}
+
+func TestServer_CreateTeamInvitation(t *testing.T) {
+	coach, _ := createRandomUser(t)
+	team := randomTeam()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"email": "Player@Example.com", "number": 0, "primary_position": util.Catcher, "expires_in_hours": 24},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, coach.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateTeamInvitation(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateTeamInvitationParams) (db.TeamInvitation, error) {
+						require.Equal(t, team.ID, arg.TeamID)
+						require.Equal(t, sql.NullString{String: "player@example.com", Valid: true}, arg.Email)
+						require.Len(t, arg.Code, 16)
+						require.Equal(t, sql.NullInt64{Int64: 0, Valid: true}, arg.Number)
+						require.Equal(t, sql.NullString{String: string(util.Catcher), Valid: true}, arg.PrimaryPosition)
+						require.Equal(t, coach.ID, arg.InvitedBy)
+						require.WithinDuration(t, time.Now().Add(24*time.Hour), arg.ExpiresAt, time.Minute)
+						return db.TeamInvitation{TeamID: arg.TeamID, Code: arg.Code}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "JoinCode",
+			body: gin.H{"expires_in_hours": 48},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, coach.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateTeamInvitation(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateTeamInvitationParams) (db.TeamInvitation, error) {
+						require.False(t, arg.Email.Valid)
+						require.False(t, arg.Number.Valid)
+						require.False(t, arg.PrimaryPosition.Valid)
+						return db.TeamInvitation{TeamID: arg.TeamID, Code: arg.Code}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "JoinCodeWithNumber",
+			body: gin.H{"number": 5, "primary_position": util.Catcher},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, coach.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateTeamInvitation(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), errJoinCodeProposal.Error())
+			},
+		},
+		{
+			name: "InvalidEmail",
+			body: gin.H{"email": "not-an-email", "number": 5, "primary_position": util.Catcher},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, coach.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateTeamInvitation(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "MissingNumber",
+			body: gin.H{"email": util.RandomEmail(), "primary_position": util.Catcher},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, coach.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateTeamInvitation(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), errInvitationProposal.Error())
+			},
+		},
+		{
+			name: "NotCoach",
+			body: gin.H{},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, coach.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateTeamInvitation(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectTeamCoach(store, coach.ID)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			buf, err := buildJsonRequest(t, tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/teams/%s/invitations", team.ID)
+			request, err := http.NewRequest(http.MethodPost, url, &buf)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_RevokeTeamInvitation(t *testing.T) {
+	coach, _ := createRandomUser(t)
+	team := randomTeam()
+	invitation := randomInvitation(team, coach)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RevokeTeamInvitation(gomock.Any(), gomock.Eq(db.RevokeTeamInvitationParams{ID: invitation.ID, TeamID: team.ID})).
+					Times(1).
+					Return(invitation, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NotPending",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RevokeTeamInvitation(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.TeamInvitation{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectTeamCoach(store, coach.ID)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/teams/%s/invitations/%s", team.ID, invitation.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, coach.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_AcceptTeamInvitation(t *testing.T) {
+	coach, _ := createRandomUser(t)
+	player, _ := createRandomUser(t)
+	team := randomTeam()
+	choice := gin.H{"number": 12, "primary_position": util.SecondBase}
+
+	testCases := []struct {
+		name          string
+		invitation    func() db.TeamInvitation
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore, invitation db.TeamInvitation)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "JoinCode",
+			invitation: func() db.TeamInvitation {
+				return randomInvitation(team, coach)
+			},
+			body: choice,
+			buildStubs: func(store *mockdb.MockStore, invitation db.TeamInvitation) {
+				arg := db.AcceptInvitationTxParams{
+					Invitation:      invitation,
+					UserID:          player.ID,
+					Number:          sql.NullInt64{Int64: 12, Valid: true},
+					PrimaryPosition: sql.NullString{String: string(util.SecondBase), Valid: true},
+				}
+				store.EXPECT().
+					AcceptInvitationTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.AcceptInvitationTxResult{Member: db.TeamMember{TeamID: team.ID, UserID: player.ID}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "JoinCodeWithoutChoice",
+			invitation: func() db.TeamInvitation {
+				return randomInvitation(team, coach)
+			},
+			body: gin.H{"number": 12},
+			buildStubs: func(store *mockdb.MockStore, invitation db.TeamInvitation) {
+				store.EXPECT().
+					AcceptInvitationTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), errJoinCodeChoice.Error())
+			},
+		},
+		{
+			name: "JoinCodeNumberTaken",
+			invitation: func() db.TeamInvitation {
+				return randomInvitation(team, coach)
+			},
+			body: choice,
+			buildStubs: func(store *mockdb.MockStore, invitation db.TeamInvitation) {
+				store.EXPECT().
+					AcceptInvitationTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AcceptInvitationTxResult{}, &pq.Error{Code: "23505", Constraint: teamMemberNumberConstraint})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+				require.Contains(t, recorder.Body.String(), errJerseyNumberUsed.Error())
+			},
+		},
+		{
+			name: "EmailMatches",
+			invitation: func() db.TeamInvitation {
+				return randomEmailInvitation(team, coach, player.Email)
+			},
+			buildStubs: func(store *mockdb.MockStore, invitation db.TeamInvitation) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(player.ID)).Times(1).Return(player, nil)
+				store.EXPECT().
+					AcceptInvitationTx(gomock.Any(), gomock.Eq(db.AcceptInvitationTxParams{Invitation: invitation, UserID: player.ID})).
+					Times(1).
+					Return(db.AcceptInvitationTxResult{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "EmailMismatch",
+			invitation: func() db.TeamInvitation {
+				return randomEmailInvitation(team, coach, util.RandomEmail())
+			},
+			buildStubs: func(store *mockdb.MockStore, invitation db.TeamInvitation) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(player.ID)).Times(1).Return(player, nil)
+				store.EXPECT().
+					AcceptInvitationTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Expired",
+			invitation: func() db.TeamInvitation {
+				invitation := randomInvitation(team, coach)
+				invitation.ExpiresAt = time.Now().Add(-time.Minute)
+				return invitation
+			},
+			body: choice,
+			buildStubs: func(store *mockdb.MockStore, invitation db.TeamInvitation) {
+				store.EXPECT().
+					AcceptInvitationTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusGone, recorder.Code)
+				require.Contains(t, recorder.Body.String(), errInvitationExpired.Error())
+			},
+		},
+		{
+			name: "Revoked",
+			invitation: func() db.TeamInvitation {
+				invitation := randomInvitation(team, coach)
+				invitation.Status = string(util.InvitationRevoked)
+				return invitation
+			},
+			body: choice,
+			buildStubs: func(store *mockdb.MockStore, invitation db.TeamInvitation) {
+				store.EXPECT().
+					AcceptInvitationTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusGone, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyOnTeam",
+			invitation: func() db.TeamInvitation {
+				return randomInvitation(team, coach)
+			},
+			body: choice,
+			buildStubs: func(store *mockdb.MockStore, invitation db.TeamInvitation) {
+				store.EXPECT().
+					AcceptInvitationTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AcceptInvitationTxResult{}, &pq.Error{Code: "23505", Constraint: teamMemberUserConstraint})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+				require.Contains(t, recorder.Body.String(), errAlreadyOnTeam.Error())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			invitation := tc.invitation()
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetTeamInvitationByCode(gomock.Any(), gomock.Eq(invitation.Code)).
+				Times(1).
+				Return(invitation, nil)
+			tc.buildStubs(store, invitation)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			buf, err := buildJsonRequest(t, tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/invitations/%s/accept", invitation.Code)
+			request, err := http.NewRequest(http.MethodPost, url, &buf)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, player.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_CreateJoinRequest(t *testing.T) {
+	player, _ := createRandomUser(t)
+	team := randomTeam()
+	body := gin.H{"number": 12, "primary_position": util.SecondBase, "message": "I can play anywhere"}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTeamMember(gomock.Any(), gomock.Eq(db.GetTeamMemberParams{TeamID: team.ID, UserID: player.ID})).
+					Times(1).
+					Return(db.TeamMember{}, sql.ErrNoRows)
+				arg := db.CreateJoinRequestParams{
+					TeamID:          team.ID,
+					UserID:          player.ID,
+					Number:          12,
+					PrimaryPosition: string(util.SecondBase),
+					Message:         "I can play anywhere",
+				}
+				store.EXPECT().
+					CreateJoinRequest(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.JoinRequest{ID: uuid.New()}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyOnTeam",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTeamMember(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.TeamMember{TeamID: team.ID, UserID: player.ID}, nil)
+				store.EXPECT().
+					CreateJoinRequest(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyPending",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTeamMember(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.TeamMember{}, sql.ErrNoRows)
+				store.EXPECT().
+					CreateJoinRequest(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.JoinRequest{}, &pq.Error{Code: "23505", Constraint: joinRequestPendingConstraint})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+				require.Contains(t, recorder.Body.String(), errJoinRequestPending.Error())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			buf, err := buildJsonRequest(t, body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/teams/%s/join-requests", team.ID)
+			request, err := http.NewRequest(http.MethodPost, url, &buf)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, player.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_DecideJoinRequest(t *testing.T) {
+	coach, _ := createRandomUser(t)
+	team := randomTeam()
+	requestID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		action        string
+		body          gin.H
+		roles         []security.Role
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Approve",
+			action: "approve",
+			roles:  coachRoles,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ApproveJoinRequestTxParams{
+					DecideJoinRequestParams: db.DecideJoinRequestParams{
+						Status:    string(util.JoinRequestApproved),
+						DecidedBy: coach.ID,
+						ID:        requestID,
+						TeamID:    team.ID,
+					},
+				}
+				store.EXPECT().
+					ApproveJoinRequestTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.ApproveJoinRequestTxResult{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "ApproveWithNewNumber",
+			action: "approve",
+			body:   gin.H{"number": 44},
+			roles:  coachRoles,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ApproveJoinRequestTxParams{
+					DecideJoinRequestParams: db.DecideJoinRequestParams{
+						Status:    string(util.JoinRequestApproved),
+						DecidedBy: coach.ID,
+						ID:        requestID,
+						TeamID:    team.ID,
+					},
+					Number: sql.NullInt64{Int64: 44, Valid: true},
+				}
+				store.EXPECT().
+					ApproveJoinRequestTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.ApproveJoinRequestTxResult{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "ApproveNotPending",
+			action: "approve",
+			roles:  coachRoles,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ApproveJoinRequestTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ApproveJoinRequestTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "ApproveNotCoach",
+			action: "approve",
+			roles:  security.UserRoles,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ApproveJoinRequestTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "Reject",
+			action: "reject",
+			roles:  coachRoles,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.DecideJoinRequestParams{
+					Status:    string(util.JoinRequestRejected),
+					DecidedBy: coach.ID,
+					ID:        requestID,
+					TeamID:    team.ID,
+				}
+				store.EXPECT().
+					DecideJoinRequest(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.JoinRequest{ID: requestID, Status: string(util.JoinRequestRejected)}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectTeamCoach(store, coach.ID)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			var buf bytes.Buffer
+			if tc.body != nil {
+				var err error
+				buf, err = buildJsonRequest(t, tc.body)
+				require.NoError(t, err)
+			}
+
+			url := fmt.Sprintf("/api/v1/teams/%s/join-requests/%s/%s", team.ID, requestID, tc.action)
+			request, err := http.NewRequest(http.MethodPost, url, &buf)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, tc.roles, middleware.AuthorizationTypeBearer, coach.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
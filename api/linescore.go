@@ -0,0 +1,111 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/helpers"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"net/http"
+	"strconv"
+)
+
+// unplayedHalf marks the bottom of the last inning of a final game the home team did not need to bat in
+const unplayedHalf = "X"
+
+// LinescoreInning represents one column of a line score. Away and Home hold the runs scored in each
+// half, "X" for a bottom half that was not needed, or "" for a half that has not been played yet.
+type LinescoreInning struct {
+	Number int64  `json:"number"`
+	Away   string `json:"away"`
+	Home   string `json:"home"`
+}
+
+// LinescoreTotals represents a team's runs, hits and errors for the game.
+type LinescoreTotals struct {
+	Runs   int64 `json:"runs"`
+	Hits   int64 `json:"hits"`
+	Errors int64 `json:"errors"`
+}
+
+// LinescoreResponse represents a game's line score: runs by inning and R/H/E totals for each team.
+// ScoreMismatch is set when the innings do not add up to the game's score, with the differences in Mismatches.
+type LinescoreResponse struct {
+	GameID        uuid.UUID         `json:"game_id"`
+	Status        string            `json:"status"`
+	Innings       []LinescoreInning `json:"innings"`
+	Away          LinescoreTotals   `json:"away"`
+	Home          LinescoreTotals   `json:"home"`
+	ScoreMismatch bool              `json:"score_mismatch"`
+	Mismatches    []string          `json:"mismatches,omitempty"`
+}
+
+// GetLinescore gets the line score of a game from its innings, including any extra innings.
+func (s *Server) GetLinescore(context *gin.Context) {
+	var req GetGameRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	game, err := s.store.GetGame(context, uuid.MustParse(req.ID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	innings, err := s.store.ListGameInnings(context, game.ID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, linescore(game, innings))
+}
+
+// linescore builds a game's line score and checks its runs against the game's score
+func linescore(game db.Game, innings []db.ListGameInningsRow) LinescoreResponse {
+	rsp := LinescoreResponse{
+		GameID:  game.ID,
+		Status:  game.Status,
+		Innings: make([]LinescoreInning, 0, len(innings)),
+	}
+	final := util.GameStatus(game.Status) == util.GameFinal
+
+	for i, inning := range innings {
+		column := LinescoreInning{Number: inning.Number}
+		if inning.AwayBatted || inning.AwayRuns > 0 {
+			column.Away = strconv.FormatInt(inning.AwayRuns, 10)
+		}
+		switch {
+		case inning.HomeBatted || inning.HomeRuns > 0:
+			column.Home = strconv.FormatInt(inning.HomeRuns, 10)
+		case final && i == len(innings)-1:
+			column.Home = unplayedHalf
+		}
+		rsp.Innings = append(rsp.Innings, column)
+
+		rsp.Away.Runs += inning.AwayRuns
+		rsp.Away.Hits += inning.AwayHits
+		rsp.Away.Errors += inning.AwayErrors
+		rsp.Home.Runs += inning.HomeRuns
+		rsp.Home.Hits += inning.HomeHits
+		rsp.Home.Errors += inning.HomeErrors
+	}
+
+	if rsp.Away.Runs != game.AwayScore {
+		rsp.Mismatches = append(rsp.Mismatches, fmt.Sprintf("away runs by inning total %d but the game score has %d", rsp.Away.Runs, game.AwayScore))
+	}
+	if rsp.Home.Runs != game.HomeScore {
+		rsp.Mismatches = append(rsp.Mismatches, fmt.Sprintf("home runs by inning total %d but the game score has %d", rsp.Home.Runs, game.HomeScore))
+	}
+	rsp.ScoreMismatch = len(rsp.Mismatches) > 0
+	return rsp
+}
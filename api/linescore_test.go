@@ -0,0 +1,201 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	mockdb "github.com/kwalter26/scoreit-api-go/db/mock"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/security"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// playedInning returns an inning in which both teams batted
+func playedInning(gameID uuid.UUID, number, awayRuns, homeRuns int64) db.ListGameInningsRow {
+	return db.ListGameInningsRow{
+		ID:         uuid.New(),
+		GameID:     gameID,
+		Number:     number,
+		AwayRuns:   awayRuns,
+		AwayHits:   awayRuns,
+		AwayBatted: true,
+		HomeRuns:   homeRuns,
+		HomeHits:   homeRuns + 1,
+		HomeBatted: true,
+	}
+}
+
+func TestServer_GetLinescore(t *testing.T) {
+	user, _ := createRandomUser(t)
+	game := db.Game{ID: uuid.New(), Status: string(util.GameFinal), AwayScore: 3, HomeScore: 2}
+
+	// the away team wins a ten-inning game
+	var innings []db.ListGameInningsRow
+	for i := int64(1); i <= 10; i++ {
+		innings = append(innings, playedInning(game.ID, i, 0, 0))
+	}
+	innings[0] = playedInning(game.ID, 1, 2, 0)
+	innings[6] = playedInning(game.ID, 7, 0, 2)
+	innings[8].HomeErrors = 1
+	innings[9] = playedInning(game.ID, 10, 1, 0)
+
+	// the home team leads after the top of the ninth and does not bat again
+	won := db.Game{ID: uuid.New(), Status: string(util.GameFinal), AwayScore: 0, HomeScore: 1}
+	var unneeded []db.ListGameInningsRow
+	for i := int64(1); i <= 9; i++ {
+		unneeded = append(unneeded, playedInning(won.ID, i, 0, 0))
+	}
+	unneeded[3] = playedInning(won.ID, 4, 0, 1)
+	unneeded[8] = db.ListGameInningsRow{ID: uuid.New(), GameID: won.ID, Number: 9, AwayBatted: true}
+
+	testCases := []struct {
+		name          string
+		game          db.Game
+		buildStubs    func(store *mockdb.MockStore, game db.Game)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "ExtraInnings",
+			game: game,
+			buildStubs: func(store *mockdb.MockStore, game db.Game) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					ListGameInnings(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(innings, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp LinescoreResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Len(t, rsp.Innings, 10)
+				require.Equal(t, "2", rsp.Innings[0].Away)
+				require.Equal(t, "2", rsp.Innings[6].Home)
+				require.Equal(t, LinescoreInning{Number: 10, Away: "1", Home: "0"}, rsp.Innings[9])
+				require.Equal(t, LinescoreTotals{Runs: 3, Hits: 3, Errors: 0}, rsp.Away)
+				require.Equal(t, LinescoreTotals{Runs: 2, Hits: 12, Errors: 1}, rsp.Home)
+				require.False(t, rsp.ScoreMismatch)
+				require.Empty(t, rsp.Mismatches)
+			},
+		},
+		{
+			name: "HomeDidNotBat",
+			game: won,
+			buildStubs: func(store *mockdb.MockStore, game db.Game) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					ListGameInnings(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(unneeded, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp LinescoreResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Len(t, rsp.Innings, 9)
+				require.Equal(t, LinescoreInning{Number: 9, Away: "0", Home: "X"}, rsp.Innings[8])
+				require.Equal(t, int64(1), rsp.Home.Runs)
+				require.False(t, rsp.ScoreMismatch)
+			},
+		},
+		{
+			name: "InProgress",
+			game: db.Game{ID: uuid.New(), Status: string(util.GameInProgress), AwayScore: 2},
+			buildStubs: func(store *mockdb.MockStore, game db.Game) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					ListGameInnings(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return([]db.ListGameInningsRow{{GameID: game.ID, Number: 1, AwayRuns: 2, AwayHits: 3, AwayBatted: true}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp LinescoreResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, []LinescoreInning{{Number: 1, Away: "2", Home: ""}}, rsp.Innings)
+				require.False(t, rsp.ScoreMismatch)
+			},
+		},
+		{
+			name: "ScoreMismatch",
+			game: db.Game{ID: uuid.New(), Status: string(util.GameFinal), AwayScore: 2, HomeScore: 5},
+			buildStubs: func(store *mockdb.MockStore, game db.Game) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					ListGameInnings(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return([]db.ListGameInningsRow{playedInning(game.ID, 1, 2, 1)}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp LinescoreResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.True(t, rsp.ScoreMismatch)
+				require.Equal(t, []string{"home runs by inning total 1 but the game score has 5"}, rsp.Mismatches)
+			},
+		},
+		{
+			name: "NotFound",
+			game: game,
+			buildStubs: func(store *mockdb.MockStore, game db.Game) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(db.Game{}, sql.ErrNoRows)
+				store.EXPECT().
+					ListGameInnings(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store, tc.game)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/games/%s/linescore", tc.game.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
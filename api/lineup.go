@@ -0,0 +1,288 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/helpers"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"net/http"
+)
+
+var (
+	errLineupLocked   = errors.New("lineups are locked once the game starts; use substitutions instead")
+	errLineupRejected = errors.New("lineup has players who cannot play")
+)
+
+// GetLineupRequest addresses one side's lineup for a game.
+type GetLineupRequest struct {
+	ID   string `uri:"id" binding:"required,uuid"`
+	Side string `uri:"side" binding:"required,oneof=home away"`
+}
+
+// LineupPlayerRequest represents one player's place in a submitted lineup.
+// A bat_position of 0 is used for a pitcher who does not bat because of a DH.
+type LineupPlayerRequest struct {
+	PlayerID    string `json:"player_id" binding:"required,uuid"`
+	BatPosition int64  `json:"bat_position" binding:"min=0,max=30"`
+	Position    string `json:"position" binding:"required"`
+}
+
+// SetLineupRequestBody represents a batting order with defensive positions.
+// Admins may set override to start players who have a current injured, suspended or inactive status.
+type SetLineupRequestBody struct {
+	Players  []LineupPlayerRequest `json:"players" binding:"required,min=1,max=30,dive"`
+	Override bool                  `json:"override"`
+}
+
+// LineupResponse represents one side's lineup for a game.
+type LineupResponse struct {
+	GameID  uuid.UUID          `json:"game_id"`
+	TeamID  uuid.UUID          `json:"team_id"`
+	Side    string             `json:"side"`
+	Locked  bool               `json:"locked"`
+	Players []db.ListLineupRow `json:"players"`
+	// Warnings lists concerns that did not block the lineup, such as a player who said they cannot make it
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// LineupProblem explains why a player cannot be in a lineup.
+type LineupProblem struct {
+	PlayerID uuid.UUID `json:"player_id"`
+	Problem  string    `json:"problem"`
+}
+
+// LineupRejectedResponse is returned when players in a lineup are not on the roster or not eligible.
+type LineupRejectedResponse struct {
+	Error    string          `json:"error"`
+	Problems []LineupProblem `json:"problems"`
+}
+
+// SetLineup submits the batting order and defensive positions for one side of a game.
+// Every player must be on that team's roster, eligible for their position and free of a current status,
+// and a designated hitter may only bat when the game's rules have one.
+// Lineups lock when the game moves to in progress. Only that team's coaches and admins may set its lineup.
+func (s *Server) SetLineup(context *gin.Context) {
+	var req GetLineupRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var body SetLineupRequestBody
+	if err := context.ShouldBindJSON(&body); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	if !isCoachOrAdmin(payload) || (body.Override && !isAdmin(payload)) {
+		context.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	game, err := s.store.GetGame(context, uuid.MustParse(req.ID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+	homeTeam, teamID := lineupSide(game, req.Side)
+	if !s.authorizeTeamCoach(context, teamID) {
+		return
+	}
+	if lineupLocked(game) {
+		context.JSON(http.StatusConflict, helpers.ErrorResponse(errLineupLocked))
+		return
+	}
+
+	rules, err := gameRules(game)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+	spots := make([]util.LineupSpot, len(body.Players))
+	for i, player := range body.Players {
+		spots[i] = util.LineupSpot{
+			PlayerID:    player.PlayerID,
+			BatPosition: player.BatPosition,
+			Position:    util.BaseballPosition(player.Position),
+		}
+	}
+	if err := util.ValidateLineup(rules, spots); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var problems []LineupProblem
+	var warnings []string
+	params := make([]db.LineupSpotParams, len(body.Players))
+	for i, player := range body.Players {
+		playerID := uuid.MustParse(player.PlayerID)
+		params[i] = db.LineupSpotParams{PlayerID: playerID, BatPosition: player.BatPosition, Position: player.Position}
+
+		problem, warning, err := s.lineupPlayerProblem(context, game, teamID, playerID, player.Position, body.Override)
+		if err != nil {
+			context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+			return
+		}
+		if problem != "" {
+			problems = append(problems, LineupProblem{PlayerID: playerID, Problem: problem})
+		}
+		if warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+	if len(problems) > 0 {
+		context.JSON(http.StatusBadRequest, LineupRejectedResponse{
+			Error:    errLineupRejected.Error(),
+			Problems: problems,
+		})
+		return
+	}
+
+	_, err = s.store.SetLineupTx(context, db.SetLineupTxParams{
+		GameID:   game.ID,
+		HomeTeam: homeTeam,
+		Spots:    params,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusConflict, helpers.ErrorResponse(errLineupLocked))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	players, err := s.store.ListLineup(context, db.ListLineupParams{GameID: game.ID, HomeTeam: homeTeam})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, LineupResponse{
+		GameID:   game.ID,
+		TeamID:   teamID,
+		Side:     req.Side,
+		Players:  players,
+		Warnings: warnings,
+	})
+}
+
+// GetLineup gets one side's lineup for a game, in batting order.
+func (s *Server) GetLineup(context *gin.Context) {
+	var req GetLineupRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	game, err := s.store.GetGame(context, uuid.MustParse(req.ID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	homeTeam, teamID := lineupSide(game, req.Side)
+	players, err := s.store.ListLineup(context, db.ListLineupParams{GameID: game.ID, HomeTeam: homeTeam})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, LineupResponse{
+		GameID:  game.ID,
+		TeamID:  teamID,
+		Side:    req.Side,
+		Locked:  lineupLocked(game),
+		Players: players,
+	})
+}
+
+// lineupPlayerProblem checks one player against the team's roster. A problem keeps the player out
+// of the lineup; a warning is passed back to the coach. With override, a current status is only a warning.
+// An empty position skips the position check, for players who only bat or run. Pitchers must also have
+// rested enough, unless the game's rules only warn about it.
+func (s *Server) lineupPlayerProblem(context *gin.Context, game db.Game, teamID, playerID uuid.UUID, position string, override bool) (problem string, warning string, err error) {
+	_, err = s.store.GetTeamMember(context, db.GetTeamMemberParams{TeamID: teamID, UserID: playerID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "not on the team's roster", "", nil
+		}
+		return "", "", err
+	}
+
+	if position != "" && util.BaseballPosition(position) != util.DesignatedHitter {
+		eligible, err := s.isEligibleForPosition(context, teamID, playerID, position)
+		if err != nil {
+			return "", "", err
+		}
+		if !eligible {
+			return fmt.Sprintf("not eligible to play %s", position), "", nil
+		}
+	}
+
+	status, err := s.store.GetCurrentPlayerStatus(context, db.GetCurrentPlayerStatusParams{TeamID: teamID, UserID: playerID})
+	if err == nil {
+		if !override {
+			return fmt.Sprintf("is %s", status.Status), "", nil
+		}
+		warning = fmt.Sprintf("player %s is %s", playerID, status.Status)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return "", "", err
+	}
+
+	availability, err := s.store.GetGameAvailability(context, db.GetGameAvailabilityParams{GameID: game.ID, UserID: playerID})
+	if err == nil {
+		if util.Availability(availability.Response) == util.AvailabilityNo && warning == "" {
+			warning = fmt.Sprintf("player %s said they cannot play", playerID)
+		}
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return "", "", err
+	}
+
+	if util.BaseballPosition(position) == util.Pitcher {
+		rules, err := gameRules(game)
+		if err != nil {
+			return "", "", err
+		}
+		rest, err := s.pitcherRestProblem(context, game, playerID)
+		if err != nil {
+			return "", "", err
+		}
+		if rest != "" && !rules.WarnOnRest {
+			return rest, "", nil
+		}
+		if rest != "" && warning == "" {
+			warning = fmt.Sprintf("player %s %s", playerID, rest)
+		}
+	}
+
+	return "", warning, nil
+}
+
+// lineupLocked reports whether a game has started, after which lineups only change through substitutions.
+func lineupLocked(game db.Game) bool {
+	status := util.GameStatus(game.Status)
+	return status != util.GameScheduled && status != util.GamePostponed
+}
+
+// lineupSide returns whether side is the home team, and that team's ID.
+func lineupSide(game db.Game, side string) (bool, uuid.UUID) {
+	if side == "home" {
+		return true, game.HomeTeamID
+	}
+	return false, game.AwayTeamID
+}
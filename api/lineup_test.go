@@ -0,0 +1,592 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	mockdb "github.com/kwalter26/scoreit-api-go/db/mock"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/security"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// randomLineup returns nine players batting in order at the nine fielding positions, pitcher last.
+func randomLineup() []gin.H {
+	positions := []util.BaseballPosition{util.CenterField, util.SecondBase, util.FirstBase, util.ThirdBase, util.RightField, util.LeftField, util.ShortStop, util.Catcher, util.Pitcher}
+	players := make([]gin.H, len(positions))
+	for i, position := range positions {
+		players[i] = gin.H{"player_id": uuid.New().String(), "bat_position": i + 1, "position": position}
+	}
+	return players
+}
+
+// expectEligibleLineup stubs the roster checks so that every player in the lineup may play.
+func expectEligibleLineup(store *mockdb.MockStore) {
+	store.EXPECT().
+		GetTeamMember(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(db.TeamMember{}, nil)
+	store.EXPECT().
+		IsEligibleForPosition(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(true, nil)
+	store.EXPECT().
+		GetCurrentPlayerStatus(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(db.PlayerStatus{}, sql.ErrNoRows)
+	store.EXPECT().
+		GetGameAvailability(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(db.GameAvailability{}, sql.ErrNoRows)
+	store.EXPECT().
+		ListPitchingAppearances(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return([]db.ListPitchingAppearancesRow{}, nil)
+	store.EXPECT().
+		ListPlayerPitchCounts(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return([]db.ListPlayerPitchCountsRow{}, nil)
+}
+
+// tiredPitcher returns a game the player pitched yesterday, throwing enough pitches under
+// high school rules to need four days of rest.
+func tiredPitcher() db.ListPitchingAppearancesRow {
+	rules, _ := util.PresetRules(util.RulesNFHS)
+	return db.ListPitchingAppearancesRow{
+		ID:          uuid.New(),
+		ScheduledAt: sql.NullTime{Time: time.Now().AddDate(0, 0, -1), Valid: true},
+		CreatedAt:   time.Now().AddDate(0, 0, -7),
+		Rules:       encodeRules(rules),
+		Pitches:     80,
+	}
+}
+
+func TestServer_SetLineup(t *testing.T) {
+	user, _ := createRandomUser(t)
+	homeCoach, _ := createRandomUser(t)
+	game := db.Game{ID: uuid.New(), HomeTeamID: uuid.New(), AwayTeamID: uuid.New(), Status: string(util.GameScheduled)}
+	started := game
+	started.Status = string(util.GameInProgress)
+	lineup := randomLineup()
+	firstID := uuid.MustParse(lineup[0]["player_id"].(string))
+	pitcherID := uuid.MustParse(lineup[8]["player_id"].(string))
+	warnOnRest := game
+	warnOnRest.Rules = encodeRules(util.RuleSet{WarnOnRest: true})
+	withDH := game
+	withDH.Rules = encodeRules(util.RuleSet{DesignatedHitter: true})
+
+	// the pitcher sits and a designated hitter bats ninth
+	dhLineup := randomLineup()
+	dhLineup[8]["bat_position"] = 0
+	dhLineup = append(dhLineup, gin.H{"player_id": uuid.New().String(), "bat_position": 9, "position": util.DesignatedHitter})
+
+	testCases := []struct {
+		name          string
+		roles         []security.Role
+		callerID      uuid.UUID
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "CoachOfOtherTeam",
+			roles:    coachRoles,
+			callerID: homeCoach.ID,
+			body:     gin.H{"players": lineup},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					GetTeamMember(gomock.Any(), gomock.Eq(db.GetTeamMemberParams{TeamID: game.AwayTeamID, UserID: homeCoach.ID})).
+					Times(1).
+					Return(db.TeamMember{}, sql.ErrNoRows)
+				store.EXPECT().
+					SetLineupTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "OK",
+			roles: coachRoles,
+			body:  gin.H{"players": lineup},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					GetTeamMember(gomock.Any(), gomock.Eq(db.GetTeamMemberParams{TeamID: game.AwayTeamID, UserID: firstID})).
+					Times(1).
+					Return(db.TeamMember{}, nil)
+				expectEligibleLineup(store)
+				store.EXPECT().
+					SetLineupTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.SetLineupTxParams) (db.SetLineupTxResult, error) {
+						require.Equal(t, game.ID, arg.GameID)
+						require.False(t, arg.HomeTeam)
+						require.Len(t, arg.Spots, 9)
+						require.Equal(t, firstID, arg.Spots[0].PlayerID)
+						return db.SetLineupTxResult{}, nil
+					})
+				store.EXPECT().
+					ListLineup(gomock.Any(), gomock.Eq(db.ListLineupParams{GameID: game.ID, HomeTeam: false})).
+					Times(1).
+					Return([]db.ListLineupRow{{PlayerID: firstID, BatPosition: 1}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp LineupResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, game.AwayTeamID, rsp.TeamID)
+				require.Len(t, rsp.Players, 1)
+				require.Empty(t, rsp.Warnings)
+			},
+		},
+		{
+			name:  "UnavailableWarning",
+			roles: coachRoles,
+			body:  gin.H{"players": lineup},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					GetGameAvailability(gomock.Any(), gomock.Eq(db.GetGameAvailabilityParams{GameID: game.ID, UserID: firstID})).
+					Times(1).
+					Return(db.GameAvailability{Response: string(util.AvailabilityNo)}, nil)
+				expectEligibleLineup(store)
+				store.EXPECT().
+					SetLineupTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.SetLineupTxResult{}, nil)
+				store.EXPECT().
+					ListLineup(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListLineupRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp LineupResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Len(t, rsp.Warnings, 1)
+			},
+		},
+		{
+			name:  "PitcherNotRested",
+			roles: coachRoles,
+			body:  gin.H{"players": lineup},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					ListPitchingAppearances(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.ListPitchingAppearancesParams) ([]db.ListPitchingAppearancesRow, error) {
+						require.Equal(t, pitcherID, arg.PlayerID)
+						return []db.ListPitchingAppearancesRow{tiredPitcher()}, nil
+					})
+				expectEligibleLineup(store)
+				store.EXPECT().
+					SetLineupTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+				var rsp LineupRejectedResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Len(t, rsp.Problems, 1)
+				require.Equal(t, pitcherID, rsp.Problems[0].PlayerID)
+				require.Contains(t, rsp.Problems[0].Problem, "threw 80 pitches")
+			},
+		},
+		{
+			name:  "PitcherNotRestedWarning",
+			roles: coachRoles,
+			body:  gin.H{"players": lineup},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(warnOnRest, nil)
+				store.EXPECT().
+					ListPitchingAppearances(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListPitchingAppearancesRow{tiredPitcher()}, nil)
+				expectEligibleLineup(store)
+				store.EXPECT().
+					SetLineupTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.SetLineupTxResult{}, nil)
+				store.EXPECT().
+					ListLineup(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListLineupRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp LineupResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Len(t, rsp.Warnings, 1)
+				require.Contains(t, rsp.Warnings[0], "threw 80 pitches")
+			},
+		},
+		{
+			name:  "NotOnRoster",
+			roles: coachRoles,
+			body:  gin.H{"players": lineup},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					GetTeamMember(gomock.Any(), gomock.Eq(db.GetTeamMemberParams{TeamID: game.AwayTeamID, UserID: firstID})).
+					Times(1).
+					Return(db.TeamMember{}, sql.ErrNoRows)
+				expectEligibleLineup(store)
+				store.EXPECT().
+					SetLineupTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+				var rsp LineupRejectedResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Len(t, rsp.Problems, 1)
+				require.Equal(t, firstID, rsp.Problems[0].PlayerID)
+			},
+		},
+		{
+			name:  "NotEligibleForPosition",
+			roles: coachRoles,
+			body:  gin.H{"players": lineup},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					IsEligibleForPosition(gomock.Any(), gomock.Eq(db.IsEligibleForPositionParams{TeamID: game.AwayTeamID, UserID: firstID, Position: string(util.CenterField)})).
+					Times(1).
+					Return(false, nil)
+				expectEligibleLineup(store)
+				store.EXPECT().
+					SetLineupTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Suspended",
+			roles: coachRoles,
+			body:  gin.H{"players": lineup},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					GetCurrentPlayerStatus(gomock.Any(), gomock.Eq(db.GetCurrentPlayerStatusParams{TeamID: game.AwayTeamID, UserID: firstID})).
+					Times(1).
+					Return(db.PlayerStatus{Status: string(util.RosterStatusSuspended)}, nil)
+				expectEligibleLineup(store)
+				store.EXPECT().
+					SetLineupTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "SuspendedWithOverride",
+			roles: adminUserRoles,
+			body:  gin.H{"players": lineup, "override": true},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					GetCurrentPlayerStatus(gomock.Any(), gomock.Eq(db.GetCurrentPlayerStatusParams{TeamID: game.AwayTeamID, UserID: firstID})).
+					Times(1).
+					Return(db.PlayerStatus{Status: string(util.RosterStatusSuspended)}, nil)
+				expectEligibleLineup(store)
+				store.EXPECT().
+					SetLineupTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.SetLineupTxResult{}, nil)
+				store.EXPECT().
+					ListLineup(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListLineupRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp LineupResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Len(t, rsp.Warnings, 1)
+			},
+		},
+		{
+			name:  "OverrideByCoach",
+			roles: coachRoles,
+			body:  gin.H{"players": lineup, "override": true},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "Locked",
+			roles: coachRoles,
+			body:  gin.H{"players": lineup},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(started, nil)
+				store.EXPECT().
+					SetLineupTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:  "StartedConcurrently",
+			roles: coachRoles,
+			body:  gin.H{"players": lineup},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				expectEligibleLineup(store)
+				store.EXPECT().
+					SetLineupTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.SetLineupTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:  "NoPitcher",
+			roles: coachRoles,
+			body:  gin.H{"players": lineup[:8]},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					SetLineupTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "DesignatedHitter",
+			roles: coachRoles,
+			body:  gin.H{"players": dhLineup},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(withDH, nil)
+				expectEligibleLineup(store)
+				store.EXPECT().
+					SetLineupTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.SetLineupTxResult{}, nil)
+				store.EXPECT().
+					ListLineup(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListLineupRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "DesignatedHitterNotInRules",
+			roles: coachRoles,
+			body:  gin.H{"players": dhLineup},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					SetLineupTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "do not allow a DESIGNATED_HITTER")
+			},
+		},
+		{
+			name:  "NotCoach",
+			roles: security.UserRoles,
+			body:  gin.H{"players": lineup},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectTeamCoach(store, user.ID)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			buf, err := buildJsonRequest(t, tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/games/%s/lineups/away", game.ID)
+			request, err := http.NewRequest(http.MethodPut, url, &buf)
+			require.NoError(t, err)
+
+			callerID := user.ID
+			if tc.callerID != uuid.Nil {
+				callerID = tc.callerID
+			}
+			addAuthorization(t, request, server.tokenMaker, tc.roles, middleware.AuthorizationTypeBearer, callerID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_GetLineup(t *testing.T) {
+	user, _ := createRandomUser(t)
+	game := db.Game{ID: uuid.New(), HomeTeamID: uuid.New(), AwayTeamID: uuid.New(), Status: string(util.GameInProgress)}
+
+	testCases := []struct {
+		name          string
+		side          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			side: "home",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					ListLineup(gomock.Any(), gomock.Eq(db.ListLineupParams{GameID: game.ID, HomeTeam: true})).
+					Times(1).
+					Return([]db.ListLineupRow{{BatPosition: 1}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp LineupResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.True(t, rsp.Locked)
+				require.Equal(t, game.HomeTeamID, rsp.TeamID)
+			},
+		},
+		{
+			name: "InvalidSide",
+			side: "visitors",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			side: "away",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(db.Game{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/games/%s/lineups/%s", game.ID, tc.side)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
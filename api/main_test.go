@@ -8,6 +8,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	mockdb "github.com/kwalter26/scoreit-api-go/db/mock"
 	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
 	"github.com/kwalter26/scoreit-api-go/security"
 	"github.com/kwalter26/scoreit-api-go/security/token"
@@ -94,6 +95,33 @@ func EqCreateUserTxParamsMatcher(arg db.CreateUserTxParams, password string) gom
 	return eqCreateUserTxParamsMatcher{arg: arg, password: password}
 }
 
+type eqRosterTransactionTxParamsMatcher struct {
+	arg db.RosterTransactionTxParams
+}
+
+func (e eqRosterTransactionTxParamsMatcher) Matches(x interface{}) bool {
+	arg, ok := x.(db.RosterTransactionTxParams)
+	if !ok {
+		return false
+	}
+
+	if time.Since(arg.CreateRosterTransactionParams.EffectiveAt) > time.Minute {
+		return false
+	}
+
+	e.arg.CreateRosterTransactionParams.EffectiveAt = arg.CreateRosterTransactionParams.EffectiveAt
+	return reflect.DeepEqual(e.arg, arg)
+}
+
+func (e eqRosterTransactionTxParamsMatcher) String() string {
+	return fmt.Sprintf("matches arg %v effective now", e.arg)
+}
+
+// EqRosterTransactionTxParamsMatcher matches a roster transaction that takes effect now.
+func EqRosterTransactionTxParamsMatcher(arg db.RosterTransactionTxParams) gomock.Matcher {
+	return eqRosterTransactionTxParamsMatcher{arg: arg}
+}
+
 func addAuthorization(t *testing.T, request *http.Request, tokenMaker token.Maker, roles []security.Role, authorizationType string, u uuid.UUID, duration time.Duration) {
 	createToken, payload, err := tokenMaker.CreateToken(u, roles, duration)
 	require.NoError(t, err)
@@ -109,3 +137,25 @@ func buildJsonRequest(t *testing.T, body gin.H) (bytes.Buffer, error) {
 	require.NoError(t, err)
 	return buf, err
 }
+
+// teamMemberLookupMatcher matches looking up one user on any team
+type teamMemberLookupMatcher struct {
+	userID uuid.UUID
+}
+
+func (e teamMemberLookupMatcher) Matches(x interface{}) bool {
+	arg, ok := x.(db.GetTeamMemberParams)
+	return ok && arg.UserID == e.userID
+}
+
+func (e teamMemberLookupMatcher) String() string {
+	return fmt.Sprintf("looks up user %s on a team", e.userID)
+}
+
+// expectTeamCoach stubs looking up the caller on a team, finding them a coach of every team.
+func expectTeamCoach(store *mockdb.MockStore, userID uuid.UUID) {
+	store.EXPECT().
+		GetTeamMember(gomock.Any(), teamMemberLookupMatcher{userID: userID}).
+		AnyTimes().
+		Return(db.TeamMember{UserID: userID, Role: string(util.TeamRoleCoach)}, nil)
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/helpers"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"net/http"
+	"time"
+)
+
+var errInvalidDateRange = errors.New("to must be after from")
+
+// GetMe gets the authenticated user's own profile, including their privacy settings.
+func (s *Server) GetMe(context *gin.Context) {
+	payload := middleware.GetAuthorizationPayload(context)
+
+	user, err := s.store.GetUser(context, payload.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	rsp := NewGetUserResponse(user, true)
+	privacy := NewPrivacySettingsResponse(user)
+	rsp.Privacy = &privacy
+	context.JSON(http.StatusOK, rsp)
+}
+
+// MyTeamResponse is a team the authenticated user belongs to, with their membership on it.
+type MyTeamResponse struct {
+	TeamResponse
+	Role            string `json:"role"`
+	Number          int64  `json:"number"`
+	PrimaryPosition string `json:"primary_position"`
+}
+
+// ListMyTeams lists the teams the authenticated user is a member of.
+func (s *Server) ListMyTeams(context *gin.Context) {
+	var query ListTeamMembersRequestQuery
+	if err := context.ShouldBindQuery(&query); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	teams, err := s.store.ListTeamsOfUser(context, db.ListTeamsOfUserParams{
+		UserID: payload.UserID,
+		Limit:  query.PageSize,
+		Offset: (query.PageId - 1) * query.PageSize,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	rsp := make([]MyTeamResponse, 0, len(teams))
+	for _, team := range teams {
+		rsp = append(rsp, MyTeamResponse{
+			TeamResponse: NewTeamResponse(db.Team{
+				ID:             team.ID,
+				Name:           team.Name,
+				Abbreviation:   team.Abbreviation,
+				PrimaryColor:   team.PrimaryColor,
+				SecondaryColor: team.SecondaryColor,
+				LogoUrl:        team.LogoUrl,
+				ArchivedAt:     team.ArchivedAt,
+			}),
+			Role:            team.Role,
+			Number:          team.Number,
+			PrimaryPosition: team.PrimaryPosition,
+		})
+	}
+
+	context.JSON(http.StatusOK, rsp)
+}
+
+// ListMyGamesRequest represents a request to list the authenticated user's games.
+type ListMyGamesRequest struct {
+	Status   string    `form:"status" binding:"omitempty,oneof=scheduled in_progress final postponed suspended cancelled forfeit"`
+	From     time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To       time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	PageSize int32     `form:"page_size,default=10" binding:"max=100,min=1"`
+	PageID   int32     `form:"page_id,default=1" binding:"min=1"`
+}
+
+// ListMyGames lists the games of the authenticated user's teams and the games they played in, newest first.
+func (s *Server) ListMyGames(context *gin.Context) {
+	var req ListMyGamesRequest
+	if err := context.ShouldBindQuery(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	if !req.From.IsZero() && !req.To.IsZero() && !req.To.After(req.From) {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(errInvalidDateRange))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	games, err := s.store.ListGamesOfUser(context, db.ListGamesOfUserParams{
+		UserID:   payload.UserID,
+		Status:   sql.NullString{String: req.Status, Valid: req.Status != ""},
+		FromTime: sql.NullTime{Time: req.From, Valid: !req.From.IsZero()},
+		ToTime:   sql.NullTime{Time: req.To, Valid: !req.To.IsZero()},
+		Limit:    req.PageSize,
+		Offset:   (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, games)
+}
+
+// ListMyNotificationsRequest represents a request to list the authenticated user's notifications.
+type ListMyNotificationsRequest struct {
+	Unread   bool  `form:"unread"`
+	PageSize int32 `form:"page_size,default=10" binding:"max=100,min=1"`
+	PageID   int32 `form:"page_id,default=1" binding:"min=1"`
+}
+
+// ListMyNotifications lists the authenticated user's notifications, newest first. Guardians also get
+// the notifications of the players they look after, with player_id naming the player.
+func (s *Server) ListMyNotifications(context *gin.Context) {
+	var req ListMyNotificationsRequest
+	if err := context.ShouldBindQuery(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	notifications, err := s.store.ListNotifications(context, db.ListNotificationsParams{
+		UserID:     payload.UserID,
+		UnreadOnly: req.Unread,
+		PageLimit:  req.PageSize,
+		PageOffset: (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, notifications)
+}
+
+// ReadMyNotificationRequest represents a request to mark one of the authenticated user's notifications read.
+type ReadMyNotificationRequest struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+// ReadMyNotification marks one of the authenticated user's notifications read.
+func (s *Server) ReadMyNotification(context *gin.Context) {
+	var req ReadMyNotificationRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	notification, err := s.store.MarkNotificationRead(context, db.MarkNotificationReadParams{
+		ID:     uuid.MustParse(req.ID),
+		UserID: payload.UserID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, notification)
+}
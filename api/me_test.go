@@ -0,0 +1,323 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	mockdb "github.com/kwalter26/scoreit-api-go/db/mock"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/security"
+	"github.com/kwalter26/scoreit-api-go/security/token"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestServer_GetMe(t *testing.T) {
+	user, _ := createRandomUser(t)
+
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp GetUserResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, user.ID.String(), rsp.ID)
+				require.Equal(t, user.Email, rsp.Email)
+				require.NotNil(t, rsp.Privacy)
+			},
+		},
+		{
+			name: "NoAuthorization",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/api/v1/me", nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_ListMyTeams(t *testing.T) {
+	user, _ := createRandomUser(t)
+	team := randomTeam()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListTeamsOfUser(gomock.Any(), gomock.Eq(db.ListTeamsOfUserParams{UserID: user.ID, Limit: 5, Offset: 0})).
+		Times(1).
+		Return([]db.ListTeamsOfUserRow{{
+			ID:              team.ID,
+			Name:            team.Name,
+			Role:            string(util.TeamRoleCoach),
+			Number:          1,
+			PrimaryPosition: string(util.Pitcher),
+		}}, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, "/api/v1/me/teams", nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var rsp []MyTeamResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+	require.Len(t, rsp, 1)
+	require.Equal(t, team.ID, rsp[0].ID)
+	require.Equal(t, string(util.TeamRoleCoach), rsp[0].Role)
+}
+
+func TestServer_ListMyGames(t *testing.T) {
+	user, _ := createRandomUser(t)
+	from := time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		query         map[string]string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListGamesOfUser(gomock.Any(), gomock.Eq(db.ListGamesOfUserParams{UserID: user.ID, Limit: 10})).
+					Times(1).
+					Return([]db.Game{{ID: uuid.New(), Status: string(util.GameScheduled)}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Filtered",
+			query: map[string]string{
+				"status": string(util.GameFinal),
+				"from":   from.Format(time.RFC3339),
+				"to":     to.Format(time.RFC3339),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListGamesOfUserParams{
+					UserID:   user.ID,
+					Status:   sql.NullString{String: string(util.GameFinal), Valid: true},
+					FromTime: sql.NullTime{Time: from, Valid: true},
+					ToTime:   sql.NullTime{Time: to, Valid: true},
+					Limit:    10,
+				}
+				store.EXPECT().
+					ListGamesOfUser(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.Game{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidStatus",
+			query: map[string]string{"status": "rained_out"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListGamesOfUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidRange",
+			query: map[string]string{
+				"from": to.Format(time.RFC3339),
+				"to":   from.Format(time.RFC3339),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListGamesOfUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/api/v1/me/games", nil)
+			require.NoError(t, err)
+			q := request.URL.Query()
+			for k, v := range tc.query {
+				q.Add(k, v)
+			}
+			request.URL.RawQuery = q.Encode()
+
+			addAuthorization(t, request, server.tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_ListMyNotifications(t *testing.T) {
+	guardian, _ := createRandomUser(t)
+	player, _ := createRandomUser(t)
+	notification := db.Notification{
+		ID:       uuid.New(),
+		UserID:   guardian.ID,
+		PlayerID: player.ID,
+		GameID:   uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		Kind:     "game_status",
+		Message:  "the game is now postponed: rain",
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListNotifications(gomock.Any(), gomock.Eq(db.ListNotificationsParams{UserID: guardian.ID, UnreadOnly: true, PageLimit: 10, PageOffset: 0})).
+		Times(1).
+		Return([]db.Notification{notification}, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, "/api/v1/me/notifications?unread=true", nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, guardian.ID, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var rsp []db.Notification
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+	require.Len(t, rsp, 1)
+	// a guardian's notification names the player it is about
+	require.Equal(t, player.ID, rsp[0].PlayerID)
+}
+
+func TestServer_ReadMyNotification(t *testing.T) {
+	user, _ := createRandomUser(t)
+	notificationID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					MarkNotificationRead(gomock.Any(), gomock.Eq(db.MarkNotificationReadParams{ID: notificationID, UserID: user.ID})).
+					Times(1).
+					Return(db.Notification{ID: notificationID, UserID: user.ID, ReadAt: sql.NullTime{Time: time.Now(), Valid: true}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "SomeoneElses",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					MarkNotificationRead(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Notification{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodPost, "/api/v1/me/notifications/"+notificationID.String()+"/read", nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
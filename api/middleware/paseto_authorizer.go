@@ -9,49 +9,52 @@ import (
 
 // PasetoAuthorizer stores the casbin handler
 type PasetoAuthorizer struct {
-	enforcer *casbin.Enforcer
+	enforcer *casbin.SyncedEnforcer
 }
 
 // NewAuthorizeMiddleware returns the authorizer, uses a Casbin enforcer as input
-func NewAuthorizeMiddleware(e *casbin.Enforcer) gin.HandlerFunc {
+func NewAuthorizeMiddleware(e *casbin.SyncedEnforcer) gin.HandlerFunc {
 	a := &PasetoAuthorizer{enforcer: e}
 
 	return func(c *gin.Context) {
-		if !a.CheckPermission(a.GetUser(c), c.Request) {
+		if !a.CheckPermission(a.GetRoles(c), c.Request) {
 			a.RequirePermission(c)
 		}
 	}
 }
 
-func (a *PasetoAuthorizer) GetUser(c *gin.Context) string {
+// GetRoles returns the roles in the request's authorization payload.
+// The roles are enforced directly rather than added to the enforcer as groupings for the user,
+// so reloading the policy never drops them in the middle of a request.
+func (a *PasetoAuthorizer) GetRoles(c *gin.Context) []string {
 	ap, exists := c.Get(AuthorizationPayloadKey)
-	var user string
 	if !exists {
-		return ""
-	} else {
-		p := ap.(*token.Payload)
-		user = p.UserID.String()
-		var groups [][]string
-		for _, g := range p.Permissions {
-			groups = append(groups, []string{user, string(g)})
-		}
-		_, _ = a.enforcer.AddGroupingPolicies(groups)
-		return user
+		return nil
+	}
+	p := ap.(*token.Payload)
+	roles := make([]string, len(p.Permissions))
+	for i, role := range p.Permissions {
+		roles[i] = string(role)
 	}
+	return roles
 }
 
-// CheckPermission checks the user/method/path combination from the request.
+// CheckPermission checks the role/method/path combination from the request for each of the caller's roles.
 // Returns true (permission granted) or false (permission forbidden)
-func (a *PasetoAuthorizer) CheckPermission(user string, r *http.Request) bool {
+func (a *PasetoAuthorizer) CheckPermission(roles []string, r *http.Request) bool {
 	method := r.Method
 	path := r.URL.Path
 
-	allowed, err := a.enforcer.Enforce(user, path, method)
-	if err != nil {
-		panic(err)
+	for _, role := range roles {
+		allowed, err := a.enforcer.Enforce(role, path, method)
+		if err != nil {
+			panic(err)
+		}
+		if allowed {
+			return true
+		}
 	}
-
-	return allowed
+	return false
 }
 
 // RequirePermission returns the 403 Forbidden to the client
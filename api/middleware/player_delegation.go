@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"errors"
+	"github.com/casbin/casbin/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/kwalter26/scoreit-api-go/api/helpers"
+	"github.com/kwalter26/scoreit-api-go/security"
+	"net/http"
+)
+
+// PlayerIDParam is the route parameter holding the player a request acts on.
+const PlayerIDParam = "id"
+
+// NewPlayerDelegationMiddleware only lets a request through when the caller may act on behalf of
+// the player in the :id route parameter: the player themselves, an admin or an approved guardian.
+func NewPlayerDelegationMiddleware(e *casbin.SyncedEnforcer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		payload := GetAuthorizationPayload(c)
+		if payload == nil {
+			err := errors.New("authorization payload is missing")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, helpers.ErrorResponse(err))
+			return
+		}
+
+		if !security.CanActForPlayer(e, payload.UserID.String(), payload.Permissions, c.Param(PlayerIDParam)) {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/security"
+	"github.com/kwalter26/scoreit-api-go/security/token"
+	"github.com/kwalter26/scoreit-api-go/test"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPlayerDelegationMiddleware(t *testing.T) {
+	enforcer, err := security.NewEnforcer(util.Config{}, test.SecurityResources())
+	require.NoError(t, err)
+
+	player := uuid.New()
+	guardian := uuid.New()
+	stranger := uuid.New()
+	require.NoError(t, security.UseGuardianLinks(enforcer, func(ctx context.Context) ([][2]string, error) {
+		return [][2]string{{guardian.String(), player.String()}}, nil
+	}))
+
+	tests := []struct {
+		name     string
+		payload  *token.Payload
+		expected int
+	}{
+		{
+			name:     "Self",
+			payload:  &token.Payload{UserID: player, Permissions: security.UserRoles},
+			expected: http.StatusOK,
+		},
+		{
+			name:     "Guardian",
+			payload:  &token.Payload{UserID: guardian, Permissions: security.UserRoles},
+			expected: http.StatusOK,
+		},
+		{
+			name:     "Admin",
+			payload:  &token.Payload{UserID: stranger, Permissions: security.AdminRoles},
+			expected: http.StatusOK,
+		},
+		{
+			name:     "Stranger",
+			payload:  &token.Payload{UserID: stranger, Permissions: security.UserRoles},
+			expected: http.StatusForbidden,
+		},
+		{
+			name:     "NoPayload",
+			payload:  nil,
+			expected: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				if tc.payload != nil {
+					c.Set(AuthorizationPayloadKey, tc.payload)
+				}
+			})
+			router.GET("/players/:id", NewPlayerDelegationMiddleware(enforcer), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req, _ := http.NewRequest(http.MethodGet, "/players/"+player.String(), nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expected, w.Code)
+		})
+	}
+}
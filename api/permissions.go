@@ -0,0 +1,110 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/helpers"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/security"
+	"github.com/kwalter26/scoreit-api-go/security/token"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"net/http"
+)
+
+// isAdmin reports whether the caller holds the admin role.
+func isAdmin(payload *token.Payload) bool {
+	return security.HasRole(payload.Permissions, security.AdminRole)
+}
+
+// isCoachOrAdmin reports whether the caller holds the coach or admin role.
+func isCoachOrAdmin(payload *token.Payload) bool {
+	return isAdmin(payload) || security.HasRole(payload.Permissions, security.CoachRole)
+}
+
+// isTeamCoach reports whether the caller is an admin, or holds the coach role and is a coach or manager on the team.
+func (s *Server) isTeamCoach(context *gin.Context, payload *token.Payload, teamID uuid.UUID) (bool, error) {
+	if isAdmin(payload) {
+		return true, nil
+	}
+	if !security.HasRole(payload.Permissions, security.CoachRole) {
+		return false, nil
+	}
+
+	member, err := s.store.GetTeamMember(context, db.GetTeamMemberParams{TeamID: teamID, UserID: payload.UserID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	role := util.TeamRole(member.Role)
+	return role == util.TeamRoleCoach || role == util.TeamRoleManager, nil
+}
+
+// authorizeTeamCoach checks that the caller may manage the team, responding with 403 when they may not.
+// It reports whether the request may go on.
+func (s *Server) authorizeTeamCoach(context *gin.Context, teamID uuid.UUID) bool {
+	coach, err := s.isTeamCoach(context, middleware.GetAuthorizationPayload(context), teamID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return false
+	}
+	if !coach {
+		context.AbortWithStatus(http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// authorizeGameCoach checks that the caller may manage the game, as an admin or a coach of either team
+// playing in it, responding with 403 when they may not. It reports whether the request may go on.
+func (s *Server) authorizeGameCoach(context *gin.Context, game db.Game) bool {
+	payload := middleware.GetAuthorizationPayload(context)
+	for _, teamID := range []uuid.UUID{game.HomeTeamID, game.AwayTeamID} {
+		coach, err := s.isTeamCoach(context, payload, teamID)
+		if err != nil {
+			context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+			return false
+		}
+		if coach {
+			return true
+		}
+	}
+	context.AbortWithStatus(http.StatusForbidden)
+	return false
+}
+
+// authorizePlayerCoach checks that the caller may manage the player, as an admin or a coach of a team
+// the player is on, responding with 403 when they may not. It reports whether the request may go on.
+func (s *Server) authorizePlayerCoach(context *gin.Context, playerID uuid.UUID) bool {
+	payload := middleware.GetAuthorizationPayload(context)
+	if isAdmin(payload) {
+		return true
+	}
+
+	teamIDs, err := s.store.ListTeamIDsOfUser(context, playerID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return false
+	}
+	for _, teamID := range teamIDs {
+		coach, err := s.isTeamCoach(context, payload, teamID)
+		if err != nil {
+			context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+			return false
+		}
+		if coach {
+			return true
+		}
+	}
+	context.AbortWithStatus(http.StatusForbidden)
+	return false
+}
+
+// canActForPlayer reports whether the caller is the player, an admin or one of the player's approved guardians.
+func (s *Server) canActForPlayer(payload *token.Payload, playerID uuid.UUID) bool {
+	return security.CanActForPlayer(s.enforcer, payload.UserID.String(), payload.Permissions, playerID.String())
+}
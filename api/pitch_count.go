@@ -0,0 +1,458 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/helpers"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/lib/pq"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// dateLayout is how calendar dates are written in requests and responses
+const dateLayout = "2006-01-02"
+
+var errPitcherNotInGame = errors.New("player is not on either team in the game")
+
+// PitchCountRequest addresses one pitcher's pitch count in a game.
+type PitchCountRequest struct {
+	GameID   string `uri:"id" binding:"required,uuid"`
+	PlayerID string `uri:"player_id" binding:"required,uuid"`
+}
+
+// SetPitchCountRequestBody represents a pitch count entered by hand, for games not scored pitch by pitch.
+type SetPitchCountRequestBody struct {
+	Pitches int64 `json:"pitches" binding:"min=0,max=500"`
+}
+
+// PitchCountResponse represents one pitcher's pitch count in a game. Recorded is counted from the pitches
+// scored in the game and Manual is the count entered by hand, which takes precedence when set.
+type PitchCountResponse struct {
+	PlayerID uuid.UUID `json:"player_id"`
+	Recorded int64     `json:"recorded"`
+	Manual   *int64    `json:"manual,omitempty"`
+	Pitches  int64     `json:"pitches"`
+	// RestDays is the rest the game's rest table requires, and EligibleOn the first date the pitcher may pitch again
+	RestDays   int64  `json:"rest_days"`
+	EligibleOn string `json:"eligible_on"`
+}
+
+// GamePitchCountsResponse represents the pitch counts of everyone who pitched in a game.
+type GamePitchCountsResponse struct {
+	GameID      uuid.UUID            `json:"game_id"`
+	Date        string               `json:"date"`
+	PitchCounts []PitchCountResponse `json:"pitch_counts"`
+}
+
+// PitchingEligibilityPlayerRequest represents the player whose pitching eligibility is requested.
+type PitchingEligibilityPlayerRequest struct {
+	PlayerID string `uri:"id" binding:"required,uuid"`
+}
+
+// PitchingEligibilityRequest represents the date to check a pitcher's rest for, today in the game's time zone by default.
+// GameID leaves a game out of the check, so a pitcher's own outing does not count against them in it.
+type PitchingEligibilityRequest struct {
+	Date   string `form:"date" binding:"omitempty,datetime=2006-01-02"`
+	GameID string `form:"game_id" binding:"omitempty,uuid"`
+}
+
+// PitchingAppearance represents a game a player pitched in and the rest it requires.
+type PitchingAppearance struct {
+	GameID     uuid.UUID `json:"game_id"`
+	Date       string    `json:"date"`
+	Pitches    int64     `json:"pitches"`
+	Manual     bool      `json:"manual"`
+	RestDays   int64     `json:"rest_days"`
+	EligibleOn string    `json:"eligible_on"`
+}
+
+// PitchingEligibilityResponse represents whether a player may pitch on a date. EligibleOn is the
+// first date they may pitch, and Appearances their recent outings, most recent first.
+type PitchingEligibilityResponse struct {
+	PlayerID    uuid.UUID            `json:"player_id"`
+	Date        string               `json:"date"`
+	Eligible    bool                 `json:"eligible"`
+	EligibleOn  string               `json:"eligible_on"`
+	Appearances []PitchingAppearance `json:"appearances"`
+}
+
+// ListGamePitchCounts lists the pitch count of everyone who pitched in a game, with the rest each needs.
+// Pitchers who hide their stats from the caller are left out.
+func (s *Server) ListGamePitchCounts(context *gin.Context) {
+	var req GetGameRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	game, err := s.store.GetGame(context, uuid.MustParse(req.ID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+	rules, err := gameRules(game)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	recorded, err := s.store.ListGameRecordedPitches(context, game.ID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+	manual, err := s.store.ListGamePitchCounts(context, game.ID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	// pitchers are listed in the order they pitched, then those only counted by hand
+	date := gameDate(game.ScheduledAt, game.CreatedAt, game.TimeZone)
+	counts := make([]PitchCountResponse, 0, len(recorded)+len(manual))
+	index := make(map[uuid.UUID]int)
+	for _, row := range recorded {
+		index[row.PlayerID] = len(counts)
+		counts = append(counts, PitchCountResponse{PlayerID: row.PlayerID, Recorded: row.Pitches, Pitches: row.Pitches})
+	}
+	for _, row := range manual {
+		i, ok := index[row.PlayerID]
+		if !ok {
+			i = len(counts)
+			counts = append(counts, PitchCountResponse{PlayerID: row.PlayerID})
+		}
+		pitches := row.Pitches
+		counts[i].Manual = &pitches
+		counts[i].Pitches = pitches
+	}
+	playerIDs := make([]uuid.UUID, len(counts))
+	for i := range counts {
+		playerIDs[i] = counts[i].PlayerID
+	}
+	hidden, err := s.hiddenStats(context, middleware.GetAuthorizationPayload(context), playerIDs)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	visible := make([]PitchCountResponse, 0, len(counts))
+	for _, count := range counts {
+		if hidden[count.PlayerID] {
+			continue
+		}
+		count.RestDays = rules.RestDays(count.Pitches)
+		count.EligibleOn = util.EligibleToPitch(date, count.RestDays).Format(dateLayout)
+		visible = append(visible, count)
+	}
+
+	context.JSON(http.StatusOK, GamePitchCountsResponse{GameID: game.ID, Date: date.Format(dateLayout), PitchCounts: visible})
+}
+
+// SetPitchCount enters a pitcher's pitch count for a game by hand. It takes the place of the count
+// from scored pitches in rest checks. Only the coaches of the pitcher's team and admins may enter pitch counts.
+func (s *Server) SetPitchCount(context *gin.Context) {
+	var req PitchCountRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var body SetPitchCountRequestBody
+	if err := context.ShouldBindJSON(&body); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	if !isCoachOrAdmin(payload) {
+		context.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+	playerID := uuid.MustParse(req.PlayerID)
+	game, ok := s.pitchCountGame(context, uuid.MustParse(req.GameID), playerID)
+	if !ok {
+		return
+	}
+
+	count, err := s.store.SetPitchCount(context, db.SetPitchCountParams{
+		GameID:    game.ID,
+		PlayerID:  playerID,
+		Pitches:   body.Pitches,
+		EnteredBy: payload.UserID,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(pqErr))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, count)
+}
+
+// ClearPitchCount removes a pitch count entered by hand, so the count from scored pitches is used again.
+// Only the coaches of the pitcher's team and admins may clear pitch counts.
+func (s *Server) ClearPitchCount(context *gin.Context) {
+	var req PitchCountRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	if !isCoachOrAdmin(middleware.GetAuthorizationPayload(context)) {
+		context.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+	playerID := uuid.MustParse(req.PlayerID)
+	game, ok := s.pitchCountGame(context, uuid.MustParse(req.GameID), playerID)
+	if !ok {
+		return
+	}
+
+	err := s.store.DeletePitchCount(context, db.DeletePitchCountParams{
+		GameID:   game.ID,
+		PlayerID: playerID,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.Status(http.StatusNoContent)
+}
+
+// pitchCountGame gets a game whose pitch counts for a pitcher the caller may change, as a coach of
+// the pitcher's team. It writes the response itself when they may not.
+func (s *Server) pitchCountGame(context *gin.Context, gameID uuid.UUID, playerID uuid.UUID) (db.Game, bool) {
+	game, err := s.store.GetGame(context, gameID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return game, false
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return game, false
+	}
+
+	teamID, err := s.pitcherTeam(context, game, playerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(errPitcherNotInGame))
+			return game, false
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return game, false
+	}
+	return game, s.authorizeTeamCoach(context, teamID)
+}
+
+// pitcherTeam finds the team a player pitched for in a game: the side they appeared on, or for games
+// not scored pitch by pitch the team whose roster they are on. It returns sql.ErrNoRows when they are on neither.
+func (s *Server) pitcherTeam(context *gin.Context, game db.Game, playerID uuid.UUID) (uuid.UUID, error) {
+	participants, err := s.store.ListGameParticipants(context, game.ID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	for _, participant := range participants {
+		if participant.PlayerID != playerID {
+			continue
+		}
+		if participant.HomeTeam {
+			return game.HomeTeamID, nil
+		}
+		return game.AwayTeamID, nil
+	}
+
+	for _, teamID := range []uuid.UUID{game.HomeTeamID, game.AwayTeamID} {
+		_, err := s.store.GetTeamMember(context, db.GetTeamMemberParams{TeamID: teamID, UserID: playerID})
+		if err == nil {
+			return teamID, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, err
+		}
+	}
+	return uuid.Nil, sql.ErrNoRows
+}
+
+// GetPitchingEligibility reports whether a player has rested enough to pitch on a date, going by
+// the rest table of each game they recently pitched in. Players who hide their stats from the
+// caller are forbidden.
+func (s *Server) GetPitchingEligibility(context *gin.Context) {
+	var req PitchingEligibilityPlayerRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var query PitchingEligibilityRequest
+	if err := context.ShouldBindQuery(&query); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	// today is taken in the game's time zone, or in UTC when no game is given
+	date := today("UTC")
+	var excluded uuid.UUID
+	if query.GameID != "" {
+		game, err := s.store.GetGame(context, uuid.MustParse(query.GameID))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+				return
+			}
+			context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+			return
+		}
+		excluded = game.ID
+		date = today(game.TimeZone)
+	}
+	if query.Date != "" {
+		date, _ = time.Parse(dateLayout, query.Date)
+	}
+
+	playerID := uuid.MustParse(req.PlayerID)
+	hidden, err := s.hiddenStats(context, middleware.GetAuthorizationPayload(context), []uuid.UUID{playerID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+	if hidden[playerID] {
+		context.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	eligibility, err := s.pitchingEligibility(context, playerID, date, excluded)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, eligibility)
+}
+
+// pitchingEligibility checks a player's rest on a date against the games they pitched in on or before it,
+// leaving out the excluded game. Counts entered by hand take the place of those from scored pitches.
+func (s *Server) pitchingEligibility(context *gin.Context, playerID uuid.UUID, date time.Time, excluded uuid.UUID) (PitchingEligibilityResponse, error) {
+	rsp := PitchingEligibilityResponse{
+		PlayerID:    playerID,
+		Date:        date.Format(dateLayout),
+		Eligible:    true,
+		EligibleOn:  date.Format(dateLayout),
+		Appearances: []PitchingAppearance{},
+	}
+
+	// no rest table asks for more than MaxRestDays, so older games cannot matter. A day of slack
+	// covers games whose own time zone puts them on an earlier date than UTC does.
+	since := date.AddDate(0, 0, -int(util.MaxRestDays)-1)
+	recorded, err := s.store.ListPitchingAppearances(context, db.ListPitchingAppearancesParams{PlayerID: playerID, Since: since})
+	if err != nil {
+		return rsp, err
+	}
+	manual, err := s.store.ListPlayerPitchCounts(context, db.ListPlayerPitchCountsParams{PlayerID: playerID, Since: since})
+	if err != nil {
+		return rsp, err
+	}
+
+	appearances := make(map[uuid.UUID]db.ListPitchingAppearancesRow, len(recorded)+len(manual))
+	entered := make(map[uuid.UUID]bool, len(manual))
+	for _, row := range recorded {
+		appearances[row.ID] = row
+	}
+	for _, row := range manual {
+		appearances[row.ID] = db.ListPitchingAppearancesRow(row)
+		entered[row.ID] = true
+	}
+
+	eligibleOn := date
+	for id, row := range appearances {
+		pitched := gameDate(row.ScheduledAt, row.CreatedAt, row.TimeZone)
+		if id == excluded || pitched.After(date) {
+			continue
+		}
+		rules, err := decodeRules(row.Rules)
+		if err != nil {
+			return rsp, fmt.Errorf("game %s: %w", id, err)
+		}
+
+		appearance := PitchingAppearance{
+			GameID:   id,
+			Date:     pitched.Format(dateLayout),
+			Pitches:  row.Pitches,
+			Manual:   entered[id],
+			RestDays: rules.RestDays(row.Pitches),
+		}
+		next := util.EligibleToPitch(pitched, appearance.RestDays)
+		appearance.EligibleOn = next.Format(dateLayout)
+		if next.After(eligibleOn) {
+			eligibleOn = next
+		}
+		rsp.Appearances = append(rsp.Appearances, appearance)
+	}
+	sort.Slice(rsp.Appearances, func(i, j int) bool { return rsp.Appearances[i].Date > rsp.Appearances[j].Date })
+
+	rsp.Eligible = !eligibleOn.After(date)
+	rsp.EligibleOn = eligibleOn.Format(dateLayout)
+	return rsp, nil
+}
+
+// pitcherRestProblem checks that a player brought in to pitch in a game has rested enough under the
+// rest tables of the games they pitched in before. It returns why they may not pitch, or "" if they may.
+func (s *Server) pitcherRestProblem(context *gin.Context, game db.Game, playerID uuid.UUID) (string, error) {
+	// games without a start time are taken to be played today where they are played
+	date := today(game.TimeZone)
+	if game.ScheduledAt.Valid {
+		date = gameDate(game.ScheduledAt, game.CreatedAt, game.TimeZone)
+	}
+
+	eligibility, err := s.pitchingEligibility(context, playerID, date, game.ID)
+	if err != nil || eligibility.Eligible {
+		return "", err
+	}
+	for _, appearance := range eligibility.Appearances {
+		if appearance.EligibleOn == eligibility.EligibleOn {
+			return fmt.Sprintf("threw %d pitches on %s and may not pitch until %s", appearance.Pitches, appearance.Date, appearance.EligibleOn), nil
+		}
+	}
+	return fmt.Sprintf("may not pitch until %s", eligibility.EligibleOn), nil
+}
+
+// gameDate returns the calendar date a game is played on in its own time zone. Games without
+// a start time count as played on the day they were created.
+func gameDate(scheduledAt sql.NullTime, createdAt time.Time, timeZone string) time.Time {
+	at := createdAt
+	if scheduledAt.Valid {
+		at = scheduledAt.Time
+	}
+	if location, err := time.LoadLocation(timeZone); err == nil {
+		at = at.In(location)
+	}
+	return calendarDate(at)
+}
+
+// today returns the current date in a time zone, or in UTC when the zone is not known
+func today(timeZone string) time.Time {
+	return gameDate(sql.NullTime{Time: time.Now().UTC(), Valid: true}, time.Time{}, timeZone)
+}
+
+// calendarDate returns the date of t as midnight UTC, so dates compare and add whole days
+func calendarDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,698 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	mockdb "github.com/kwalter26/scoreit-api-go/db/mock"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/security"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestServer_ListGamePitchCounts(t *testing.T) {
+	user, _ := createRandomUser(t)
+	rules, _ := util.PresetRules(util.RulesLittleLeague)
+	game := db.Game{
+		ID:          uuid.New(),
+		ScheduledAt: sql.NullTime{Time: time.Date(2024, time.May, 4, 23, 0, 0, 0, time.UTC), Valid: true},
+		TimeZone:    "America/Chicago",
+		Rules:       encodeRules(rules),
+	}
+	starter, reliever, closer := uuid.New(), uuid.New(), uuid.New()
+
+	testCases := []struct {
+		name          string
+		gameID        string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			gameID: game.ID.String(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					ListGameRecordedPitches(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return([]db.ListGameRecordedPitchesRow{{PlayerID: starter, Pitches: 70}, {PlayerID: reliever, Pitches: 15}}, nil)
+				store.EXPECT().
+					ListGamePitchCounts(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return([]db.PitchCount{{PlayerID: reliever, Pitches: 40}, {PlayerID: closer, Pitches: 10}}, nil)
+				expectStatVisibility(store)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp GamePitchCountsResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				// the game starts in the evening of May 4th in Chicago
+				require.Equal(t, "2024-05-04", rsp.Date)
+				require.Len(t, rsp.PitchCounts, 3)
+
+				require.Equal(t, starter, rsp.PitchCounts[0].PlayerID)
+				require.Nil(t, rsp.PitchCounts[0].Manual)
+				require.Equal(t, int64(70), rsp.PitchCounts[0].Pitches)
+				require.Equal(t, int64(4), rsp.PitchCounts[0].RestDays)
+				require.Equal(t, "2024-05-09", rsp.PitchCounts[0].EligibleOn)
+
+				// a count entered by hand takes the place of the scored pitches
+				require.Equal(t, reliever, rsp.PitchCounts[1].PlayerID)
+				require.Equal(t, int64(15), rsp.PitchCounts[1].Recorded)
+				require.Equal(t, int64(40), *rsp.PitchCounts[1].Manual)
+				require.Equal(t, int64(40), rsp.PitchCounts[1].Pitches)
+				require.Equal(t, int64(2), rsp.PitchCounts[1].RestDays)
+
+				require.Equal(t, closer, rsp.PitchCounts[2].PlayerID)
+				require.Equal(t, int64(0), rsp.PitchCounts[2].RestDays)
+				require.Equal(t, "2024-05-04", rsp.PitchCounts[2].EligibleOn)
+			},
+		},
+		{
+			name:   "PitcherHidesStats",
+			gameID: game.ID.String(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					ListGameRecordedPitches(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return([]db.ListGameRecordedPitchesRow{{PlayerID: starter, Pitches: 70}, {PlayerID: reliever, Pitches: 15}}, nil)
+				store.EXPECT().
+					ListGamePitchCounts(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return([]db.PitchCount{}, nil)
+				expectStatVisibility(store, starter)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp GamePitchCountsResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Len(t, rsp.PitchCounts, 1)
+				require.Equal(t, reliever, rsp.PitchCounts[0].PlayerID)
+			},
+		},
+		{
+			name:   "NotFound",
+			gameID: game.ID.String(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(db.Game{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "InvalidID",
+			gameID: "invalid",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/games/%s/pitch-counts", tc.gameID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_SetPitchCount(t *testing.T) {
+	user, _ := createRandomUser(t)
+	gameID, playerID := uuid.New(), uuid.New()
+	game := db.Game{ID: gameID, HomeTeamID: uuid.New(), AwayTeamID: uuid.New()}
+	// the pitcher played for the home team
+	participants := []db.ListGameParticipantsRow{{ID: uuid.New(), GameID: gameID, PlayerID: playerID, HomeTeam: true, Position: string(util.Pitcher)}}
+
+	testCases := []struct {
+		name          string
+		roles         []security.Role
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			roles: coachRoles,
+			body:  gin.H{"pitches": 64},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.SetPitchCountParams{GameID: gameID, PlayerID: playerID, Pitches: 64, EnteredBy: user.ID}
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(gameID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					ListGameParticipants(gomock.Any(), gomock.Eq(gameID)).
+					Times(1).
+					Return(participants, nil)
+				store.EXPECT().
+					SetPitchCount(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.PitchCount{ID: uuid.New(), GameID: gameID, PlayerID: playerID, Pitches: 64, EnteredBy: user.ID}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var count db.PitchCount
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &count))
+				require.Equal(t, int64(64), count.Pitches)
+			},
+		},
+		{
+			name:  "RosterPitcher",
+			roles: coachRoles,
+			body:  gin.H{"pitches": 64},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(gameID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					ListGameParticipants(gomock.Any(), gomock.Eq(gameID)).
+					Times(1).
+					Return([]db.ListGameParticipantsRow{}, nil)
+				store.EXPECT().
+					GetTeamMember(gomock.Any(), gomock.Eq(db.GetTeamMemberParams{TeamID: game.HomeTeamID, UserID: playerID})).
+					Times(1).
+					Return(db.TeamMember{}, sql.ErrNoRows)
+				store.EXPECT().
+					GetTeamMember(gomock.Any(), gomock.Eq(db.GetTeamMemberParams{TeamID: game.AwayTeamID, UserID: playerID})).
+					Times(1).
+					Return(db.TeamMember{TeamID: game.AwayTeamID, UserID: playerID}, nil)
+				store.EXPECT().
+					GetTeamMember(gomock.Any(), gomock.Eq(db.GetTeamMemberParams{TeamID: game.AwayTeamID, UserID: user.ID})).
+					Times(1).
+					Return(db.TeamMember{Role: string(util.TeamRoleCoach)}, nil)
+				store.EXPECT().
+					SetPitchCount(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.PitchCount{GameID: gameID, PlayerID: playerID, Pitches: 64}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "OpposingCoach",
+			roles: coachRoles,
+			body:  gin.H{"pitches": 64},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(gameID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					ListGameParticipants(gomock.Any(), gomock.Eq(gameID)).
+					Times(1).
+					Return(participants, nil)
+				store.EXPECT().
+					GetTeamMember(gomock.Any(), gomock.Eq(db.GetTeamMemberParams{TeamID: game.HomeTeamID, UserID: user.ID})).
+					Times(1).
+					Return(db.TeamMember{}, sql.ErrNoRows)
+				store.EXPECT().
+					SetPitchCount(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "UnknownPlayer",
+			roles: coachRoles,
+			body:  gin.H{"pitches": 64},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(gameID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					ListGameParticipants(gomock.Any(), gomock.Eq(gameID)).
+					Times(1).
+					Return([]db.ListGameParticipantsRow{}, nil)
+				store.EXPECT().
+					GetTeamMember(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(db.TeamMember{}, sql.ErrNoRows)
+				store.EXPECT().
+					SetPitchCount(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "GameNotFound",
+			roles: coachRoles,
+			body:  gin.H{"pitches": 64},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(gameID)).
+					Times(1).
+					Return(db.Game{}, sql.ErrNoRows)
+				store.EXPECT().
+					SetPitchCount(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "TooManyPitches",
+			roles: coachRoles,
+			body:  gin.H{"pitches": 501},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetPitchCount(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "NotCoach",
+			roles: security.UserRoles,
+			body:  gin.H{"pitches": 64},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetPitchCount(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			roles: coachRoles,
+			body:  gin.H{"pitches": 64},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(gameID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					ListGameParticipants(gomock.Any(), gomock.Eq(gameID)).
+					Times(1).
+					Return(participants, nil)
+				store.EXPECT().
+					SetPitchCount(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.PitchCount{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+			expectTeamCoach(store, user.ID)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			buf, err := buildJsonRequest(t, tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/games/%s/pitch-counts/%s", gameID, playerID)
+			request, err := http.NewRequest(http.MethodPut, url, &buf)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, tc.roles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_ClearPitchCount(t *testing.T) {
+	user, _ := createRandomUser(t)
+	gameID, playerID := uuid.New(), uuid.New()
+	game := db.Game{ID: gameID, HomeTeamID: uuid.New(), AwayTeamID: uuid.New()}
+	// the pitcher played for the home team
+	participants := []db.ListGameParticipantsRow{{ID: uuid.New(), GameID: gameID, PlayerID: playerID, HomeTeam: true, Position: string(util.Pitcher)}}
+
+	testCases := []struct {
+		name          string
+		roles         []security.Role
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			roles: coachRoles,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(gameID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					ListGameParticipants(gomock.Any(), gomock.Eq(gameID)).
+					Times(1).
+					Return(participants, nil)
+				store.EXPECT().
+					DeletePitchCount(gomock.Any(), gomock.Eq(db.DeletePitchCountParams{GameID: gameID, PlayerID: playerID})).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNoContent, recorder.Code)
+			},
+		},
+		{
+			name:  "NotCoach",
+			roles: security.UserRoles,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeletePitchCount(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+			expectTeamCoach(store, user.ID)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/games/%s/pitch-counts/%s", gameID, playerID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, tc.roles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_GetPitchingEligibility(t *testing.T) {
+	user, _ := createRandomUser(t)
+	playerID := uuid.New()
+	nfhs, _ := util.PresetRules(util.RulesNFHS)
+	legacy := util.RuleSet{}
+
+	// a scored outing of 50 pitches on May 1st and a hand-counted one of 80 pitches on May 3rd
+	scored := db.ListPitchingAppearancesRow{
+		ID:          uuid.New(),
+		ScheduledAt: sql.NullTime{Time: time.Date(2024, time.May, 1, 17, 0, 0, 0, time.UTC), Valid: true},
+		TimeZone:    "UTC",
+		Rules:       encodeRules(nfhs),
+		Pitches:     50,
+	}
+	counted := db.ListPlayerPitchCountsRow{
+		ID:        uuid.New(),
+		CreatedAt: time.Date(2024, time.May, 3, 17, 0, 0, 0, time.UTC),
+		TimeZone:  "UTC",
+		Rules:     encodeRules(nfhs),
+		Pitches:   80,
+	}
+	// a game without a rest table needs no rest however many pitches were thrown
+	unlimited := db.ListPitchingAppearancesRow{
+		ID:          uuid.New(),
+		ScheduledAt: sql.NullTime{Time: time.Date(2024, time.May, 4, 17, 0, 0, 0, time.UTC), Valid: true},
+		TimeZone:    "UTC",
+		Rules:       encodeRules(legacy),
+		Pitches:     120,
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "NotRested",
+			query: "?date=2024-05-05",
+			buildStubs: func(store *mockdb.MockStore) {
+				expectStatVisibility(store)
+				store.EXPECT().
+					ListPitchingAppearances(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.ListPitchingAppearancesParams) ([]db.ListPitchingAppearancesRow, error) {
+						require.Equal(t, playerID, arg.PlayerID)
+						require.Equal(t, time.Date(2024, time.April, 4, 0, 0, 0, 0, time.UTC), arg.Since)
+						return []db.ListPitchingAppearancesRow{scored, unlimited}, nil
+					})
+				store.EXPECT().
+					ListPlayerPitchCounts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListPlayerPitchCountsRow{counted}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp PitchingEligibilityResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.False(t, rsp.Eligible)
+				require.Equal(t, "2024-05-08", rsp.EligibleOn)
+				require.Len(t, rsp.Appearances, 3)
+
+				require.Equal(t, unlimited.ID, rsp.Appearances[0].GameID)
+				require.Equal(t, int64(0), rsp.Appearances[0].RestDays)
+				require.Equal(t, counted.ID, rsp.Appearances[1].GameID)
+				require.True(t, rsp.Appearances[1].Manual)
+				require.Equal(t, int64(4), rsp.Appearances[1].RestDays)
+				require.Equal(t, scored.ID, rsp.Appearances[2].GameID)
+				require.Equal(t, "2024-05-04", rsp.Appearances[2].EligibleOn)
+			},
+		},
+		{
+			name:  "Rested",
+			query: "?date=2024-05-08",
+			buildStubs: func(store *mockdb.MockStore) {
+				expectStatVisibility(store)
+				store.EXPECT().
+					ListPitchingAppearances(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListPitchingAppearancesRow{scored}, nil)
+				store.EXPECT().
+					ListPlayerPitchCounts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListPlayerPitchCountsRow{counted}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp PitchingEligibilityResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.True(t, rsp.Eligible)
+				require.Equal(t, "2024-05-08", rsp.EligibleOn)
+			},
+		},
+		{
+			name:  "ExcludedGame",
+			query: fmt.Sprintf("?date=2024-05-05&game_id=%s", counted.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectStatVisibility(store)
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(counted.ID)).
+					Times(1).
+					Return(db.Game{ID: counted.ID, TimeZone: "UTC"}, nil)
+				store.EXPECT().
+					ListPitchingAppearances(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListPitchingAppearancesRow{scored}, nil)
+				store.EXPECT().
+					ListPlayerPitchCounts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListPlayerPitchCountsRow{counted}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp PitchingEligibilityResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.True(t, rsp.Eligible)
+				require.Len(t, rsp.Appearances, 1)
+			},
+		},
+		{
+			name:  "ExcludedGameNotFound",
+			query: fmt.Sprintf("?game_id=%s", counted.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				expectStatVisibility(store)
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(counted.ID)).
+					Times(1).
+					Return(db.Game{}, sql.ErrNoRows)
+				store.EXPECT().
+					ListPitchingAppearances(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "LaterGamesIgnored",
+			query: "?date=2024-05-02",
+			buildStubs: func(store *mockdb.MockStore) {
+				expectStatVisibility(store)
+				store.EXPECT().
+					ListPitchingAppearances(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListPitchingAppearancesRow{scored}, nil)
+				store.EXPECT().
+					ListPlayerPitchCounts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListPlayerPitchCountsRow{counted}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp PitchingEligibilityResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.False(t, rsp.Eligible)
+				require.Equal(t, "2024-05-04", rsp.EligibleOn)
+				require.Len(t, rsp.Appearances, 1)
+			},
+		},
+		{
+			name:  "PlayerHidesStats",
+			query: "?date=2024-05-05",
+			buildStubs: func(store *mockdb.MockStore) {
+				expectStatVisibility(store, playerID)
+				store.EXPECT().
+					ListPitchingAppearances(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "PlayerNotFound",
+			query: "?date=2024-05-05",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(playerID)).
+					Times(1).
+					Return(db.User{}, sql.ErrNoRows)
+				store.EXPECT().
+					ListPitchingAppearances(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidDate",
+			query: "?date=05/05/2024",
+			buildStubs: func(store *mockdb.MockStore) {
+				expectStatVisibility(store)
+				store.EXPECT().
+					ListPitchingAppearances(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: "",
+			buildStubs: func(store *mockdb.MockStore) {
+				expectStatVisibility(store)
+				store.EXPECT().
+					ListPitchingAppearances(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/players/%s/pitching-eligibility%s", playerID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
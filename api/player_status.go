@@ -0,0 +1,209 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/helpers"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/lib/pq"
+	"net/http"
+	"time"
+)
+
+var (
+	errStatusEndsBeforeStart = errors.New("ends_at must be after starts_at")
+	errGamesOnlyForSuspended = errors.New("games can only be set for suspensions")
+)
+
+// PlayerStatusRequest represents a request scoped to a single status of a team member.
+type PlayerStatusRequest struct {
+	TeamID   string `uri:"id" binding:"required,uuid"`
+	UserID   string `uri:"user_id" binding:"required,uuid"`
+	StatusID string `uri:"status_id" binding:"required,uuid"`
+}
+
+// PlayerStatusResponse represents a period during which a player cannot play for a team.
+type PlayerStatusResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	TeamID         uuid.UUID  `json:"team_id"`
+	UserID         uuid.UUID  `json:"user_id"`
+	Status         string     `json:"status"`
+	Reason         string     `json:"reason,omitempty"`
+	StartsAt       time.Time  `json:"starts_at"`
+	EndsAt         *time.Time `json:"ends_at,omitempty"`
+	GamesRemaining *int64     `json:"games_remaining,omitempty"`
+	CreatedBy      uuid.UUID  `json:"created_by"`
+}
+
+// NewPlayerStatusResponse creates a new PlayerStatusResponse from a db.PlayerStatus.
+func NewPlayerStatusResponse(status db.PlayerStatus) PlayerStatusResponse {
+	rsp := PlayerStatusResponse{
+		ID:        status.ID,
+		TeamID:    status.TeamID,
+		UserID:    status.UserID,
+		Status:    status.Status,
+		Reason:    status.Reason,
+		StartsAt:  status.StartsAt,
+		CreatedBy: status.CreatedBy,
+	}
+	if status.EndsAt.Valid {
+		rsp.EndsAt = &status.EndsAt.Time
+	}
+	if status.GamesRemaining.Valid {
+		rsp.GamesRemaining = &status.GamesRemaining.Int64
+	}
+	return rsp
+}
+
+// ListPlayerStatuses lists the status history of a team member, newest first.
+// The player, their guardians, coaches and admins may see it.
+func (s *Server) ListPlayerStatuses(context *gin.Context) {
+	var req AddTeamMemberRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	userID := uuid.MustParse(req.UserID)
+	if !s.canActForPlayer(payload, userID) && !isCoachOrAdmin(payload) {
+		context.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	statuses, err := s.store.ListPlayerStatuses(context, db.ListPlayerStatusesParams{
+		TeamID: uuid.MustParse(req.TeamID),
+		UserID: userID,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	rsp := make([]PlayerStatusResponse, 0, len(statuses))
+	for _, status := range statuses {
+		rsp = append(rsp, NewPlayerStatusResponse(status))
+	}
+
+	context.JSON(http.StatusOK, rsp)
+}
+
+// CreatePlayerStatusRequestBody represents the body of a request to mark a player injured, suspended or inactive.
+type CreatePlayerStatusRequestBody struct {
+	Status   string     `json:"status" binding:"required,oneof=injured suspended inactive"`
+	Reason   string     `json:"reason" binding:"max=500"`
+	StartsAt *time.Time `json:"starts_at"`
+	EndsAt   *time.Time `json:"ends_at"`
+	Games    int64      `json:"games" binding:"omitempty,min=1,max=162"`
+}
+
+// CreatePlayerStatus marks a team member as unable to play from starts_at (default now).
+// The status lifts at ends_at if given; a suspension may instead run for a number of the team's games.
+// Only the team's coaches and admins may set statuses; a status stays on record if the player is later released.
+func (s *Server) CreatePlayerStatus(context *gin.Context) {
+	var req AddTeamMemberRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var body CreatePlayerStatusRequestBody
+	if err := context.ShouldBindJSON(&body); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	startsAt := time.Now()
+	if body.StartsAt != nil {
+		startsAt = *body.StartsAt
+	}
+	if body.EndsAt != nil && !body.EndsAt.After(startsAt) {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(errStatusEndsBeforeStart))
+		return
+	}
+	if body.Games > 0 && util.RosterStatus(body.Status) != util.RosterStatusSuspended {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(errGamesOnlyForSuspended))
+		return
+	}
+
+	teamID := uuid.MustParse(req.TeamID)
+	userID := uuid.MustParse(req.UserID)
+	if !s.authorizeTeamCoach(context, teamID) {
+		return
+	}
+
+	// statuses outlive the membership, so the player is checked to be on the team when one is set
+	_, err := s.store.GetTeamMember(context, db.GetTeamMemberParams{TeamID: teamID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	arg := db.CreatePlayerStatusParams{
+		TeamID:         teamID,
+		UserID:         userID,
+		Status:         body.Status,
+		Reason:         body.Reason,
+		StartsAt:       startsAt,
+		GamesRemaining: sql.NullInt64{Int64: body.Games, Valid: body.Games > 0},
+		CreatedBy:      payload.UserID,
+	}
+	if body.EndsAt != nil {
+		arg.EndsAt = sql.NullTime{Time: *body.EndsAt, Valid: true}
+	}
+
+	status, err := s.store.CreatePlayerStatus(context, arg)
+	if err != nil {
+		if pgErr, ok := err.(*pq.Error); ok {
+			switch pgErr.Code.Name() {
+			case "foreign_key_violation":
+				context.JSON(http.StatusNotFound, helpers.ErrorResponse(pgErr))
+				return
+			}
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, NewPlayerStatusResponse(status))
+}
+
+// ClearPlayerStatus ends a status now, making the player eligible again.
+// Only the team's coaches and admins may clear statuses.
+func (s *Server) ClearPlayerStatus(context *gin.Context) {
+	var req PlayerStatusRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	teamID := uuid.MustParse(req.TeamID)
+	if !s.authorizeTeamCoach(context, teamID) {
+		return
+	}
+
+	status, err := s.store.ClearPlayerStatus(context, db.ClearPlayerStatusParams{
+		ID:     uuid.MustParse(req.StatusID),
+		TeamID: teamID,
+		UserID: uuid.MustParse(req.UserID),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, NewPlayerStatusResponse(status))
+}
@@ -0,0 +1,353 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	mockdb "github.com/kwalter26/scoreit-api-go/db/mock"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/security"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestServer_CreatePlayerStatus(t *testing.T) {
+	coach, _ := createRandomUser(t)
+	player, _ := createRandomUser(t)
+	team := randomTeam()
+	startsAt := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	suspension := db.PlayerStatus{
+		ID:             uuid.New(),
+		TeamID:         team.ID,
+		UserID:         player.ID,
+		Status:         string(util.RosterStatusSuspended),
+		Reason:         "ejected",
+		StartsAt:       startsAt,
+		GamesRemaining: sql.NullInt64{Int64: 2, Valid: true},
+		CreatedBy:      coach.ID,
+	}
+
+	membership := db.GetTeamMemberParams{TeamID: team.ID, UserID: player.ID}
+
+	testCases := []struct {
+		name          string
+		roles         []security.Role
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "Suspension",
+			roles: coachRoles,
+			body: gin.H{
+				"status":    util.RosterStatusSuspended,
+				"reason":    "ejected",
+				"starts_at": startsAt,
+				"games":     2,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreatePlayerStatusParams{
+					TeamID:         team.ID,
+					UserID:         player.ID,
+					Status:         string(util.RosterStatusSuspended),
+					Reason:         "ejected",
+					StartsAt:       startsAt,
+					GamesRemaining: sql.NullInt64{Int64: 2, Valid: true},
+					CreatedBy:      coach.ID,
+				}
+				store.EXPECT().
+					GetTeamMember(gomock.Any(), gomock.Eq(membership)).
+					Times(1).
+					Return(db.TeamMember{TeamID: team.ID, UserID: player.ID}, nil)
+				store.EXPECT().
+					CreatePlayerStatus(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(suspension, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp PlayerStatusResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, suspension.ID, rsp.ID)
+				require.NotNil(t, rsp.GamesRemaining)
+				require.Equal(t, int64(2), *rsp.GamesRemaining)
+				require.Nil(t, rsp.EndsAt)
+			},
+		},
+		{
+			name:  "GamesOnlyForSuspensions",
+			roles: coachRoles,
+			body:  gin.H{"status": util.RosterStatusInjured, "games": 3},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePlayerStatus(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "EndsBeforeStart",
+			roles: coachRoles,
+			body: gin.H{
+				"status":    util.RosterStatusInjured,
+				"starts_at": startsAt,
+				"ends_at":   startsAt.Add(-time.Hour),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePlayerStatus(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidStatus",
+			roles: coachRoles,
+			body:  gin.H{"status": util.RosterStatusActive},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePlayerStatus(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "NotCoach",
+			roles: security.UserRoles,
+			body:  gin.H{"status": util.RosterStatusInjured},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePlayerStatus(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "NotTeamCoach",
+			roles: coachRoles,
+			body:  gin.H{"status": util.RosterStatusSuspended},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTeamMember(gomock.Any(), gomock.Eq(db.GetTeamMemberParams{TeamID: team.ID, UserID: coach.ID})).
+					Times(1).
+					Return(db.TeamMember{}, sql.ErrNoRows)
+				store.EXPECT().
+					CreatePlayerStatus(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "NotOnTeam",
+			roles: coachRoles,
+			body:  gin.H{"status": util.RosterStatusInactive},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTeamMember(gomock.Any(), gomock.Eq(membership)).
+					Times(1).
+					Return(db.TeamMember{}, sql.ErrNoRows)
+				store.EXPECT().
+					CreatePlayerStatus(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+			expectTeamCoach(store, coach.ID)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			buf, err := buildJsonRequest(t, tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/teams/%s/members/%s/statuses", team.ID, player.ID)
+			request, err := http.NewRequest(http.MethodPost, url, &buf)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, tc.roles, middleware.AuthorizationTypeBearer, coach.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_ClearPlayerStatus(t *testing.T) {
+	coach, _ := createRandomUser(t)
+	playerID := uuid.New()
+	teamID := uuid.New()
+	statusID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ClearPlayerStatusParams{ID: statusID, TeamID: teamID, UserID: playerID}
+				store.EXPECT().
+					ClearPlayerStatus(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.PlayerStatus{ID: statusID, EndsAt: sql.NullTime{Time: time.Now(), Valid: true}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp PlayerStatusResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.NotNil(t, rsp.EndsAt)
+			},
+		},
+		{
+			name: "NotTeamCoach",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTeamMember(gomock.Any(), gomock.Eq(db.GetTeamMemberParams{TeamID: teamID, UserID: coach.ID})).
+					Times(1).
+					Return(db.TeamMember{TeamID: teamID, UserID: coach.ID, Role: string(util.TeamRolePlayer)}, nil)
+				store.EXPECT().
+					ClearPlayerStatus(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyEnded",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ClearPlayerStatus(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.PlayerStatus{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+			expectTeamCoach(store, coach.ID)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/teams/%s/members/%s/statuses/%s", teamID, playerID, statusID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, coach.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_ListPlayerStatuses(t *testing.T) {
+	player, _ := createRandomUser(t)
+	stranger, _ := createRandomUser(t)
+	teamID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		callerID      uuid.UUID
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "Player",
+			callerID: player.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListPlayerStatuses(gomock.Any(), gomock.Eq(db.ListPlayerStatusesParams{TeamID: teamID, UserID: player.ID})).
+					Times(1).
+					Return([]db.PlayerStatus{{ID: uuid.New(), Status: string(util.RosterStatusInjured)}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp []PlayerStatusResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Len(t, rsp, 1)
+			},
+		},
+		{
+			name:     "Stranger",
+			callerID: stranger.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListPlayerStatuses(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/teams/%s/members/%s/statuses", teamID, player.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, tc.callerID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
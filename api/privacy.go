@@ -0,0 +1,208 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/helpers"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/security"
+	"github.com/kwalter26/scoreit-api-go/security/token"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"net/http"
+)
+
+// privacyView describes which parts of a user's profile the caller may see.
+type privacyView struct {
+	Owner   bool // the caller is the user or one of their approved guardians
+	Profile bool
+	Email   bool
+	Stats   bool
+	// ProfileBypass and StatsBypass are set when the profile or email, or the stats, are only visible because the caller is an admin
+	ProfileBypass bool
+	StatsBypass   bool
+}
+
+// resolvePrivacy works out what the caller may see of user, honoring the user's visibility settings.
+// A minor's email is never shown to anyone but the minor, their guardians and admins.
+func (s *Server) resolvePrivacy(context *gin.Context, payload *token.Payload, user db.User) (privacyView, error) {
+	if payload.UserID == user.ID || security.IsGuardianOf(s.enforcer, payload.UserID.String(), user.ID.String()) {
+		return privacyView{Owner: true, Profile: true, Email: true, Stats: true}, nil
+	}
+
+	teammate := false
+	if util.Visibility(user.ProfileVisibility) == util.VisibilityTeammates ||
+		util.Visibility(user.EmailVisibility) == util.VisibilityTeammates ||
+		util.Visibility(user.StatVisibility) == util.VisibilityTeammates {
+		var err error
+		teammate, err = s.store.AreTeammates(context, db.AreTeammatesParams{
+			UserID:   user.ID,
+			ViewerID: payload.UserID,
+		})
+		if err != nil {
+			return privacyView{}, err
+		}
+	}
+
+	visible := func(v string) bool {
+		return util.Visibility(v) == util.VisibilityPublic ||
+			(util.Visibility(v) == util.VisibilityTeammates && teammate)
+	}
+
+	view := privacyView{
+		Profile: visible(user.ProfileVisibility),
+		Email:   visible(user.EmailVisibility) && !user.IsMinor,
+		Stats:   visible(user.StatVisibility),
+	}
+	if isAdmin(payload) && (!view.Profile || !view.Email || !view.Stats) {
+		view = privacyView{
+			Profile:       true,
+			Email:         true,
+			Stats:         true,
+			ProfileBypass: !view.Profile || !view.Email,
+			StatsBypass:   !view.Stats,
+		}
+	}
+	return view, nil
+}
+
+// hiddenStats returns which of the players hide their stats from the caller. When an admin sees stats
+// that would otherwise be hidden, the read is recorded in the audit log.
+func (s *Server) hiddenStats(context *gin.Context, payload *token.Payload, playerIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	hidden := make(map[uuid.UUID]bool, len(playerIDs))
+	checked := make(map[uuid.UUID]bool, len(playerIDs))
+	var bypassed []uuid.UUID
+	for _, id := range playerIDs {
+		if checked[id] {
+			continue
+		}
+		checked[id] = true
+
+		user, err := s.store.GetUser(context, id)
+		if err != nil {
+			return nil, err
+		}
+		view, err := s.resolvePrivacy(context, payload, user)
+		if err != nil {
+			return nil, err
+		}
+		hidden[id] = !view.Stats
+		if view.StatsBypass {
+			bypassed = append(bypassed, id)
+		}
+	}
+
+	if len(bypassed) > 0 {
+		subjectID := uuid.NullUUID{}
+		if len(bypassed) == 1 {
+			subjectID = uuid.NullUUID{UUID: bypassed[0], Valid: true}
+		}
+		if err := s.auditPrivacyBypass(context, payload, subjectID); err != nil {
+			return nil, err
+		}
+	}
+	return hidden, nil
+}
+
+// auditPrivacyBypass records that an admin read data hidden by a user's privacy settings.
+func (s *Server) auditPrivacyBypass(context *gin.Context, payload *token.Payload, subjectID uuid.NullUUID) error {
+	_, err := s.store.CreateAuditLog(context, db.CreateAuditLogParams{
+		ActorID:   payload.UserID,
+		Action:    string(util.AuditPrivacyBypass),
+		SubjectID: subjectID,
+		Details:   fmt.Sprintf("%s %s", context.Request.Method, context.Request.URL.String()),
+	})
+	return err
+}
+
+// PrivacySettingsResponse represents a user's privacy settings in a response.
+type PrivacySettingsResponse struct {
+	ProfileVisibility string `json:"profile_visibility"`
+	EmailVisibility   string `json:"email_visibility"`
+	StatVisibility    string `json:"stat_visibility"`
+}
+
+// NewPrivacySettingsResponse creates a new PrivacySettingsResponse from a db.User.
+func NewPrivacySettingsResponse(user db.User) PrivacySettingsResponse {
+	return PrivacySettingsResponse{
+		ProfileVisibility: user.ProfileVisibility,
+		EmailVisibility:   user.EmailVisibility,
+		StatVisibility:    user.StatVisibility,
+	}
+}
+
+// UpdatePrivacyRequestBody represents the body of a request to update a user's privacy settings.
+type UpdatePrivacyRequestBody struct {
+	ProfileVisibility string `json:"profile_visibility" binding:"omitempty,oneof=public teammates private"`
+	EmailVisibility   string `json:"email_visibility" binding:"omitempty,oneof=public teammates private"`
+	StatVisibility    string `json:"stat_visibility" binding:"omitempty,oneof=public teammates private"`
+}
+
+// UpdatePrivacy updates a user's privacy settings. Guardians may manage the settings of their players.
+func (s *Server) UpdatePrivacy(context *gin.Context) {
+	var req UpdateUserRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var body UpdatePrivacyRequestBody
+	if err := context.ShouldBindJSON(&body); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	arg := db.UpdateUserPrivacyParams{
+		ID:                uuid.MustParse(req.ID),
+		ProfileVisibility: sql.NullString{String: body.ProfileVisibility, Valid: body.ProfileVisibility != ""},
+		EmailVisibility:   sql.NullString{String: body.EmailVisibility, Valid: body.EmailVisibility != ""},
+		StatVisibility:    sql.NullString{String: body.StatVisibility, Valid: body.StatVisibility != ""},
+	}
+
+	user, err := s.store.UpdateUserPrivacy(context, arg)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, NewPrivacySettingsResponse(user))
+}
+
+// ListAuditLogsRequest represents a request to list audit log entries.
+type ListAuditLogsRequest struct {
+	PageSize int32 `form:"page_size,default=25" binding:"min=1,max=100"`
+	PageID   int32 `form:"page_id,default=1" binding:"min=1"`
+}
+
+// ListAuditLogs lists audit log entries, newest first. Only admins may read the audit log.
+func (s *Server) ListAuditLogs(context *gin.Context) {
+	var req ListAuditLogsRequest
+	if err := context.ShouldBindQuery(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	if !isAdmin(payload) {
+		context.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	logs, err := s.store.ListAuditLogs(context, db.ListAuditLogsParams{
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, logs)
+}
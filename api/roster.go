@@ -0,0 +1,261 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/helpers"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"net/http"
+	"time"
+)
+
+var (
+	errTradeTeamRequired = errors.New("to_team_id is required for a trade and must be another team")
+	errFutureTransaction = errors.New("effective_at must not be in the future")
+	errNoOpenStint       = errors.New("player has no stint on this team that started before effective_at")
+	errInvalidAsOf       = errors.New("as_of must be a date (2006-01-02) or an RFC 3339 timestamp")
+	errInvalidAsOfZone   = errors.New("tz must be an IANA time zone such as America/Chicago")
+)
+
+// rosterMove describes how a transaction type moves a player between teams.
+type rosterMove struct {
+	leaves bool
+	joins  bool
+	status util.RosterStatus
+}
+
+var rosterMoves = map[util.RosterTransactionType]rosterMove{
+	util.RosterSigning:      {joins: true, status: util.RosterStatusActive},
+	util.RosterRelease:      {leaves: true},
+	util.RosterTrade:        {leaves: true, joins: true, status: util.RosterStatusActive},
+	util.RosterInjuredList:  {leaves: true, joins: true, status: util.RosterStatusInjured},
+	util.RosterInactiveList: {leaves: true, joins: true, status: util.RosterStatusInactive},
+	util.RosterActivation:   {leaves: true, joins: true, status: util.RosterStatusActive},
+}
+
+// RosterTransactionResponse is the result of a roster transaction.
+type RosterTransactionResponse struct {
+	Transaction db.RosterTransaction `json:"transaction"`
+	Member      db.TeamMember        `json:"member"`
+	Stint       db.TeamMemberStint   `json:"stint"`
+}
+
+// runRosterTransaction runs a roster transaction for the authenticated user.
+// It writes the error response and returns false when the transaction fails.
+func (s *Server) runRosterTransaction(context *gin.Context, arg db.RosterTransactionTxParams) (db.RosterTransactionTxResult, bool) {
+	arg.CreateRosterTransactionParams.CreatedBy = middleware.GetAuthorizationPayload(context).UserID
+
+	result, err := s.store.RosterTransactionTx(context, arg)
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrMissingJersey):
+			context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		case errors.Is(err, sql.ErrNoRows) && arg.CreateRosterTransactionParams.FromTeamID.Valid:
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(errNoOpenStint))
+		default:
+			rosterError(context, err)
+		}
+		return result, false
+	}
+	return result, true
+}
+
+// CreateRosterTransactionRequestBody represents the body of a request to record a roster transaction.
+type CreateRosterTransactionRequestBody struct {
+	Type            string     `json:"type" binding:"required,oneof=signing release trade injured_list inactive_list activation"`
+	UserID          string     `json:"user_id" binding:"required,uuid"`
+	ToTeamID        string     `json:"to_team_id" binding:"omitempty,uuid"`
+	Number          *int64     `json:"number" binding:"omitempty,min=0,max=99"`
+	PrimaryPosition string     `json:"primary_position"`
+	EffectiveAt     *time.Time `json:"effective_at"`
+	Note            string     `json:"note" binding:"max=500"`
+}
+
+// CreateRosterTransaction records a signing, release, trade or list move for a team.
+// Trades move the player from this team to to_team_id. Only the team's coaches and admins may change the roster.
+func (s *Server) CreateRosterTransaction(context *gin.Context) {
+	var req GetTeamRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var body CreateRosterTransactionRequestBody
+	if err := context.ShouldBindJSON(&body); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	if body.PrimaryPosition != "" && !util.IsBaseballPosition(body.PrimaryPosition) {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(errInvalidPosition))
+		return
+	}
+
+	effectiveAt := time.Now()
+	if body.EffectiveAt != nil {
+		if body.EffectiveAt.After(effectiveAt) {
+			context.JSON(http.StatusBadRequest, helpers.ErrorResponse(errFutureTransaction))
+			return
+		}
+		effectiveAt = *body.EffectiveAt
+	}
+
+	if !s.authorizeTeamCoach(context, uuid.MustParse(req.ID)) {
+		return
+	}
+
+	txType := util.RosterTransactionType(body.Type)
+	move := rosterMoves[txType]
+	teamID := uuid.NullUUID{UUID: uuid.MustParse(req.ID), Valid: true}
+
+	arg := db.RosterTransactionTxParams{
+		CreateRosterTransactionParams: db.CreateRosterTransactionParams{
+			Type:        body.Type,
+			UserID:      uuid.MustParse(body.UserID),
+			EffectiveAt: effectiveAt,
+			Note:        body.Note,
+		},
+		PrimaryPosition: sql.NullString{String: body.PrimaryPosition, Valid: body.PrimaryPosition != ""},
+		Status:          string(move.status),
+	}
+	if body.Number != nil {
+		arg.Number = sql.NullInt64{Int64: *body.Number, Valid: true}
+	}
+	if move.leaves {
+		arg.CreateRosterTransactionParams.FromTeamID = teamID
+	}
+	if move.joins {
+		arg.CreateRosterTransactionParams.ToTeamID = teamID
+	}
+	if txType == util.RosterTrade {
+		if body.ToTeamID == "" || uuid.MustParse(body.ToTeamID) == teamID.UUID {
+			context.JSON(http.StatusBadRequest, helpers.ErrorResponse(errTradeTeamRequired))
+			return
+		}
+		arg.CreateRosterTransactionParams.ToTeamID = uuid.NullUUID{UUID: uuid.MustParse(body.ToTeamID), Valid: true}
+	}
+
+	result, ok := s.runRosterTransaction(context, arg)
+	if !ok {
+		return
+	}
+
+	context.JSON(http.StatusOK, RosterTransactionResponse{
+		Transaction: result.Transaction,
+		Member:      result.Member,
+		Stint:       result.Stint,
+	})
+}
+
+// ListRosterTransactions lists the transactions involving a team, most recent first.
+func (s *Server) ListRosterTransactions(context *gin.Context) {
+	var req GetTeamRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var query ListTeamMembersRequestQuery
+	if err := context.ShouldBindQuery(&query); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	transactions, err := s.store.ListRosterTransactions(context, db.ListRosterTransactionsParams{
+		TeamID: uuid.MustParse(req.ID),
+		Limit:  query.PageSize,
+		Offset: (query.PageId - 1) * query.PageSize,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, transactions)
+}
+
+// GetRosterRequestQuery represents the date a roster is reconstructed for.
+// TimeZone is the zone a bare date is read in and defaults to UTC.
+type GetRosterRequestQuery struct {
+	AsOf     string `form:"as_of"`
+	TimeZone string `form:"tz"`
+}
+
+// parseAsOf parses a date or timestamp. A bare date means the end of that day in zone, or in UTC when zone is empty.
+func parseAsOf(value string, zone string) (time.Time, error) {
+	location := time.UTC
+	if zone != "" {
+		if !util.IsValidTimeZone(zone) {
+			return time.Time{}, errInvalidAsOfZone
+		}
+		location, _ = time.LoadLocation(zone)
+	}
+
+	if value == "" {
+		return time.Now(), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	day, err := time.ParseInLocation("2006-01-02", value, location)
+	if err != nil {
+		return time.Time{}, errInvalidAsOf
+	}
+	// days are not always 24 hours long where the clocks change
+	return day.AddDate(0, 0, 1).Add(-time.Nanosecond).UTC(), nil
+}
+
+// GetRoster reconstructs a team's roster as it stood at as_of, defaulting to now.
+// Players whose profiles are hidden from the caller are left out.
+func (s *Server) GetRoster(context *gin.Context) {
+	var req GetTeamRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var query GetRosterRequestQuery
+	if err := context.ShouldBindQuery(&query); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	asOf, err := parseAsOf(query.AsOf, query.TimeZone)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	arg := db.ListRosterAsOfParams{
+		TeamID:         uuid.MustParse(req.ID),
+		AsOf:           asOf,
+		IncludePrivate: isAdmin(payload),
+		ViewerID:       payload.UserID,
+	}
+
+	roster, err := s.store.ListRosterAsOf(context, arg)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	if arg.IncludePrivate {
+		hidden := false
+		for _, row := range roster {
+			hidden = hidden || row.Hidden
+		}
+		if hidden {
+			if err := s.auditPrivacyBypass(context, payload, uuid.NullUUID{}); err != nil {
+				context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+				return
+			}
+		}
+	}
+
+	context.JSON(http.StatusOK, roster)
+}
@@ -0,0 +1,426 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	mockdb "github.com/kwalter26/scoreit-api-go/db/mock"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/security"
+	"github.com/kwalter26/scoreit-api-go/security/token"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestServer_CreateRosterTransaction(t *testing.T) {
+	coach, _ := createRandomUser(t)
+	player, _ := createRandomUser(t)
+	team := randomTeam()
+	otherTeam := randomTeam()
+	effectiveAt := time.Now().Add(-48 * time.Hour).UTC().Truncate(time.Second)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Trade",
+			body: gin.H{
+				"type":         util.RosterTrade,
+				"user_id":      player.ID,
+				"to_team_id":   otherTeam.ID,
+				"effective_at": effectiveAt,
+				"note":         "deadline deal",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, coach.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.RosterTransactionTxParams{
+					CreateRosterTransactionParams: db.CreateRosterTransactionParams{
+						Type:        string(util.RosterTrade),
+						UserID:      player.ID,
+						FromTeamID:  uuid.NullUUID{UUID: team.ID, Valid: true},
+						ToTeamID:    uuid.NullUUID{UUID: otherTeam.ID, Valid: true},
+						EffectiveAt: effectiveAt,
+						Note:        "deadline deal",
+						CreatedBy:   coach.ID,
+					},
+					Status: string(util.RosterStatusActive),
+				}
+				store.EXPECT().
+					RosterTransactionTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.RosterTransactionTxResult{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "InjuredList",
+			body: gin.H{
+				"type":    util.RosterInjuredList,
+				"user_id": player.ID,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, coach.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.RosterTransactionTxParams{
+					CreateRosterTransactionParams: db.CreateRosterTransactionParams{
+						Type:       string(util.RosterInjuredList),
+						UserID:     player.ID,
+						FromTeamID: uuid.NullUUID{UUID: team.ID, Valid: true},
+						ToTeamID:   uuid.NullUUID{UUID: team.ID, Valid: true},
+						CreatedBy:  coach.ID,
+					},
+					Status: string(util.RosterStatusInjured),
+				}
+				store.EXPECT().
+					RosterTransactionTx(gomock.Any(), EqRosterTransactionTxParamsMatcher(arg)).
+					Times(1).
+					Return(db.RosterTransactionTxResult{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "SigningWithoutNumber",
+			body: gin.H{
+				"type":    util.RosterSigning,
+				"user_id": player.ID,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, coach.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RosterTransactionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RosterTransactionTxResult{}, db.ErrMissingJersey)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NoOpenStint",
+			body: gin.H{
+				"type":         util.RosterRelease,
+				"user_id":      player.ID,
+				"effective_at": effectiveAt,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, coach.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RosterTransactionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RosterTransactionTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+				require.Contains(t, recorder.Body.String(), errNoOpenStint.Error())
+			},
+		},
+		{
+			name: "TradeWithoutTeam",
+			body: gin.H{
+				"type":    util.RosterTrade,
+				"user_id": player.ID,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, coach.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RosterTransactionTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "FutureEffectiveAt",
+			body: gin.H{
+				"type":         util.RosterRelease,
+				"user_id":      player.ID,
+				"effective_at": time.Now().Add(time.Hour),
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, coach.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RosterTransactionTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidType",
+			body: gin.H{
+				"type":    "waiver",
+				"user_id": player.ID,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, coach.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RosterTransactionTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotCoach",
+			body: gin.H{
+				"type":    util.RosterRelease,
+				"user_id": player.ID,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, player.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RosterTransactionTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectTeamCoach(store, coach.ID)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			buf, err := buildJsonRequest(t, tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/teams/%s/transactions", team.ID)
+			request, err := http.NewRequest(http.MethodPost, url, &buf)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_GetRoster(t *testing.T) {
+	user, _ := createRandomUser(t)
+	team := randomTeam()
+
+	testCases := []struct {
+		name          string
+		asOf          string
+		tz            string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Date",
+			asOf: "2023-06-15",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListRosterAsOfParams{
+					TeamID:   team.ID,
+					AsOf:     time.Date(2023, 6, 15, 23, 59, 59, 999999999, time.UTC),
+					ViewerID: user.ID,
+				}
+				store.EXPECT().
+					ListRosterAsOf(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.ListRosterAsOfRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "DateInTimeZone",
+			asOf: "2023-06-15",
+			tz:   "America/Chicago",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListRosterAsOfParams{
+					TeamID:   team.ID,
+					AsOf:     time.Date(2023, 6, 16, 4, 59, 59, 999999999, time.UTC),
+					ViewerID: user.ID,
+				}
+				store.EXPECT().
+					ListRosterAsOf(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.ListRosterAsOfRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "DateAcrossClockChange",
+			asOf: "2023-03-12",
+			tz:   "America/Chicago",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListRosterAsOfParams{
+					TeamID:   team.ID,
+					AsOf:     time.Date(2023, 3, 13, 4, 59, 59, 999999999, time.UTC),
+					ViewerID: user.ID,
+				}
+				store.EXPECT().
+					ListRosterAsOf(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.ListRosterAsOfRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidTimeZone",
+			asOf: "2023-06-15",
+			tz:   "Mars/Olympus_Mons",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListRosterAsOf(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), errInvalidAsOfZone.Error())
+			},
+		},
+		{
+			name: "Timestamp",
+			asOf: "2023-06-15T12:00:00Z",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListRosterAsOfParams{
+					TeamID:   team.ID,
+					AsOf:     time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC),
+					ViewerID: user.ID,
+				}
+				store.EXPECT().
+					ListRosterAsOf(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.ListRosterAsOfRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Now",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListRosterAsOf(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListRosterAsOfRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidAsOf",
+			asOf: "last tuesday",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListRosterAsOf(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/teams/%s/roster", team.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+			q := request.URL.Query()
+			if tc.asOf != "" {
+				q.Add("as_of", tc.asOf)
+			}
+			if tc.tz != "" {
+				q.Add("tz", tc.tz)
+			}
+			request.URL.RawQuery = q.Encode()
+
+			addAuthorization(t, request, server.tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_ListRosterTransactions(t *testing.T) {
+	user, _ := createRandomUser(t)
+	team := randomTeam()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListRosterTransactions(gomock.Any(), gomock.Eq(db.ListRosterTransactionsParams{
+			TeamID: team.ID,
+			Limit:  5,
+			Offset: 5,
+		})).
+		Times(1).
+		Return([]db.RosterTransaction{}, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/api/v1/teams/%s/transactions?page_id=2&page_size=5", team.ID)
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
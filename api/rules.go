@@ -0,0 +1,61 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"net/http"
+)
+
+var errRulesConflict = errors.New("give either a rule_preset or rules, not both")
+
+// ListRulePresetsResponse defines the response body for ListRulePresets.
+type ListRulePresetsResponse struct {
+	Presets []util.RuleSet `json:"presets"`
+}
+
+// ListRulePresets lists the standard rule sets a game can be played under.
+func (s *Server) ListRulePresets(context *gin.Context) {
+	presets := make([]util.RuleSet, 0, len(util.RulePresets))
+	for _, preset := range util.RulePresets {
+		rules, _ := util.PresetRules(preset)
+		presets = append(presets, rules)
+	}
+	context.JSON(http.StatusOK, ListRulePresetsResponse{Presets: presets})
+}
+
+// newGameRules returns the rules a new game is played under: a preset, a league's own rules, or none
+func newGameRules(preset string, rules *util.RuleSet) (util.RuleSet, error) {
+	switch {
+	case preset != "" && rules != nil:
+		return util.RuleSet{}, errRulesConflict
+	case preset != "":
+		presetRules, ok := util.PresetRules(util.RulePreset(preset))
+		if !ok {
+			return util.RuleSet{}, fmt.Errorf("%s is not a rule preset", preset)
+		}
+		return presetRules, nil
+	case rules != nil:
+		custom := *rules
+		return custom, custom.Validate()
+	}
+	return util.RuleSet{}, nil
+}
+
+// gameRules decodes the rules a game is played under. Games created before rule sets existed have none.
+func gameRules(game db.Game) (util.RuleSet, error) {
+	return decodeRules(game.Rules)
+}
+
+// decodeRules decodes rules stored with a game, which are empty for games that have none
+func decodeRules(data json.RawMessage) (util.RuleSet, error) {
+	var rules util.RuleSet
+	if len(data) == 0 {
+		return rules, nil
+	}
+	err := json.Unmarshal(data, &rules)
+	return rules, err
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	mockdb "github.com/kwalter26/scoreit-api-go/db/mock"
+	"github.com/kwalter26/scoreit-api-go/security"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestServer_ListRulePresets(t *testing.T) {
+	user, _ := createRandomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := newTestServer(t, mockdb.NewMockStore(ctrl))
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, "/api/v1/rule-presets", nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var rsp ListRulePresetsResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+	require.Len(t, rsp.Presets, len(util.RulePresets))
+	require.Equal(t, util.RulesMLB, rsp.Presets[0].Preset)
+	require.Equal(t, int64(10), rsp.Presets[0].TiebreakerInning)
+	require.Equal(t, int64(6), rsp.Presets[2].RegulationInnings)
+	require.Equal(t, int64(5), rsp.Presets[3].MaxRunsPerInning)
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/helpers"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"net/http"
+	"sort"
+)
+
+// SearchRequest represents a search across players and teams.
+type SearchRequest struct {
+	Query string `form:"q" binding:"required,min=2,max=100"`
+	Type  string `form:"type" binding:"omitempty,oneof=user team"`
+	Limit int32  `form:"limit,default=10" binding:"min=1,max=50"`
+}
+
+// SearchResult represents a single ranked search hit.
+type SearchResult struct {
+	Type      util.SearchResultType `json:"type"`
+	ID        uuid.UUID             `json:"id"`
+	Title     string                `json:"title"`
+	Subtitle  string                `json:"subtitle,omitempty"`
+	Highlight string                `json:"highlight"`
+	Rank      float32               `json:"rank"`
+}
+
+// Search looks up players and teams by approximate name, best matches first.
+// Players hidden by their privacy settings are left out unless the caller is an admin, which is audited.
+func (s *Server) Search(context *gin.Context) {
+	var req SearchRequest
+	if err := context.ShouldBindQuery(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	results := []SearchResult{}
+
+	if req.Type == "" || util.SearchResultType(req.Type) == util.SearchResultUser {
+		arg := db.SearchUsersParams{
+			Query:          req.Query,
+			IncludePrivate: isAdmin(payload),
+			ViewerID:       payload.UserID,
+			MaxResults:     req.Limit,
+		}
+		users, err := s.store.SearchUsers(context, arg)
+		if err != nil {
+			context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+			return
+		}
+
+		if arg.IncludePrivate {
+			hidden := false
+			for _, row := range users {
+				hidden = hidden || row.Hidden
+			}
+			if hidden {
+				if err := s.auditPrivacyBypass(context, payload, uuid.NullUUID{}); err != nil {
+					context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+					return
+				}
+			}
+		}
+
+		for _, user := range users {
+			name := fmt.Sprintf("%s %s", user.FirstName, user.LastName)
+			results = append(results, SearchResult{
+				Type:      util.SearchResultUser,
+				ID:        user.ID,
+				Title:     name,
+				Subtitle:  user.Username,
+				Highlight: util.Highlight(name, req.Query),
+				Rank:      user.Rank,
+			})
+		}
+	}
+
+	if req.Type == "" || util.SearchResultType(req.Type) == util.SearchResultTeam {
+		teams, err := s.store.SearchTeams(context, db.SearchTeamsParams{
+			Query:      req.Query,
+			MaxResults: req.Limit,
+		})
+		if err != nil {
+			context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+			return
+		}
+		for _, team := range teams {
+			results = append(results, SearchResult{
+				Type:      util.SearchResultTeam,
+				ID:        team.ID,
+				Title:     team.Name,
+				Highlight: util.Highlight(team.Name, req.Query),
+				Rank:      team.Rank,
+			})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Rank > results[j].Rank
+	})
+	if len(results) > int(req.Limit) {
+		results = results[:req.Limit]
+	}
+
+	context.JSON(http.StatusOK, results)
+}
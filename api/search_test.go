@@ -0,0 +1,216 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	mockdb "github.com/kwalter26/scoreit-api-go/db/mock"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/security"
+	"github.com/kwalter26/scoreit-api-go/security/token"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestServerSearch(t *testing.T) {
+	user, _ := createRandomUser(t)
+	team := randomTeam()
+
+	userRows := []db.SearchUsersRow{
+		{ID: user.ID, Username: user.Username, FirstName: user.FirstName, LastName: user.LastName, Rank: 0.4},
+	}
+	hiddenUserRows := []db.SearchUsersRow{
+		{ID: user.ID, Username: user.Username, FirstName: user.FirstName, LastName: user.LastName, Rank: 0.4, Hidden: true},
+	}
+	teamRows := []db.SearchTeamsRow{
+		{ID: team.ID, Name: team.Name, Rank: 0.9},
+	}
+
+	testCases := []struct {
+		name          string
+		query         url.Values
+		buildStubs    func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: url.Values{"q": {user.FirstName}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SearchUsers(gomock.Any(), gomock.Eq(db.SearchUsersParams{
+						Query:      user.FirstName,
+						ViewerID:   user.ID,
+						MaxResults: 10,
+					})).
+					Times(1).
+					Return(userRows, nil)
+				store.EXPECT().
+					SearchTeams(gomock.Any(), gomock.Eq(db.SearchTeamsParams{Query: user.FirstName, MaxResults: 10})).
+					Times(1).
+					Return(teamRows, nil)
+				store.EXPECT().
+					CreateAuditLog(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var rsp []SearchResult
+				err := json.NewDecoder(recorder.Body).Decode(&rsp)
+				require.NoError(t, err)
+				require.Len(t, rsp, 2)
+				require.Equal(t, util.SearchResultTeam, rsp[0].Type)
+				require.Equal(t, util.SearchResultUser, rsp[1].Type)
+				require.Equal(t, user.ID, rsp[1].ID)
+				require.Contains(t, rsp[1].Highlight, util.HighlightStart+user.FirstName+util.HighlightEnd)
+			},
+		},
+		{
+			name:  "TeamsOnly",
+			query: url.Values{"q": {team.Name}, "type": {"team"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SearchUsers(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					SearchTeams(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(teamRows, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var rsp []SearchResult
+				err := json.NewDecoder(recorder.Body).Decode(&rsp)
+				require.NoError(t, err)
+				require.Len(t, rsp, 1)
+				require.Equal(t, team.ID, rsp[0].ID)
+			},
+		},
+		{
+			name:  "AdminIncludesPrivateAudited",
+			query: url.Values{"q": {user.FirstName}, "type": {"user"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateAuditLog(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AuditLog{}, nil)
+				store.EXPECT().
+					SearchUsers(gomock.Any(), gomock.Eq(db.SearchUsersParams{
+						Query:          user.FirstName,
+						IncludePrivate: true,
+						ViewerID:       user.ID,
+						MaxResults:     10,
+					})).
+					Times(1).
+					Return(hiddenUserRows, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, adminUserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "AdminNothingHidden",
+			query: url.Values{"q": {user.FirstName}, "type": {"user"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateAuditLog(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					SearchUsers(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(userRows, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, adminUserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "QueryTooShort",
+			query: url.Values{"q": {"a"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SearchUsers(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					SearchTeams(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: url.Values{"q": {user.FirstName}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SearchUsers(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:  "Unauthenticated",
+			query: url.Values{"q": {user.FirstName}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SearchUsers(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/api/v1/search?"+tc.query.Encode(), nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
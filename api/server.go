@@ -1,6 +1,8 @@
 package api
 
 import (
+	"context"
+	"github.com/casbin/casbin/v2"
 	"github.com/gin-gonic/gin"
 	"github.com/kwalter26/scoreit-api-go/api/middleware"
 	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
@@ -9,12 +11,14 @@ import (
 	"github.com/kwalter26/scoreit-api-go/util"
 	"github.com/rs/zerolog/log"
 	"os"
+	"time"
 )
 
 type Server struct {
 	config     util.Config
 	store      db.Store
 	tokenMaker token.Maker
+	enforcer   *casbin.SyncedEnforcer
 	router     *gin.Engine
 	//app    *newrelic.Application
 }
@@ -25,6 +29,7 @@ func (s *Server) setupRouter() {
 		log.Fatal().Err(err).Msg("failed to create casbin enforcer")
 	}
 	log.Info().Msg("created casbin enforcer")
+	s.enforcer = enforcer
 
 	router := gin.New()
 	router.Use(gin.Recovery())
@@ -39,22 +44,102 @@ func (s *Server) setupRouter() {
 	authRoutes.Use(middleware.AuthMiddleware(s.tokenMaker))
 	authRoutes.Use(middleware.NewAuthorizeMiddleware(enforcer))
 
+	authRoutes.GET("/v1/me", s.GetMe)
+	authRoutes.GET("/v1/me/teams", s.ListMyTeams)
+	authRoutes.GET("/v1/me/games", s.ListMyGames)
+	authRoutes.GET("/v1/me/notifications", s.ListMyNotifications)
+	authRoutes.POST("/v1/me/notifications/:id/read", s.ReadMyNotification)
+
 	authRoutes.GET("/v1/teams", s.ListTeams)
 	authRoutes.POST("/v1/teams", s.CreateTeam)
 	authRoutes.PUT("/v1/teams/:id/members/:user_id", s.AddTeamMember)
+	authRoutes.PATCH("/v1/teams/:id/members/:user_id", s.UpdateTeamMember)
+	authRoutes.DELETE("/v1/teams/:id/members/:user_id", s.RemoveTeamMember)
 	authRoutes.GET("/v1/teams/:id/members", s.ListTeamMembers)
+	authRoutes.GET("/v1/teams/:id/members/:user_id/positions", s.ListPlayerPositions)
+	authRoutes.PUT("/v1/teams/:id/members/:user_id/positions", s.SetPlayerPositions)
+	authRoutes.GET("/v1/teams/:id/members/:user_id/statuses", s.ListPlayerStatuses)
+	authRoutes.POST("/v1/teams/:id/members/:user_id/statuses", s.CreatePlayerStatus)
+	authRoutes.DELETE("/v1/teams/:id/members/:user_id/statuses/:status_id", s.ClearPlayerStatus)
+	authRoutes.GET("/v1/teams/:id/depth-chart", s.GetDepthChart)
+	authRoutes.PUT("/v1/teams/:id/depth-chart/:position", s.SetDepthChart)
+	authRoutes.GET("/v1/teams/:id/roster", s.GetRoster)
+	authRoutes.GET("/v1/teams/:id/transactions", s.ListRosterTransactions)
+	authRoutes.POST("/v1/teams/:id/transactions", s.CreateRosterTransaction)
+	authRoutes.GET("/v1/teams/:id/invitations", s.ListTeamInvitations)
+	authRoutes.POST("/v1/teams/:id/invitations", s.CreateTeamInvitation)
+	authRoutes.DELETE("/v1/teams/:id/invitations/:invitation_id", s.RevokeTeamInvitation)
+	authRoutes.POST("/v1/invitations/:code/accept", s.AcceptTeamInvitation)
+	authRoutes.GET("/v1/teams/:id/join-requests", s.ListJoinRequests)
+	authRoutes.POST("/v1/teams/:id/join-requests", s.CreateJoinRequest)
+	authRoutes.POST("/v1/teams/:id/join-requests/:request_id/approve", s.ApproveJoinRequest)
+	authRoutes.POST("/v1/teams/:id/join-requests/:request_id/reject", s.RejectJoinRequest)
 	authRoutes.GET("/v1/teams/:id", s.GetTeam)
+	authRoutes.PATCH("/v1/teams/:id", s.UpdateTeam)
+	authRoutes.DELETE("/v1/teams/:id", s.DeleteTeam)
+	authRoutes.POST("/v1/teams/:id/archive", s.ArchiveTeam)
+	authRoutes.POST("/v1/teams/:id/unarchive", s.UnarchiveTeam)
 
 	authRoutes.GET("/v1/players", s.ListUsers)
 	authRoutes.GET("/v1/players/roles", s.ListUserRoles)
 	authRoutes.GET("/v1/players/:id", s.GetUser)
+	authRoutes.PATCH("/v1/players/:id", middleware.NewPlayerDelegationMiddleware(enforcer), s.UpdateUser)
+	authRoutes.PUT("/v1/players/:id/privacy", middleware.NewPlayerDelegationMiddleware(enforcer), s.UpdatePrivacy)
+	authRoutes.GET("/v1/players/:id/guardians", s.ListGuardians)
+	authRoutes.POST("/v1/players/:id/guardians", s.RequestGuardian)
+	authRoutes.PUT("/v1/players/:id/guardians/:guardian_id", s.UpdateGuardian)
+	authRoutes.DELETE("/v1/players/:id/guardians/:guardian_id", s.RemoveGuardian)
+	authRoutes.GET("/v1/players/:id/pitching-eligibility", s.GetPitchingEligibility)
 	authRoutes.GET("/v1/players/:id/roles", s.GetUserRoles)
 	authRoutes.PUT("/v1/players/:id/roles", s.CreateUserRole)
 
+	authRoutes.GET("/v1/search", s.Search)
+
+	authRoutes.GET("/v1/audit-logs", s.ListAuditLogs)
+
+	authRoutes.GET("/v1/rule-presets", s.ListRulePresets)
+
 	authRoutes.POST("/v1/games", s.CreateGame)
 	authRoutes.GET("/v1/games", s.ListGames)
 	authRoutes.GET("/v1/games/:id", s.GetGame)
-	authRoutes.PUT("/v1/games/:id", s.UpdateGame)
+	authRoutes.POST("/v1/games/:id/status", s.SetGameStatus)
+	authRoutes.GET("/v1/games/:id/status-changes", s.ListGameStatusChanges)
+	authRoutes.POST("/v1/games/:id/reschedule", s.RescheduleGame)
+	authRoutes.GET("/v1/games/:id/schedule-changes", s.ListGameScheduleChanges)
+	authRoutes.GET("/v1/games/:id/availability", s.GetGameAvailability)
+	authRoutes.GET("/v1/games/:id/availability/:user_id", s.GetAvailability)
+	authRoutes.PUT("/v1/games/:id/availability/:user_id", s.SetAvailability)
+	authRoutes.GET("/v1/games/:id/lineups/:side", s.GetLineup)
+	authRoutes.PUT("/v1/games/:id/lineups/:side", s.SetLineup)
+	authRoutes.POST("/v1/games/:id/lineups/:side/substitutions", s.Substitute)
+	authRoutes.GET("/v1/games/:id/participants", s.ListGameParticipants)
+	authRoutes.GET("/v1/games/:id/events", s.ListGameEvents)
+	authRoutes.POST("/v1/games/:id/events", s.RecordGameEvent)
+	authRoutes.POST("/v1/games/:id/events/undo", s.UndoGameEvents)
+	authRoutes.PUT("/v1/games/:id/events/:sequence", s.CorrectGameEvent)
+	authRoutes.GET("/v1/games/:id/events/history", s.ListGameEventHistory)
+	authRoutes.GET("/v1/games/:id/state", s.GetGameState)
+	authRoutes.GET("/v1/games/:id/linescore", s.GetLinescore)
+	authRoutes.GET("/v1/games/:id/boxscore", s.GetBoxScore)
+	authRoutes.POST("/v1/games/:id/pitches", s.RecordPitch)
+	authRoutes.GET("/v1/games/:id/pitch-counts", s.ListGamePitchCounts)
+	authRoutes.PUT("/v1/games/:id/pitch-counts/:player_id", s.SetPitchCount)
+	authRoutes.DELETE("/v1/games/:id/pitch-counts/:player_id", s.ClearPitchCount)
+	authRoutes.GET("/v1/games/:id/atbats", s.ListGameAtbats)
+	authRoutes.GET("/v1/games/:id/atbats/:atbat_id", s.GetAtbat)
+
+	authRoutes.POST("/v1/venues", s.CreateVenue)
+	authRoutes.GET("/v1/venues", s.ListVenues)
+	authRoutes.GET("/v1/venues/:id", s.GetVenue)
+	authRoutes.PUT("/v1/venues/:id", s.UpdateVenue)
+	authRoutes.DELETE("/v1/venues/:id", s.DeleteVenue)
+	authRoutes.POST("/v1/venues/:id/fields", s.CreateField)
+	authRoutes.PUT("/v1/venues/:id/fields/:field_id", s.UpdateField)
+	authRoutes.DELETE("/v1/venues/:id/fields/:field_id", s.DeleteField)
+	authRoutes.GET("/v1/venues/:id/fields/:field_id/availability", s.ListFieldAvailability)
+	authRoutes.POST("/v1/venues/:id/fields/:field_id/availability", s.CreateFieldAvailability)
+	authRoutes.DELETE("/v1/venues/:id/fields/:field_id/availability/:window_id", s.DeleteFieldAvailability)
+	authRoutes.GET("/v1/fields/nearby", s.ListNearbyFields)
 
 	s.router = router
 }
@@ -74,7 +159,44 @@ func NewServer(config util.Config, store db.Store) (*Server, error) {
 	return server, nil
 }
 
+// guardianPolicyRefresh is how often the casbin enforcer reloads the guardian links, picking up
+// links approved or removed through other server instances
+const guardianPolicyRefresh = time.Minute
+
+// LoadGuardianPolicies has the casbin enforcer load every approved guardian link from the database,
+// and load them again whenever its policy is reloaded.
+func (s *Server) LoadGuardianPolicies() error {
+	if err := security.UseGuardianLinks(s.enforcer, s.guardianLinks); err != nil {
+		return err
+	}
+	log.Info().Msg("loaded guardian policies")
+	return nil
+}
+
+// guardianLinks lists the approved guardian links as guardian and player ID pairs.
+func (s *Server) guardianLinks(ctx context.Context) ([][2]string, error) {
+	guardians, err := s.store.ListApprovedGuardians(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	links := make([][2]string, len(guardians))
+	for i, guardian := range guardians {
+		links[i] = [2]string{guardian.GuardianID.String(), guardian.PlayerID.String()}
+	}
+	return links, nil
+}
+
+// reloadGuardianPolicies reloads the guardian links after one changes, so the change applies on this
+// instance at once. A failed reload is only logged since the link is saved and the next refresh picks it up.
+func (s *Server) reloadGuardianPolicies() {
+	if err := s.enforcer.LoadPolicy(); err != nil {
+		log.Error().Err(err).Msg("cannot reload guardian policies")
+	}
+}
+
 func (s *Server) Start(address string) error {
 	log.Info().Str("address", address).Msg("starting server")
+	s.enforcer.StartAutoLoadPolicy(guardianPolicyRefresh)
 	return s.router.Run(address)
 }
@@ -0,0 +1,283 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/helpers"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/scoring"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/lib/pq"
+	"net/http"
+)
+
+var (
+	errGameNotInProgress = errors.New("substitutions can only be made while the game is in progress")
+	errWrongInning       = errors.New("inning does not match the game's current inning")
+)
+
+// SubstitutionRequest represents one change to a lineup during a game. A position change moves
+// out_player_id to position and has no in_player_id. Players coming in take the batting slot of the
+// player they replace unless bat_position is given, as in a double switch.
+type SubstitutionRequest struct {
+	Kind        string `json:"kind" binding:"required,oneof=pinch_hitter pinch_runner defensive pitching_change position_change"`
+	OutPlayerID string `json:"out_player_id" binding:"required,uuid"`
+	InPlayerID  string `json:"in_player_id" binding:"omitempty,uuid"`
+	Position    string `json:"position"`
+	BatPosition *int64 `json:"bat_position" binding:"omitempty,min=0,max=30"`
+}
+
+// SubstituteRequestBody represents substitutions made together in one inning, which must be the inning the game is in.
+// Admins may set override to bring in players who have a current injured, suspended or inactive status.
+type SubstituteRequestBody struct {
+	Inning        int64                 `json:"inning" binding:"required,min=1,max=99"`
+	Substitutions []SubstitutionRequest `json:"substitutions" binding:"required,min=1,max=20,dive"`
+	Override      bool                  `json:"override"`
+}
+
+// GameParticipantsResponse lists everyone who has played in a game, with when they entered and left.
+type GameParticipantsResponse struct {
+	GameID uuid.UUID                    `json:"game_id"`
+	Home   []db.ListGameParticipantsRow `json:"home"`
+	Away   []db.ListGameParticipantsRow `json:"away"`
+}
+
+// Substitute records pinch hitters, pinch runners, defensive substitutions, pitching changes and
+// position changes for one side of a game in progress. Substitutions are applied in order and together,
+// so a double switch is one request. Players who have left may only come back as the game's re-entry rule allows,
+// and the change is refused once the game's play-by-play has ended.
+// Only that team's coaches and admins may make its substitutions.
+func (s *Server) Substitute(context *gin.Context) {
+	var req GetLineupRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var body SubstituteRequestBody
+	if err := context.ShouldBindJSON(&body); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	if !isCoachOrAdmin(payload) || (body.Override && !isAdmin(payload)) {
+		context.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	game, err := s.store.GetGame(context, uuid.MustParse(req.ID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+	homeTeam, teamID := lineupSide(game, req.Side)
+	if !s.authorizeTeamCoach(context, teamID) {
+		return
+	}
+	if util.GameStatus(game.Status) != util.GameInProgress {
+		context.JSON(http.StatusConflict, helpers.ErrorResponse(errGameNotInProgress))
+		return
+	}
+	rows, err := s.store.ListGameParticipants(context, game.ID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+	var participants []util.Participant
+	for _, row := range rows {
+		if row.HomeTeam != homeTeam {
+			continue
+		}
+		participants = append(participants, util.Participant{
+			ID:          row.ID.String(),
+			PlayerID:    row.PlayerID.String(),
+			Entry:       util.ParticipantEntry(row.Entry),
+			BatPosition: row.BatPosition,
+			Position:    util.BaseballPosition(row.Position),
+			Active:      !row.ExitedInning.Valid,
+		})
+	}
+
+	subs := make([]util.Substitution, len(body.Substitutions))
+	for i, sub := range body.Substitutions {
+		subs[i] = util.Substitution{
+			Kind:        util.ParticipantEntry(sub.Kind),
+			OutPlayerID: sub.OutPlayerID,
+			InPlayerID:  sub.InPlayerID,
+			Position:    util.BaseballPosition(sub.Position),
+			BatPosition: sub.BatPosition,
+		}
+	}
+	rules, err := gameRules(game)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+	steps, err := util.PlanSubstitutions(rules, util.ReentryRule(game.ReentryRule), participants, subs)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var problems []LineupProblem
+	var warnings []string
+	params := make([]db.SubstitutionStepParams, len(steps))
+	event := scoring.Substitution{Home: homeTeam}
+	for i, step := range steps {
+		playerID := uuid.MustParse(step.PlayerID)
+		params[i] = db.SubstitutionStepParams{
+			ID:          uuid.New(),
+			Replaces:    uuid.MustParse(step.Replaces),
+			PlayerID:    playerID,
+			Entry:       string(step.Entry),
+			BatPosition: step.BatPosition,
+			Position:    string(step.Position),
+		}
+		event.Changes = append(event.Changes, scoring.Change{
+			ReplacesID:  step.Replaces,
+			InID:        params[i].ID.String(),
+			BatPosition: step.BatPosition,
+			Position:    step.Position,
+		})
+
+		position := string(step.Position)
+		if step.Entry == util.EntryPinchHitter || step.Entry == util.EntryPinchRunner {
+			position = ""
+		}
+		problem, warning, err := s.lineupPlayerProblem(context, game, teamID, playerID, position, body.Override)
+		if err != nil {
+			context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+			return
+		}
+		if problem != "" {
+			problems = append(problems, LineupProblem{PlayerID: playerID, Problem: problem})
+		}
+		if warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+	if len(problems) > 0 {
+		context.JSON(http.StatusBadRequest, LineupRejectedResponse{
+			Error:    errLineupRejected.Error(),
+			Problems: problems,
+		})
+		return
+	}
+
+	// the change is checked against the replayed game like any other event
+	state, events, err := s.replayGame(context, game)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+	if state.Final {
+		context.JSON(http.StatusConflict, helpers.ErrorResponse(errGameNotInProgress))
+		return
+	}
+	// participants enter and leave in the inning the play-by-play is in
+	if body.Inning != state.Inning {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(errWrongInning))
+		return
+	}
+	substitution := scoring.Event{Type: scoring.EventSubstitution, Substitution: &event}
+	fromAtbat, fromInning := len(state.PlateAppearances), state.Inning
+	if err := state.Apply(substitution); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	encoded, err := scoring.Encode(substitution)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	scorers := gameEventLog{events: events}.scorers()
+	scorers[state.Sequence] = payload.UserID
+
+	_, err = s.store.SubstituteTx(context, db.SubstituteTxParams{
+		GameID:     game.ID,
+		HomeTeam:   homeTeam,
+		Inning:     state.Inning,
+		Steps:      params,
+		Sequence:   state.Sequence,
+		Event:      db.GameEventParams{Type: string(scoring.EventSubstitution), Payload: encoded},
+		CreatedBy:  payload.UserID,
+		Projection: gameProjection(state, fromAtbat, fromInning, scorers),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusConflict, helpers.ErrorResponse(errGameChanged))
+			return
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			context.JSON(http.StatusConflict, helpers.ErrorResponse(errGameChanged))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	players, err := s.store.ListLineup(context, db.ListLineupParams{GameID: game.ID, HomeTeam: homeTeam})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, LineupResponse{
+		GameID:   game.ID,
+		TeamID:   teamID,
+		Side:     req.Side,
+		Locked:   true,
+		Players:  players,
+		Warnings: warnings,
+	})
+}
+
+// ListGameParticipants lists every player who has appeared in a game for each side,
+// including starters, substitutes and players who have left.
+func (s *Server) ListGameParticipants(context *gin.Context) {
+	var req GetGameRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	game, err := s.store.GetGame(context, uuid.MustParse(req.ID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	rows, err := s.store.ListGameParticipants(context, game.ID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	rsp := GameParticipantsResponse{
+		GameID: game.ID,
+		Home:   []db.ListGameParticipantsRow{},
+		Away:   []db.ListGameParticipantsRow{},
+	}
+	for _, row := range rows {
+		if row.HomeTeam {
+			rsp.Home = append(rsp.Home, row)
+		} else {
+			rsp.Away = append(rsp.Away, row)
+		}
+	}
+	context.JSON(http.StatusOK, rsp)
+}
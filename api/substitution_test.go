@@ -0,0 +1,517 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	mockdb "github.com/kwalter26/scoreit-api-go/db/mock"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/scoring"
+	"github.com/kwalter26/scoreit-api-go/security"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// randomParticipants returns a home starting nine for a game, with the pitcher batting last.
+func randomParticipants(gameID uuid.UUID) []db.ListGameParticipantsRow {
+	positions := []util.BaseballPosition{util.CenterField, util.SecondBase, util.FirstBase, util.ThirdBase, util.RightField, util.LeftField, util.ShortStop, util.Catcher, util.Pitcher}
+	rows := make([]db.ListGameParticipantsRow, len(positions))
+	for i, position := range positions {
+		rows[i] = db.ListGameParticipantsRow{
+			ID:            uuid.New(),
+			GameID:        gameID,
+			PlayerID:      uuid.New(),
+			HomeTeam:      true,
+			BatPosition:   int64(i + 1),
+			Position:      string(position),
+			Entry:         string(util.EntryStarter),
+			EnteredInning: 1,
+		}
+	}
+	return rows
+}
+
+func TestServer_Substitute(t *testing.T) {
+	user, _ := createRandomUser(t)
+	game := db.Game{ID: uuid.New(), HomeTeamID: uuid.New(), AwayTeamID: uuid.New(), Status: string(util.GameInProgress), ReentryRule: string(util.ReentryNone)}
+	scheduled := game
+	scheduled.Status = string(util.GameScheduled)
+
+	starters := randomParticipants(game.ID)
+	pitcher := starters[8]
+	reliever := uuid.New()
+
+	// the starting pitcher has already been relieved
+	relieved := append([]db.ListGameParticipantsRow{}, starters...)
+	relieved[8].ExitedInning = sql.NullInt64{Int64: 5, Valid: true}
+	relieved = append(relieved, db.ListGameParticipantsRow{ID: uuid.New(), PlayerID: reliever, HomeTeam: true, BatPosition: 9, Position: string(util.Pitcher), Entry: string(util.EntryPitchingChange), EnteredInning: 5})
+
+	pitchingChange := gin.H{
+		"inning": 1,
+		"substitutions": []gin.H{
+			{"kind": util.EntryPitchingChange, "out_player_id": pitcher.PlayerID, "in_player_id": reliever},
+		},
+	}
+
+	overridden := gin.H{
+		"inning":        1,
+		"substitutions": pitchingChange["substitutions"],
+		"override":      true,
+	}
+
+	testCases := []struct {
+		name          string
+		roles         []security.Role
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			roles: coachRoles,
+			body:  pitchingChange,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					ListGameParticipants(gomock.Any(), gomock.Eq(game.ID)).
+					Times(2).
+					Return(starters, nil)
+				store.EXPECT().
+					ListGameEvents(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return([]db.GameEvent{}, nil)
+				store.EXPECT().
+					IsEligibleForPosition(gomock.Any(), gomock.Eq(db.IsEligibleForPositionParams{TeamID: game.HomeTeamID, UserID: reliever, Position: string(util.Pitcher)})).
+					Times(1).
+					Return(true, nil)
+				expectEligibleLineup(store)
+				store.EXPECT().
+					SubstituteTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.SubstituteTxParams) (db.SubstituteTxResult, error) {
+						require.Equal(t, game.ID, arg.GameID)
+						require.True(t, arg.HomeTeam)
+						require.Equal(t, int64(1), arg.Inning)
+						require.Len(t, arg.Steps, 1)
+						step := arg.Steps[0]
+						require.Equal(t, pitcher.ID, step.Replaces)
+						require.Equal(t, reliever, step.PlayerID)
+						require.Equal(t, string(util.EntryPitchingChange), step.Entry)
+						require.Equal(t, int64(9), step.BatPosition)
+						require.NotEqual(t, uuid.Nil, step.ID)
+
+						// the play-by-play gets the change under the new participant's ID
+						require.Equal(t, int64(1), arg.Sequence)
+						event, err := scoring.Decode(arg.Event.Payload)
+						require.NoError(t, err)
+						require.Equal(t, []scoring.Change{{
+							ReplacesID:  pitcher.ID.String(),
+							InID:        step.ID.String(),
+							BatPosition: 9,
+							Position:    util.Pitcher,
+						}}, event.Substitution.Changes)
+						require.Equal(t, user.ID, arg.CreatedBy)
+						return db.SubstituteTxResult{}, nil
+					})
+				store.EXPECT().
+					ListLineup(gomock.Any(), gomock.Eq(db.ListLineupParams{GameID: game.ID, HomeTeam: true})).
+					Times(1).
+					Return([]db.ListLineupRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp LineupResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.True(t, rsp.Locked)
+				require.Equal(t, game.HomeTeamID, rsp.TeamID)
+			},
+		},
+		{
+			name:  "RelieverNotRested",
+			roles: coachRoles,
+			body:  pitchingChange,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					ListGameParticipants(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(starters, nil)
+				store.EXPECT().
+					ListPitchingAppearances(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.ListPitchingAppearancesParams) ([]db.ListPitchingAppearancesRow, error) {
+						require.Equal(t, reliever, arg.PlayerID)
+						return []db.ListPitchingAppearancesRow{tiredPitcher()}, nil
+					})
+				expectEligibleLineup(store)
+				store.EXPECT().
+					SubstituteTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+				var rsp LineupRejectedResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Len(t, rsp.Problems, 1)
+				require.Equal(t, reliever, rsp.Problems[0].PlayerID)
+			},
+		},
+		{
+			name:  "SuspendedWithOverride",
+			roles: adminUserRoles,
+			body:  overridden,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					ListGameParticipants(gomock.Any(), gomock.Eq(game.ID)).
+					Times(2).
+					Return(starters, nil)
+				store.EXPECT().
+					ListGameEvents(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return([]db.GameEvent{}, nil)
+				store.EXPECT().
+					GetCurrentPlayerStatus(gomock.Any(), gomock.Eq(db.GetCurrentPlayerStatusParams{TeamID: game.HomeTeamID, UserID: reliever})).
+					Times(1).
+					Return(db.PlayerStatus{Status: string(util.RosterStatusSuspended)}, nil)
+				expectEligibleLineup(store)
+				store.EXPECT().
+					SubstituteTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.SubstituteTxResult{}, nil)
+				store.EXPECT().
+					ListLineup(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListLineupRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp LineupResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Len(t, rsp.Warnings, 1)
+			},
+		},
+		{
+			name:  "OverrideByCoach",
+			roles: coachRoles,
+			body:  overridden,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "WrongInning",
+			roles: coachRoles,
+			body: gin.H{
+				"inning":        6,
+				"substitutions": pitchingChange["substitutions"],
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					ListGameParticipants(gomock.Any(), gomock.Eq(game.ID)).
+					Times(2).
+					Return(starters, nil)
+				store.EXPECT().
+					ListGameEvents(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return([]db.GameEvent{}, nil)
+				expectEligibleLineup(store)
+				store.EXPECT().
+					SubstituteTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), errWrongInning.Error())
+			},
+		},
+		{
+			name:  "NoReentry",
+			roles: coachRoles,
+			body: gin.H{
+				"inning": 7,
+				"substitutions": []gin.H{
+					{"kind": util.EntryPitchingChange, "out_player_id": reliever, "in_player_id": pitcher.PlayerID},
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					ListGameParticipants(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(relieved, nil)
+				store.EXPECT().
+					SubstituteTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "may not re-enter")
+			},
+		},
+		{
+			name:  "NotOnRoster",
+			roles: coachRoles,
+			body:  pitchingChange,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					ListGameParticipants(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(starters, nil)
+				store.EXPECT().
+					GetTeamMember(gomock.Any(), gomock.Eq(db.GetTeamMemberParams{TeamID: game.HomeTeamID, UserID: reliever})).
+					Times(1).
+					Return(db.TeamMember{}, sql.ErrNoRows)
+				store.EXPECT().
+					SubstituteTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+				var rsp LineupRejectedResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Len(t, rsp.Problems, 1)
+				require.Equal(t, reliever, rsp.Problems[0].PlayerID)
+			},
+		},
+		{
+			name:  "NotInProgress",
+			roles: coachRoles,
+			body:  pitchingChange,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(scheduled, nil)
+				store.EXPECT().
+					ListGameParticipants(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:  "ChangedConcurrently",
+			roles: coachRoles,
+			body:  pitchingChange,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					ListGameParticipants(gomock.Any(), gomock.Eq(game.ID)).
+					Times(2).
+					Return(starters, nil)
+				store.EXPECT().
+					ListGameEvents(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return([]db.GameEvent{}, nil)
+				expectEligibleLineup(store)
+				store.EXPECT().
+					SubstituteTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.SubstituteTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:  "AfterGameEnd",
+			roles: coachRoles,
+			body:  pitchingChange,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					ListGameParticipants(gomock.Any(), gomock.Eq(game.ID)).
+					Times(2).
+					Return(starters, nil)
+				store.EXPECT().
+					ListGameEvents(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return([]db.GameEvent{recordedEvent(t, game.ID, 1, scoring.Event{Type: scoring.EventGameEnd})}, nil)
+				expectEligibleLineup(store)
+				store.EXPECT().
+					SubstituteTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+				require.Contains(t, recorder.Body.String(), errGameNotInProgress.Error())
+			},
+		},
+		{
+			name:  "InvalidKind",
+			roles: coachRoles,
+			body: gin.H{
+				"inning": 6,
+				"substitutions": []gin.H{
+					{"kind": "courtesy_runner", "out_player_id": pitcher.PlayerID, "in_player_id": reliever},
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "NotCoach",
+			roles: security.UserRoles,
+			body:  pitchingChange,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectTeamCoach(store, user.ID)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			buf, err := buildJsonRequest(t, tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/games/%s/lineups/home/substitutions", game.ID)
+			request, err := http.NewRequest(http.MethodPost, url, &buf)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, tc.roles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_ListGameParticipants(t *testing.T) {
+	user, _ := createRandomUser(t)
+	game := db.Game{ID: uuid.New(), HomeTeamID: uuid.New(), AwayTeamID: uuid.New(), Status: string(util.GameInProgress)}
+	rows := randomParticipants(game.ID)
+	rows[0].HomeTeam = false
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(game, nil)
+				store.EXPECT().
+					ListGameParticipants(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(rows, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp GameParticipantsResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Len(t, rsp.Home, 8)
+				require.Len(t, rsp.Away, 1)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGame(gomock.Any(), gomock.Eq(game.ID)).
+					Times(1).
+					Return(db.Game{}, sql.ErrNoRows)
+				store.EXPECT().
+					ListGameParticipants(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/games/%s/participants", game.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -2,24 +2,46 @@ package api
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/kwalter26/scoreit-api-go/api/helpers"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
 	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/lib/pq"
+	"net/http"
+	"strings"
+	"time"
 )
 
 // ListTeamsRequest represents a request to list teams.
 type ListTeamsRequest struct {
-	PageSize int32 `form:"page_size,default=5" binding:"max=100,min=1"`
-	PageID   int32 `form:"page_id,default=1" binding:"min=1"`
+	PageSize        int32 `form:"page_size,default=5" binding:"max=100,min=1"`
+	PageID          int32 `form:"page_id,default=1" binding:"min=1"`
+	IncludeArchived bool  `form:"include_archived"`
 }
 
 // TeamResponse represents a response from a team request.
 type TeamResponse struct {
-	ID   uuid.UUID `json:"id"`
-	Name string    `json:"name"`
+	ID             uuid.UUID  `json:"id"`
+	Name           string     `json:"name"`
+	Abbreviation   string     `json:"abbreviation,omitempty"`
+	PrimaryColor   string     `json:"primary_color,omitempty"`
+	SecondaryColor string     `json:"secondary_color,omitempty"`
+	HomeVenue      string     `json:"home_venue,omitempty"`
+	City           string     `json:"city,omitempty"`
+	Division       string     `json:"division,omitempty"`
+	LogoURL        string     `json:"logo_url,omitempty"`
+	WebsiteURL     string     `json:"website_url,omitempty"`
+	FacebookURL    string     `json:"facebook_url,omitempty"`
+	InstagramURL   string     `json:"instagram_url,omitempty"`
+	XURL           string     `json:"x_url,omitempty"`
+	ArchivedAt     *time.Time `json:"archived_at,omitempty"`
 }
 
+// ListTeams lists teams. Archived teams are left out unless include_archived is set.
 func (s *Server) ListTeams(context *gin.Context) {
 	var req ListTeamsRequest
 	if err := context.ShouldBindQuery(&req); err != nil {
@@ -28,8 +50,9 @@ func (s *Server) ListTeams(context *gin.Context) {
 	}
 
 	arg := db.ListTeamsParams{
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+		Limit:           req.PageSize,
+		Offset:          (req.PageID - 1) * req.PageSize,
+		IncludeArchived: req.IncludeArchived,
 	}
 
 	teams, err := s.store.ListTeams(context, arg)
@@ -48,10 +71,25 @@ func (s *Server) ListTeams(context *gin.Context) {
 
 // NewTeamResponse creates a new TeamResponse from a db.Team.
 func NewTeamResponse(team db.Team) TeamResponse {
-	return TeamResponse{
-		ID:   team.ID,
-		Name: team.Name,
+	rsp := TeamResponse{
+		ID:             team.ID,
+		Name:           team.Name,
+		Abbreviation:   team.Abbreviation,
+		PrimaryColor:   team.PrimaryColor,
+		SecondaryColor: team.SecondaryColor,
+		HomeVenue:      team.HomeVenue,
+		City:           team.City,
+		Division:       team.Division,
+		LogoURL:        team.LogoUrl,
+		WebsiteURL:     team.WebsiteUrl,
+		FacebookURL:    team.FacebookUrl,
+		InstagramURL:   team.InstagramUrl,
+		XURL:           team.XUrl,
+	}
+	if team.ArchivedAt.Valid {
+		rsp.ArchivedAt = &team.ArchivedAt.Time
 	}
+	return rsp
 }
 
 // AddTeamMemberRequest represents a request to add a user to a team.
@@ -62,11 +100,51 @@ type AddTeamMemberRequest struct {
 
 // AddTeamRequestBody represents the body of a request to add a team.
 type AddTeamRequestBody struct {
-	Number          int64  `json:"number" binding:"required,min=0"`
+	Number          int64  `json:"number" binding:"required,min=0,max=99"`
 	PrimaryPosition string `json:"primary_position" binding:"required"`
 }
 
-// AddTeamMember adds a user to a team.
+// Roster constraint names, used to explain unique violations on team_members.
+const (
+	teamMemberUserConstraint   = "team_members_team_id_user_id_idx"
+	teamMemberNumberConstraint = "team_members_team_id_number_idx"
+)
+
+var (
+	errInvalidPosition  = errors.New("primary_position is not a valid baseball position")
+	errAlreadyOnTeam    = errors.New("user is already on this team")
+	errJerseyNumberUsed = errors.New("jersey number is already taken on this team")
+)
+
+// rosterError maps a database error from a roster change to a response.
+func rosterError(context *gin.Context, err error) {
+	if errors.Is(err, sql.ErrNoRows) {
+		context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+		return
+	}
+	if pgErr, ok := err.(*pq.Error); ok {
+		switch pgErr.Code.Name() {
+		case "unique_violation":
+			switch pgErr.Constraint {
+			case teamMemberUserConstraint:
+				context.JSON(http.StatusConflict, helpers.ErrorResponse(errAlreadyOnTeam))
+			case teamMemberNumberConstraint:
+				context.JSON(http.StatusConflict, helpers.ErrorResponse(errJerseyNumberUsed))
+			default:
+				context.JSON(http.StatusConflict, helpers.ErrorResponse(pgErr))
+			}
+			return
+		case "foreign_key_violation":
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(pgErr))
+			return
+		}
+	}
+	context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+}
+
+// AddTeamMember adds a user to a team and records the signing in the team's transaction log.
+// A user can only be on a team once and jersey numbers are unique within a team.
+// Only the team's coaches and admins may add members; players join through invitations and join requests.
 func (s *Server) AddTeamMember(context *gin.Context) {
 	var req AddTeamMemberRequest
 	if err := context.ShouldBindUri(&req); err != nil {
@@ -80,24 +158,122 @@ func (s *Server) AddTeamMember(context *gin.Context) {
 		return
 	}
 
+	if !util.IsBaseballPosition(body.PrimaryPosition) {
+		context.JSON(400, helpers.ErrorResponse(errInvalidPosition))
+		return
+	}
+
 	userId := uuid.MustParse(req.UserID)
 
 	teamId := uuid.MustParse(req.TeamID)
 
-	arg := db.AddTeamMemberParams{
-		UserID:          userId,
-		TeamID:          teamId,
-		Number:          body.Number,
-		PrimaryPosition: body.PrimaryPosition,
+	if !s.authorizeTeamCoach(context, teamId) {
+		return
+	}
+
+	arg := db.RosterTransactionTxParams{
+		CreateRosterTransactionParams: db.CreateRosterTransactionParams{
+			Type:        string(util.RosterSigning),
+			UserID:      userId,
+			ToTeamID:    uuid.NullUUID{UUID: teamId, Valid: true},
+			EffectiveAt: time.Now(),
+		},
+		Number:          sql.NullInt64{Int64: body.Number, Valid: true},
+		PrimaryPosition: sql.NullString{String: body.PrimaryPosition, Valid: true},
+		Status:          string(util.RosterStatusActive),
+	}
+
+	result, ok := s.runRosterTransaction(context, arg)
+	if !ok {
+		return
+	}
+
+	context.JSON(200, result.Member)
+}
+
+// UpdateTeamMemberRequestBody represents the body of a request to update a team member.
+type UpdateTeamMemberRequestBody struct {
+	Number          *int64 `json:"number" binding:"omitempty,min=0,max=99"`
+	PrimaryPosition string `json:"primary_position"`
+	Role            string `json:"role" binding:"omitempty,oneof=player coach manager"`
+}
+
+// UpdateTeamMember changes a member's jersey number, primary position or team role.
+// A new number or position is logged as a member_update roster transaction.
+// Only the team's coaches and admins may update the roster.
+func (s *Server) UpdateTeamMember(context *gin.Context) {
+	var req AddTeamMemberRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var body UpdateTeamMemberRequestBody
+	if err := context.ShouldBindJSON(&body); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	if body.PrimaryPosition != "" && !util.IsBaseballPosition(body.PrimaryPosition) {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(errInvalidPosition))
+		return
+	}
+
+	if !s.authorizeTeamCoach(context, uuid.MustParse(req.TeamID)) {
+		return
 	}
 
-	userTeam, err := s.store.AddTeamMember(context, arg)
+	arg := db.UpdateTeamMemberParams{
+		TeamID:          uuid.MustParse(req.TeamID),
+		UserID:          uuid.MustParse(req.UserID),
+		PrimaryPosition: sql.NullString{String: body.PrimaryPosition, Valid: body.PrimaryPosition != ""},
+		Role:            sql.NullString{String: body.Role, Valid: body.Role != ""},
+	}
+	if body.Number != nil {
+		arg.Number = sql.NullInt64{Int64: *body.Number, Valid: true}
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	result, err := s.store.UpdateTeamMemberTx(context, db.UpdateTeamMemberTxParams{
+		UpdateTeamMemberParams: arg,
+		ChangedBy:              payload.UserID,
+	})
 	if err != nil {
-		context.JSON(500, helpers.ErrorResponse(err))
+		rosterError(context, err)
 		return
 	}
 
-	context.JSON(200, userTeam)
+	context.JSON(http.StatusOK, result.Member)
+}
+
+// RemoveTeamMember takes a user off a team's roster and records the release in the team's transaction log.
+// The team's coaches and admins may remove anyone; players may remove themselves.
+func (s *Server) RemoveTeamMember(context *gin.Context) {
+	var req AddTeamMemberRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	userID := uuid.MustParse(req.UserID)
+	if payload.UserID != userID && !s.authorizeTeamCoach(context, uuid.MustParse(req.TeamID)) {
+		return
+	}
+
+	result, ok := s.runRosterTransaction(context, db.RosterTransactionTxParams{
+		CreateRosterTransactionParams: db.CreateRosterTransactionParams{
+			Type:        string(util.RosterRelease),
+			UserID:      userID,
+			FromTeamID:  uuid.NullUUID{UUID: uuid.MustParse(req.TeamID), Valid: true},
+			EffectiveAt: time.Now(),
+		},
+	})
+	if !ok {
+		return
+	}
+
+	context.JSON(http.StatusOK, result.Member)
 }
 
 // GetTeamRequest represents a request to get a team.
@@ -125,7 +301,7 @@ func (s *Server) GetTeam(context *gin.Context) {
 		return
 	}
 
-	context.JSON(200, team)
+	context.JSON(200, NewTeamResponse(team))
 }
 
 // CreateTeamRequest represents a request to create a team.
@@ -147,7 +323,7 @@ func (s *Server) CreateTeam(context *gin.Context) {
 		return
 	}
 
-	context.JSON(200, team)
+	context.JSON(200, NewTeamResponse(team))
 }
 
 // ListTeamMembersRequest represents a request to list team members.
@@ -161,7 +337,7 @@ type ListTeamMembersRequestQuery struct {
 	PageId   int32 `form:"page_id,default=1" binding:"min=1"`
 }
 
-// ListTeamMembers lists team members.
+// ListTeamMembers lists the members of a team whose profiles are visible to the caller.
 func (s *Server) ListTeamMembers(context *gin.Context) {
 	var req ListTeamMembersRequest
 	if err := context.ShouldBindUri(&req); err != nil {
@@ -176,11 +352,14 @@ func (s *Server) ListTeamMembers(context *gin.Context) {
 	}
 
 	id := uuid.MustParse(req.ID)
+	payload := middleware.GetAuthorizationPayload(context)
 
 	arg := db.ListTeamMembersParams{
-		TeamID: id,
-		Limit:  query.PageSize * (query.PageId),
-		Offset: query.PageId - 1,
+		TeamID:         id,
+		Limit:          query.PageSize,
+		Offset:         (query.PageId - 1) * query.PageSize,
+		IncludePrivate: isAdmin(payload),
+		ViewerID:       payload.UserID,
 	}
 
 	members, err := s.store.ListTeamMembers(context, arg)
@@ -189,5 +368,258 @@ func (s *Server) ListTeamMembers(context *gin.Context) {
 		return
 	}
 
+	if arg.IncludePrivate {
+		hidden := false
+		for _, row := range members {
+			hidden = hidden || row.Hidden
+		}
+		if hidden {
+			if err := s.auditPrivacyBypass(context, payload, uuid.NullUUID{}); err != nil {
+				context.JSON(500, helpers.ErrorResponse(err))
+				return
+			}
+		}
+	}
+
 	context.JSON(200, members)
 }
+
+// UpdateTeamRequestBody represents the body of a request to update a team's name and branding.
+// Fields left out are unchanged; optional branding fields are cleared by sending an empty string.
+type UpdateTeamRequestBody struct {
+	Name           *string `json:"name" binding:"omitempty,min=1,max=100"`
+	Abbreviation   *string `json:"abbreviation" binding:"omitempty,max=5,eq=|min=2,eq=|alphanum"`
+	PrimaryColor   *string `json:"primary_color"`
+	SecondaryColor *string `json:"secondary_color"`
+	HomeVenue      *string `json:"home_venue" binding:"omitempty,max=100"`
+	City           *string `json:"city" binding:"omitempty,max=100"`
+	Division       *string `json:"division" binding:"omitempty,max=50"`
+	LogoURL        *string `json:"logo_url" binding:"omitempty,eq=|url"`
+	WebsiteURL     *string `json:"website_url" binding:"omitempty,eq=|url"`
+	FacebookURL    *string `json:"facebook_url" binding:"omitempty,eq=|url"`
+	InstagramURL   *string `json:"instagram_url" binding:"omitempty,eq=|url"`
+	XURL           *string `json:"x_url" binding:"omitempty,eq=|url"`
+}
+
+var (
+	errNoTeamChanges = errors.New("no team fields to update")
+	errBlankTeamName = errors.New("name must not be blank")
+)
+
+// teamColor validates an optional team color, normalizing it to lowercase #rrggbb. An empty color clears it.
+func teamColor(field string, color *string) (sql.NullString, error) {
+	if color == nil {
+		return sql.NullString{}, nil
+	}
+	if *color == "" {
+		return sql.NullString{Valid: true}, nil
+	}
+	normalized, ok := util.NormalizeHexColor(*color)
+	if !ok {
+		return sql.NullString{}, fmt.Errorf("%s must be a hex color such as #003366", field)
+	}
+	return sql.NullString{String: normalized, Valid: true}, nil
+}
+
+// optionalString converts an optional request field to the nullable argument of an update query.
+func optionalString(value *string) sql.NullString {
+	if value == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: strings.TrimSpace(*value), Valid: true}
+}
+
+// UpdateTeam renames a team and edits its branding. Only the team's coaches and admins may update it.
+func (s *Server) UpdateTeam(context *gin.Context) {
+	var req GetTeamRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var body UpdateTeamRequestBody
+	if err := context.ShouldBindJSON(&body); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	if !s.authorizeTeamCoach(context, uuid.MustParse(req.ID)) {
+		return
+	}
+
+	arg := db.UpdateTeamParams{
+		ID:           uuid.MustParse(req.ID),
+		Name:         optionalString(body.Name),
+		Abbreviation: optionalString(body.Abbreviation),
+		HomeVenue:    optionalString(body.HomeVenue),
+		City:         optionalString(body.City),
+		Division:     optionalString(body.Division),
+		LogoUrl:      optionalString(body.LogoURL),
+		WebsiteUrl:   optionalString(body.WebsiteURL),
+		FacebookUrl:  optionalString(body.FacebookURL),
+		InstagramUrl: optionalString(body.InstagramURL),
+		XUrl:         optionalString(body.XURL),
+	}
+	arg.Abbreviation.String = strings.ToUpper(arg.Abbreviation.String)
+
+	var err error
+	if arg.PrimaryColor, err = teamColor("primary_color", body.PrimaryColor); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+	if arg.SecondaryColor, err = teamColor("secondary_color", body.SecondaryColor); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	if arg == (db.UpdateTeamParams{ID: arg.ID}) {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(errNoTeamChanges))
+		return
+	}
+	if arg.Name.Valid && arg.Name.String == "" {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(errBlankTeamName))
+		return
+	}
+
+	team, err := s.store.UpdateTeam(context, arg)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		if pgErr, ok := err.(*pq.Error); ok {
+			switch pgErr.Code.Name() {
+			case "unique_violation":
+				context.JSON(http.StatusConflict, helpers.ErrorResponse(pgErr))
+				return
+			}
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, NewTeamResponse(team))
+}
+
+// ArchiveTeam hides a team from listings and search while keeping its history.
+// Only the team's coaches and admins may archive it.
+func (s *Server) ArchiveTeam(context *gin.Context) {
+	s.setTeamArchived(context, true)
+}
+
+// UnarchiveTeam restores an archived team. Only the team's coaches and admins may unarchive it.
+func (s *Server) UnarchiveTeam(context *gin.Context) {
+	s.setTeamArchived(context, false)
+}
+
+func (s *Server) setTeamArchived(context *gin.Context, archived bool) {
+	var req GetTeamRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	if !s.authorizeTeamCoach(context, uuid.MustParse(req.ID)) {
+		return
+	}
+
+	var team db.Team
+	var err error
+	if archived {
+		team, err = s.store.ArchiveTeam(context, uuid.MustParse(req.ID))
+	} else {
+		team, err = s.store.UnarchiveTeam(context, uuid.MustParse(req.ID))
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, NewTeamResponse(team))
+}
+
+// DeleteTeamBlocker describes records that prevent a team from being deleted.
+type DeleteTeamBlocker struct {
+	Type  string `json:"type"`
+	Count int64  `json:"count"`
+}
+
+// DeleteTeamConflictResponse is returned when a team still has history and cannot be deleted.
+type DeleteTeamConflictResponse struct {
+	Error    string              `json:"error"`
+	Blockers []DeleteTeamBlocker `json:"blockers"`
+}
+
+// errTeamNotEmpty is reported when deleting a team that still has members, games or roster history.
+var errTeamNotEmpty = errors.New("team has members, games or roster history; archive it instead")
+
+// DeleteTeam permanently deletes a team that has no members, games or roster history.
+// Teams with history must be archived instead. Only the team's coaches and admins may delete it.
+func (s *Server) DeleteTeam(context *gin.Context) {
+	var req GetTeamRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	if !s.authorizeTeamCoach(context, uuid.MustParse(req.ID)) {
+		return
+	}
+
+	id := uuid.MustParse(req.ID)
+
+	if _, err := s.store.GetTeam(context, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	counts, err := s.store.GetTeamDeleteBlockers(context, id)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	blockers := make([]DeleteTeamBlocker, 0, 4)
+	if counts.MemberCount > 0 {
+		blockers = append(blockers, DeleteTeamBlocker{Type: "members", Count: counts.MemberCount})
+	}
+	if counts.GameCount > 0 {
+		blockers = append(blockers, DeleteTeamBlocker{Type: "games", Count: counts.GameCount})
+	}
+	if counts.StintCount > 0 {
+		blockers = append(blockers, DeleteTeamBlocker{Type: "roster_stints", Count: counts.StintCount})
+	}
+	if counts.TransactionCount > 0 {
+		blockers = append(blockers, DeleteTeamBlocker{Type: "roster_transactions", Count: counts.TransactionCount})
+	}
+	if len(blockers) > 0 {
+		context.JSON(http.StatusConflict, DeleteTeamConflictResponse{
+			Error:    errTeamNotEmpty.Error(),
+			Blockers: blockers,
+		})
+		return
+	}
+
+	if err := s.store.DeleteTeam(context, id); err != nil {
+		if pgErr, ok := err.(*pq.Error); ok {
+			switch pgErr.Code.Name() {
+			case "foreign_key_violation":
+				// something was attached to the team after the blocker check
+				context.JSON(http.StatusConflict, helpers.ErrorResponse(errTeamNotEmpty))
+				return
+			}
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, nil)
+}
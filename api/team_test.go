@@ -14,6 +14,7 @@ import (
 	"github.com/kwalter26/scoreit-api-go/security"
 	"github.com/kwalter26/scoreit-api-go/security/token"
 	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 	"net/http"
 	"net/http/httptest"
@@ -295,9 +296,10 @@ func TestServer_ListTeamMembers(t *testing.T) {
 			pageID:   1,
 			buildStubs: func(store *mockdb.MockStore) {
 				arg := db.ListTeamMembersParams{
-					TeamID: team.ID,
-					Limit:  5,
-					Offset: 0,
+					TeamID:   team.ID,
+					Limit:    5,
+					Offset:   0,
+					ViewerID: user.ID,
 				}
 				store.EXPECT().
 					ListTeamMembers(gomock.Any(), gomock.Eq(arg)).
@@ -344,6 +346,30 @@ func TestServer_ListTeamMembers(t *testing.T) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
+		{
+			name:     "SecondPage",
+			teamID:   team.ID.String(),
+			pageSize: 5,
+			pageID:   3,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListTeamMembersParams{
+					TeamID:   team.ID,
+					Limit:    5,
+					Offset:   10,
+					ViewerID: user.ID,
+				}
+				store.EXPECT().
+					ListTeamMembers(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(teamMembers, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
 		{
 			name:     "InternalError",
 			teamID:   team.ID.String(),
@@ -351,9 +377,10 @@ func TestServer_ListTeamMembers(t *testing.T) {
 			pageID:   1,
 			buildStubs: func(store *mockdb.MockStore) {
 				arg := db.ListTeamMembersParams{
-					TeamID: team.ID,
-					Limit:  5,
-					Offset: 0,
+					TeamID:   team.ID,
+					Limit:    5,
+					Offset:   0,
+					ViewerID: user.ID,
 				}
 				store.EXPECT().
 					ListTeamMembers(gomock.Any(), gomock.Eq(arg)).
@@ -464,16 +491,21 @@ func TestServer_AddTeamMember(t *testing.T) {
 				"primary_position": position,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				arg := db.AddTeamMemberParams{
-					UserID:          user.ID,
-					TeamID:          team.ID,
-					Number:          5,
-					PrimaryPosition: position,
+				arg := db.RosterTransactionTxParams{
+					CreateRosterTransactionParams: db.CreateRosterTransactionParams{
+						Type:      string(util.RosterSigning),
+						UserID:    user.ID,
+						ToTeamID:  uuid.NullUUID{UUID: team.ID, Valid: true},
+						CreatedBy: user.ID,
+					},
+					Number:          sql.NullInt64{Int64: 5, Valid: true},
+					PrimaryPosition: sql.NullString{String: position, Valid: true},
+					Status:          string(util.RosterStatusActive),
 				}
 				store.EXPECT().
-					AddTeamMember(gomock.Any(), gomock.Eq(arg)).
+					RosterTransactionTx(gomock.Any(), EqRosterTransactionTxParamsMatcher(arg)).
 					Times(1).
-					Return(db.TeamMember{
+					Return(db.RosterTransactionTxResult{Member: db.TeamMember{
 						ID:              uuid.UUID{},
 						Number:          5,
 						PrimaryPosition: position,
@@ -481,16 +513,36 @@ func TestServer_AddTeamMember(t *testing.T) {
 						TeamID:          team.ID,
 						CreatedAt:       time.Now(),
 						UpdatedAt:       time.Now(),
-					}, nil)
+					}}, nil)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
 
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
+		{
+			name:   "NotCoach",
+			teamID: team.ID.String(),
+			userID: user.ID.String(),
+			body: gin.H{
+				"number":           5,
+				"primary_position": position,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RosterTransactionTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
 		{
 			name:   "NoAuthorization",
 			teamID: team.ID.String(),
@@ -521,7 +573,7 @@ func TestServer_AddTeamMember(t *testing.T) {
 				// no expectations
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
 
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -540,7 +592,7 @@ func TestServer_AddTeamMember(t *testing.T) {
 				// no expectations
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
 
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -559,7 +611,7 @@ func TestServer_AddTeamMember(t *testing.T) {
 				// no expectations
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
 
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -577,7 +629,7 @@ func TestServer_AddTeamMember(t *testing.T) {
 				// no expectations
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
 
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -596,7 +648,7 @@ func TestServer_AddTeamMember(t *testing.T) {
 				// no expectations
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
 
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -614,13 +666,96 @@ func TestServer_AddTeamMember(t *testing.T) {
 				// no expectations
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
 
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
+		{
+			name:   "AlreadyOnTeam",
+			teamID: team.ID.String(),
+			userID: user.ID.String(),
+			body: gin.H{
+				"number":           5,
+				"primary_position": position,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RosterTransactionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RosterTransactionTxResult{}, &pq.Error{Code: "23505", Constraint: "team_members_team_id_user_id_idx"})
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:   "JerseyNumberTaken",
+			teamID: team.ID.String(),
+			userID: user.ID.String(),
+			body: gin.H{
+				"number":           5,
+				"primary_position": position,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RosterTransactionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RosterTransactionTxResult{}, &pq.Error{Code: "23505", Constraint: "team_members_team_id_number_idx"})
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:   "InvalidPosition",
+			teamID: team.ID.String(),
+			userID: user.ID.String(),
+			body: gin.H{
+				"number":           5,
+				"primary_position": "GOALIE",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RosterTransactionTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "UnknownTeam",
+			teamID: team.ID.String(),
+			userID: user.ID.String(),
+			body: gin.H{
+				"number":           5,
+				"primary_position": position,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RosterTransactionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RosterTransactionTxResult{}, &pq.Error{Code: "23503"})
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
 		{
 			name:   "InternalError",
 			teamID: team.ID.String(),
@@ -630,19 +765,13 @@ func TestServer_AddTeamMember(t *testing.T) {
 				"primary_position": position,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				arg := db.AddTeamMemberParams{
-					TeamID:          team.ID,
-					UserID:          user.ID,
-					Number:          5,
-					PrimaryPosition: position,
-				}
 				store.EXPECT().
-					AddTeamMember(gomock.Any(), gomock.Eq(arg)).
+					RosterTransactionTx(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(db.TeamMember{}, sql.ErrConnDone)
+					Return(db.RosterTransactionTxResult{}, sql.ErrConnDone)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
 
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -660,6 +789,7 @@ func TestServer_AddTeamMember(t *testing.T) {
 
 			store := mockdb.NewMockStore(ctrl)
 			tc.buildStubs(store)
+			expectTeamCoach(store, user.ID)
 
 			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
@@ -679,78 +809,174 @@ func TestServer_AddTeamMember(t *testing.T) {
 	}
 }
 
-func TestServer_GetTeam(t *testing.T) {
-
+func TestServer_UpdateTeamMember(t *testing.T) {
 	user, _ := createRandomUser(t)
 	team := randomTeam()
+	number := int64(12)
+	member := db.TeamMember{
+		ID:              uuid.New(),
+		Number:          number,
+		PrimaryPosition: string(util.Catcher),
+		UserID:          user.ID,
+		TeamID:          team.ID,
+	}
 
 	testCases := []struct {
 		name          string
-		teamID        string
-		setupAuth     func(t *testing.T, request *http.Request, tokenMake token.Maker)
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
 		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(recorder *httptest.ResponseRecorder)
 	}{
 		{
-			name:   "OK",
-			teamID: team.ID.String(),
+			name: "OK",
+			body: gin.H{"number": number, "primary_position": util.Catcher},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
 			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateTeamMemberTxParams{
+					UpdateTeamMemberParams: db.UpdateTeamMemberParams{
+						Number:          sql.NullInt64{Int64: number, Valid: true},
+						PrimaryPosition: sql.NullString{String: string(util.Catcher), Valid: true},
+						TeamID:          team.ID,
+						UserID:          user.ID,
+					},
+					ChangedBy: user.ID,
+				}
 				store.EXPECT().
-					GetTeam(gomock.Any(), gomock.Eq(team.ID)).
+					UpdateTeamMemberTx(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
-					Return(team, nil)
+					Return(db.UpdateTeamMemberTxResult{Member: member}, nil)
 			},
-			setupAuth: func(t *testing.T, request *http.Request, tokenMake token.Maker) {
-				addAuthorization(t, request, tokenMake, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NumberZero",
+			body: gin.H{"number": 0},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateTeamMemberTxParams{
+					UpdateTeamMemberParams: db.UpdateTeamMemberParams{
+						Number: sql.NullInt64{Int64: 0, Valid: true},
+						TeamID: team.ID,
+						UserID: user.ID,
+					},
+					ChangedBy: user.ID,
+				}
+				store.EXPECT().
+					UpdateTeamMemberTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.UpdateTeamMemberTxResult{Member: member}, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchTeam(t, recorder.Body, team)
 			},
 		},
 		{
-			name:   "NotFound",
-			teamID: team.ID.String(),
+			name: "Role",
+			body: gin.H{"role": util.TeamRoleCoach},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
 			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateTeamMemberTxParams{
+					UpdateTeamMemberParams: db.UpdateTeamMemberParams{
+						Role:   sql.NullString{String: string(util.TeamRoleCoach), Valid: true},
+						TeamID: team.ID,
+						UserID: user.ID,
+					},
+					ChangedBy: user.ID,
+				}
 				store.EXPECT().
-					GetTeam(gomock.Any(), gomock.Eq(team.ID)).
+					UpdateTeamMemberTx(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
-					Return(db.Team{}, sql.ErrNoRows)
+					Return(db.UpdateTeamMemberTxResult{Member: member}, nil)
 			},
-			setupAuth: func(t *testing.T, request *http.Request, tokenMake token.Maker) {
-				addAuthorization(t, request, tokenMake, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidRole",
+			body: gin.H{"role": "owner"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateTeamMemberTx(gomock.Any(), gomock.Any()).
+					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusNotFound, recorder.Code)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
 		{
-			name:   "InvalidID",
-			teamID: "invalid_id",
+			name: "JerseyNumberTaken",
+			body: gin.H{"number": number},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
 			buildStubs: func(store *mockdb.MockStore) {
-				// no expectations
+				store.EXPECT().
+					UpdateTeamMemberTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UpdateTeamMemberTxResult{}, &pq.Error{Code: "23505", Constraint: teamMemberNumberConstraint})
 			},
-			setupAuth: func(t *testing.T, request *http.Request, tokenMake token.Maker) {
-				addAuthorization(t, request, tokenMake, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+				require.Contains(t, recorder.Body.String(), errJerseyNumberUsed.Error())
+			},
+		},
+		{
+			name: "InvalidPosition",
+			body: gin.H{"primary_position": "GOALIE"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateTeamMemberTx(gomock.Any(), gomock.Any()).
+					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
 		{
-			name:   "InternalError",
-			teamID: team.ID.String(),
+			name: "NotMember",
+			body: gin.H{"number": number},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					GetTeam(gomock.Any(), gomock.Eq(team.ID)).
+					UpdateTeamMemberTx(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(db.Team{}, sql.ErrConnDone)
+					Return(db.UpdateTeamMemberTxResult{}, sql.ErrNoRows)
 			},
-			setupAuth: func(t *testing.T, request *http.Request, tokenMake token.Maker) {
-				addAuthorization(t, request, tokenMake, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "NotCoach",
+			body: gin.H{"number": number},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateTeamMemberTx(gomock.Any(), gomock.Any()).
+					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusForbidden, recorder.Code)
 			},
 		},
 	}
@@ -763,13 +989,17 @@ func TestServer_GetTeam(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mockdb.NewMockStore(ctrl)
+			expectTeamCoach(store, user.ID)
 			tc.buildStubs(store)
 
 			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
-			url := fmt.Sprintf("/api/v1/teams/%s", tc.teamID)
-			request, err := http.NewRequest(http.MethodGet, url, nil)
+			buf, err := buildJsonRequest(t, tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/teams/%s/members/%s", team.ID, user.ID)
+			request, err := http.NewRequest(http.MethodPatch, url, &buf)
 			require.NoError(t, err)
 
 			tc.setupAuth(t, request, server.tokenMaker)
@@ -779,66 +1009,816 @@ func TestServer_GetTeam(t *testing.T) {
 	}
 }
 
-func requireBodyMatchUserTeams(t *testing.T, body *bytes.Buffer, i int) {
-	teamMembers := make([]db.TeamMember, 5)
-	err := json.NewDecoder(body).Decode(&teamMembers)
-	require.NoError(t, err)
-	require.NoError(t, err)
-	for _, member := range teamMembers {
-		require.NotEmpty(t, member)
-	}
-	require.Equal(t, i, len(teamMembers))
-}
-
-func randomTeamMember(user db.User, team db.Team) db.ListTeamMembersRow {
-	return db.ListTeamMembersRow{
-		ID:              uuid.New(),
-		FirstName:       user.FirstName,
-		LastName:        user.LastName,
-		Number:          0,
-		PrimaryPosition: string(util.RandomBaseballPosition()),
-		TeamName:        team.Name,
-	}
-}
-
-func requireBodyMatchTeam(t *testing.T, body *bytes.Buffer, team db.Team) {
-	var createdUser db.Team
-	err := json.NewDecoder(body).Decode(&createdUser)
-	require.NoError(t, err)
-
-	require.Equal(t, team.Name, createdUser.Name)
-	require.NotEmpty(t, createdUser.ID)
-	require.WithinDurationf(t, createdUser.CreatedAt.UTC(), time.Now().UTC(), time.Second, "now should be within 5 seconds of CreatedAt")
-}
-
-func requireBodyMatchTeams(t *testing.T, body *bytes.Buffer, i int) {
-	teams := make([]db.Team, 5)
-	err := json.NewDecoder(body).Decode(&teams)
-	require.NoError(t, err)
-	require.NoError(t, err)
-	for _, account := range teams {
-		require.NotEmpty(t, account)
-	}
-	require.Equal(t, i, len(teams))
-}
-
-func randomTeams(i int) []db.Team {
-	teams := make([]db.Team, i)
-	for i := range teams {
-		teams[i] = randomTeam()
-	}
-	return teams
-
-}
+func TestServer_RemoveTeamMember(t *testing.T) {
+	user, _ := createRandomUser(t)
+	other, _ := createRandomUser(t)
+	team := randomTeam()
 
-func randomTeam() db.Team {
-	return db.Team{
-		ID:        uuid.New(),
-		Name:      util.RandomString(5),
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}
-}
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Self",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RosterTransactionTx(gomock.Any(), EqRosterTransactionTxParamsMatcher(db.RosterTransactionTxParams{
+						CreateRosterTransactionParams: db.CreateRosterTransactionParams{
+							Type:       string(util.RosterRelease),
+							UserID:     user.ID,
+							FromTeamID: uuid.NullUUID{UUID: team.ID, Valid: true},
+							CreatedBy:  user.ID,
+						},
+					})).
+					Times(1).
+					Return(db.RosterTransactionTxResult{Member: db.TeamMember{TeamID: team.ID, UserID: user.ID}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Coach",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, other.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RosterTransactionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RosterTransactionTxResult{Member: db.TeamMember{TeamID: team.ID, UserID: user.ID}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "OtherPlayerForbidden",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, other.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RosterTransactionTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "NotMember",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RosterTransactionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RosterTransactionTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectTeamCoach(store, other.ID)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/teams/%s/members/%s", team.ID, user.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_GetTeam(t *testing.T) {
+
+	user, _ := createRandomUser(t)
+	team := randomTeam()
+
+	testCases := []struct {
+		name          string
+		teamID        string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMake token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			teamID: team.ID.String(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTeam(gomock.Any(), gomock.Eq(team.ID)).
+					Times(1).
+					Return(team, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMake token.Maker) {
+				addAuthorization(t, request, tokenMake, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchTeam(t, recorder.Body, team)
+			},
+		},
+		{
+			name:   "NotFound",
+			teamID: team.ID.String(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTeam(gomock.Any(), gomock.Eq(team.ID)).
+					Times(1).
+					Return(db.Team{}, sql.ErrNoRows)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMake token.Maker) {
+				addAuthorization(t, request, tokenMake, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "InvalidID",
+			teamID: "invalid_id",
+			buildStubs: func(store *mockdb.MockStore) {
+				// no expectations
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMake token.Maker) {
+				addAuthorization(t, request, tokenMake, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "InternalError",
+			teamID: team.ID.String(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTeam(gomock.Any(), gomock.Eq(team.ID)).
+					Times(1).
+					Return(db.Team{}, sql.ErrConnDone)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMake token.Maker) {
+				addAuthorization(t, request, tokenMake, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/teams/%s", tc.teamID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func requireBodyMatchUserTeams(t *testing.T, body *bytes.Buffer, i int) {
+	teamMembers := make([]db.TeamMember, 5)
+	err := json.NewDecoder(body).Decode(&teamMembers)
+	require.NoError(t, err)
+	require.NoError(t, err)
+	for _, member := range teamMembers {
+		require.NotEmpty(t, member)
+	}
+	require.Equal(t, i, len(teamMembers))
+}
+
+func randomTeamMember(user db.User, team db.Team) db.ListTeamMembersRow {
+	return db.ListTeamMembersRow{
+		ID:              uuid.New(),
+		FirstName:       user.FirstName,
+		LastName:        user.LastName,
+		Number:          0,
+		PrimaryPosition: string(util.RandomBaseballPosition()),
+		TeamName:        team.Name,
+	}
+}
+
+func TestServer_UpdateTeam(t *testing.T) {
+	user, _ := createRandomUser(t)
+	other, _ := createRandomUser(t)
+	team := randomTeam()
+	renamed := team
+	renamed.Name = util.RandomString(6)
+
+	testCases := []struct {
+		name          string
+		teamID        string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			teamID: team.ID.String(),
+			body:   gin.H{"name": renamed.Name},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateTeamParams{
+					ID:   team.ID,
+					Name: sql.NullString{String: renamed.Name, Valid: true},
+				}
+				store.EXPECT().
+					UpdateTeam(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(renamed, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var rsp TeamResponse
+				err := json.NewDecoder(recorder.Body).Decode(&rsp)
+				require.NoError(t, err)
+				require.Equal(t, NewTeamResponse(renamed), rsp)
+			},
+		},
+		{
+			name:   "CoachOfOtherTeam",
+			teamID: team.ID.String(),
+			body:   gin.H{"name": renamed.Name},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, other.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTeamMember(gomock.Any(), gomock.Eq(db.GetTeamMemberParams{TeamID: team.ID, UserID: other.ID})).
+					Times(1).
+					Return(db.TeamMember{}, sql.ErrNoRows)
+				store.EXPECT().
+					UpdateTeam(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "CoachPlayingOnTeam",
+			teamID: team.ID.String(),
+			body:   gin.H{"name": renamed.Name},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, other.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTeamMember(gomock.Any(), gomock.Eq(db.GetTeamMemberParams{TeamID: team.ID, UserID: other.ID})).
+					Times(1).
+					Return(db.TeamMember{TeamID: team.ID, UserID: other.ID, Role: string(util.TeamRolePlayer)}, nil)
+				store.EXPECT().
+					UpdateTeam(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "Branding",
+			teamID: team.ID.String(),
+			body: gin.H{
+				"abbreviation":    "nyy",
+				"primary_color":   "#FA0",
+				"secondary_color": "003366",
+				"city":            " New York ",
+				"division":        "12U",
+				"logo_url":        "",
+				"x_url":           "https://x.com/yankees",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateTeamParams{
+					ID:             team.ID,
+					Abbreviation:   sql.NullString{String: "NYY", Valid: true},
+					PrimaryColor:   sql.NullString{String: "#ffaa00", Valid: true},
+					SecondaryColor: sql.NullString{String: "#003366", Valid: true},
+					City:           sql.NullString{String: "New York", Valid: true},
+					Division:       sql.NullString{String: "12U", Valid: true},
+					LogoUrl:        sql.NullString{Valid: true},
+					XUrl:           sql.NullString{String: "https://x.com/yankees", Valid: true},
+				}
+				branded := team
+				branded.Abbreviation = "NYY"
+				branded.PrimaryColor = "#ffaa00"
+				store.EXPECT().
+					UpdateTeam(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(branded, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var rsp TeamResponse
+				err := json.NewDecoder(recorder.Body).Decode(&rsp)
+				require.NoError(t, err)
+				require.Equal(t, "NYY", rsp.Abbreviation)
+				require.Equal(t, "#ffaa00", rsp.PrimaryColor)
+			},
+		},
+		{
+			name:   "InvalidColor",
+			teamID: team.ID.String(),
+			body:   gin.H{"primary_color": "navy"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateTeam(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "AbbreviationTooLong",
+			teamID: team.ID.String(),
+			body:   gin.H{"abbreviation": "YANKEES"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateTeam(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "InvalidURL",
+			teamID: team.ID.String(),
+			body:   gin.H{"website_url": "yankees dot com"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateTeam(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "DuplicateName",
+			teamID: team.ID.String(),
+			body:   gin.H{"name": renamed.Name},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateTeam(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Team{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:   "NotFound",
+			teamID: team.ID.String(),
+			body:   gin.H{"name": renamed.Name},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateTeam(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Team{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "NotCoach",
+			teamID: team.ID.String(),
+			body:   gin.H{"name": renamed.Name},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateTeam(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "NoChanges",
+			teamID: team.ID.String(),
+			body:   gin.H{},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateTeam(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectTeamCoach(store, user.ID)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			buf, err := buildJsonRequest(t, tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/teams/%s", tc.teamID)
+			request, err := http.NewRequest(http.MethodPatch, url, &buf)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_ArchiveTeam(t *testing.T) {
+	user, _ := createRandomUser(t)
+	other, _ := createRandomUser(t)
+	team := randomTeam()
+	archived := team
+	archived.ArchivedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	testCases := []struct {
+		name          string
+		action        string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Archive",
+			action: "archive",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ArchiveTeam(gomock.Any(), gomock.Eq(team.ID)).
+					Times(1).
+					Return(archived, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var rsp TeamResponse
+				err := json.NewDecoder(recorder.Body).Decode(&rsp)
+				require.NoError(t, err)
+				require.NotNil(t, rsp.ArchivedAt)
+			},
+		},
+		{
+			name:   "CoachOfOtherTeam",
+			action: "archive",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, other.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTeamMember(gomock.Any(), gomock.Eq(db.GetTeamMemberParams{TeamID: team.ID, UserID: other.ID})).
+					Times(1).
+					Return(db.TeamMember{}, sql.ErrNoRows)
+				store.EXPECT().
+					ArchiveTeam(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "Unarchive",
+			action: "unarchive",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UnarchiveTeam(gomock.Any(), gomock.Eq(team.ID)).
+					Times(1).
+					Return(team, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var rsp TeamResponse
+				err := json.NewDecoder(recorder.Body).Decode(&rsp)
+				require.NoError(t, err)
+				require.Nil(t, rsp.ArchivedAt)
+			},
+		},
+		{
+			name:   "NotFound",
+			action: "archive",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ArchiveTeam(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Team{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "NotCoach",
+			action: "archive",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ArchiveTeam(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectTeamCoach(store, user.ID)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/teams/%s/%s", team.ID, tc.action)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_DeleteTeam(t *testing.T) {
+	user, _ := createRandomUser(t)
+	team := randomTeam()
+
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTeam(gomock.Any(), gomock.Eq(team.ID)).
+					Times(1).
+					Return(team, nil)
+				store.EXPECT().
+					GetTeamDeleteBlockers(gomock.Any(), gomock.Eq(team.ID)).
+					Times(1).
+					Return(db.GetTeamDeleteBlockersRow{}, nil)
+				store.EXPECT().
+					DeleteTeam(gomock.Any(), gomock.Eq(team.ID)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "HasHistory",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTeam(gomock.Any(), gomock.Eq(team.ID)).
+					Times(1).
+					Return(team, nil)
+				store.EXPECT().
+					GetTeamDeleteBlockers(gomock.Any(), gomock.Eq(team.ID)).
+					Times(1).
+					Return(db.GetTeamDeleteBlockersRow{MemberCount: 3, GameCount: 2, StintCount: 4, TransactionCount: 5}, nil)
+				store.EXPECT().
+					DeleteTeam(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+				var rsp DeleteTeamConflictResponse
+				err := json.NewDecoder(recorder.Body).Decode(&rsp)
+				require.NoError(t, err)
+				require.NotEmpty(t, rsp.Error)
+				require.Equal(t, []DeleteTeamBlocker{
+					{Type: "members", Count: 3},
+					{Type: "games", Count: 2},
+					{Type: "roster_stints", Count: 4},
+					{Type: "roster_transactions", Count: 5},
+				}, rsp.Blockers)
+			},
+		},
+		{
+			name: "NotFound",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTeam(gomock.Any(), gomock.Eq(team.ID)).
+					Times(1).
+					Return(db.Team{}, sql.ErrNoRows)
+				store.EXPECT().
+					DeleteTeam(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "ForeignKeyRace",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTeam(gomock.Any(), gomock.Eq(team.ID)).
+					Times(1).
+					Return(team, nil)
+				store.EXPECT().
+					GetTeamDeleteBlockers(gomock.Any(), gomock.Eq(team.ID)).
+					Times(1).
+					Return(db.GetTeamDeleteBlockersRow{}, nil)
+				store.EXPECT().
+					DeleteTeam(gomock.Any(), gomock.Eq(team.ID)).
+					Times(1).
+					Return(&pq.Error{Code: "23503"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NotCoach",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTeam(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					DeleteTeam(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectTeamCoach(store, user.ID)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/teams/%s", team.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func requireBodyMatchTeam(t *testing.T, body *bytes.Buffer, team db.Team) {
+	var rsp TeamResponse
+	err := json.NewDecoder(body).Decode(&rsp)
+	require.NoError(t, err)
+
+	require.Equal(t, NewTeamResponse(team), rsp)
+}
+
+func requireBodyMatchTeams(t *testing.T, body *bytes.Buffer, i int) {
+	teams := make([]TeamResponse, 5)
+	err := json.NewDecoder(body).Decode(&teams)
+	require.NoError(t, err)
+	require.NoError(t, err)
+	for _, account := range teams {
+		require.NotEmpty(t, account)
+	}
+	require.Equal(t, i, len(teams))
+}
+
+func randomTeams(i int) []db.Team {
+	teams := make([]db.Team, i)
+	for i := range teams {
+		teams[i] = randomTeam()
+	}
+	return teams
+
+}
+
+func randomTeam() db.Team {
+	return db.Team{
+		ID:        uuid.New(),
+		Name:      util.RandomString(5),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
 
 func createRandomUser(t *testing.T) (db.User, string) {
 	password := util.RandomString(6)
@@ -857,6 +1837,10 @@ func createRandomUser(t *testing.T) (db.User, string) {
 		HashedPassword: hashedPassword,
 		CreatedAt:      time.Now(),
 		UpdatedAt:      time.Now(),
+
+		ProfileVisibility: string(util.VisibilityPublic),
+		EmailVisibility:   string(util.VisibilityPrivate),
+		StatVisibility:    string(util.VisibilityPublic),
 	}
 	return user, password
 }
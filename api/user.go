@@ -5,6 +5,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/kwalter26/scoreit-api-go/api/helpers"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
 	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
 	"github.com/kwalter26/scoreit-api-go/security"
 	"github.com/lib/pq"
@@ -100,7 +101,8 @@ type UserResponse struct {
 	LastName  string `json:"last_name"`
 }
 
-// ListUsers lists all users.
+// ListUsers lists the users whose profiles are visible to the caller.
+// Admins see every user, and each such listing is audited.
 func (s *Server) ListUsers(context *gin.Context) {
 	var req ListUsersRequest
 	if err := context.ShouldBindQuery(&req); err != nil {
@@ -108,9 +110,13 @@ func (s *Server) ListUsers(context *gin.Context) {
 		return
 	}
 
+	payload := middleware.GetAuthorizationPayload(context)
+
 	arg := db.ListUsersParams{
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+		Limit:          req.PageSize,
+		Offset:         (req.PageID - 1) * req.PageSize,
+		IncludePrivate: isAdmin(payload),
+		ViewerID:       payload.UserID,
 	}
 
 	users, err := s.store.ListUsers(context, arg)
@@ -119,6 +125,19 @@ func (s *Server) ListUsers(context *gin.Context) {
 		return
 	}
 
+	if arg.IncludePrivate {
+		hidden := false
+		for _, row := range users {
+			hidden = hidden || row.Hidden
+		}
+		if hidden {
+			if err := s.auditPrivacyBypass(context, payload, uuid.NullUUID{}); err != nil {
+				context.JSON(500, helpers.ErrorResponse(err))
+				return
+			}
+		}
+	}
+
 	rsp := make([]UserResponse, len(users))
 	for i, user := range users {
 		rsp[i] = UserResponse{
@@ -143,9 +162,30 @@ type GetUserResponse struct {
 	Username  string    `json:"username"`
 	FirstName string    `json:"first_name"`
 	LastName  string    `json:"last_name"`
-	Email     string    `json:"email"`
+	Email     string    `json:"email,omitempty"`
+	IsMinor   bool      `json:"is_minor"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	Privacy *PrivacySettingsResponse `json:"privacy,omitempty"`
+}
+
+// NewGetUserResponse creates a new GetUserResponse from a db.User.
+// The email is left out when showEmail is false.
+func NewGetUserResponse(user db.User, showEmail bool) GetUserResponse {
+	rsp := GetUserResponse{
+		ID:        user.ID.String(),
+		Username:  user.Username,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		IsMinor:   user.IsMinor,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
+	if showEmail {
+		rsp.Email = user.Email
+	}
+	return rsp
 }
 
 // GetUser gets a user.
@@ -168,15 +208,80 @@ func (s *Server) GetUser(context *gin.Context) {
 		return
 	}
 
-	rsp := GetUserResponse{
-		ID:        user.ID.String(),
-		Username:  user.Username,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		Email:     user.Email,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+	payload := middleware.GetAuthorizationPayload(context)
+	view, err := s.resolvePrivacy(context, payload, user)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+	if !view.Profile {
+		context.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+	if view.ProfileBypass {
+		if err := s.auditPrivacyBypass(context, payload, uuid.NullUUID{UUID: user.ID, Valid: true}); err != nil {
+			context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+			return
+		}
 	}
 
+	rsp := NewGetUserResponse(user, view.Email)
+	if view.Owner || isAdmin(payload) {
+		privacy := NewPrivacySettingsResponse(user)
+		rsp.Privacy = &privacy
+	}
 	context.JSON(http.StatusOK, rsp)
 }
+
+// UpdateUserRequest represents a request to update a user's profile.
+type UpdateUserRequest struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+// UpdateUserRequestBody represents the body of a request to update a user's profile.
+type UpdateUserRequestBody struct {
+	FirstName string `json:"first_name" binding:"omitempty,max=40"`
+	LastName  string `json:"last_name" binding:"omitempty,max=40"`
+	Email     string `json:"email" binding:"omitempty,email"`
+}
+
+// UpdateUser updates a user's profile. Guardians may edit the profiles of their players.
+func (s *Server) UpdateUser(context *gin.Context) {
+	var req UpdateUserRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var body UpdateUserRequestBody
+	if err := context.ShouldBindJSON(&body); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	arg := db.UpdateUserParams{
+		ID:        uuid.MustParse(req.ID),
+		FirstName: sql.NullString{String: body.FirstName, Valid: body.FirstName != ""},
+		LastName:  sql.NullString{String: body.LastName, Valid: body.LastName != ""},
+		Email:     sql.NullString{String: body.Email, Valid: body.Email != ""},
+	}
+
+	user, err := s.store.UpdateUser(context, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		if pgErr, ok := err.(*pq.Error); ok {
+			switch pgErr.Code.Name() {
+			case "unique_violation":
+				context.JSON(http.StatusConflict, helpers.ErrorResponse(pgErr))
+				return
+			}
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, NewGetUserResponse(user, true))
+}
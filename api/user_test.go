@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 	"github.com/kwalter26/scoreit-api-go/api/middleware"
 	mockdb "github.com/kwalter26/scoreit-api-go/db/mock"
 	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
@@ -225,6 +226,9 @@ func TestServerListUsers(t *testing.T) {
 			LastName:  user.LastName,
 		}
 	}
+	// the admin sees one user who has hidden their profile
+	hiddenUserRows := append([]db.ListUsersRow{}, listUserRows...)
+	hiddenUserRows[2].Hidden = true
 
 	usersResponse := make([]UserResponse, len(users))
 	for i, user := range users {
@@ -250,8 +254,9 @@ func TestServerListUsers(t *testing.T) {
 			pageID:   1,
 			buildStubs: func(store *mockdb.MockStore) {
 				arg := db.ListUsersParams{
-					Limit:  5,
-					Offset: 0,
+					Limit:    5,
+					Offset:   0,
+					ViewerID: users[0].ID,
 				}
 				store.EXPECT().
 					ListUsers(gomock.Any(), gomock.Eq(arg)).
@@ -272,8 +277,9 @@ func TestServerListUsers(t *testing.T) {
 			pageID:   1,
 			buildStubs: func(store *mockdb.MockStore) {
 				arg := db.ListUsersParams{
-					Limit:  5,
-					Offset: 0,
+					Limit:    5,
+					Offset:   0,
+					ViewerID: users[0].ID,
 				}
 				store.EXPECT().
 					ListUsers(gomock.Any(), gomock.Eq(arg)).
@@ -287,6 +293,54 @@ func TestServerListUsers(t *testing.T) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name:     "AdminIncludesPrivate",
+			pageSize: 5,
+			pageID:   1,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListUsersParams{
+					Limit:          5,
+					Offset:         0,
+					IncludePrivate: true,
+					ViewerID:       users[0].ID,
+				}
+				store.EXPECT().
+					CreateAuditLog(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AuditLog{}, nil)
+				store.EXPECT().
+					ListUsers(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(hiddenUserRows, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, adminUserRoles, middleware.AuthorizationTypeBearer, users[0].ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchUsers(t, recorder.Body, usersResponse)
+			},
+		},
+		{
+			name:     "AdminNothingHidden",
+			pageSize: 5,
+			pageID:   1,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateAuditLog(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					ListUsers(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(listUserRows, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, adminUserRoles, middleware.AuthorizationTypeBearer, users[0].ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
 		{
 			name:     "Unauthenticated",
 			pageSize: 5,
@@ -350,6 +404,13 @@ func TestServerListUsers(t *testing.T) {
 
 func TestServerGetUser(t *testing.T) {
 	user, _ := createRandomUser(t)
+	minor, _ := createRandomUser(t)
+	minor.IsMinor = true
+	private, _ := createRandomUser(t)
+	private.ProfileVisibility = string(util.VisibilityPrivate)
+	teammatesOnly, _ := createRandomUser(t)
+	teammatesOnly.ProfileVisibility = string(util.VisibilityTeammates)
+	teammatesOnly.EmailVisibility = string(util.VisibilityTeammates)
 
 	testCases := []struct {
 		name          string
@@ -407,6 +468,151 @@ func TestServerGetUser(t *testing.T) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name:   "MinorEmailHidden",
+			userID: minor.ID.String(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(minor.ID)).
+					Times(1).
+					Return(minor, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var rsp GetUserResponse
+				err := json.NewDecoder(recorder.Body).Decode(&rsp)
+				require.NoError(t, err)
+				require.Equal(t, minor.Username, rsp.Username)
+				require.Empty(t, rsp.Email)
+			},
+		},
+		{
+			name:   "MinorEmailVisibleToSelf",
+			userID: minor.ID.String(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(minor.ID)).
+					Times(1).
+					Return(minor, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, minor.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchUser(t, recorder.Body, minor)
+			},
+		},
+		{
+			name:   "PrivateProfileForbidden",
+			userID: private.ID.String(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(private.ID)).
+					Times(1).
+					Return(private, nil)
+				store.EXPECT().
+					CreateAuditLog(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "PrivateProfileAdminBypassAudited",
+			userID: private.ID.String(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(private.ID)).
+					Times(1).
+					Return(private, nil)
+				store.EXPECT().
+					CreateAuditLog(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.CreateAuditLogParams) (db.AuditLog, error) {
+						require.Equal(t, user.ID, arg.ActorID)
+						require.Equal(t, string(util.AuditPrivacyBypass), arg.Action)
+						require.Equal(t, uuid.NullUUID{UUID: private.ID, Valid: true}, arg.SubjectID)
+						return db.AuditLog{}, nil
+					})
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, adminUserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchUser(t, recorder.Body, private)
+			},
+		},
+		{
+			name:   "TeammatesOnlyVisibleToTeammate",
+			userID: teammatesOnly.ID.String(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(teammatesOnly.ID)).
+					Times(1).
+					Return(teammatesOnly, nil)
+				store.EXPECT().
+					AreTeammates(gomock.Any(), gomock.Eq(db.AreTeammatesParams{UserID: teammatesOnly.ID, ViewerID: user.ID})).
+					Times(1).
+					Return(true, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchUser(t, recorder.Body, teammatesOnly)
+			},
+		},
+		{
+			name:   "TeammatesOnlyHiddenFromOthers",
+			userID: teammatesOnly.ID.String(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(teammatesOnly.ID)).
+					Times(1).
+					Return(teammatesOnly, nil)
+				store.EXPECT().
+					AreTeammates(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(false, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "PrivateEmailHidden",
+			userID: user.ID.String(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, minor.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var rsp GetUserResponse
+				err := json.NewDecoder(recorder.Body).Decode(&rsp)
+				require.NoError(t, err)
+				require.Empty(t, rsp.Email)
+				require.Nil(t, rsp.Privacy)
+			},
+		},
 		{
 			name:   "InvalidID",
 			userID: "invalid_id",
@@ -448,6 +654,147 @@ func TestServerGetUser(t *testing.T) {
 	}
 }
 
+func TestServerUpdateUser(t *testing.T) {
+	user, _ := createRandomUser(t)
+	guardian, _ := createRandomUser(t)
+	stranger, _ := createRandomUser(t)
+	updated := user
+	updated.FirstName = util.RandomName()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"first_name": updated.FirstName},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateUserParams{
+					ID:        user.ID,
+					FirstName: sql.NullString{String: updated.FirstName, Valid: true},
+				}
+				store.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(updated, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchUser(t, recorder.Body, updated)
+			},
+		},
+		{
+			name: "Guardian",
+			body: gin.H{"first_name": updated.FirstName},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(updated, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, guardian.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden",
+			body: gin.H{"first_name": updated.FirstName},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, stranger.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidEmail",
+			body: gin.H{"email": "not-an-email"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "DuplicateEmail",
+			body: gin.H{"email": stranger.Email},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.User{}, &pg.Error{Code: "23505"})
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: gin.H{"first_name": updated.FirstName},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.User{}, sql.ErrConnDone)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			loadGuardianLinks(t, server, store, randomGuardian(guardian.ID, user.ID))
+			recorder := httptest.NewRecorder()
+
+			data, err := buildJsonRequest(t, tc.body)
+			url := fmt.Sprintf("/api/v1/players/%s", user.ID)
+			request, err := http.NewRequest(http.MethodPatch, url, &data)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
 func requireBodyMatchUser(t *testing.T, body *bytes.Buffer, user db.User) {
 	var createdUser db.User
 	err := json.NewDecoder(body).Decode(&createdUser)
@@ -0,0 +1,611 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/helpers"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/lib/pq"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	errNoVenueChanges        = errors.New("no venue fields to update")
+	errNoFieldChanges        = errors.New("no field attributes to update")
+	errVenueInUse            = errors.New("venue has games; move them to another venue first")
+	errFieldInUse            = errors.New("field has games; move them to another field first")
+	errWindowEndsBeforeStart = errors.New("ends_at must be after starts_at")
+)
+
+// GetVenueRequest represents a request addressing a single venue.
+type GetVenueRequest struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+// GetFieldRequest represents a request addressing a single field of a venue.
+type GetFieldRequest struct {
+	VenueID string `uri:"id" binding:"required,uuid"`
+	FieldID string `uri:"field_id" binding:"required,uuid"`
+}
+
+// DeleteFieldAvailabilityRequest represents a request to remove an availability window of a field.
+type DeleteFieldAvailabilityRequest struct {
+	VenueID  string `uri:"id" binding:"required,uuid"`
+	FieldID  string `uri:"field_id" binding:"required,uuid"`
+	WindowID string `uri:"window_id" binding:"required,uuid"`
+}
+
+// VenueResponse represents a venue together with its fields.
+type VenueResponse struct {
+	db.Venue
+	Fields []db.Field `json:"fields"`
+}
+
+// CreateVenueRequest represents a request to register a venue.
+type CreateVenueRequest struct {
+	Name      string   `json:"name" binding:"required,max=100"`
+	Address   string   `json:"address" binding:"max=200"`
+	City      string   `json:"city" binding:"max=100"`
+	Latitude  *float64 `json:"latitude" binding:"required,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" binding:"required,min=-180,max=180"`
+}
+
+// CreateVenue registers a venue. Only coaches and admins may manage venues.
+func (s *Server) CreateVenue(context *gin.Context) {
+	var req CreateVenueRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	if !isCoachOrAdmin(payload) {
+		context.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	venue, err := s.store.CreateVenue(context, db.CreateVenueParams{
+		Name:      strings.TrimSpace(req.Name),
+		Address:   strings.TrimSpace(req.Address),
+		City:      strings.TrimSpace(req.City),
+		Latitude:  *req.Latitude,
+		Longitude: *req.Longitude,
+		CreatedBy: payload.UserID,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, VenueResponse{Venue: venue, Fields: []db.Field{}})
+}
+
+// ListVenuesRequest represents a request to page through venues.
+type ListVenuesRequest struct {
+	City     string `form:"city" binding:"max=100"`
+	PageSize int32  `form:"page_size,default=10" binding:"max=100,min=1"`
+	PageID   int32  `form:"page_id,default=1" binding:"min=1"`
+}
+
+// ListVenues lists venues by name, optionally only those in a city.
+func (s *Server) ListVenues(context *gin.Context) {
+	var req ListVenuesRequest
+	if err := context.ShouldBindQuery(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	venues, err := s.store.ListVenues(context, db.ListVenuesParams{
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+		City:   sql.NullString{String: req.City, Valid: req.City != ""},
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, venues)
+}
+
+// GetVenue gets a venue and its fields.
+func (s *Server) GetVenue(context *gin.Context) {
+	var req GetVenueRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	id := uuid.MustParse(req.ID)
+	venue, err := s.store.GetVenue(context, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	fields, err := s.store.ListFields(context, id)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, VenueResponse{Venue: venue, Fields: fields})
+}
+
+// UpdateVenueRequestBody represents a request to edit a venue. Omitted fields are left unchanged.
+type UpdateVenueRequestBody struct {
+	Name      *string  `json:"name" binding:"omitempty,min=1,max=100"`
+	Address   *string  `json:"address" binding:"omitempty,max=200"`
+	City      *string  `json:"city" binding:"omitempty,max=100"`
+	Latitude  *float64 `json:"latitude" binding:"omitempty,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" binding:"omitempty,min=-180,max=180"`
+}
+
+// UpdateVenue edits a venue. Only coaches and admins may manage venues.
+func (s *Server) UpdateVenue(context *gin.Context) {
+	var req GetVenueRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var body UpdateVenueRequestBody
+	if err := context.ShouldBindJSON(&body); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+	if body == (UpdateVenueRequestBody{}) {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(errNoVenueChanges))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	if !isCoachOrAdmin(payload) {
+		context.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	venue, err := s.store.UpdateVenue(context, db.UpdateVenueParams{
+		Name:      optionalString(body.Name),
+		Address:   optionalString(body.Address),
+		City:      optionalString(body.City),
+		Latitude:  optionalFloat(body.Latitude),
+		Longitude: optionalFloat(body.Longitude),
+		ID:        uuid.MustParse(req.ID),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, venue)
+}
+
+// DeleteVenue deletes a venue and its fields. Venues that games are played at cannot be deleted.
+func (s *Server) DeleteVenue(context *gin.Context) {
+	var req GetVenueRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	if !isCoachOrAdmin(payload) {
+		context.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	if _, err := s.store.DeleteVenue(context, uuid.MustParse(req.ID)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		if pgErr, ok := err.(*pq.Error); ok {
+			switch pgErr.Code.Name() {
+			case "foreign_key_violation":
+				context.JSON(http.StatusConflict, helpers.ErrorResponse(errVenueInUse))
+				return
+			}
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, nil)
+}
+
+// CreateFieldRequestBody represents a request to add a field to a venue.
+// Fence distances are in feet down the lines and to center.
+type CreateFieldRequestBody struct {
+	Name          string `json:"name" binding:"required,max=50"`
+	Surface       string `json:"surface" binding:"omitempty,oneof=grass turf dirt"`
+	LeftFieldFt   *int64 `json:"left_field_ft" binding:"omitempty,min=50,max=600"`
+	CenterFieldFt *int64 `json:"center_field_ft" binding:"omitempty,min=50,max=600"`
+	RightFieldFt  *int64 `json:"right_field_ft" binding:"omitempty,min=50,max=600"`
+	HasLights     bool   `json:"has_lights"`
+}
+
+// CreateField adds a field to a venue. Only coaches and admins may manage venues.
+func (s *Server) CreateField(context *gin.Context) {
+	var req GetVenueRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var body CreateFieldRequestBody
+	if err := context.ShouldBindJSON(&body); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+	if body.Surface == "" {
+		body.Surface = string(util.SurfaceGrass)
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	if !isCoachOrAdmin(payload) {
+		context.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	field, err := s.store.CreateField(context, db.CreateFieldParams{
+		VenueID:       uuid.MustParse(req.ID),
+		Name:          strings.TrimSpace(body.Name),
+		Surface:       body.Surface,
+		LeftFieldFt:   optionalInt(body.LeftFieldFt),
+		CenterFieldFt: optionalInt(body.CenterFieldFt),
+		RightFieldFt:  optionalInt(body.RightFieldFt),
+		HasLights:     body.HasLights,
+	})
+	if err != nil {
+		if pgErr, ok := err.(*pq.Error); ok {
+			switch pgErr.Code.Name() {
+			case "foreign_key_violation":
+				context.JSON(http.StatusNotFound, helpers.ErrorResponse(pgErr))
+				return
+			case "unique_violation":
+				context.JSON(http.StatusConflict, helpers.ErrorResponse(pgErr))
+				return
+			}
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, field)
+}
+
+// UpdateFieldRequestBody represents a request to edit a field. Omitted attributes are left unchanged.
+type UpdateFieldRequestBody struct {
+	Name          *string `json:"name" binding:"omitempty,min=1,max=50"`
+	Surface       *string `json:"surface" binding:"omitempty,oneof=grass turf dirt"`
+	LeftFieldFt   *int64  `json:"left_field_ft" binding:"omitempty,min=50,max=600"`
+	CenterFieldFt *int64  `json:"center_field_ft" binding:"omitempty,min=50,max=600"`
+	RightFieldFt  *int64  `json:"right_field_ft" binding:"omitempty,min=50,max=600"`
+	HasLights     *bool   `json:"has_lights"`
+}
+
+// UpdateField edits a field of a venue. Only coaches and admins may manage venues.
+func (s *Server) UpdateField(context *gin.Context) {
+	var req GetFieldRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var body UpdateFieldRequestBody
+	if err := context.ShouldBindJSON(&body); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+	if body == (UpdateFieldRequestBody{}) {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(errNoFieldChanges))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	if !isCoachOrAdmin(payload) {
+		context.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	hasLights := sql.NullBool{}
+	if body.HasLights != nil {
+		hasLights = sql.NullBool{Bool: *body.HasLights, Valid: true}
+	}
+
+	field, err := s.store.UpdateField(context, db.UpdateFieldParams{
+		Name:          optionalString(body.Name),
+		Surface:       optionalString(body.Surface),
+		LeftFieldFt:   optionalInt(body.LeftFieldFt),
+		CenterFieldFt: optionalInt(body.CenterFieldFt),
+		RightFieldFt:  optionalInt(body.RightFieldFt),
+		HasLights:     hasLights,
+		ID:            uuid.MustParse(req.FieldID),
+		VenueID:       uuid.MustParse(req.VenueID),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		if pgErr, ok := err.(*pq.Error); ok {
+			switch pgErr.Code.Name() {
+			case "unique_violation":
+				context.JSON(http.StatusConflict, helpers.ErrorResponse(pgErr))
+				return
+			}
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, field)
+}
+
+// DeleteField removes a field from a venue. Fields that games are played on cannot be deleted.
+func (s *Server) DeleteField(context *gin.Context) {
+	var req GetFieldRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	if !isCoachOrAdmin(payload) {
+		context.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	_, err := s.store.DeleteField(context, db.DeleteFieldParams{
+		ID:      uuid.MustParse(req.FieldID),
+		VenueID: uuid.MustParse(req.VenueID),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		if pgErr, ok := err.(*pq.Error); ok {
+			switch pgErr.Code.Name() {
+			case "foreign_key_violation":
+				context.JSON(http.StatusConflict, helpers.ErrorResponse(errFieldInUse))
+				return
+			}
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, nil)
+}
+
+// NearbyFieldsRequest represents a search for fields around a point.
+type NearbyFieldsRequest struct {
+	Latitude  *float64 `form:"latitude" binding:"required,min=-90,max=90"`
+	Longitude *float64 `form:"longitude" binding:"required,min=-180,max=180"`
+	RadiusKm  float64  `form:"radius_km,default=10" binding:"gt=0,max=500"`
+	Surface   string   `form:"surface" binding:"omitempty,oneof=grass turf dirt"`
+	Lights    *bool    `form:"lights"`
+}
+
+// NearbyField represents a field found by a distance search.
+type NearbyField struct {
+	db.ListFieldsInBoundsRow
+	DistanceKm float64 `json:"distance_km"`
+}
+
+// ListNearbyFields lists the fields within radius_km of a point, closest first.
+// The database narrows the search to a bounding box; exact distances are computed here.
+func (s *Server) ListNearbyFields(context *gin.Context) {
+	var req NearbyFieldsRequest
+	if err := context.ShouldBindQuery(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	lights := sql.NullBool{}
+	if req.Lights != nil {
+		lights = sql.NullBool{Bool: *req.Lights, Valid: true}
+	}
+
+	minLat, maxLat, minLng, maxLng := util.BoundingBox(*req.Latitude, *req.Longitude, req.RadiusKm)
+	rows, err := s.store.ListFieldsInBounds(context, db.ListFieldsInBoundsParams{
+		MinLatitude:  minLat,
+		MaxLatitude:  maxLat,
+		MinLongitude: minLng,
+		MaxLongitude: maxLng,
+		Surface:      sql.NullString{String: req.Surface, Valid: req.Surface != ""},
+		HasLights:    lights,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	fields := []NearbyField{}
+	for _, row := range rows {
+		distance := util.DistanceKm(*req.Latitude, *req.Longitude, row.Latitude, row.Longitude)
+		if distance <= req.RadiusKm {
+			fields = append(fields, NearbyField{ListFieldsInBoundsRow: row, DistanceKm: distance})
+		}
+	}
+	sort.SliceStable(fields, func(i, j int) bool {
+		return fields[i].DistanceKm < fields[j].DistanceKm
+	})
+
+	context.JSON(http.StatusOK, fields)
+}
+
+// CreateFieldAvailabilityRequestBody represents a window during which a field may be booked.
+type CreateFieldAvailabilityRequestBody struct {
+	StartsAt time.Time `json:"starts_at" binding:"required"`
+	EndsAt   time.Time `json:"ends_at" binding:"required"`
+	Note     string    `json:"note" binding:"max=200"`
+}
+
+// CreateFieldAvailability opens a field for games during a window, such as a permit.
+// Once a field has any windows, games may only be scheduled on it inside one of them.
+func (s *Server) CreateFieldAvailability(context *gin.Context) {
+	var req GetFieldRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var body CreateFieldAvailabilityRequestBody
+	if err := context.ShouldBindJSON(&body); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+	if !body.EndsAt.After(body.StartsAt) {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(errWindowEndsBeforeStart))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	if !isCoachOrAdmin(payload) {
+		context.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	field, ok := s.getVenueField(context, req)
+	if !ok {
+		return
+	}
+
+	window, err := s.store.CreateFieldAvailability(context, db.CreateFieldAvailabilityParams{
+		FieldID:   field.ID,
+		StartsAt:  body.StartsAt,
+		EndsAt:    body.EndsAt,
+		Note:      strings.TrimSpace(body.Note),
+		CreatedBy: payload.UserID,
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, window)
+}
+
+// ListFieldAvailabilityRequest represents a request for the availability windows of a field.
+type ListFieldAvailabilityRequest struct {
+	From time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// ListFieldAvailability lists the availability windows of a field, optionally only those ending after from.
+func (s *Server) ListFieldAvailability(context *gin.Context) {
+	var req GetFieldRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	var query ListFieldAvailabilityRequest
+	if err := context.ShouldBindQuery(&query); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	field, ok := s.getVenueField(context, req)
+	if !ok {
+		return
+	}
+
+	windows, err := s.store.ListFieldAvailability(context, db.ListFieldAvailabilityParams{
+		FieldID:  field.ID,
+		FromTime: sql.NullTime{Time: query.From, Valid: !query.From.IsZero()},
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, windows)
+}
+
+// DeleteFieldAvailability removes an availability window from a field.
+func (s *Server) DeleteFieldAvailability(context *gin.Context) {
+	var req DeleteFieldAvailabilityRequest
+	if err := context.ShouldBindUri(&req); err != nil {
+		context.JSON(http.StatusBadRequest, helpers.ErrorResponse(err))
+		return
+	}
+
+	payload := middleware.GetAuthorizationPayload(context)
+	if !isCoachOrAdmin(payload) {
+		context.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	field, ok := s.getVenueField(context, GetFieldRequest{VenueID: req.VenueID, FieldID: req.FieldID})
+	if !ok {
+		return
+	}
+
+	_, err := s.store.DeleteFieldAvailability(context, db.DeleteFieldAvailabilityParams{
+		ID:      uuid.MustParse(req.WindowID),
+		FieldID: field.ID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, nil)
+}
+
+// getVenueField loads a field and checks that it belongs to the venue in the path.
+// It writes a 404 or 500 response and returns false if it does not.
+func (s *Server) getVenueField(context *gin.Context, req GetFieldRequest) (db.Field, bool) {
+	field, err := s.store.GetField(context, uuid.MustParse(req.FieldID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			context.JSON(http.StatusNotFound, helpers.ErrorResponse(err))
+			return db.Field{}, false
+		}
+		context.JSON(http.StatusInternalServerError, helpers.ErrorResponse(err))
+		return db.Field{}, false
+	}
+	if field.VenueID != uuid.MustParse(req.VenueID) {
+		context.JSON(http.StatusNotFound, helpers.ErrorResponse(sql.ErrNoRows))
+		return db.Field{}, false
+	}
+	return field, true
+}
+
+// optionalFloat converts an optional request field to the nullable argument of an update query.
+func optionalFloat(value *float64) sql.NullFloat64 {
+	if value == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *value, Valid: true}
+}
+
+// optionalInt converts an optional request field to a nullable column value.
+func optionalInt(value *int64) sql.NullInt64 {
+	if value == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *value, Valid: true}
+}
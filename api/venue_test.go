@@ -0,0 +1,660 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/api/middleware"
+	mockdb "github.com/kwalter26/scoreit-api-go/db/mock"
+	db "github.com/kwalter26/scoreit-api-go/db/sqlc"
+	"github.com/kwalter26/scoreit-api-go/security"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func randomVenue() db.Venue {
+	return db.Venue{
+		ID:        uuid.New(),
+		Name:      util.RandomName(),
+		City:      "Chicago",
+		Latitude:  41.9484,
+		Longitude: -87.6553,
+	}
+}
+
+func TestServer_CreateVenue(t *testing.T) {
+	user, _ := createRandomUser(t)
+	venue := randomVenue()
+
+	testCases := []struct {
+		name          string
+		roles         []security.Role
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			roles: coachRoles,
+			body:  gin.H{"name": venue.Name, "city": " Chicago ", "latitude": venue.Latitude, "longitude": venue.Longitude},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateVenueParams{
+					Name:      venue.Name,
+					City:      "Chicago",
+					Latitude:  venue.Latitude,
+					Longitude: venue.Longitude,
+					CreatedBy: user.ID,
+				}
+				store.EXPECT().
+					CreateVenue(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(venue, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp VenueResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, venue.ID, rsp.ID)
+				require.Empty(t, rsp.Fields)
+			},
+		},
+		{
+			name:  "Equator",
+			roles: coachRoles,
+			body:  gin.H{"name": venue.Name, "latitude": 0, "longitude": 0},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateVenue(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(venue, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "MissingLatitude",
+			roles: coachRoles,
+			body:  gin.H{"name": venue.Name, "longitude": venue.Longitude},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateVenue(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidLongitude",
+			roles: coachRoles,
+			body:  gin.H{"name": venue.Name, "latitude": venue.Latitude, "longitude": 200},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateVenue(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "NotCoach",
+			roles: security.UserRoles,
+			body:  gin.H{"name": venue.Name, "latitude": venue.Latitude, "longitude": venue.Longitude},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateVenue(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			buf, err := buildJsonRequest(t, tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/api/v1/venues", &buf)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, tc.roles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_GetVenue(t *testing.T) {
+	user, _ := createRandomUser(t)
+	venue := randomVenue()
+	field := db.Field{ID: uuid.New(), VenueID: venue.ID, Name: "Diamond 1", Surface: string(util.SurfaceGrass)}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetVenue(gomock.Any(), gomock.Eq(venue.ID)).
+					Times(1).
+					Return(venue, nil)
+				store.EXPECT().
+					ListFields(gomock.Any(), gomock.Eq(venue.ID)).
+					Times(1).
+					Return([]db.Field{field}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp VenueResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, venue.Name, rsp.Name)
+				require.Len(t, rsp.Fields, 1)
+				require.Equal(t, field.ID, rsp.Fields[0].ID)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetVenue(gomock.Any(), gomock.Eq(venue.ID)).
+					Times(1).
+					Return(db.Venue{}, sql.ErrNoRows)
+				store.EXPECT().
+					ListFields(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/venues/%s", venue.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_UpdateVenue(t *testing.T) {
+	user, _ := createRandomUser(t)
+	venue := randomVenue()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"address": "1060 W Addison St", "latitude": 41.95},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateVenueParams{
+					Address:  sql.NullString{String: "1060 W Addison St", Valid: true},
+					Latitude: sql.NullFloat64{Float64: 41.95, Valid: true},
+					ID:       venue.ID,
+				}
+				store.EXPECT().
+					UpdateVenue(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(venue, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NoChanges",
+			body: gin.H{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateVenue(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			body: gin.H{"name": "Renamed"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateVenue(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Venue{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			buf, err := buildJsonRequest(t, tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/venues/%s", venue.ID)
+			request, err := http.NewRequest(http.MethodPut, url, &buf)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_DeleteVenue(t *testing.T) {
+	user, _ := createRandomUser(t)
+	venue := randomVenue()
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteVenue(gomock.Any(), gomock.Eq(venue.ID)).
+					Times(1).
+					Return(venue, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "InUse",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteVenue(gomock.Any(), gomock.Eq(venue.ID)).
+					Times(1).
+					Return(db.Venue{}, &pq.Error{Code: "23503"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteVenue(gomock.Any(), gomock.Eq(venue.ID)).
+					Times(1).
+					Return(db.Venue{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/v1/venues/%s", venue.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_CreateField(t *testing.T) {
+	user, _ := createRandomUser(t)
+	venue := randomVenue()
+	center := int64(400)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"name": "Diamond 1", "center_field_ft": center, "has_lights": true},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateFieldParams{
+					VenueID:       venue.ID,
+					Name:          "Diamond 1",
+					Surface:       string(util.SurfaceGrass),
+					CenterFieldFt: sql.NullInt64{Int64: center, Valid: true},
+					HasLights:     true,
+				}
+				store.EXPECT().
+					CreateField(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.Field{ID: uuid.New(), VenueID: venue.ID, Name: arg.Name}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidSurface",
+			body: gin.H{"name": "Diamond 1", "surface": "ice"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateField(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Duplicate",
+			body: gin.H{"name": "Diamond 1"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateField(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Field{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "VenueNotFound",
+			body: gin.H{"name": "Diamond 1"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateField(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Field{}, &pq.Error{Code: "23503"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			buf, err := buildJsonRequest(t, tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/venues/%s/fields", venue.ID)
+			request, err := http.NewRequest(http.MethodPost, url, &buf)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_ListNearbyFields(t *testing.T) {
+	user, _ := createRandomUser(t)
+	// Wrigley Field
+	lat, lng := 41.9484, -87.6553
+	near := db.ListFieldsInBoundsRow{ID: uuid.New(), Name: "Near", Latitude: 41.9500, Longitude: -87.6600}
+	nearer := db.ListFieldsInBoundsRow{ID: uuid.New(), Name: "Nearer", Latitude: lat, Longitude: lng}
+	// inside the bounding box corner but outside the circle
+	corner := db.ListFieldsInBoundsRow{ID: uuid.New(), Name: "Corner", Latitude: lat + 0.085, Longitude: lng + 0.115}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: fmt.Sprintf("latitude=%f&longitude=%f&radius_km=10&lights=true", lat, lng),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListFieldsInBounds(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.ListFieldsInBoundsParams) ([]db.ListFieldsInBoundsRow, error) {
+						require.Less(t, arg.MinLatitude, lat)
+						require.Greater(t, arg.MaxLongitude, lng)
+						require.Equal(t, sql.NullBool{Bool: true, Valid: true}, arg.HasLights)
+						return []db.ListFieldsInBoundsRow{near, corner, nearer}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp []NearbyField
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Len(t, rsp, 2)
+				require.Equal(t, nearer.ID, rsp[0].ID)
+				require.Equal(t, near.ID, rsp[1].ID)
+				require.Greater(t, rsp[1].DistanceKm, rsp[0].DistanceKm)
+			},
+		},
+		{
+			name:  "MissingLatitude",
+			query: fmt.Sprintf("longitude=%f", lng),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListFieldsInBounds(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "RadiusTooLarge",
+			query: fmt.Sprintf("latitude=%f&longitude=%f&radius_km=5000", lat, lng),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListFieldsInBounds(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/api/v1/fields/nearby?"+tc.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, security.UserRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestServer_CreateFieldAvailability(t *testing.T) {
+	user, _ := createRandomUser(t)
+	venue := randomVenue()
+	field := db.Field{ID: uuid.New(), VenueID: venue.ID, Name: "Diamond 1"}
+	start := time.Date(2026, time.May, 2, 9, 0, 0, 0, time.UTC)
+	end := start.Add(10 * time.Hour)
+
+	testCases := []struct {
+		name          string
+		venueID       uuid.UUID
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "OK",
+			venueID: venue.ID,
+			body:    gin.H{"starts_at": start, "ends_at": end, "note": "permit 42"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetField(gomock.Any(), gomock.Eq(field.ID)).
+					Times(1).
+					Return(field, nil)
+				arg := db.CreateFieldAvailabilityParams{
+					FieldID:   field.ID,
+					StartsAt:  start,
+					EndsAt:    end,
+					Note:      "permit 42",
+					CreatedBy: user.ID,
+				}
+				store.EXPECT().
+					CreateFieldAvailability(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.FieldAvailability{ID: uuid.New(), FieldID: field.ID}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:    "EndsBeforeStart",
+			venueID: venue.ID,
+			body:    gin.H{"starts_at": end, "ends_at": start},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetField(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:    "FieldAtOtherVenue",
+			venueID: uuid.New(),
+			body:    gin.H{"starts_at": start, "ends_at": end},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetField(gomock.Any(), gomock.Eq(field.ID)).
+					Times(1).
+					Return(field, nil)
+				store.EXPECT().
+					CreateFieldAvailability(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			buf, err := buildJsonRequest(t, tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/v1/venues/%s/fields/%s/availability", tc.venueID, field.ID)
+			request, err := http.NewRequest(http.MethodPost, url, &buf)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, coachRoles, middleware.AuthorizationTypeBearer, user.ID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,4 @@
+DROP TABLE "guardians";
+
+ALTER TABLE "users"
+    DROP COLUMN "is_minor";
\ No newline at end of file
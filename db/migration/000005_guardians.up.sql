@@ -0,0 +1,25 @@
+ALTER TABLE "users"
+    ADD COLUMN "is_minor" boolean NOT NULL DEFAULT false;
+
+CREATE TABLE "guardians"
+(
+    "id"          uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "guardian_id" uuid             NOT NULL,
+    "player_id"   uuid             NOT NULL,
+    "status"      varchar          NOT NULL DEFAULT 'pending',
+    "approved_by" uuid,
+    "approved_at" timestamptz,
+    "created_at"  timestamptz      NOT NULL DEFAULT (now()),
+    "updated_at"  timestamptz      NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "guardians" ("guardian_id", "player_id");
+
+ALTER TABLE "guardians"
+    ADD FOREIGN KEY ("guardian_id") REFERENCES "users" ("id");
+
+ALTER TABLE "guardians"
+    ADD FOREIGN KEY ("player_id") REFERENCES "users" ("id");
+
+ALTER TABLE "guardians"
+    ADD FOREIGN KEY ("approved_by") REFERENCES "users" ("id");
\ No newline at end of file
@@ -0,0 +1,6 @@
+DROP TABLE "audit_logs";
+
+ALTER TABLE "users"
+    DROP COLUMN "profile_visibility",
+    DROP COLUMN "email_visibility",
+    DROP COLUMN "stat_visibility";
\ No newline at end of file
@@ -0,0 +1,19 @@
+ALTER TABLE "users"
+    ADD COLUMN "profile_visibility" varchar NOT NULL DEFAULT 'public',
+    ADD COLUMN "email_visibility" varchar NOT NULL DEFAULT 'private',
+    ADD COLUMN "stat_visibility" varchar NOT NULL DEFAULT 'public';
+
+CREATE TABLE "audit_logs"
+(
+    "id"         uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "actor_id"   uuid             NOT NULL,
+    "action"     varchar          NOT NULL,
+    "subject_id" uuid,
+    "details"    varchar          NOT NULL,
+    "created_at" timestamptz      NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "audit_logs" ("created_at");
+
+ALTER TABLE "audit_logs"
+    ADD FOREIGN KEY ("actor_id") REFERENCES "users" ("id");
\ No newline at end of file
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "teams_name_trgm_idx";
+
+DROP INDEX IF EXISTS "users_full_name_trgm_idx";
+
+DROP INDEX IF EXISTS "users_username_trgm_idx";
+
+DROP EXTENSION IF EXISTS pg_trgm;
\ No newline at end of file
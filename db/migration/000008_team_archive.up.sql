@@ -0,0 +1,2 @@
+ALTER TABLE "teams"
+    ADD COLUMN "archived_at" timestamptz;
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "team_members_team_id_number_idx";
+
+DROP INDEX IF EXISTS "team_members_team_id_user_id_idx";
\ No newline at end of file
@@ -0,0 +1,31 @@
+DO
+$$
+DECLARE
+    "duplicates" text;
+BEGIN
+    SELECT string_agg(format('team %s user %s', "team_id", "user_id"), ', ')
+    INTO "duplicates"
+    FROM (SELECT "team_id", "user_id"
+          FROM "team_members"
+          GROUP BY "team_id", "user_id"
+          HAVING count(*) > 1) AS "repeated";
+    IF "duplicates" IS NOT NULL THEN
+        RAISE EXCEPTION 'players are on the same team more than once, remove the extra memberships before migrating: %', "duplicates";
+    END IF;
+
+    SELECT string_agg(format('team %s number %s', "team_id", "number"), ', ')
+    INTO "duplicates"
+    FROM (SELECT "team_id", "number"
+          FROM "team_members"
+          WHERE "number" IS NOT NULL
+          GROUP BY "team_id", "number"
+          HAVING count(*) > 1) AS "repeated";
+    IF "duplicates" IS NOT NULL THEN
+        RAISE EXCEPTION 'players share a jersey number on the same team, renumber them before migrating: %', "duplicates";
+    END IF;
+END
+$$;
+
+CREATE UNIQUE INDEX "team_members_team_id_user_id_idx" ON "team_members" ("team_id", "user_id");
+
+CREATE UNIQUE INDEX "team_members_team_id_number_idx" ON "team_members" ("team_id", "number");
\ No newline at end of file
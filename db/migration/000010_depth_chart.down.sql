@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "depth_chart_entries";
+
+DROP TABLE IF EXISTS "player_positions";
\ No newline at end of file
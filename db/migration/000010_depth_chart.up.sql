@@ -0,0 +1,29 @@
+CREATE TABLE "player_positions"
+(
+    "id"         uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "team_id"    uuid             NOT NULL,
+    "user_id"    uuid             NOT NULL,
+    "position"   varchar          NOT NULL,
+    "rank"       bigint           NOT NULL,
+    "created_at" timestamptz      NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "depth_chart_entries"
+(
+    "id"         uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "team_id"    uuid             NOT NULL,
+    "position"   varchar          NOT NULL,
+    "user_id"    uuid             NOT NULL,
+    "rank"       bigint           NOT NULL,
+    "created_at" timestamptz      NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "player_positions" ("team_id", "user_id", "position");
+
+CREATE UNIQUE INDEX ON "depth_chart_entries" ("team_id", "position", "user_id");
+
+ALTER TABLE "player_positions"
+    ADD FOREIGN KEY ("team_id", "user_id") REFERENCES "team_members" ("team_id", "user_id") ON DELETE CASCADE;
+
+ALTER TABLE "depth_chart_entries"
+    ADD FOREIGN KEY ("team_id", "user_id") REFERENCES "team_members" ("team_id", "user_id") ON DELETE CASCADE;
\ No newline at end of file
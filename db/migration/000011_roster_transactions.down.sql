@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "roster_transactions";
+
+DROP TABLE IF EXISTS "team_member_stints";
\ No newline at end of file
@@ -0,0 +1,54 @@
+CREATE TABLE "team_member_stints"
+(
+    "id"               uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "team_id"          uuid             NOT NULL,
+    "user_id"          uuid             NOT NULL,
+    "number"           bigint           NOT NULL,
+    "primary_position" varchar          NOT NULL,
+    "status"           varchar          NOT NULL DEFAULT 'active',
+    "started_at"       timestamptz      NOT NULL DEFAULT (now()),
+    "ended_at"         timestamptz
+);
+
+CREATE TABLE "roster_transactions"
+(
+    "id"           uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "type"         varchar          NOT NULL,
+    "user_id"      uuid             NOT NULL,
+    "from_team_id" uuid,
+    "to_team_id"   uuid,
+    "effective_at" timestamptz      NOT NULL,
+    "note"         varchar          NOT NULL DEFAULT '',
+    "created_by"   uuid             NOT NULL,
+    "created_at"   timestamptz      NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "team_member_stints" ("team_id", "started_at");
+
+CREATE UNIQUE INDEX "team_member_stints_open_idx" ON "team_member_stints" ("team_id", "user_id") WHERE "ended_at" IS NULL;
+
+CREATE INDEX ON "roster_transactions" ("from_team_id", "effective_at");
+
+CREATE INDEX ON "roster_transactions" ("to_team_id", "effective_at");
+
+ALTER TABLE "team_member_stints"
+    ADD FOREIGN KEY ("team_id") REFERENCES "teams" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "team_member_stints"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+ALTER TABLE "roster_transactions"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+ALTER TABLE "roster_transactions"
+    ADD FOREIGN KEY ("from_team_id") REFERENCES "teams" ("id") ON DELETE SET NULL;
+
+ALTER TABLE "roster_transactions"
+    ADD FOREIGN KEY ("to_team_id") REFERENCES "teams" ("id") ON DELETE SET NULL;
+
+ALTER TABLE "roster_transactions"
+    ADD FOREIGN KEY ("created_by") REFERENCES "users" ("id");
+
+INSERT INTO "team_member_stints" ("team_id", "user_id", "number", "primary_position", "started_at")
+SELECT "team_id", "user_id", "number", "primary_position", "created_at"
+FROM "team_members";
\ No newline at end of file
@@ -0,0 +1,56 @@
+CREATE TABLE "team_invitations"
+(
+    "id"               uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "team_id"          uuid             NOT NULL,
+    "email"            varchar,
+    "code"             varchar          NOT NULL,
+    "number"           bigint,
+    "primary_position" varchar,
+    "status"           varchar          NOT NULL DEFAULT 'pending',
+    "invited_by"       uuid             NOT NULL,
+    "accepted_by"      uuid,
+    "expires_at"       timestamptz      NOT NULL,
+    "created_at"       timestamptz      NOT NULL DEFAULT (now()),
+    "updated_at"       timestamptz      NOT NULL DEFAULT (now()),
+    CONSTRAINT "team_invitations_proposal_check" CHECK (
+        ("email" IS NOT NULL AND "number" IS NOT NULL AND "primary_position" IS NOT NULL) OR
+        ("email" IS NULL AND "number" IS NULL AND "primary_position" IS NULL))
+);
+
+CREATE TABLE "join_requests"
+(
+    "id"               uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "team_id"          uuid             NOT NULL,
+    "user_id"          uuid             NOT NULL,
+    "number"           bigint           NOT NULL,
+    "primary_position" varchar          NOT NULL,
+    "message"          varchar          NOT NULL DEFAULT '',
+    "status"           varchar          NOT NULL DEFAULT 'pending',
+    "decided_by"       uuid,
+    "decided_at"       timestamptz,
+    "created_at"       timestamptz      NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "team_invitations" ("code");
+
+CREATE INDEX ON "team_invitations" ("team_id", "created_at");
+
+CREATE UNIQUE INDEX "join_requests_pending_idx" ON "join_requests" ("team_id", "user_id") WHERE "status" = 'pending';
+
+ALTER TABLE "team_invitations"
+    ADD FOREIGN KEY ("team_id") REFERENCES "teams" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "team_invitations"
+    ADD FOREIGN KEY ("invited_by") REFERENCES "users" ("id");
+
+ALTER TABLE "team_invitations"
+    ADD FOREIGN KEY ("accepted_by") REFERENCES "users" ("id");
+
+ALTER TABLE "join_requests"
+    ADD FOREIGN KEY ("team_id") REFERENCES "teams" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "join_requests"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+ALTER TABLE "join_requests"
+    ADD FOREIGN KEY ("decided_by") REFERENCES "users" ("id");
\ No newline at end of file
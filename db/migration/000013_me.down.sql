@@ -0,0 +1,5 @@
+ALTER TABLE "game"
+    DROP COLUMN IF EXISTS "status";
+
+ALTER TABLE "team_members"
+    DROP COLUMN IF EXISTS "role";
\ No newline at end of file
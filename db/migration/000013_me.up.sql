@@ -0,0 +1,7 @@
+ALTER TABLE "team_members"
+    ADD COLUMN "role" varchar NOT NULL DEFAULT 'player';
+
+ALTER TABLE "game"
+    ADD COLUMN "status" varchar NOT NULL DEFAULT 'scheduled';
+
+CREATE INDEX ON "game" ("status");
\ No newline at end of file
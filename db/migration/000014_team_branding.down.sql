@@ -0,0 +1,14 @@
+ALTER TABLE "teams"
+    DROP CONSTRAINT IF EXISTS "teams_secondary_color_check",
+    DROP CONSTRAINT IF EXISTS "teams_primary_color_check",
+    DROP COLUMN IF EXISTS "x_url",
+    DROP COLUMN IF EXISTS "instagram_url",
+    DROP COLUMN IF EXISTS "facebook_url",
+    DROP COLUMN IF EXISTS "website_url",
+    DROP COLUMN IF EXISTS "logo_url",
+    DROP COLUMN IF EXISTS "division",
+    DROP COLUMN IF EXISTS "city",
+    DROP COLUMN IF EXISTS "home_venue",
+    DROP COLUMN IF EXISTS "secondary_color",
+    DROP COLUMN IF EXISTS "primary_color",
+    DROP COLUMN IF EXISTS "abbreviation";
\ No newline at end of file
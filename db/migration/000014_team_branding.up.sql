@@ -0,0 +1,16 @@
+ALTER TABLE "teams"
+    ADD COLUMN "abbreviation"    varchar(5) NOT NULL DEFAULT '',
+    ADD COLUMN "primary_color"   varchar(7) NOT NULL DEFAULT '',
+    ADD COLUMN "secondary_color" varchar(7) NOT NULL DEFAULT '',
+    ADD COLUMN "home_venue"      varchar    NOT NULL DEFAULT '',
+    ADD COLUMN "city"            varchar    NOT NULL DEFAULT '',
+    ADD COLUMN "division"        varchar    NOT NULL DEFAULT '',
+    ADD COLUMN "logo_url"        varchar    NOT NULL DEFAULT '',
+    ADD COLUMN "website_url"     varchar    NOT NULL DEFAULT '',
+    ADD COLUMN "facebook_url"    varchar    NOT NULL DEFAULT '',
+    ADD COLUMN "instagram_url"   varchar    NOT NULL DEFAULT '',
+    ADD COLUMN "x_url"           varchar    NOT NULL DEFAULT '';
+
+ALTER TABLE "teams"
+    ADD CONSTRAINT "teams_primary_color_check" CHECK ("primary_color" = '' OR "primary_color" ~ '^#[0-9a-f]{6}$'),
+    ADD CONSTRAINT "teams_secondary_color_check" CHECK ("secondary_color" = '' OR "secondary_color" ~ '^#[0-9a-f]{6}$');
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "game_availability";
\ No newline at end of file
@@ -0,0 +1,26 @@
+CREATE TABLE "game_availability"
+(
+    "id"           uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "game_id"      uuid             NOT NULL,
+    "team_id"      uuid             NOT NULL,
+    "user_id"      uuid             NOT NULL,
+    "response"     varchar          NOT NULL,
+    "note"         varchar          NOT NULL DEFAULT '',
+    "responded_by" uuid             NOT NULL,
+    "created_at"   timestamptz      NOT NULL DEFAULT (now()),
+    "updated_at"   timestamptz      NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "game_availability" ("game_id", "user_id");
+
+ALTER TABLE "game_availability"
+    ADD FOREIGN KEY ("game_id") REFERENCES "game" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "game_availability"
+    ADD FOREIGN KEY ("team_id") REFERENCES "teams" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "game_availability"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+ALTER TABLE "game_availability"
+    ADD FOREIGN KEY ("responded_by") REFERENCES "users" ("id");
\ No newline at end of file
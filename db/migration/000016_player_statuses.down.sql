@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "player_statuses";
\ No newline at end of file
@@ -0,0 +1,25 @@
+CREATE TABLE "player_statuses"
+(
+    "id"              uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "team_id"         uuid             NOT NULL,
+    "user_id"         uuid             NOT NULL,
+    "status"          varchar          NOT NULL,
+    "reason"          varchar          NOT NULL DEFAULT '',
+    "starts_at"       timestamptz      NOT NULL DEFAULT (now()),
+    "ends_at"         timestamptz,
+    "games_remaining" bigint,
+    "created_by"      uuid             NOT NULL,
+    "created_at"      timestamptz      NOT NULL DEFAULT (now()),
+    "updated_at"      timestamptz      NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "player_statuses" ("team_id", "user_id", "starts_at");
+
+ALTER TABLE "player_statuses"
+    ADD FOREIGN KEY ("team_id") REFERENCES "teams" ("id");
+
+ALTER TABLE "player_statuses"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+ALTER TABLE "player_statuses"
+    ADD FOREIGN KEY ("created_by") REFERENCES "users" ("id");
\ No newline at end of file
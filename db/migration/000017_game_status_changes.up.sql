@@ -0,0 +1,18 @@
+CREATE TABLE "game_status_changes"
+(
+    "id"          uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "game_id"     uuid             NOT NULL,
+    "from_status" varchar          NOT NULL,
+    "to_status"   varchar          NOT NULL,
+    "reason"      varchar          NOT NULL DEFAULT '',
+    "changed_by"  uuid             NOT NULL,
+    "changed_at"  timestamptz      NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "game_status_changes" ("game_id", "changed_at");
+
+ALTER TABLE "game_status_changes"
+    ADD FOREIGN KEY ("game_id") REFERENCES "game" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "game_status_changes"
+    ADD FOREIGN KEY ("changed_by") REFERENCES "users" ("id");
\ No newline at end of file
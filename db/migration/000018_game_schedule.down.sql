@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS "game_schedule_changes";
+
+ALTER TABLE "game"
+    DROP COLUMN IF EXISTS "venue_id",
+    DROP COLUMN IF EXISTS "time_zone",
+    DROP COLUMN IF EXISTS "scheduled_at";
\ No newline at end of file
@@ -0,0 +1,31 @@
+ALTER TABLE "game"
+    ADD COLUMN "scheduled_at" timestamptz,
+    ADD COLUMN "time_zone"    varchar NOT NULL DEFAULT 'UTC',
+    ADD COLUMN "venue_id"     uuid;
+
+CREATE INDEX ON "game" ("scheduled_at");
+
+CREATE INDEX ON "game" ("venue_id", "scheduled_at");
+
+CREATE TABLE "game_schedule_changes"
+(
+    "id"               uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "game_id"          uuid             NOT NULL,
+    "old_scheduled_at" timestamptz,
+    "new_scheduled_at" timestamptz      NOT NULL,
+    "old_time_zone"    varchar          NOT NULL,
+    "new_time_zone"    varchar          NOT NULL,
+    "old_venue_id"     uuid,
+    "new_venue_id"     uuid,
+    "reason"           varchar          NOT NULL DEFAULT '',
+    "changed_by"       uuid             NOT NULL,
+    "changed_at"       timestamptz      NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "game_schedule_changes" ("game_id", "changed_at");
+
+ALTER TABLE "game_schedule_changes"
+    ADD FOREIGN KEY ("game_id") REFERENCES "game" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "game_schedule_changes"
+    ADD FOREIGN KEY ("changed_by") REFERENCES "users" ("id");
\ No newline at end of file
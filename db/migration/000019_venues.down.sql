@@ -0,0 +1,14 @@
+ALTER TABLE "game_schedule_changes"
+    DROP COLUMN IF EXISTS "new_field_id",
+    DROP COLUMN IF EXISTS "old_field_id";
+
+ALTER TABLE "game"
+    DROP CONSTRAINT IF EXISTS "game_field_id_fkey",
+    DROP CONSTRAINT IF EXISTS "game_venue_id_fkey",
+    DROP COLUMN IF EXISTS "field_id";
+
+DROP TABLE IF EXISTS "field_availability";
+
+DROP TABLE IF EXISTS "fields";
+
+DROP TABLE IF EXISTS "venues";
\ No newline at end of file
@@ -0,0 +1,71 @@
+CREATE TABLE "venues"
+(
+    "id"         uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "name"       varchar          NOT NULL,
+    "address"    varchar          NOT NULL DEFAULT '',
+    "city"       varchar          NOT NULL DEFAULT '',
+    "latitude"   double precision NOT NULL CHECK ("latitude" BETWEEN -90 AND 90),
+    "longitude"  double precision NOT NULL CHECK ("longitude" BETWEEN -180 AND 180),
+    "created_by" uuid             NOT NULL,
+    "created_at" timestamptz      NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz      NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "fields"
+(
+    "id"              uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "venue_id"        uuid             NOT NULL,
+    "name"            varchar          NOT NULL,
+    "surface"         varchar          NOT NULL DEFAULT 'grass',
+    "left_field_ft"   bigint,
+    "center_field_ft" bigint,
+    "right_field_ft"  bigint,
+    "has_lights"      boolean          NOT NULL DEFAULT false,
+    "created_at"      timestamptz      NOT NULL DEFAULT (now()),
+    "updated_at"      timestamptz      NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "field_availability"
+(
+    "id"         uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "field_id"   uuid             NOT NULL,
+    "starts_at"  timestamptz      NOT NULL,
+    "ends_at"    timestamptz      NOT NULL,
+    "note"       varchar          NOT NULL DEFAULT '',
+    "created_by" uuid             NOT NULL,
+    "created_at" timestamptz      NOT NULL DEFAULT (now()),
+    CHECK ("ends_at" > "starts_at")
+);
+
+ALTER TABLE "game"
+    ADD COLUMN "field_id" uuid;
+
+ALTER TABLE "game_schedule_changes"
+    ADD COLUMN "old_field_id" uuid,
+    ADD COLUMN "new_field_id" uuid;
+
+CREATE INDEX ON "venues" ("latitude", "longitude");
+
+CREATE UNIQUE INDEX ON "fields" ("venue_id", "name");
+
+CREATE INDEX ON "field_availability" ("field_id", "starts_at");
+
+CREATE INDEX ON "game" ("field_id", "scheduled_at");
+
+ALTER TABLE "venues"
+    ADD FOREIGN KEY ("created_by") REFERENCES "users" ("id");
+
+ALTER TABLE "fields"
+    ADD FOREIGN KEY ("venue_id") REFERENCES "venues" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "field_availability"
+    ADD FOREIGN KEY ("field_id") REFERENCES "fields" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "field_availability"
+    ADD FOREIGN KEY ("created_by") REFERENCES "users" ("id");
+
+ALTER TABLE "game"
+    ADD FOREIGN KEY ("venue_id") REFERENCES "venues" ("id");
+
+ALTER TABLE "game"
+    ADD FOREIGN KEY ("field_id") REFERENCES "fields" ("id");
\ No newline at end of file
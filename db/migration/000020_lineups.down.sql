@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "game_participant_game_id_home_team_bat_position_idx";
+
+ALTER TABLE "game_participant"
+    DROP COLUMN IF EXISTS "created_at",
+    DROP COLUMN IF EXISTS "position",
+    ALTER COLUMN "player_id" DROP NOT NULL,
+    ALTER COLUMN "game_id" DROP NOT NULL;
\ No newline at end of file
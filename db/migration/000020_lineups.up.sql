@@ -0,0 +1,7 @@
+ALTER TABLE "game_participant"
+    ALTER COLUMN "game_id" SET NOT NULL,
+    ALTER COLUMN "player_id" SET NOT NULL,
+    ADD COLUMN "position"   varchar     NOT NULL DEFAULT '',
+    ADD COLUMN "created_at" timestamptz NOT NULL DEFAULT (now());
+
+CREATE INDEX ON "game_participant" ("game_id", "home_team", "bat_position");
\ No newline at end of file
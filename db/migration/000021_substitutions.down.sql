@@ -0,0 +1,8 @@
+ALTER TABLE "game_participant"
+    DROP COLUMN IF EXISTS "replaced_id",
+    DROP COLUMN IF EXISTS "exited_inning",
+    DROP COLUMN IF EXISTS "entered_inning",
+    DROP COLUMN IF EXISTS "entry";
+
+ALTER TABLE "game"
+    DROP COLUMN IF EXISTS "reentry_rule";
\ No newline at end of file
@@ -0,0 +1,11 @@
+ALTER TABLE "game"
+    ADD COLUMN "reentry_rule" varchar NOT NULL DEFAULT 'none';
+
+ALTER TABLE "game_participant"
+    ADD COLUMN "entry"          varchar NOT NULL DEFAULT 'starter',
+    ADD COLUMN "entered_inning" bigint  NOT NULL DEFAULT 1,
+    ADD COLUMN "exited_inning"  bigint,
+    ADD COLUMN "replaced_id"    uuid;
+
+ALTER TABLE "game_participant"
+    ADD FOREIGN KEY ("replaced_id") REFERENCES "game_participant" ("id");
\ No newline at end of file
@@ -0,0 +1,34 @@
+DROP TABLE IF EXISTS "pitches";
+
+DROP INDEX IF EXISTS "atbat_open_idx";
+
+DROP INDEX IF EXISTS "atbat_game_id_created_at_idx";
+
+ALTER TABLE "atbat"
+    DROP COLUMN IF EXISTS "ended_at",
+    DROP COLUMN IF EXISTS "created_at",
+    DROP COLUMN IF EXISTS "result",
+    DROP COLUMN IF EXISTS "pitches",
+    DROP COLUMN IF EXISTS "half",
+    DROP COLUMN IF EXISTS "game_id",
+    ALTER COLUMN "out" DROP DEFAULT,
+    ALTER COLUMN "total_bases" DROP DEFAULT,
+    ALTER COLUMN "init_bases" DROP DEFAULT,
+    ALTER COLUMN "strikes" DROP DEFAULT,
+    ALTER COLUMN "balls" DROP DEFAULT,
+    ALTER COLUMN "pitcher_id" DROP NOT NULL,
+    ALTER COLUMN "batter_id" DROP NOT NULL,
+    ALTER COLUMN "inning_id" DROP NOT NULL;
+
+DROP INDEX IF EXISTS "inning_game_id_number_idx";
+
+ALTER TABLE "inning"
+    ALTER COLUMN "away_last_bat" SET NOT NULL,
+    ALTER COLUMN "away_errors" DROP DEFAULT,
+    ALTER COLUMN "away_hits" DROP DEFAULT,
+    ALTER COLUMN "away_runs" DROP DEFAULT,
+    ALTER COLUMN "home_last_bat" SET NOT NULL,
+    ALTER COLUMN "home_errors" DROP DEFAULT,
+    ALTER COLUMN "home_hits" DROP DEFAULT,
+    ALTER COLUMN "home_runs" DROP DEFAULT,
+    ALTER COLUMN "game_id" DROP NOT NULL;
\ No newline at end of file
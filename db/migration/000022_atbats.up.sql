@@ -0,0 +1,55 @@
+ALTER TABLE "inning"
+    ALTER COLUMN "game_id" SET NOT NULL,
+    ALTER COLUMN "home_runs" SET DEFAULT 0,
+    ALTER COLUMN "home_hits" SET DEFAULT 0,
+    ALTER COLUMN "home_errors" SET DEFAULT 0,
+    ALTER COLUMN "home_last_bat" DROP NOT NULL,
+    ALTER COLUMN "away_runs" SET DEFAULT 0,
+    ALTER COLUMN "away_hits" SET DEFAULT 0,
+    ALTER COLUMN "away_errors" SET DEFAULT 0,
+    ALTER COLUMN "away_last_bat" DROP NOT NULL;
+
+CREATE UNIQUE INDEX ON "inning" ("game_id", "number");
+
+ALTER TABLE "atbat"
+    ALTER COLUMN "inning_id" SET NOT NULL,
+    ALTER COLUMN "batter_id" SET NOT NULL,
+    ALTER COLUMN "pitcher_id" SET NOT NULL,
+    ALTER COLUMN "balls" SET DEFAULT 0,
+    ALTER COLUMN "strikes" SET DEFAULT 0,
+    ALTER COLUMN "init_bases" SET DEFAULT 0,
+    ALTER COLUMN "total_bases" SET DEFAULT 0,
+    ALTER COLUMN "out" SET DEFAULT false,
+    ADD COLUMN "game_id"    uuid        NOT NULL,
+    ADD COLUMN "half"       varchar     NOT NULL,
+    ADD COLUMN "pitches"    bigint      NOT NULL DEFAULT 0,
+    ADD COLUMN "result"     varchar,
+    ADD COLUMN "created_at" timestamptz NOT NULL DEFAULT (now()),
+    ADD COLUMN "ended_at"   timestamptz;
+
+CREATE UNIQUE INDEX "atbat_open_idx" ON "atbat" ("game_id") WHERE "result" IS NULL;
+
+CREATE INDEX ON "atbat" ("game_id", "created_at");
+
+CREATE TABLE "pitches"
+(
+    "id"         uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "atbat_id"   uuid             NOT NULL,
+    "number"     bigint           NOT NULL,
+    "type"       varchar          NOT NULL,
+    "balls"      bigint           NOT NULL,
+    "strikes"    bigint           NOT NULL,
+    "created_by" uuid             NOT NULL,
+    "created_at" timestamptz      NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "pitches" ("atbat_id", "number");
+
+ALTER TABLE "atbat"
+    ADD FOREIGN KEY ("game_id") REFERENCES "game" ("id");
+
+ALTER TABLE "pitches"
+    ADD FOREIGN KEY ("atbat_id") REFERENCES "atbat" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "pitches"
+    ADD FOREIGN KEY ("created_by") REFERENCES "users" ("id");
\ No newline at end of file
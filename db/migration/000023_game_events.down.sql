@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS "atbat_game_id_number_idx";
+
+ALTER TABLE "atbat"
+    DROP COLUMN IF EXISTS "number";
+
+CREATE UNIQUE INDEX "atbat_open_idx" ON "atbat" ("game_id") WHERE "result" IS NULL;
+
+DROP TABLE IF EXISTS "game_events";
\ No newline at end of file
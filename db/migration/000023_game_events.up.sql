@@ -0,0 +1,34 @@
+CREATE TABLE "game_events"
+(
+    "id"         uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "game_id"    uuid             NOT NULL,
+    "sequence"   bigint           NOT NULL,
+    "type"       varchar          NOT NULL,
+    "payload"    jsonb            NOT NULL,
+    "created_by" uuid             NOT NULL,
+    "created_at" timestamptz      NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "game_events" ("game_id", "sequence");
+
+ALTER TABLE "game_events"
+    ADD FOREIGN KEY ("game_id") REFERENCES "game" ("id");
+
+ALTER TABLE "game_events"
+    ADD FOREIGN KEY ("created_by") REFERENCES "users" ("id");
+
+DROP INDEX IF EXISTS "atbat_open_idx";
+
+ALTER TABLE "atbat"
+    ADD COLUMN "number" bigint NOT NULL DEFAULT 0;
+
+UPDATE "atbat" a
+SET "number" = n.number
+FROM (SELECT "id", row_number() OVER (PARTITION BY "game_id" ORDER BY "created_at") AS number
+      FROM "atbat") n
+WHERE a."id" = n."id";
+
+ALTER TABLE "atbat"
+    ALTER COLUMN "number" DROP DEFAULT;
+
+CREATE UNIQUE INDEX ON "atbat" ("game_id", "number");
\ No newline at end of file
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "atbat_runners";
+
+ALTER TABLE "atbat"
+    DROP COLUMN IF EXISTS "rbi",
+    DROP COLUMN IF EXISTS "runner_on_third",
+    DROP COLUMN IF EXISTS "runner_on_second",
+    DROP COLUMN IF EXISTS "runner_on_first";
\ No newline at end of file
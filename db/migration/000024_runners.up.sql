@@ -0,0 +1,43 @@
+ALTER TABLE "atbat"
+    ADD COLUMN "runner_on_first"  uuid,
+    ADD COLUMN "runner_on_second" uuid,
+    ADD COLUMN "runner_on_third"  uuid,
+    ADD COLUMN "rbi"              bigint NOT NULL DEFAULT 0;
+
+CREATE TABLE "atbat_runners"
+(
+    "id"         uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "atbat_id"   uuid             NOT NULL,
+    "number"     bigint           NOT NULL,
+    "runner_id"  uuid             NOT NULL,
+    "from_base"  bigint           NOT NULL,
+    "to_base"    bigint           NOT NULL,
+    "out"        boolean          NOT NULL DEFAULT false,
+    "reason"     varchar          NOT NULL,
+    "scored"     boolean          NOT NULL DEFAULT false,
+    "rbi"        boolean          NOT NULL DEFAULT false,
+    "earned"     boolean          NOT NULL DEFAULT false,
+    "pitcher_id" uuid
+);
+
+CREATE UNIQUE INDEX ON "atbat_runners" ("atbat_id", "number");
+
+CREATE INDEX ON "atbat_runners" ("runner_id");
+
+ALTER TABLE "atbat"
+    ADD FOREIGN KEY ("runner_on_first") REFERENCES "game_participant" ("id");
+
+ALTER TABLE "atbat"
+    ADD FOREIGN KEY ("runner_on_second") REFERENCES "game_participant" ("id");
+
+ALTER TABLE "atbat"
+    ADD FOREIGN KEY ("runner_on_third") REFERENCES "game_participant" ("id");
+
+ALTER TABLE "atbat_runners"
+    ADD FOREIGN KEY ("atbat_id") REFERENCES "atbat" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "atbat_runners"
+    ADD FOREIGN KEY ("runner_id") REFERENCES "game_participant" ("id");
+
+ALTER TABLE "atbat_runners"
+    ADD FOREIGN KEY ("pitcher_id") REFERENCES "game_participant" ("id");
\ No newline at end of file
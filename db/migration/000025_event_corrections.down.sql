@@ -0,0 +1,16 @@
+DROP INDEX IF EXISTS "game_events_active_idx";
+
+DROP INDEX IF EXISTS "game_events_game_id_sequence_idx";
+
+ALTER TABLE "game_events"
+    DROP COLUMN IF EXISTS "corrects_id";
+
+DELETE
+FROM "game_events"
+WHERE "voided_at" IS NOT NULL;
+
+ALTER TABLE "game_events"
+    DROP COLUMN IF EXISTS "voided_by",
+    DROP COLUMN IF EXISTS "voided_at";
+
+CREATE UNIQUE INDEX ON "game_events" ("game_id", "sequence");
\ No newline at end of file
@@ -0,0 +1,16 @@
+ALTER TABLE "game_events"
+    ADD COLUMN "corrects_id" uuid,
+    ADD COLUMN "voided_by"   uuid,
+    ADD COLUMN "voided_at"   timestamptz;
+
+DROP INDEX IF EXISTS "game_events_game_id_sequence_idx";
+
+CREATE UNIQUE INDEX "game_events_active_idx" ON "game_events" ("game_id", "sequence") WHERE "voided_at" IS NULL;
+
+CREATE INDEX ON "game_events" ("game_id", "sequence");
+
+ALTER TABLE "game_events"
+    ADD FOREIGN KEY ("corrects_id") REFERENCES "game_events" ("id");
+
+ALTER TABLE "game_events"
+    ADD FOREIGN KEY ("voided_by") REFERENCES "users" ("id");
\ No newline at end of file
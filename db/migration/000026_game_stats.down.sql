@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS "game_stat_atbat_id_idx";
+
+ALTER TABLE "game_stat"
+    DROP CONSTRAINT IF EXISTS "game_stat_atbat_id_fkey";
+
+ALTER TABLE "game_stat"
+    DROP COLUMN IF EXISTS "participant_id";
+
+ALTER TABLE "game_stat"
+    ADD FOREIGN KEY ("atbat_id") REFERENCES "atbat" ("id");
\ No newline at end of file
@@ -0,0 +1,13 @@
+ALTER TABLE "game_stat"
+    ADD COLUMN "participant_id" uuid;
+
+ALTER TABLE "game_stat"
+    DROP CONSTRAINT IF EXISTS "game_stat_atbat_id_fkey";
+
+CREATE INDEX ON "game_stat" ("atbat_id");
+
+ALTER TABLE "game_stat"
+    ADD FOREIGN KEY ("atbat_id") REFERENCES "atbat" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "game_stat"
+    ADD FOREIGN KEY ("participant_id") REFERENCES "game_participant" ("id");
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE "game"
+    ADD COLUMN "rules" jsonb NOT NULL DEFAULT '{}';
\ No newline at end of file
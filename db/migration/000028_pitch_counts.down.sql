@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "atbat_pitcher_id_idx";
+
+DROP TABLE IF EXISTS "pitch_counts";
\ No newline at end of file
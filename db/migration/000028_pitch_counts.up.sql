@@ -0,0 +1,25 @@
+CREATE TABLE "pitch_counts"
+(
+    "id"         uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "game_id"    uuid             NOT NULL,
+    "player_id"  uuid             NOT NULL,
+    "pitches"    bigint           NOT NULL,
+    "entered_by" uuid             NOT NULL,
+    "created_at" timestamptz      NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz      NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "pitch_counts" ("game_id", "player_id");
+
+CREATE INDEX ON "pitch_counts" ("player_id");
+
+CREATE INDEX ON "atbat" ("pitcher_id");
+
+ALTER TABLE "pitch_counts"
+    ADD FOREIGN KEY ("game_id") REFERENCES "game" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "pitch_counts"
+    ADD FOREIGN KEY ("player_id") REFERENCES "users" ("id");
+
+ALTER TABLE "pitch_counts"
+    ADD FOREIGN KEY ("entered_by") REFERENCES "users" ("id");
\ No newline at end of file
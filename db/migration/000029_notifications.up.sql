@@ -0,0 +1,22 @@
+CREATE TABLE "notifications"
+(
+    "id"         uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "user_id"    uuid             NOT NULL,
+    "player_id"  uuid             NOT NULL,
+    "game_id"    uuid,
+    "kind"       varchar          NOT NULL,
+    "message"    varchar          NOT NULL,
+    "read_at"    timestamptz,
+    "created_at" timestamptz      NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "notifications" ("user_id", "created_at");
+
+ALTER TABLE "notifications"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "notifications"
+    ADD FOREIGN KEY ("player_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "notifications"
+    ADD FOREIGN KEY ("game_id") REFERENCES "game" ("id") ON DELETE CASCADE;
\ No newline at end of file
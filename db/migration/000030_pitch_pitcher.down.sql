@@ -0,0 +1,2 @@
+ALTER TABLE "pitches"
+    DROP COLUMN IF EXISTS "pitcher_id";
\ No newline at end of file
@@ -0,0 +1,15 @@
+ALTER TABLE "pitches"
+    ADD COLUMN "pitcher_id" uuid;
+
+UPDATE "pitches" pi
+SET "pitcher_id" = a."pitcher_id"
+FROM "atbat" a
+WHERE a."id" = pi."atbat_id";
+
+ALTER TABLE "pitches"
+    ALTER COLUMN "pitcher_id" SET NOT NULL;
+
+CREATE INDEX ON "pitches" ("pitcher_id");
+
+ALTER TABLE "pitches"
+    ADD FOREIGN KEY ("pitcher_id") REFERENCES "game_participant" ("id");
\ No newline at end of file
@@ -0,0 +1 @@
+DROP FUNCTION IF EXISTS "profile_visible"(uuid, uuid);
\ No newline at end of file
@@ -0,0 +1,20 @@
+CREATE FUNCTION "profile_visible"("viewer" uuid, "target" uuid) RETURNS boolean
+    LANGUAGE sql
+    STABLE
+AS
+$$
+SELECT u."id" = "viewer"
+           OR u."profile_visibility" = 'public'
+           OR (u."profile_visibility" = 'teammates' AND EXISTS(SELECT 1
+                                                               FROM "team_members" a
+                                                                        JOIN "team_members" b ON a."team_id" = b."team_id"
+                                                               WHERE a."user_id" = u."id"
+                                                                 AND b."user_id" = "viewer"))
+           OR EXISTS(SELECT 1
+                     FROM "guardians" g
+                     WHERE g."player_id" = u."id"
+                       AND g."guardian_id" = "viewer"
+                       AND g."status" = 'approved')
+FROM "users" u
+WHERE u."id" = "target"
+$$;
\ No newline at end of file
@@ -36,376 +36,2223 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AcceptInvitationTx mocks base method.
+func (m *MockStore) AcceptInvitationTx(arg0 context.Context, arg1 db.AcceptInvitationTxParams) (db.AcceptInvitationTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitationTx", arg0, arg1)
+	ret0, _ := ret[0].(db.AcceptInvitationTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptInvitationTx indicates an expected call of AcceptInvitationTx.
+func (mr *MockStoreMockRecorder) AcceptInvitationTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitationTx", reflect.TypeOf((*MockStore)(nil).AcceptInvitationTx), arg0, arg1)
+}
+
+// AcceptTeamInvitation mocks base method.
+func (m *MockStore) AcceptTeamInvitation(arg0 context.Context, arg1 db.AcceptTeamInvitationParams) (db.TeamInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptTeamInvitation", arg0, arg1)
+	ret0, _ := ret[0].(db.TeamInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptTeamInvitation indicates an expected call of AcceptTeamInvitation.
+func (mr *MockStoreMockRecorder) AcceptTeamInvitation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptTeamInvitation", reflect.TypeOf((*MockStore)(nil).AcceptTeamInvitation), arg0, arg1)
+}
+
 // AddTeamMember mocks base method.
 func (m *MockStore) AddTeamMember(arg0 context.Context, arg1 db.AddTeamMemberParams) (db.TeamMember, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddTeamMember", arg0, arg1)
-	ret0, _ := ret[0].(db.TeamMember)
+	ret := m.ctrl.Call(m, "AddTeamMember", arg0, arg1)
+	ret0, _ := ret[0].(db.TeamMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTeamMember indicates an expected call of AddTeamMember.
+func (mr *MockStoreMockRecorder) AddTeamMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTeamMember", reflect.TypeOf((*MockStore)(nil).AddTeamMember), arg0, arg1)
+}
+
+// ApproveGuardianTx mocks base method.
+func (m *MockStore) ApproveGuardianTx(arg0 context.Context, arg1 db.ApproveGuardianTxParams) (db.ApproveGuardianTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveGuardianTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ApproveGuardianTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveGuardianTx indicates an expected call of ApproveGuardianTx.
+func (mr *MockStoreMockRecorder) ApproveGuardianTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveGuardianTx", reflect.TypeOf((*MockStore)(nil).ApproveGuardianTx), arg0, arg1)
+}
+
+// ApproveJoinRequestTx mocks base method.
+func (m *MockStore) ApproveJoinRequestTx(arg0 context.Context, arg1 db.ApproveJoinRequestTxParams) (db.ApproveJoinRequestTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveJoinRequestTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ApproveJoinRequestTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveJoinRequestTx indicates an expected call of ApproveJoinRequestTx.
+func (mr *MockStoreMockRecorder) ApproveJoinRequestTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveJoinRequestTx", reflect.TypeOf((*MockStore)(nil).ApproveJoinRequestTx), arg0, arg1)
+}
+
+// ArchiveTeam mocks base method.
+func (m *MockStore) ArchiveTeam(arg0 context.Context, arg1 uuid.UUID) (db.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveTeam", arg0, arg1)
+	ret0, _ := ret[0].(db.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveTeam indicates an expected call of ArchiveTeam.
+func (mr *MockStoreMockRecorder) ArchiveTeam(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveTeam", reflect.TypeOf((*MockStore)(nil).ArchiveTeam), arg0, arg1)
+}
+
+// AreTeammates mocks base method.
+func (m *MockStore) AreTeammates(arg0 context.Context, arg1 db.AreTeammatesParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AreTeammates", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AreTeammates indicates an expected call of AreTeammates.
+func (mr *MockStoreMockRecorder) AreTeammates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AreTeammates", reflect.TypeOf((*MockStore)(nil).AreTeammates), arg0, arg1)
+}
+
+// ClearPlayerStatus mocks base method.
+func (m *MockStore) ClearPlayerStatus(arg0 context.Context, arg1 db.ClearPlayerStatusParams) (db.PlayerStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearPlayerStatus", arg0, arg1)
+	ret0, _ := ret[0].(db.PlayerStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearPlayerStatus indicates an expected call of ClearPlayerStatus.
+func (mr *MockStoreMockRecorder) ClearPlayerStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearPlayerStatus", reflect.TypeOf((*MockStore)(nil).ClearPlayerStatus), arg0, arg1)
+}
+
+// CloseTeamMemberStint mocks base method.
+func (m *MockStore) CloseTeamMemberStint(arg0 context.Context, arg1 db.CloseTeamMemberStintParams) (db.TeamMemberStint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseTeamMemberStint", arg0, arg1)
+	ret0, _ := ret[0].(db.TeamMemberStint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseTeamMemberStint indicates an expected call of CloseTeamMemberStint.
+func (mr *MockStoreMockRecorder) CloseTeamMemberStint(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseTeamMemberStint", reflect.TypeOf((*MockStore)(nil).CloseTeamMemberStint), arg0, arg1)
+}
+
+// CorrectGameEventTx mocks base method.
+func (m *MockStore) CorrectGameEventTx(arg0 context.Context, arg1 db.CorrectGameEventTxParams) (db.CorrectGameEventTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CorrectGameEventTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CorrectGameEventTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CorrectGameEventTx indicates an expected call of CorrectGameEventTx.
+func (mr *MockStoreMockRecorder) CorrectGameEventTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CorrectGameEventTx", reflect.TypeOf((*MockStore)(nil).CorrectGameEventTx), arg0, arg1)
+}
+
+// CreateAtbatRunner mocks base method.
+func (m *MockStore) CreateAtbatRunner(arg0 context.Context, arg1 db.CreateAtbatRunnerParams) (db.AtbatRunner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAtbatRunner", arg0, arg1)
+	ret0, _ := ret[0].(db.AtbatRunner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAtbatRunner indicates an expected call of CreateAtbatRunner.
+func (mr *MockStoreMockRecorder) CreateAtbatRunner(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAtbatRunner", reflect.TypeOf((*MockStore)(nil).CreateAtbatRunner), arg0, arg1)
+}
+
+// CreateAuditLog mocks base method.
+func (m *MockStore) CreateAuditLog(arg0 context.Context, arg1 db.CreateAuditLogParams) (db.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuditLog", arg0, arg1)
+	ret0, _ := ret[0].(db.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAuditLog indicates an expected call of CreateAuditLog.
+func (mr *MockStoreMockRecorder) CreateAuditLog(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuditLog", reflect.TypeOf((*MockStore)(nil).CreateAuditLog), arg0, arg1)
+}
+
+// CreateDepthChartEntry mocks base method.
+func (m *MockStore) CreateDepthChartEntry(arg0 context.Context, arg1 db.CreateDepthChartEntryParams) (db.DepthChartEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDepthChartEntry", arg0, arg1)
+	ret0, _ := ret[0].(db.DepthChartEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDepthChartEntry indicates an expected call of CreateDepthChartEntry.
+func (mr *MockStoreMockRecorder) CreateDepthChartEntry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDepthChartEntry", reflect.TypeOf((*MockStore)(nil).CreateDepthChartEntry), arg0, arg1)
+}
+
+// CreateField mocks base method.
+func (m *MockStore) CreateField(arg0 context.Context, arg1 db.CreateFieldParams) (db.Field, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateField", arg0, arg1)
+	ret0, _ := ret[0].(db.Field)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateField indicates an expected call of CreateField.
+func (mr *MockStoreMockRecorder) CreateField(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateField", reflect.TypeOf((*MockStore)(nil).CreateField), arg0, arg1)
+}
+
+// CreateFieldAvailability mocks base method.
+func (m *MockStore) CreateFieldAvailability(arg0 context.Context, arg1 db.CreateFieldAvailabilityParams) (db.FieldAvailability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFieldAvailability", arg0, arg1)
+	ret0, _ := ret[0].(db.FieldAvailability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFieldAvailability indicates an expected call of CreateFieldAvailability.
+func (mr *MockStoreMockRecorder) CreateFieldAvailability(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFieldAvailability", reflect.TypeOf((*MockStore)(nil).CreateFieldAvailability), arg0, arg1)
+}
+
+// CreateGame mocks base method.
+func (m *MockStore) CreateGame(arg0 context.Context, arg1 db.CreateGameParams) (db.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGame", arg0, arg1)
+	ret0, _ := ret[0].(db.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGame indicates an expected call of CreateGame.
+func (mr *MockStoreMockRecorder) CreateGame(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGame", reflect.TypeOf((*MockStore)(nil).CreateGame), arg0, arg1)
+}
+
+// CreateGameEvent mocks base method.
+func (m *MockStore) CreateGameEvent(arg0 context.Context, arg1 db.CreateGameEventParams) (db.GameEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGameEvent", arg0, arg1)
+	ret0, _ := ret[0].(db.GameEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGameEvent indicates an expected call of CreateGameEvent.
+func (mr *MockStoreMockRecorder) CreateGameEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGameEvent", reflect.TypeOf((*MockStore)(nil).CreateGameEvent), arg0, arg1)
+}
+
+// CreateGameParticipant mocks base method.
+func (m *MockStore) CreateGameParticipant(arg0 context.Context, arg1 db.CreateGameParticipantParams) (db.GameParticipant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGameParticipant", arg0, arg1)
+	ret0, _ := ret[0].(db.GameParticipant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGameParticipant indicates an expected call of CreateGameParticipant.
+func (mr *MockStoreMockRecorder) CreateGameParticipant(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGameParticipant", reflect.TypeOf((*MockStore)(nil).CreateGameParticipant), arg0, arg1)
+}
+
+// CreateGameScheduleChange mocks base method.
+func (m *MockStore) CreateGameScheduleChange(arg0 context.Context, arg1 db.CreateGameScheduleChangeParams) (db.GameScheduleChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGameScheduleChange", arg0, arg1)
+	ret0, _ := ret[0].(db.GameScheduleChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGameScheduleChange indicates an expected call of CreateGameScheduleChange.
+func (mr *MockStoreMockRecorder) CreateGameScheduleChange(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGameScheduleChange", reflect.TypeOf((*MockStore)(nil).CreateGameScheduleChange), arg0, arg1)
+}
+
+// CreateGameStat mocks base method.
+func (m *MockStore) CreateGameStat(arg0 context.Context, arg1 db.CreateGameStatParams) (db.GameStat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGameStat", arg0, arg1)
+	ret0, _ := ret[0].(db.GameStat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGameStat indicates an expected call of CreateGameStat.
+func (mr *MockStoreMockRecorder) CreateGameStat(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGameStat", reflect.TypeOf((*MockStore)(nil).CreateGameStat), arg0, arg1)
+}
+
+// CreateGameStatusChange mocks base method.
+func (m *MockStore) CreateGameStatusChange(arg0 context.Context, arg1 db.CreateGameStatusChangeParams) (db.GameStatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGameStatusChange", arg0, arg1)
+	ret0, _ := ret[0].(db.GameStatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGameStatusChange indicates an expected call of CreateGameStatusChange.
+func (mr *MockStoreMockRecorder) CreateGameStatusChange(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGameStatusChange", reflect.TypeOf((*MockStore)(nil).CreateGameStatusChange), arg0, arg1)
+}
+
+// CreateGameTx mocks base method.
+func (m *MockStore) CreateGameTx(arg0 context.Context, arg1 db.CreateGameTxParams) (db.CreateGameTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGameTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CreateGameTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGameTx indicates an expected call of CreateGameTx.
+func (mr *MockStoreMockRecorder) CreateGameTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGameTx", reflect.TypeOf((*MockStore)(nil).CreateGameTx), arg0, arg1)
+}
+
+// CreateGuardian mocks base method.
+func (m *MockStore) CreateGuardian(arg0 context.Context, arg1 db.CreateGuardianParams) (db.Guardian, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGuardian", arg0, arg1)
+	ret0, _ := ret[0].(db.Guardian)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGuardian indicates an expected call of CreateGuardian.
+func (mr *MockStoreMockRecorder) CreateGuardian(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGuardian", reflect.TypeOf((*MockStore)(nil).CreateGuardian), arg0, arg1)
+}
+
+// CreateJoinRequest mocks base method.
+func (m *MockStore) CreateJoinRequest(arg0 context.Context, arg1 db.CreateJoinRequestParams) (db.JoinRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJoinRequest", arg0, arg1)
+	ret0, _ := ret[0].(db.JoinRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateJoinRequest indicates an expected call of CreateJoinRequest.
+func (mr *MockStoreMockRecorder) CreateJoinRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJoinRequest", reflect.TypeOf((*MockStore)(nil).CreateJoinRequest), arg0, arg1)
+}
+
+// CreatePlayerPosition mocks base method.
+func (m *MockStore) CreatePlayerPosition(arg0 context.Context, arg1 db.CreatePlayerPositionParams) (db.PlayerPosition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePlayerPosition", arg0, arg1)
+	ret0, _ := ret[0].(db.PlayerPosition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePlayerPosition indicates an expected call of CreatePlayerPosition.
+func (mr *MockStoreMockRecorder) CreatePlayerPosition(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePlayerPosition", reflect.TypeOf((*MockStore)(nil).CreatePlayerPosition), arg0, arg1)
+}
+
+// CreatePlayerStatus mocks base method.
+func (m *MockStore) CreatePlayerStatus(arg0 context.Context, arg1 db.CreatePlayerStatusParams) (db.PlayerStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePlayerStatus", arg0, arg1)
+	ret0, _ := ret[0].(db.PlayerStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePlayerStatus indicates an expected call of CreatePlayerStatus.
+func (mr *MockStoreMockRecorder) CreatePlayerStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePlayerStatus", reflect.TypeOf((*MockStore)(nil).CreatePlayerStatus), arg0, arg1)
+}
+
+// CreateRole mocks base method.
+func (m *MockStore) CreateRole(arg0 context.Context, arg1 db.CreateRoleParams) (db.UserRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRole", arg0, arg1)
+	ret0, _ := ret[0].(db.UserRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRole indicates an expected call of CreateRole.
+func (mr *MockStoreMockRecorder) CreateRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*MockStore)(nil).CreateRole), arg0, arg1)
+}
+
+// CreateRosterTransaction mocks base method.
+func (m *MockStore) CreateRosterTransaction(arg0 context.Context, arg1 db.CreateRosterTransactionParams) (db.RosterTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRosterTransaction", arg0, arg1)
+	ret0, _ := ret[0].(db.RosterTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRosterTransaction indicates an expected call of CreateRosterTransaction.
+func (mr *MockStoreMockRecorder) CreateRosterTransaction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRosterTransaction", reflect.TypeOf((*MockStore)(nil).CreateRosterTransaction), arg0, arg1)
+}
+
+// CreateSession mocks base method.
+func (m *MockStore) CreateSession(arg0 context.Context, arg1 db.CreateSessionParams) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", arg0, arg1)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockStoreMockRecorder) CreateSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), arg0, arg1)
+}
+
+// CreateTeam mocks base method.
+func (m *MockStore) CreateTeam(arg0 context.Context, arg1 string) (db.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTeam", arg0, arg1)
+	ret0, _ := ret[0].(db.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTeam indicates an expected call of CreateTeam.
+func (mr *MockStoreMockRecorder) CreateTeam(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTeam", reflect.TypeOf((*MockStore)(nil).CreateTeam), arg0, arg1)
+}
+
+// CreateTeamInvitation mocks base method.
+func (m *MockStore) CreateTeamInvitation(arg0 context.Context, arg1 db.CreateTeamInvitationParams) (db.TeamInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTeamInvitation", arg0, arg1)
+	ret0, _ := ret[0].(db.TeamInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTeamInvitation indicates an expected call of CreateTeamInvitation.
+func (mr *MockStoreMockRecorder) CreateTeamInvitation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTeamInvitation", reflect.TypeOf((*MockStore)(nil).CreateTeamInvitation), arg0, arg1)
+}
+
+// CreateUser mocks base method.
+func (m *MockStore) CreateUser(arg0 context.Context, arg1 db.CreateUserParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
+}
+
+// CreateUserTx mocks base method.
+func (m *MockStore) CreateUserTx(arg0 context.Context, arg1 db.CreateUserTxParams) (db.CreateUserTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CreateUserTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUserTx indicates an expected call of CreateUserTx.
+func (mr *MockStoreMockRecorder) CreateUserTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserTx", reflect.TypeOf((*MockStore)(nil).CreateUserTx), arg0, arg1)
+}
+
+// CreateVenue mocks base method.
+func (m *MockStore) CreateVenue(arg0 context.Context, arg1 db.CreateVenueParams) (db.Venue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVenue", arg0, arg1)
+	ret0, _ := ret[0].(db.Venue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVenue indicates an expected call of CreateVenue.
+func (mr *MockStoreMockRecorder) CreateVenue(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVenue", reflect.TypeOf((*MockStore)(nil).CreateVenue), arg0, arg1)
+}
+
+// DecideJoinRequest mocks base method.
+func (m *MockStore) DecideJoinRequest(arg0 context.Context, arg1 db.DecideJoinRequestParams) (db.JoinRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecideJoinRequest", arg0, arg1)
+	ret0, _ := ret[0].(db.JoinRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecideJoinRequest indicates an expected call of DecideJoinRequest.
+func (mr *MockStoreMockRecorder) DecideJoinRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecideJoinRequest", reflect.TypeOf((*MockStore)(nil).DecideJoinRequest), arg0, arg1)
+}
+
+// DeleteAtbatRunners mocks base method.
+func (m *MockStore) DeleteAtbatRunners(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAtbatRunners", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAtbatRunners indicates an expected call of DeleteAtbatRunners.
+func (mr *MockStoreMockRecorder) DeleteAtbatRunners(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAtbatRunners", reflect.TypeOf((*MockStore)(nil).DeleteAtbatRunners), arg0, arg1)
+}
+
+// DeleteAtbatStats mocks base method.
+func (m *MockStore) DeleteAtbatStats(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAtbatStats", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAtbatStats indicates an expected call of DeleteAtbatStats.
+func (mr *MockStoreMockRecorder) DeleteAtbatStats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAtbatStats", reflect.TypeOf((*MockStore)(nil).DeleteAtbatStats), arg0, arg1)
+}
+
+// DeleteAtbatsAfter mocks base method.
+func (m *MockStore) DeleteAtbatsAfter(arg0 context.Context, arg1 db.DeleteAtbatsAfterParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAtbatsAfter", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAtbatsAfter indicates an expected call of DeleteAtbatsAfter.
+func (mr *MockStoreMockRecorder) DeleteAtbatsAfter(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAtbatsAfter", reflect.TypeOf((*MockStore)(nil).DeleteAtbatsAfter), arg0, arg1)
+}
+
+// DeleteDepthChartPosition mocks base method.
+func (m *MockStore) DeleteDepthChartPosition(arg0 context.Context, arg1 db.DeleteDepthChartPositionParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDepthChartPosition", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDepthChartPosition indicates an expected call of DeleteDepthChartPosition.
+func (mr *MockStoreMockRecorder) DeleteDepthChartPosition(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDepthChartPosition", reflect.TypeOf((*MockStore)(nil).DeleteDepthChartPosition), arg0, arg1)
+}
+
+// DeleteField mocks base method.
+func (m *MockStore) DeleteField(arg0 context.Context, arg1 db.DeleteFieldParams) (db.Field, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteField", arg0, arg1)
+	ret0, _ := ret[0].(db.Field)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteField indicates an expected call of DeleteField.
+func (mr *MockStoreMockRecorder) DeleteField(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteField", reflect.TypeOf((*MockStore)(nil).DeleteField), arg0, arg1)
+}
+
+// DeleteFieldAvailability mocks base method.
+func (m *MockStore) DeleteFieldAvailability(arg0 context.Context, arg1 db.DeleteFieldAvailabilityParams) (db.FieldAvailability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFieldAvailability", arg0, arg1)
+	ret0, _ := ret[0].(db.FieldAvailability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFieldAvailability indicates an expected call of DeleteFieldAvailability.
+func (mr *MockStoreMockRecorder) DeleteFieldAvailability(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFieldAvailability", reflect.TypeOf((*MockStore)(nil).DeleteFieldAvailability), arg0, arg1)
+}
+
+// DeleteGameParticipant mocks base method.
+func (m *MockStore) DeleteGameParticipant(arg0 context.Context, arg1 uuid.UUID) (db.GameParticipant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGameParticipant", arg0, arg1)
+	ret0, _ := ret[0].(db.GameParticipant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteGameParticipant indicates an expected call of DeleteGameParticipant.
+func (mr *MockStoreMockRecorder) DeleteGameParticipant(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGameParticipant", reflect.TypeOf((*MockStore)(nil).DeleteGameParticipant), arg0, arg1)
+}
+
+// DeleteGuardian mocks base method.
+func (m *MockStore) DeleteGuardian(arg0 context.Context, arg1 db.DeleteGuardianParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGuardian", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGuardian indicates an expected call of DeleteGuardian.
+func (mr *MockStoreMockRecorder) DeleteGuardian(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGuardian", reflect.TypeOf((*MockStore)(nil).DeleteGuardian), arg0, arg1)
+}
+
+// DeleteInningsAfter mocks base method.
+func (m *MockStore) DeleteInningsAfter(arg0 context.Context, arg1 db.DeleteInningsAfterParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInningsAfter", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteInningsAfter indicates an expected call of DeleteInningsAfter.
+func (mr *MockStoreMockRecorder) DeleteInningsAfter(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInningsAfter", reflect.TypeOf((*MockStore)(nil).DeleteInningsAfter), arg0, arg1)
+}
+
+// DeleteLineup mocks base method.
+func (m *MockStore) DeleteLineup(arg0 context.Context, arg1 db.DeleteLineupParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLineup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLineup indicates an expected call of DeleteLineup.
+func (mr *MockStoreMockRecorder) DeleteLineup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLineup", reflect.TypeOf((*MockStore)(nil).DeleteLineup), arg0, arg1)
+}
+
+// DeletePitchCount mocks base method.
+func (m *MockStore) DeletePitchCount(arg0 context.Context, arg1 db.DeletePitchCountParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePitchCount", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePitchCount indicates an expected call of DeletePitchCount.
+func (mr *MockStoreMockRecorder) DeletePitchCount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePitchCount", reflect.TypeOf((*MockStore)(nil).DeletePitchCount), arg0, arg1)
+}
+
+// DeletePitchesAfter mocks base method.
+func (m *MockStore) DeletePitchesAfter(arg0 context.Context, arg1 db.DeletePitchesAfterParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePitchesAfter", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePitchesAfter indicates an expected call of DeletePitchesAfter.
+func (mr *MockStoreMockRecorder) DeletePitchesAfter(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePitchesAfter", reflect.TypeOf((*MockStore)(nil).DeletePitchesAfter), arg0, arg1)
+}
+
+// DeletePlayerPositions mocks base method.
+func (m *MockStore) DeletePlayerPositions(arg0 context.Context, arg1 db.DeletePlayerPositionsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePlayerPositions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePlayerPositions indicates an expected call of DeletePlayerPositions.
+func (mr *MockStoreMockRecorder) DeletePlayerPositions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlayerPositions", reflect.TypeOf((*MockStore)(nil).DeletePlayerPositions), arg0, arg1)
+}
+
+// DeleteRole mocks base method.
+func (m *MockStore) DeleteRole(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRole", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRole indicates an expected call of DeleteRole.
+func (mr *MockStoreMockRecorder) DeleteRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockStore)(nil).DeleteRole), arg0, arg1)
+}
+
+// DeleteTeam mocks base method.
+func (m *MockStore) DeleteTeam(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTeam", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTeam indicates an expected call of DeleteTeam.
+func (mr *MockStoreMockRecorder) DeleteTeam(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTeam", reflect.TypeOf((*MockStore)(nil).DeleteTeam), arg0, arg1)
+}
+
+// DeleteUser mocks base method.
+func (m *MockStore) DeleteUser(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockStoreMockRecorder) DeleteUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockStore)(nil).DeleteUser), arg0, arg1)
+}
+
+// DeleteVenue mocks base method.
+func (m *MockStore) DeleteVenue(arg0 context.Context, arg1 uuid.UUID) (db.Venue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVenue", arg0, arg1)
+	ret0, _ := ret[0].(db.Venue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteVenue indicates an expected call of DeleteVenue.
+func (mr *MockStoreMockRecorder) DeleteVenue(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVenue", reflect.TypeOf((*MockStore)(nil).DeleteVenue), arg0, arg1)
+}
+
+// ExitGameParticipant mocks base method.
+func (m *MockStore) ExitGameParticipant(arg0 context.Context, arg1 db.ExitGameParticipantParams) (db.GameParticipant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExitGameParticipant", arg0, arg1)
+	ret0, _ := ret[0].(db.GameParticipant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExitGameParticipant indicates an expected call of ExitGameParticipant.
+func (mr *MockStoreMockRecorder) ExitGameParticipant(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExitGameParticipant", reflect.TypeOf((*MockStore)(nil).ExitGameParticipant), arg0, arg1)
+}
+
+// GameStatusTx mocks base method.
+func (m *MockStore) GameStatusTx(arg0 context.Context, arg1 db.GameStatusTxParams) (db.GameStatusTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GameStatusTx", arg0, arg1)
+	ret0, _ := ret[0].(db.GameStatusTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GameStatusTx indicates an expected call of GameStatusTx.
+func (mr *MockStoreMockRecorder) GameStatusTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GameStatusTx", reflect.TypeOf((*MockStore)(nil).GameStatusTx), arg0, arg1)
+}
+
+// GetAtbat mocks base method.
+func (m *MockStore) GetAtbat(arg0 context.Context, arg1 uuid.UUID) (db.Atbat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAtbat", arg0, arg1)
+	ret0, _ := ret[0].(db.Atbat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAtbat indicates an expected call of GetAtbat.
+func (mr *MockStoreMockRecorder) GetAtbat(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAtbat", reflect.TypeOf((*MockStore)(nil).GetAtbat), arg0, arg1)
+}
+
+// GetCurrentPlayerStatus mocks base method.
+func (m *MockStore) GetCurrentPlayerStatus(arg0 context.Context, arg1 db.GetCurrentPlayerStatusParams) (db.PlayerStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentPlayerStatus", arg0, arg1)
+	ret0, _ := ret[0].(db.PlayerStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentPlayerStatus indicates an expected call of GetCurrentPlayerStatus.
+func (mr *MockStoreMockRecorder) GetCurrentPlayerStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentPlayerStatus", reflect.TypeOf((*MockStore)(nil).GetCurrentPlayerStatus), arg0, arg1)
+}
+
+// GetField mocks base method.
+func (m *MockStore) GetField(arg0 context.Context, arg1 uuid.UUID) (db.Field, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetField", arg0, arg1)
+	ret0, _ := ret[0].(db.Field)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetField indicates an expected call of GetField.
+func (mr *MockStoreMockRecorder) GetField(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetField", reflect.TypeOf((*MockStore)(nil).GetField), arg0, arg1)
+}
+
+// GetGame mocks base method.
+func (m *MockStore) GetGame(arg0 context.Context, arg1 uuid.UUID) (db.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGame", arg0, arg1)
+	ret0, _ := ret[0].(db.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGame indicates an expected call of GetGame.
+func (mr *MockStoreMockRecorder) GetGame(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGame", reflect.TypeOf((*MockStore)(nil).GetGame), arg0, arg1)
+}
+
+// GetGameAvailability mocks base method.
+func (m *MockStore) GetGameAvailability(arg0 context.Context, arg1 db.GetGameAvailabilityParams) (db.GameAvailability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGameAvailability", arg0, arg1)
+	ret0, _ := ret[0].(db.GameAvailability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGameAvailability indicates an expected call of GetGameAvailability.
+func (mr *MockStoreMockRecorder) GetGameAvailability(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGameAvailability", reflect.TypeOf((*MockStore)(nil).GetGameAvailability), arg0, arg1)
+}
+
+// GetGuardian mocks base method.
+func (m *MockStore) GetGuardian(arg0 context.Context, arg1 db.GetGuardianParams) (db.Guardian, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGuardian", arg0, arg1)
+	ret0, _ := ret[0].(db.Guardian)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGuardian indicates an expected call of GetGuardian.
+func (mr *MockStoreMockRecorder) GetGuardian(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGuardian", reflect.TypeOf((*MockStore)(nil).GetGuardian), arg0, arg1)
+}
+
+// GetNextEventSequence mocks base method.
+func (m *MockStore) GetNextEventSequence(arg0 context.Context, arg1 uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNextEventSequence", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNextEventSequence indicates an expected call of GetNextEventSequence.
+func (mr *MockStoreMockRecorder) GetNextEventSequence(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextEventSequence", reflect.TypeOf((*MockStore)(nil).GetNextEventSequence), arg0, arg1)
+}
+
+// GetPlayerGameTeam mocks base method.
+func (m *MockStore) GetPlayerGameTeam(arg0 context.Context, arg1 db.GetPlayerGameTeamParams) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlayerGameTeam", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlayerGameTeam indicates an expected call of GetPlayerGameTeam.
+func (mr *MockStoreMockRecorder) GetPlayerGameTeam(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlayerGameTeam", reflect.TypeOf((*MockStore)(nil).GetPlayerGameTeam), arg0, arg1)
+}
+
+// GetRole mocks base method.
+func (m *MockStore) GetRole(arg0 context.Context, arg1 uuid.UUID) (db.UserRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRole", arg0, arg1)
+	ret0, _ := ret[0].(db.UserRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRole indicates an expected call of GetRole.
+func (mr *MockStoreMockRecorder) GetRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockStore)(nil).GetRole), arg0, arg1)
+}
+
+// GetRoles mocks base method.
+func (m *MockStore) GetRoles(arg0 context.Context, arg1 uuid.UUID) ([]db.UserRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoles", arg0, arg1)
+	ret0, _ := ret[0].([]db.UserRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoles indicates an expected call of GetRoles.
+func (mr *MockStoreMockRecorder) GetRoles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoles", reflect.TypeOf((*MockStore)(nil).GetRoles), arg0, arg1)
+}
+
+// GetRolesByName mocks base method.
+func (m *MockStore) GetRolesByName(arg0 context.Context, arg1 string) ([]db.UserRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRolesByName", arg0, arg1)
+	ret0, _ := ret[0].([]db.UserRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRolesByName indicates an expected call of GetRolesByName.
+func (mr *MockStoreMockRecorder) GetRolesByName(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRolesByName", reflect.TypeOf((*MockStore)(nil).GetRolesByName), arg0, arg1)
+}
+
+// GetSession mocks base method.
+func (m *MockStore) GetSession(arg0 context.Context, arg1 uuid.UUID) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", arg0, arg1)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockStoreMockRecorder) GetSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), arg0, arg1)
+}
+
+// GetTeam mocks base method.
+func (m *MockStore) GetTeam(arg0 context.Context, arg1 uuid.UUID) (db.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeam", arg0, arg1)
+	ret0, _ := ret[0].(db.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeam indicates an expected call of GetTeam.
+func (mr *MockStoreMockRecorder) GetTeam(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeam", reflect.TypeOf((*MockStore)(nil).GetTeam), arg0, arg1)
+}
+
+// GetTeamDeleteBlockers mocks base method.
+func (m *MockStore) GetTeamDeleteBlockers(arg0 context.Context, arg1 uuid.UUID) (db.GetTeamDeleteBlockersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamDeleteBlockers", arg0, arg1)
+	ret0, _ := ret[0].(db.GetTeamDeleteBlockersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamDeleteBlockers indicates an expected call of GetTeamDeleteBlockers.
+func (mr *MockStoreMockRecorder) GetTeamDeleteBlockers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamDeleteBlockers", reflect.TypeOf((*MockStore)(nil).GetTeamDeleteBlockers), arg0, arg1)
+}
+
+// GetTeamInvitationByCode mocks base method.
+func (m *MockStore) GetTeamInvitationByCode(arg0 context.Context, arg1 string) (db.TeamInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamInvitationByCode", arg0, arg1)
+	ret0, _ := ret[0].(db.TeamInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamInvitationByCode indicates an expected call of GetTeamInvitationByCode.
+func (mr *MockStoreMockRecorder) GetTeamInvitationByCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamInvitationByCode", reflect.TypeOf((*MockStore)(nil).GetTeamInvitationByCode), arg0, arg1)
+}
+
+// GetTeamMember mocks base method.
+func (m *MockStore) GetTeamMember(arg0 context.Context, arg1 db.GetTeamMemberParams) (db.TeamMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamMember", arg0, arg1)
+	ret0, _ := ret[0].(db.TeamMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamMember indicates an expected call of GetTeamMember.
+func (mr *MockStoreMockRecorder) GetTeamMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamMember", reflect.TypeOf((*MockStore)(nil).GetTeamMember), arg0, arg1)
+}
+
+// GetUser mocks base method.
+func (m *MockStore) GetUser(arg0 context.Context, arg1 uuid.UUID) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+}
+
+// GetUserByUsername mocks base method.
+func (m *MockStore) GetUserByUsername(arg0 context.Context, arg1 string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByUsername", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByUsername indicates an expected call of GetUserByUsername.
+func (mr *MockStoreMockRecorder) GetUserByUsername(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockStore)(nil).GetUserByUsername), arg0, arg1)
+}
+
+// GetVenue mocks base method.
+func (m *MockStore) GetVenue(arg0 context.Context, arg1 uuid.UUID) (db.Venue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVenue", arg0, arg1)
+	ret0, _ := ret[0].(db.Venue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVenue indicates an expected call of GetVenue.
+func (mr *MockStoreMockRecorder) GetVenue(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVenue", reflect.TypeOf((*MockStore)(nil).GetVenue), arg0, arg1)
+}
+
+// HasGameReachedStatus mocks base method.
+func (m *MockStore) HasGameReachedStatus(arg0 context.Context, arg1 db.HasGameReachedStatusParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasGameReachedStatus", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasGameReachedStatus indicates an expected call of HasGameReachedStatus.
+func (mr *MockStoreMockRecorder) HasGameReachedStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasGameReachedStatus", reflect.TypeOf((*MockStore)(nil).HasGameReachedStatus), arg0, arg1)
+}
+
+// IsEligibleForPosition mocks base method.
+func (m *MockStore) IsEligibleForPosition(arg0 context.Context, arg1 db.IsEligibleForPositionParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsEligibleForPosition", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsEligibleForPosition indicates an expected call of IsEligibleForPosition.
+func (mr *MockStoreMockRecorder) IsEligibleForPosition(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEligibleForPosition", reflect.TypeOf((*MockStore)(nil).IsEligibleForPosition), arg0, arg1)
+}
+
+// IsFieldAvailable mocks base method.
+func (m *MockStore) IsFieldAvailable(arg0 context.Context, arg1 db.IsFieldAvailableParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsFieldAvailable", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsFieldAvailable indicates an expected call of IsFieldAvailable.
+func (mr *MockStoreMockRecorder) IsFieldAvailable(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsFieldAvailable", reflect.TypeOf((*MockStore)(nil).IsFieldAvailable), arg0, arg1)
+}
+
+// ListApprovedGuardians mocks base method.
+func (m *MockStore) ListApprovedGuardians(arg0 context.Context) ([]db.Guardian, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListApprovedGuardians", arg0)
+	ret0, _ := ret[0].([]db.Guardian)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListApprovedGuardians indicates an expected call of ListApprovedGuardians.
+func (mr *MockStoreMockRecorder) ListApprovedGuardians(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListApprovedGuardians", reflect.TypeOf((*MockStore)(nil).ListApprovedGuardians), arg0)
+}
+
+// ListAtbatRunners mocks base method.
+func (m *MockStore) ListAtbatRunners(arg0 context.Context, arg1 uuid.UUID) ([]db.AtbatRunner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAtbatRunners", arg0, arg1)
+	ret0, _ := ret[0].([]db.AtbatRunner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAtbatRunners indicates an expected call of ListAtbatRunners.
+func (mr *MockStoreMockRecorder) ListAtbatRunners(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAtbatRunners", reflect.TypeOf((*MockStore)(nil).ListAtbatRunners), arg0, arg1)
+}
+
+// ListAuditLogs mocks base method.
+func (m *MockStore) ListAuditLogs(arg0 context.Context, arg1 db.ListAuditLogsParams) ([]db.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditLogs", arg0, arg1)
+	ret0, _ := ret[0].([]db.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditLogs indicates an expected call of ListAuditLogs.
+func (mr *MockStoreMockRecorder) ListAuditLogs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditLogs", reflect.TypeOf((*MockStore)(nil).ListAuditLogs), arg0, arg1)
+}
+
+// ListDepthChart mocks base method.
+func (m *MockStore) ListDepthChart(arg0 context.Context, arg1 db.ListDepthChartParams) ([]db.ListDepthChartRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDepthChart", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListDepthChartRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDepthChart indicates an expected call of ListDepthChart.
+func (mr *MockStoreMockRecorder) ListDepthChart(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDepthChart", reflect.TypeOf((*MockStore)(nil).ListDepthChart), arg0, arg1)
+}
+
+// ListFieldAvailability mocks base method.
+func (m *MockStore) ListFieldAvailability(arg0 context.Context, arg1 db.ListFieldAvailabilityParams) ([]db.FieldAvailability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFieldAvailability", arg0, arg1)
+	ret0, _ := ret[0].([]db.FieldAvailability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFieldAvailability indicates an expected call of ListFieldAvailability.
+func (mr *MockStoreMockRecorder) ListFieldAvailability(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFieldAvailability", reflect.TypeOf((*MockStore)(nil).ListFieldAvailability), arg0, arg1)
+}
+
+// ListFields mocks base method.
+func (m *MockStore) ListFields(arg0 context.Context, arg1 uuid.UUID) ([]db.Field, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFields", arg0, arg1)
+	ret0, _ := ret[0].([]db.Field)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFields indicates an expected call of ListFields.
+func (mr *MockStoreMockRecorder) ListFields(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFields", reflect.TypeOf((*MockStore)(nil).ListFields), arg0, arg1)
+}
+
+// ListFieldsInBounds mocks base method.
+func (m *MockStore) ListFieldsInBounds(arg0 context.Context, arg1 db.ListFieldsInBoundsParams) ([]db.ListFieldsInBoundsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFieldsInBounds", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListFieldsInBoundsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFieldsInBounds indicates an expected call of ListFieldsInBounds.
+func (mr *MockStoreMockRecorder) ListFieldsInBounds(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFieldsInBounds", reflect.TypeOf((*MockStore)(nil).ListFieldsInBounds), arg0, arg1)
+}
+
+// ListGameAtbats mocks base method.
+func (m *MockStore) ListGameAtbats(arg0 context.Context, arg1 uuid.UUID) ([]db.ListGameAtbatsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGameAtbats", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListGameAtbatsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGameAtbats indicates an expected call of ListGameAtbats.
+func (mr *MockStoreMockRecorder) ListGameAtbats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGameAtbats", reflect.TypeOf((*MockStore)(nil).ListGameAtbats), arg0, arg1)
+}
+
+// ListGameAvailability mocks base method.
+func (m *MockStore) ListGameAvailability(arg0 context.Context, arg1 uuid.UUID) ([]db.ListGameAvailabilityRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGameAvailability", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListGameAvailabilityRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGameAvailability indicates an expected call of ListGameAvailability.
+func (mr *MockStoreMockRecorder) ListGameAvailability(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGameAvailability", reflect.TypeOf((*MockStore)(nil).ListGameAvailability), arg0, arg1)
+}
+
+// ListGameEventHistory mocks base method.
+func (m *MockStore) ListGameEventHistory(arg0 context.Context, arg1 uuid.UUID) ([]db.GameEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGameEventHistory", arg0, arg1)
+	ret0, _ := ret[0].([]db.GameEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGameEventHistory indicates an expected call of ListGameEventHistory.
+func (mr *MockStoreMockRecorder) ListGameEventHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGameEventHistory", reflect.TypeOf((*MockStore)(nil).ListGameEventHistory), arg0, arg1)
+}
+
+// ListGameEvents mocks base method.
+func (m *MockStore) ListGameEvents(arg0 context.Context, arg1 uuid.UUID) ([]db.GameEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGameEvents", arg0, arg1)
+	ret0, _ := ret[0].([]db.GameEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGameEvents indicates an expected call of ListGameEvents.
+func (mr *MockStoreMockRecorder) ListGameEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGameEvents", reflect.TypeOf((*MockStore)(nil).ListGameEvents), arg0, arg1)
+}
+
+// ListGameInnings mocks base method.
+func (m *MockStore) ListGameInnings(arg0 context.Context, arg1 uuid.UUID) ([]db.ListGameInningsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGameInnings", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListGameInningsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGameInnings indicates an expected call of ListGameInnings.
+func (mr *MockStoreMockRecorder) ListGameInnings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGameInnings", reflect.TypeOf((*MockStore)(nil).ListGameInnings), arg0, arg1)
+}
+
+// ListGameParticipants mocks base method.
+func (m *MockStore) ListGameParticipants(arg0 context.Context, arg1 uuid.UUID) ([]db.ListGameParticipantsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGameParticipants", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListGameParticipantsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGameParticipants indicates an expected call of ListGameParticipants.
+func (mr *MockStoreMockRecorder) ListGameParticipants(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGameParticipants", reflect.TypeOf((*MockStore)(nil).ListGameParticipants), arg0, arg1)
+}
+
+// ListGamePitchCounts mocks base method.
+func (m *MockStore) ListGamePitchCounts(arg0 context.Context, arg1 uuid.UUID) ([]db.PitchCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGamePitchCounts", arg0, arg1)
+	ret0, _ := ret[0].([]db.PitchCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGamePitchCounts indicates an expected call of ListGamePitchCounts.
+func (mr *MockStoreMockRecorder) ListGamePitchCounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGamePitchCounts", reflect.TypeOf((*MockStore)(nil).ListGamePitchCounts), arg0, arg1)
+}
+
+// ListGameRecordedPitches mocks base method.
+func (m *MockStore) ListGameRecordedPitches(arg0 context.Context, arg1 uuid.UUID) ([]db.ListGameRecordedPitchesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGameRecordedPitches", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListGameRecordedPitchesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGameRecordedPitches indicates an expected call of ListGameRecordedPitches.
+func (mr *MockStoreMockRecorder) ListGameRecordedPitches(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGameRecordedPitches", reflect.TypeOf((*MockStore)(nil).ListGameRecordedPitches), arg0, arg1)
+}
+
+// ListGameRunners mocks base method.
+func (m *MockStore) ListGameRunners(arg0 context.Context, arg1 uuid.UUID) ([]db.AtbatRunner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGameRunners", arg0, arg1)
+	ret0, _ := ret[0].([]db.AtbatRunner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGameRunners indicates an expected call of ListGameRunners.
+func (mr *MockStoreMockRecorder) ListGameRunners(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGameRunners", reflect.TypeOf((*MockStore)(nil).ListGameRunners), arg0, arg1)
+}
+
+// ListGameScheduleChanges mocks base method.
+func (m *MockStore) ListGameScheduleChanges(arg0 context.Context, arg1 uuid.UUID) ([]db.GameScheduleChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGameScheduleChanges", arg0, arg1)
+	ret0, _ := ret[0].([]db.GameScheduleChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGameScheduleChanges indicates an expected call of ListGameScheduleChanges.
+func (mr *MockStoreMockRecorder) ListGameScheduleChanges(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGameScheduleChanges", reflect.TypeOf((*MockStore)(nil).ListGameScheduleChanges), arg0, arg1)
+}
+
+// ListGameStats mocks base method.
+func (m *MockStore) ListGameStats(arg0 context.Context, arg1 uuid.UUID) ([]db.ListGameStatsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGameStats", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListGameStatsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGameStats indicates an expected call of ListGameStats.
+func (mr *MockStoreMockRecorder) ListGameStats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGameStats", reflect.TypeOf((*MockStore)(nil).ListGameStats), arg0, arg1)
+}
+
+// ListGameStatusChanges mocks base method.
+func (m *MockStore) ListGameStatusChanges(arg0 context.Context, arg1 uuid.UUID) ([]db.GameStatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGameStatusChanges", arg0, arg1)
+	ret0, _ := ret[0].([]db.GameStatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGameStatusChanges indicates an expected call of ListGameStatusChanges.
+func (mr *MockStoreMockRecorder) ListGameStatusChanges(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGameStatusChanges", reflect.TypeOf((*MockStore)(nil).ListGameStatusChanges), arg0, arg1)
+}
+
+// ListGames mocks base method.
+func (m *MockStore) ListGames(arg0 context.Context, arg1 db.ListGamesParams) ([]db.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGames", arg0, arg1)
+	ret0, _ := ret[0].([]db.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGames indicates an expected call of ListGames.
+func (mr *MockStoreMockRecorder) ListGames(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGames", reflect.TypeOf((*MockStore)(nil).ListGames), arg0, arg1)
+}
+
+// ListGamesOfUser mocks base method.
+func (m *MockStore) ListGamesOfUser(arg0 context.Context, arg1 db.ListGamesOfUserParams) ([]db.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGamesOfUser", arg0, arg1)
+	ret0, _ := ret[0].([]db.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGamesOfUser indicates an expected call of ListGamesOfUser.
+func (mr *MockStoreMockRecorder) ListGamesOfUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGamesOfUser", reflect.TypeOf((*MockStore)(nil).ListGamesOfUser), arg0, arg1)
+}
+
+// ListGuardiansOfPlayer mocks base method.
+func (m *MockStore) ListGuardiansOfPlayer(arg0 context.Context, arg1 uuid.UUID) ([]db.ListGuardiansOfPlayerRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGuardiansOfPlayer", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListGuardiansOfPlayerRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGuardiansOfPlayer indicates an expected call of ListGuardiansOfPlayer.
+func (mr *MockStoreMockRecorder) ListGuardiansOfPlayer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGuardiansOfPlayer", reflect.TypeOf((*MockStore)(nil).ListGuardiansOfPlayer), arg0, arg1)
+}
+
+// ListJoinRequests mocks base method.
+func (m *MockStore) ListJoinRequests(arg0 context.Context, arg1 db.ListJoinRequestsParams) ([]db.JoinRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJoinRequests", arg0, arg1)
+	ret0, _ := ret[0].([]db.JoinRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJoinRequests indicates an expected call of ListJoinRequests.
+func (mr *MockStoreMockRecorder) ListJoinRequests(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJoinRequests", reflect.TypeOf((*MockStore)(nil).ListJoinRequests), arg0, arg1)
+}
+
+// ListLineup mocks base method.
+func (m *MockStore) ListLineup(arg0 context.Context, arg1 db.ListLineupParams) ([]db.ListLineupRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLineup", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListLineupRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLineup indicates an expected call of ListLineup.
+func (mr *MockStoreMockRecorder) ListLineup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLineup", reflect.TypeOf((*MockStore)(nil).ListLineup), arg0, arg1)
+}
+
+// ListNotifications mocks base method.
+func (m *MockStore) ListNotifications(arg0 context.Context, arg1 db.ListNotificationsParams) ([]db.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotifications", arg0, arg1)
+	ret0, _ := ret[0].([]db.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotifications indicates an expected call of ListNotifications.
+func (mr *MockStoreMockRecorder) ListNotifications(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotifications", reflect.TypeOf((*MockStore)(nil).ListNotifications), arg0, arg1)
+}
+
+// ListPitches mocks base method.
+func (m *MockStore) ListPitches(arg0 context.Context, arg1 uuid.UUID) ([]db.Pitch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPitches", arg0, arg1)
+	ret0, _ := ret[0].([]db.Pitch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPitches indicates an expected call of ListPitches.
+func (mr *MockStoreMockRecorder) ListPitches(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPitches", reflect.TypeOf((*MockStore)(nil).ListPitches), arg0, arg1)
+}
+
+// ListPitchingAppearances mocks base method.
+func (m *MockStore) ListPitchingAppearances(arg0 context.Context, arg1 db.ListPitchingAppearancesParams) ([]db.ListPitchingAppearancesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPitchingAppearances", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListPitchingAppearancesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPitchingAppearances indicates an expected call of ListPitchingAppearances.
+func (mr *MockStoreMockRecorder) ListPitchingAppearances(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPitchingAppearances", reflect.TypeOf((*MockStore)(nil).ListPitchingAppearances), arg0, arg1)
+}
+
+// ListPlayerPitchCounts mocks base method.
+func (m *MockStore) ListPlayerPitchCounts(arg0 context.Context, arg1 db.ListPlayerPitchCountsParams) ([]db.ListPlayerPitchCountsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPlayerPitchCounts", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListPlayerPitchCountsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPlayerPitchCounts indicates an expected call of ListPlayerPitchCounts.
+func (mr *MockStoreMockRecorder) ListPlayerPitchCounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlayerPitchCounts", reflect.TypeOf((*MockStore)(nil).ListPlayerPitchCounts), arg0, arg1)
+}
+
+// ListPlayerPositions mocks base method.
+func (m *MockStore) ListPlayerPositions(arg0 context.Context, arg1 db.ListPlayerPositionsParams) ([]db.PlayerPosition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPlayerPositions", arg0, arg1)
+	ret0, _ := ret[0].([]db.PlayerPosition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPlayerPositions indicates an expected call of ListPlayerPositions.
+func (mr *MockStoreMockRecorder) ListPlayerPositions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlayerPositions", reflect.TypeOf((*MockStore)(nil).ListPlayerPositions), arg0, arg1)
+}
+
+// ListPlayerStatuses mocks base method.
+func (m *MockStore) ListPlayerStatuses(arg0 context.Context, arg1 db.ListPlayerStatusesParams) ([]db.PlayerStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPlayerStatuses", arg0, arg1)
+	ret0, _ := ret[0].([]db.PlayerStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPlayerStatuses indicates an expected call of ListPlayerStatuses.
+func (mr *MockStoreMockRecorder) ListPlayerStatuses(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlayerStatuses", reflect.TypeOf((*MockStore)(nil).ListPlayerStatuses), arg0, arg1)
+}
+
+// ListRoles mocks base method.
+func (m *MockStore) ListRoles(arg0 context.Context, arg1 db.ListRolesParams) ([]db.UserRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoles", arg0, arg1)
+	ret0, _ := ret[0].([]db.UserRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoles indicates an expected call of ListRoles.
+func (mr *MockStoreMockRecorder) ListRoles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoles", reflect.TypeOf((*MockStore)(nil).ListRoles), arg0, arg1)
+}
+
+// ListRosterAsOf mocks base method.
+func (m *MockStore) ListRosterAsOf(arg0 context.Context, arg1 db.ListRosterAsOfParams) ([]db.ListRosterAsOfRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRosterAsOf", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListRosterAsOfRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRosterAsOf indicates an expected call of ListRosterAsOf.
+func (mr *MockStoreMockRecorder) ListRosterAsOf(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRosterAsOf", reflect.TypeOf((*MockStore)(nil).ListRosterAsOf), arg0, arg1)
+}
+
+// ListRosterTransactions mocks base method.
+func (m *MockStore) ListRosterTransactions(arg0 context.Context, arg1 db.ListRosterTransactionsParams) ([]db.RosterTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRosterTransactions", arg0, arg1)
+	ret0, _ := ret[0].([]db.RosterTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRosterTransactions indicates an expected call of ListRosterTransactions.
+func (mr *MockStoreMockRecorder) ListRosterTransactions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRosterTransactions", reflect.TypeOf((*MockStore)(nil).ListRosterTransactions), arg0, arg1)
+}
+
+// ListScheduleConflicts mocks base method.
+func (m *MockStore) ListScheduleConflicts(arg0 context.Context, arg1 db.ListScheduleConflictsParams) ([]db.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScheduleConflicts", arg0, arg1)
+	ret0, _ := ret[0].([]db.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScheduleConflicts indicates an expected call of ListScheduleConflicts.
+func (mr *MockStoreMockRecorder) ListScheduleConflicts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScheduleConflicts", reflect.TypeOf((*MockStore)(nil).ListScheduleConflicts), arg0, arg1)
+}
+
+// ListTeamIDsOfUser mocks base method.
+func (m *MockStore) ListTeamIDsOfUser(arg0 context.Context, arg1 uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTeamIDsOfUser", arg0, arg1)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTeamIDsOfUser indicates an expected call of ListTeamIDsOfUser.
+func (mr *MockStoreMockRecorder) ListTeamIDsOfUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTeamIDsOfUser", reflect.TypeOf((*MockStore)(nil).ListTeamIDsOfUser), arg0, arg1)
+}
+
+// ListTeamInvitations mocks base method.
+func (m *MockStore) ListTeamInvitations(arg0 context.Context, arg1 db.ListTeamInvitationsParams) ([]db.TeamInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTeamInvitations", arg0, arg1)
+	ret0, _ := ret[0].([]db.TeamInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTeamInvitations indicates an expected call of ListTeamInvitations.
+func (mr *MockStoreMockRecorder) ListTeamInvitations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTeamInvitations", reflect.TypeOf((*MockStore)(nil).ListTeamInvitations), arg0, arg1)
+}
+
+// ListTeamMembers mocks base method.
+func (m *MockStore) ListTeamMembers(arg0 context.Context, arg1 db.ListTeamMembersParams) ([]db.ListTeamMembersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTeamMembers", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListTeamMembersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTeamMembers indicates an expected call of ListTeamMembers.
+func (mr *MockStoreMockRecorder) ListTeamMembers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTeamMembers", reflect.TypeOf((*MockStore)(nil).ListTeamMembers), arg0, arg1)
+}
+
+// ListTeams mocks base method.
+func (m *MockStore) ListTeams(arg0 context.Context, arg1 db.ListTeamsParams) ([]db.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTeams", arg0, arg1)
+	ret0, _ := ret[0].([]db.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTeams indicates an expected call of ListTeams.
+func (mr *MockStoreMockRecorder) ListTeams(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTeams", reflect.TypeOf((*MockStore)(nil).ListTeams), arg0, arg1)
+}
+
+// ListTeamsOfUser mocks base method.
+func (m *MockStore) ListTeamsOfUser(arg0 context.Context, arg1 db.ListTeamsOfUserParams) ([]db.ListTeamsOfUserRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTeamsOfUser", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListTeamsOfUserRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTeamsOfUser indicates an expected call of ListTeamsOfUser.
+func (mr *MockStoreMockRecorder) ListTeamsOfUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTeamsOfUser", reflect.TypeOf((*MockStore)(nil).ListTeamsOfUser), arg0, arg1)
+}
+
+// ListUsers mocks base method.
+func (m *MockStore) ListUsers(arg0 context.Context, arg1 db.ListUsersParams) ([]db.ListUsersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListUsersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockStoreMockRecorder) ListUsers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockStore)(nil).ListUsers), arg0, arg1)
+}
+
+// ListVenues mocks base method.
+func (m *MockStore) ListVenues(arg0 context.Context, arg1 db.ListVenuesParams) ([]db.Venue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVenues", arg0, arg1)
+	ret0, _ := ret[0].([]db.Venue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVenues indicates an expected call of ListVenues.
+func (mr *MockStoreMockRecorder) ListVenues(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVenues", reflect.TypeOf((*MockStore)(nil).ListVenues), arg0, arg1)
+}
+
+// LockGameForLineup mocks base method.
+func (m *MockStore) LockGameForLineup(arg0 context.Context, arg1 uuid.UUID) (db.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockGameForLineup", arg0, arg1)
+	ret0, _ := ret[0].(db.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockGameForLineup indicates an expected call of LockGameForLineup.
+func (mr *MockStoreMockRecorder) LockGameForLineup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockGameForLineup", reflect.TypeOf((*MockStore)(nil).LockGameForLineup), arg0, arg1)
+}
+
+// LockGameInProgress mocks base method.
+func (m *MockStore) LockGameInProgress(arg0 context.Context, arg1 uuid.UUID) (db.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockGameInProgress", arg0, arg1)
+	ret0, _ := ret[0].(db.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockGameInProgress indicates an expected call of LockGameInProgress.
+func (mr *MockStoreMockRecorder) LockGameInProgress(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockGameInProgress", reflect.TypeOf((*MockStore)(nil).LockGameInProgress), arg0, arg1)
+}
+
+// LockScheduleTeams mocks base method.
+func (m *MockStore) LockScheduleTeams(arg0 context.Context, arg1 db.LockScheduleTeamsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockScheduleTeams", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockScheduleTeams indicates an expected call of LockScheduleTeams.
+func (mr *MockStoreMockRecorder) LockScheduleTeams(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockScheduleTeams", reflect.TypeOf((*MockStore)(nil).LockScheduleTeams), arg0, arg1)
+}
+
+// LockVenue mocks base method.
+func (m *MockStore) LockVenue(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockVenue", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockVenue indicates an expected call of LockVenue.
+func (mr *MockStoreMockRecorder) LockVenue(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockVenue", reflect.TypeOf((*MockStore)(nil).LockVenue), arg0, arg1)
+}
+
+// MarkNotificationRead mocks base method.
+func (m *MockStore) MarkNotificationRead(arg0 context.Context, arg1 db.MarkNotificationReadParams) (db.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationRead", arg0, arg1)
+	ret0, _ := ret[0].(db.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkNotificationRead indicates an expected call of MarkNotificationRead.
+func (mr *MockStoreMockRecorder) MarkNotificationRead(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationRead", reflect.TypeOf((*MockStore)(nil).MarkNotificationRead), arg0, arg1)
+}
+
+// NotifyGamePlayers mocks base method.
+func (m *MockStore) NotifyGamePlayers(arg0 context.Context, arg1 db.NotifyGamePlayersParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyGamePlayers", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyGamePlayers indicates an expected call of NotifyGamePlayers.
+func (mr *MockStoreMockRecorder) NotifyGamePlayers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyGamePlayers", reflect.TypeOf((*MockStore)(nil).NotifyGamePlayers), arg0, arg1)
+}
+
+// OpenTeamMemberStint mocks base method.
+func (m *MockStore) OpenTeamMemberStint(arg0 context.Context, arg1 db.OpenTeamMemberStintParams) (db.TeamMemberStint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenTeamMemberStint", arg0, arg1)
+	ret0, _ := ret[0].(db.TeamMemberStint)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// AddTeamMember indicates an expected call of AddTeamMember.
-func (mr *MockStoreMockRecorder) AddTeamMember(arg0, arg1 interface{}) *gomock.Call {
+// OpenTeamMemberStint indicates an expected call of OpenTeamMemberStint.
+func (mr *MockStoreMockRecorder) OpenTeamMemberStint(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTeamMember", reflect.TypeOf((*MockStore)(nil).AddTeamMember), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenTeamMemberStint", reflect.TypeOf((*MockStore)(nil).OpenTeamMemberStint), arg0, arg1)
 }
 
-// CreateGame mocks base method.
-func (m *MockStore) CreateGame(arg0 context.Context, arg1 db.CreateGameParams) (db.Game, error) {
+// ProjectAtbat mocks base method.
+func (m *MockStore) ProjectAtbat(arg0 context.Context, arg1 db.ProjectAtbatParams) (db.Atbat, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateGame", arg0, arg1)
-	ret0, _ := ret[0].(db.Game)
+	ret := m.ctrl.Call(m, "ProjectAtbat", arg0, arg1)
+	ret0, _ := ret[0].(db.Atbat)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateGame indicates an expected call of CreateGame.
-func (mr *MockStoreMockRecorder) CreateGame(arg0, arg1 interface{}) *gomock.Call {
+// ProjectAtbat indicates an expected call of ProjectAtbat.
+func (mr *MockStoreMockRecorder) ProjectAtbat(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGame", reflect.TypeOf((*MockStore)(nil).CreateGame), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectAtbat", reflect.TypeOf((*MockStore)(nil).ProjectAtbat), arg0, arg1)
 }
 
-// CreateRole mocks base method.
-func (m *MockStore) CreateRole(arg0 context.Context, arg1 db.CreateRoleParams) (db.UserRole, error) {
+// ProjectInning mocks base method.
+func (m *MockStore) ProjectInning(arg0 context.Context, arg1 db.ProjectInningParams) (db.Inning, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateRole", arg0, arg1)
-	ret0, _ := ret[0].(db.UserRole)
+	ret := m.ctrl.Call(m, "ProjectInning", arg0, arg1)
+	ret0, _ := ret[0].(db.Inning)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateRole indicates an expected call of CreateRole.
-func (mr *MockStoreMockRecorder) CreateRole(arg0, arg1 interface{}) *gomock.Call {
+// ProjectInning indicates an expected call of ProjectInning.
+func (mr *MockStoreMockRecorder) ProjectInning(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*MockStore)(nil).CreateRole), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectInning", reflect.TypeOf((*MockStore)(nil).ProjectInning), arg0, arg1)
 }
 
-// CreateSession mocks base method.
-func (m *MockStore) CreateSession(arg0 context.Context, arg1 db.CreateSessionParams) (db.Session, error) {
+// ProjectPitch mocks base method.
+func (m *MockStore) ProjectPitch(arg0 context.Context, arg1 db.ProjectPitchParams) (db.Pitch, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSession", arg0, arg1)
-	ret0, _ := ret[0].(db.Session)
+	ret := m.ctrl.Call(m, "ProjectPitch", arg0, arg1)
+	ret0, _ := ret[0].(db.Pitch)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateSession indicates an expected call of CreateSession.
-func (mr *MockStoreMockRecorder) CreateSession(arg0, arg1 interface{}) *gomock.Call {
+// ProjectPitch indicates an expected call of ProjectPitch.
+func (mr *MockStoreMockRecorder) ProjectPitch(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectPitch", reflect.TypeOf((*MockStore)(nil).ProjectPitch), arg0, arg1)
 }
 
-// CreateTeam mocks base method.
-func (m *MockStore) CreateTeam(arg0 context.Context, arg1 string) (db.Team, error) {
+// RecordGameEventTx mocks base method.
+func (m *MockStore) RecordGameEventTx(arg0 context.Context, arg1 db.RecordGameEventTxParams) (db.RecordGameEventTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTeam", arg0, arg1)
-	ret0, _ := ret[0].(db.Team)
+	ret := m.ctrl.Call(m, "RecordGameEventTx", arg0, arg1)
+	ret0, _ := ret[0].(db.RecordGameEventTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateTeam indicates an expected call of CreateTeam.
-func (mr *MockStoreMockRecorder) CreateTeam(arg0, arg1 interface{}) *gomock.Call {
+// RecordGameEventTx indicates an expected call of RecordGameEventTx.
+func (mr *MockStoreMockRecorder) RecordGameEventTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTeam", reflect.TypeOf((*MockStore)(nil).CreateTeam), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordGameEventTx", reflect.TypeOf((*MockStore)(nil).RecordGameEventTx), arg0, arg1)
 }
 
-// CreateUser mocks base method.
-func (m *MockStore) CreateUser(arg0 context.Context, arg1 db.CreateUserParams) (db.User, error) {
+// ReenterGameParticipant mocks base method.
+func (m *MockStore) ReenterGameParticipant(arg0 context.Context, arg1 uuid.UUID) (db.GameParticipant, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "ReenterGameParticipant", arg0, arg1)
+	ret0, _ := ret[0].(db.GameParticipant)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUser indicates an expected call of CreateUser.
-func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
+// ReenterGameParticipant indicates an expected call of ReenterGameParticipant.
+func (mr *MockStoreMockRecorder) ReenterGameParticipant(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReenterGameParticipant", reflect.TypeOf((*MockStore)(nil).ReenterGameParticipant), arg0, arg1)
 }
 
-// CreateUserTx mocks base method.
-func (m *MockStore) CreateUserTx(arg0 context.Context, arg1 db.CreateUserTxParams) (db.CreateUserTxResult, error) {
+// RemoveTeamMember mocks base method.
+func (m *MockStore) RemoveTeamMember(arg0 context.Context, arg1 db.RemoveTeamMemberParams) (db.TeamMember, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUserTx", arg0, arg1)
-	ret0, _ := ret[0].(db.CreateUserTxResult)
+	ret := m.ctrl.Call(m, "RemoveTeamMember", arg0, arg1)
+	ret0, _ := ret[0].(db.TeamMember)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUserTx indicates an expected call of CreateUserTx.
-func (mr *MockStoreMockRecorder) CreateUserTx(arg0, arg1 interface{}) *gomock.Call {
+// RemoveTeamMember indicates an expected call of RemoveTeamMember.
+func (mr *MockStoreMockRecorder) RemoveTeamMember(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserTx", reflect.TypeOf((*MockStore)(nil).CreateUserTx), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTeamMember", reflect.TypeOf((*MockStore)(nil).RemoveTeamMember), arg0, arg1)
 }
 
-// DeleteRole mocks base method.
-func (m *MockStore) DeleteRole(arg0 context.Context, arg1 uuid.UUID) error {
+// RescheduleGameTx mocks base method.
+func (m *MockStore) RescheduleGameTx(arg0 context.Context, arg1 db.RescheduleGameTxParams) (db.RescheduleGameTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteRole", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "RescheduleGameTx", arg0, arg1)
+	ret0, _ := ret[0].(db.RescheduleGameTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteRole indicates an expected call of DeleteRole.
-func (mr *MockStoreMockRecorder) DeleteRole(arg0, arg1 interface{}) *gomock.Call {
+// RescheduleGameTx indicates an expected call of RescheduleGameTx.
+func (mr *MockStoreMockRecorder) RescheduleGameTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockStore)(nil).DeleteRole), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RescheduleGameTx", reflect.TypeOf((*MockStore)(nil).RescheduleGameTx), arg0, arg1)
 }
 
-// DeleteTeam mocks base method.
-func (m *MockStore) DeleteTeam(arg0 context.Context, arg1 uuid.UUID) error {
+// RevokeTeamInvitation mocks base method.
+func (m *MockStore) RevokeTeamInvitation(arg0 context.Context, arg1 db.RevokeTeamInvitationParams) (db.TeamInvitation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTeam", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "RevokeTeamInvitation", arg0, arg1)
+	ret0, _ := ret[0].(db.TeamInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteTeam indicates an expected call of DeleteTeam.
-func (mr *MockStoreMockRecorder) DeleteTeam(arg0, arg1 interface{}) *gomock.Call {
+// RevokeTeamInvitation indicates an expected call of RevokeTeamInvitation.
+func (mr *MockStoreMockRecorder) RevokeTeamInvitation(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTeam", reflect.TypeOf((*MockStore)(nil).DeleteTeam), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeTeamInvitation", reflect.TypeOf((*MockStore)(nil).RevokeTeamInvitation), arg0, arg1)
 }
 
-// DeleteUser mocks base method.
-func (m *MockStore) DeleteUser(arg0 context.Context, arg1 uuid.UUID) error {
+// RosterTransactionTx mocks base method.
+func (m *MockStore) RosterTransactionTx(arg0 context.Context, arg1 db.RosterTransactionTxParams) (db.RosterTransactionTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "RosterTransactionTx", arg0, arg1)
+	ret0, _ := ret[0].(db.RosterTransactionTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteUser indicates an expected call of DeleteUser.
-func (mr *MockStoreMockRecorder) DeleteUser(arg0, arg1 interface{}) *gomock.Call {
+// RosterTransactionTx indicates an expected call of RosterTransactionTx.
+func (mr *MockStoreMockRecorder) RosterTransactionTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockStore)(nil).DeleteUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RosterTransactionTx", reflect.TypeOf((*MockStore)(nil).RosterTransactionTx), arg0, arg1)
 }
 
-// GetGame mocks base method.
-func (m *MockStore) GetGame(arg0 context.Context, arg1 uuid.UUID) (db.Game, error) {
+// SearchTeams mocks base method.
+func (m *MockStore) SearchTeams(arg0 context.Context, arg1 db.SearchTeamsParams) ([]db.SearchTeamsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetGame", arg0, arg1)
-	ret0, _ := ret[0].(db.Game)
+	ret := m.ctrl.Call(m, "SearchTeams", arg0, arg1)
+	ret0, _ := ret[0].([]db.SearchTeamsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetGame indicates an expected call of GetGame.
-func (mr *MockStoreMockRecorder) GetGame(arg0, arg1 interface{}) *gomock.Call {
+// SearchTeams indicates an expected call of SearchTeams.
+func (mr *MockStoreMockRecorder) SearchTeams(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGame", reflect.TypeOf((*MockStore)(nil).GetGame), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTeams", reflect.TypeOf((*MockStore)(nil).SearchTeams), arg0, arg1)
 }
 
-// GetRole mocks base method.
-func (m *MockStore) GetRole(arg0 context.Context, arg1 uuid.UUID) (db.UserRole, error) {
+// SearchUsers mocks base method.
+func (m *MockStore) SearchUsers(arg0 context.Context, arg1 db.SearchUsersParams) ([]db.SearchUsersRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRole", arg0, arg1)
-	ret0, _ := ret[0].(db.UserRole)
+	ret := m.ctrl.Call(m, "SearchUsers", arg0, arg1)
+	ret0, _ := ret[0].([]db.SearchUsersRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetRole indicates an expected call of GetRole.
-func (mr *MockStoreMockRecorder) GetRole(arg0, arg1 interface{}) *gomock.Call {
+// SearchUsers indicates an expected call of SearchUsers.
+func (mr *MockStoreMockRecorder) SearchUsers(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockStore)(nil).GetRole), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsers", reflect.TypeOf((*MockStore)(nil).SearchUsers), arg0, arg1)
 }
 
-// GetRoles mocks base method.
-func (m *MockStore) GetRoles(arg0 context.Context, arg1 uuid.UUID) ([]db.UserRole, error) {
+// ServeSuspensionGame mocks base method.
+func (m *MockStore) ServeSuspensionGame(arg0 context.Context, arg1 uuid.UUID) ([]db.PlayerStatus, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRoles", arg0, arg1)
-	ret0, _ := ret[0].([]db.UserRole)
+	ret := m.ctrl.Call(m, "ServeSuspensionGame", arg0, arg1)
+	ret0, _ := ret[0].([]db.PlayerStatus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetRoles indicates an expected call of GetRoles.
-func (mr *MockStoreMockRecorder) GetRoles(arg0, arg1 interface{}) *gomock.Call {
+// ServeSuspensionGame indicates an expected call of ServeSuspensionGame.
+func (mr *MockStoreMockRecorder) ServeSuspensionGame(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoles", reflect.TypeOf((*MockStore)(nil).GetRoles), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServeSuspensionGame", reflect.TypeOf((*MockStore)(nil).ServeSuspensionGame), arg0, arg1)
 }
 
-// GetRolesByName mocks base method.
-func (m *MockStore) GetRolesByName(arg0 context.Context, arg1 string) ([]db.UserRole, error) {
+// SetDepthChartTx mocks base method.
+func (m *MockStore) SetDepthChartTx(arg0 context.Context, arg1 db.SetDepthChartTxParams) ([]db.DepthChartEntry, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRolesByName", arg0, arg1)
-	ret0, _ := ret[0].([]db.UserRole)
+	ret := m.ctrl.Call(m, "SetDepthChartTx", arg0, arg1)
+	ret0, _ := ret[0].([]db.DepthChartEntry)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetRolesByName indicates an expected call of GetRolesByName.
-func (mr *MockStoreMockRecorder) GetRolesByName(arg0, arg1 interface{}) *gomock.Call {
+// SetDepthChartTx indicates an expected call of SetDepthChartTx.
+func (mr *MockStoreMockRecorder) SetDepthChartTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRolesByName", reflect.TypeOf((*MockStore)(nil).GetRolesByName), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDepthChartTx", reflect.TypeOf((*MockStore)(nil).SetDepthChartTx), arg0, arg1)
 }
 
-// GetSession mocks base method.
-func (m *MockStore) GetSession(arg0 context.Context, arg1 uuid.UUID) (db.Session, error) {
+// SetGameAvailability mocks base method.
+func (m *MockStore) SetGameAvailability(arg0 context.Context, arg1 db.SetGameAvailabilityParams) (db.GameAvailability, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSession", arg0, arg1)
-	ret0, _ := ret[0].(db.Session)
+	ret := m.ctrl.Call(m, "SetGameAvailability", arg0, arg1)
+	ret0, _ := ret[0].(db.GameAvailability)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSession indicates an expected call of GetSession.
-func (mr *MockStoreMockRecorder) GetSession(arg0, arg1 interface{}) *gomock.Call {
+// SetGameAvailability indicates an expected call of SetGameAvailability.
+func (mr *MockStoreMockRecorder) SetGameAvailability(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGameAvailability", reflect.TypeOf((*MockStore)(nil).SetGameAvailability), arg0, arg1)
 }
 
-// GetTeam mocks base method.
-func (m *MockStore) GetTeam(arg0 context.Context, arg1 uuid.UUID) (db.Team, error) {
+// SetGameScore mocks base method.
+func (m *MockStore) SetGameScore(arg0 context.Context, arg1 db.SetGameScoreParams) (db.Game, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTeam", arg0, arg1)
-	ret0, _ := ret[0].(db.Team)
+	ret := m.ctrl.Call(m, "SetGameScore", arg0, arg1)
+	ret0, _ := ret[0].(db.Game)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTeam indicates an expected call of GetTeam.
-func (mr *MockStoreMockRecorder) GetTeam(arg0, arg1 interface{}) *gomock.Call {
+// SetGameScore indicates an expected call of SetGameScore.
+func (mr *MockStoreMockRecorder) SetGameScore(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeam", reflect.TypeOf((*MockStore)(nil).GetTeam), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGameScore", reflect.TypeOf((*MockStore)(nil).SetGameScore), arg0, arg1)
 }
 
-// GetUser mocks base method.
-func (m *MockStore) GetUser(arg0 context.Context, arg1 uuid.UUID) (db.User, error) {
+// SetLineupTx mocks base method.
+func (m *MockStore) SetLineupTx(arg0 context.Context, arg1 db.SetLineupTxParams) (db.SetLineupTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "SetLineupTx", arg0, arg1)
+	ret0, _ := ret[0].(db.SetLineupTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+// SetLineupTx indicates an expected call of SetLineupTx.
+func (mr *MockStoreMockRecorder) SetLineupTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLineupTx", reflect.TypeOf((*MockStore)(nil).SetLineupTx), arg0, arg1)
 }
 
-// GetUserByUsername mocks base method.
-func (m *MockStore) GetUserByUsername(arg0 context.Context, arg1 string) (db.User, error) {
+// SetPitchCount mocks base method.
+func (m *MockStore) SetPitchCount(arg0 context.Context, arg1 db.SetPitchCountParams) (db.PitchCount, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByUsername", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "SetPitchCount", arg0, arg1)
+	ret0, _ := ret[0].(db.PitchCount)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserByUsername indicates an expected call of GetUserByUsername.
-func (mr *MockStoreMockRecorder) GetUserByUsername(arg0, arg1 interface{}) *gomock.Call {
+// SetPitchCount indicates an expected call of SetPitchCount.
+func (mr *MockStoreMockRecorder) SetPitchCount(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockStore)(nil).GetUserByUsername), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPitchCount", reflect.TypeOf((*MockStore)(nil).SetPitchCount), arg0, arg1)
 }
 
-// ListGames mocks base method.
-func (m *MockStore) ListGames(arg0 context.Context, arg1 db.ListGamesParams) ([]db.Game, error) {
+// SetPlayerPositionsTx mocks base method.
+func (m *MockStore) SetPlayerPositionsTx(arg0 context.Context, arg1 db.SetPlayerPositionsTxParams) ([]db.PlayerPosition, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListGames", arg0, arg1)
-	ret0, _ := ret[0].([]db.Game)
+	ret := m.ctrl.Call(m, "SetPlayerPositionsTx", arg0, arg1)
+	ret0, _ := ret[0].([]db.PlayerPosition)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListGames indicates an expected call of ListGames.
-func (mr *MockStoreMockRecorder) ListGames(arg0, arg1 interface{}) *gomock.Call {
+// SetPlayerPositionsTx indicates an expected call of SetPlayerPositionsTx.
+func (mr *MockStoreMockRecorder) SetPlayerPositionsTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGames", reflect.TypeOf((*MockStore)(nil).ListGames), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPlayerPositionsTx", reflect.TypeOf((*MockStore)(nil).SetPlayerPositionsTx), arg0, arg1)
 }
 
-// ListRoles mocks base method.
-func (m *MockStore) ListRoles(arg0 context.Context, arg1 db.ListRolesParams) ([]db.UserRole, error) {
+// SubstituteTx mocks base method.
+func (m *MockStore) SubstituteTx(arg0 context.Context, arg1 db.SubstituteTxParams) (db.SubstituteTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListRoles", arg0, arg1)
-	ret0, _ := ret[0].([]db.UserRole)
+	ret := m.ctrl.Call(m, "SubstituteTx", arg0, arg1)
+	ret0, _ := ret[0].(db.SubstituteTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListRoles indicates an expected call of ListRoles.
-func (mr *MockStoreMockRecorder) ListRoles(arg0, arg1 interface{}) *gomock.Call {
+// SubstituteTx indicates an expected call of SubstituteTx.
+func (mr *MockStoreMockRecorder) SubstituteTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoles", reflect.TypeOf((*MockStore)(nil).ListRoles), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubstituteTx", reflect.TypeOf((*MockStore)(nil).SubstituteTx), arg0, arg1)
 }
 
-// ListTeamMembers mocks base method.
-func (m *MockStore) ListTeamMembers(arg0 context.Context, arg1 db.ListTeamMembersParams) ([]db.ListTeamMembersRow, error) {
+// UnarchiveTeam mocks base method.
+func (m *MockStore) UnarchiveTeam(arg0 context.Context, arg1 uuid.UUID) (db.Team, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListTeamMembers", arg0, arg1)
-	ret0, _ := ret[0].([]db.ListTeamMembersRow)
+	ret := m.ctrl.Call(m, "UnarchiveTeam", arg0, arg1)
+	ret0, _ := ret[0].(db.Team)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListTeamMembers indicates an expected call of ListTeamMembers.
-func (mr *MockStoreMockRecorder) ListTeamMembers(arg0, arg1 interface{}) *gomock.Call {
+// UnarchiveTeam indicates an expected call of UnarchiveTeam.
+func (mr *MockStoreMockRecorder) UnarchiveTeam(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTeamMembers", reflect.TypeOf((*MockStore)(nil).ListTeamMembers), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnarchiveTeam", reflect.TypeOf((*MockStore)(nil).UnarchiveTeam), arg0, arg1)
 }
 
-// ListTeams mocks base method.
-func (m *MockStore) ListTeams(arg0 context.Context, arg1 db.ListTeamsParams) ([]db.Team, error) {
+// UndoGameEventsTx mocks base method.
+func (m *MockStore) UndoGameEventsTx(arg0 context.Context, arg1 db.UndoGameEventsTxParams) (db.UndoGameEventsTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListTeams", arg0, arg1)
-	ret0, _ := ret[0].([]db.Team)
+	ret := m.ctrl.Call(m, "UndoGameEventsTx", arg0, arg1)
+	ret0, _ := ret[0].(db.UndoGameEventsTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListTeams indicates an expected call of ListTeams.
-func (mr *MockStoreMockRecorder) ListTeams(arg0, arg1 interface{}) *gomock.Call {
+// UndoGameEventsTx indicates an expected call of UndoGameEventsTx.
+func (mr *MockStoreMockRecorder) UndoGameEventsTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTeams", reflect.TypeOf((*MockStore)(nil).ListTeams), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndoGameEventsTx", reflect.TypeOf((*MockStore)(nil).UndoGameEventsTx), arg0, arg1)
 }
 
-// ListTeamsOfUser mocks base method.
-func (m *MockStore) ListTeamsOfUser(arg0 context.Context, arg1 db.ListTeamsOfUserParams) ([]db.ListTeamsOfUserRow, error) {
+// UpdateField mocks base method.
+func (m *MockStore) UpdateField(arg0 context.Context, arg1 db.UpdateFieldParams) (db.Field, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListTeamsOfUser", arg0, arg1)
-	ret0, _ := ret[0].([]db.ListTeamsOfUserRow)
+	ret := m.ctrl.Call(m, "UpdateField", arg0, arg1)
+	ret0, _ := ret[0].(db.Field)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListTeamsOfUser indicates an expected call of ListTeamsOfUser.
-func (mr *MockStoreMockRecorder) ListTeamsOfUser(arg0, arg1 interface{}) *gomock.Call {
+// UpdateField indicates an expected call of UpdateField.
+func (mr *MockStoreMockRecorder) UpdateField(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTeamsOfUser", reflect.TypeOf((*MockStore)(nil).ListTeamsOfUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateField", reflect.TypeOf((*MockStore)(nil).UpdateField), arg0, arg1)
 }
 
-// ListUsers mocks base method.
-func (m *MockStore) ListUsers(arg0 context.Context, arg1 db.ListUsersParams) ([]db.ListUsersRow, error) {
+// UpdateGameSchedule mocks base method.
+func (m *MockStore) UpdateGameSchedule(arg0 context.Context, arg1 db.UpdateGameScheduleParams) (db.Game, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUsers", arg0, arg1)
-	ret0, _ := ret[0].([]db.ListUsersRow)
+	ret := m.ctrl.Call(m, "UpdateGameSchedule", arg0, arg1)
+	ret0, _ := ret[0].(db.Game)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListUsers indicates an expected call of ListUsers.
-func (mr *MockStoreMockRecorder) ListUsers(arg0, arg1 interface{}) *gomock.Call {
+// UpdateGameSchedule indicates an expected call of UpdateGameSchedule.
+func (mr *MockStoreMockRecorder) UpdateGameSchedule(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockStore)(nil).ListUsers), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGameSchedule", reflect.TypeOf((*MockStore)(nil).UpdateGameSchedule), arg0, arg1)
 }
 
-// UpdateGame mocks base method.
-func (m *MockStore) UpdateGame(arg0 context.Context, arg1 db.UpdateGameParams) (db.Game, error) {
+// UpdateGameStatus mocks base method.
+func (m *MockStore) UpdateGameStatus(arg0 context.Context, arg1 db.UpdateGameStatusParams) (db.Game, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateGame", arg0, arg1)
+	ret := m.ctrl.Call(m, "UpdateGameStatus", arg0, arg1)
 	ret0, _ := ret[0].(db.Game)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateGame indicates an expected call of UpdateGame.
-func (mr *MockStoreMockRecorder) UpdateGame(arg0, arg1 interface{}) *gomock.Call {
+// UpdateGameStatus indicates an expected call of UpdateGameStatus.
+func (mr *MockStoreMockRecorder) UpdateGameStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGameStatus", reflect.TypeOf((*MockStore)(nil).UpdateGameStatus), arg0, arg1)
+}
+
+// UpdateGuardianStatus mocks base method.
+func (m *MockStore) UpdateGuardianStatus(arg0 context.Context, arg1 db.UpdateGuardianStatusParams) (db.Guardian, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGuardianStatus", arg0, arg1)
+	ret0, _ := ret[0].(db.Guardian)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGuardianStatus indicates an expected call of UpdateGuardianStatus.
+func (mr *MockStoreMockRecorder) UpdateGuardianStatus(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGame", reflect.TypeOf((*MockStore)(nil).UpdateGame), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGuardianStatus", reflect.TypeOf((*MockStore)(nil).UpdateGuardianStatus), arg0, arg1)
 }
 
 // UpdateSession mocks base method.
@@ -438,6 +2285,36 @@ func (mr *MockStoreMockRecorder) UpdateTeam(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTeam", reflect.TypeOf((*MockStore)(nil).UpdateTeam), arg0, arg1)
 }
 
+// UpdateTeamMember mocks base method.
+func (m *MockStore) UpdateTeamMember(arg0 context.Context, arg1 db.UpdateTeamMemberParams) (db.TeamMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTeamMember", arg0, arg1)
+	ret0, _ := ret[0].(db.TeamMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTeamMember indicates an expected call of UpdateTeamMember.
+func (mr *MockStoreMockRecorder) UpdateTeamMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTeamMember", reflect.TypeOf((*MockStore)(nil).UpdateTeamMember), arg0, arg1)
+}
+
+// UpdateTeamMemberTx mocks base method.
+func (m *MockStore) UpdateTeamMemberTx(arg0 context.Context, arg1 db.UpdateTeamMemberTxParams) (db.UpdateTeamMemberTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTeamMemberTx", arg0, arg1)
+	ret0, _ := ret[0].(db.UpdateTeamMemberTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTeamMemberTx indicates an expected call of UpdateTeamMemberTx.
+func (mr *MockStoreMockRecorder) UpdateTeamMemberTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTeamMemberTx", reflect.TypeOf((*MockStore)(nil).UpdateTeamMemberTx), arg0, arg1)
+}
+
 // UpdateUser mocks base method.
 func (m *MockStore) UpdateUser(arg0 context.Context, arg1 db.UpdateUserParams) (db.User, error) {
 	m.ctrl.T.Helper()
@@ -452,3 +2329,78 @@ func (mr *MockStoreMockRecorder) UpdateUser(arg0, arg1 interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockStore)(nil).UpdateUser), arg0, arg1)
 }
+
+// UpdateUserPrivacy mocks base method.
+func (m *MockStore) UpdateUserPrivacy(arg0 context.Context, arg1 db.UpdateUserPrivacyParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPrivacy", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserPrivacy indicates an expected call of UpdateUserPrivacy.
+func (mr *MockStoreMockRecorder) UpdateUserPrivacy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPrivacy", reflect.TypeOf((*MockStore)(nil).UpdateUserPrivacy), arg0, arg1)
+}
+
+// UpdateVenue mocks base method.
+func (m *MockStore) UpdateVenue(arg0 context.Context, arg1 db.UpdateVenueParams) (db.Venue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVenue", arg0, arg1)
+	ret0, _ := ret[0].(db.Venue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateVenue indicates an expected call of UpdateVenue.
+func (mr *MockStoreMockRecorder) UpdateVenue(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVenue", reflect.TypeOf((*MockStore)(nil).UpdateVenue), arg0, arg1)
+}
+
+// UseTeamJoinCode mocks base method.
+func (m *MockStore) UseTeamJoinCode(arg0 context.Context, arg1 uuid.UUID) (db.TeamInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTeamJoinCode", arg0, arg1)
+	ret0, _ := ret[0].(db.TeamInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseTeamJoinCode indicates an expected call of UseTeamJoinCode.
+func (mr *MockStoreMockRecorder) UseTeamJoinCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTeamJoinCode", reflect.TypeOf((*MockStore)(nil).UseTeamJoinCode), arg0, arg1)
+}
+
+// VoidGameEvent mocks base method.
+func (m *MockStore) VoidGameEvent(arg0 context.Context, arg1 db.VoidGameEventParams) (db.GameEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoidGameEvent", arg0, arg1)
+	ret0, _ := ret[0].(db.GameEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VoidGameEvent indicates an expected call of VoidGameEvent.
+func (mr *MockStoreMockRecorder) VoidGameEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoidGameEvent", reflect.TypeOf((*MockStore)(nil).VoidGameEvent), arg0, arg1)
+}
+
+// VoidGameEvents mocks base method.
+func (m *MockStore) VoidGameEvents(arg0 context.Context, arg1 db.VoidGameEventsParams) ([]db.GameEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoidGameEvents", arg0, arg1)
+	ret0, _ := ret[0].([]db.GameEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VoidGameEvents indicates an expected call of VoidGameEvents.
+func (mr *MockStoreMockRecorder) VoidGameEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoidGameEvents", reflect.TypeOf((*MockStore)(nil).VoidGameEvents), arg0, arg1)
+}
@@ -0,0 +1,143 @@
+-- name: ProjectInning :one
+INSERT INTO inning (game_id, number, home_runs, home_hits, home_errors, home_last_bat,
+                    away_runs, away_hits, away_errors, away_last_bat)
+VALUES (sqlc.arg(game_id), sqlc.arg(number), sqlc.arg(home_runs), sqlc.arg(home_hits), sqlc.arg(home_errors),
+        sqlc.narg(home_last_bat), sqlc.arg(away_runs), sqlc.arg(away_hits), sqlc.arg(away_errors),
+        sqlc.narg(away_last_bat))
+ON CONFLICT (game_id, number) DO UPDATE
+    SET home_runs     = EXCLUDED.home_runs,
+        home_hits     = EXCLUDED.home_hits,
+        home_errors   = EXCLUDED.home_errors,
+        home_last_bat = EXCLUDED.home_last_bat,
+        away_runs     = EXCLUDED.away_runs,
+        away_hits     = EXCLUDED.away_hits,
+        away_errors   = EXCLUDED.away_errors,
+        away_last_bat = EXCLUDED.away_last_bat
+RETURNING *;
+
+-- name: ProjectAtbat :one
+INSERT INTO atbat (game_id, number, inning_id, half, batter_id, pitcher_id, balls, strikes, pitches,
+                   result, out, init_bases, total_bases, runner_on_first, runner_on_second, runner_on_third, rbi,
+                   ended_at)
+VALUES (sqlc.arg(game_id), sqlc.arg(number), sqlc.arg(inning_id), sqlc.arg(half), sqlc.arg(batter_id),
+        sqlc.arg(pitcher_id), sqlc.arg(balls), sqlc.arg(strikes), sqlc.arg(pitches), sqlc.narg(result),
+        sqlc.arg(out), sqlc.arg(init_bases), sqlc.arg(total_bases), sqlc.narg(runner_on_first),
+        sqlc.narg(runner_on_second), sqlc.narg(runner_on_third), sqlc.arg(rbi),
+        CASE WHEN sqlc.narg(result)::varchar IS NULL THEN NULL ELSE now() END)
+ON CONFLICT (game_id, number) DO UPDATE
+    SET inning_id        = EXCLUDED.inning_id,
+        half             = EXCLUDED.half,
+        batter_id        = EXCLUDED.batter_id,
+        pitcher_id       = EXCLUDED.pitcher_id,
+        balls            = EXCLUDED.balls,
+        strikes          = EXCLUDED.strikes,
+        pitches          = EXCLUDED.pitches,
+        result           = EXCLUDED.result,
+        out              = EXCLUDED.out,
+        init_bases       = EXCLUDED.init_bases,
+        total_bases      = EXCLUDED.total_bases,
+        runner_on_first  = EXCLUDED.runner_on_first,
+        runner_on_second = EXCLUDED.runner_on_second,
+        runner_on_third  = EXCLUDED.runner_on_third,
+        rbi              = EXCLUDED.rbi,
+        ended_at         = CASE WHEN EXCLUDED.result IS NULL THEN NULL ELSE COALESCE(atbat.ended_at, EXCLUDED.ended_at) END
+RETURNING *;
+
+-- name: ProjectPitch :one
+INSERT INTO pitches (atbat_id, number, type, balls, strikes, created_by, pitcher_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (atbat_id, number) DO UPDATE
+    SET type       = EXCLUDED.type,
+        balls      = EXCLUDED.balls,
+        strikes    = EXCLUDED.strikes,
+        pitcher_id = EXCLUDED.pitcher_id
+RETURNING *;
+
+-- name: DeletePitchesAfter :exec
+DELETE
+FROM pitches
+WHERE atbat_id = $1
+  AND number > $2;
+
+-- name: DeleteAtbatsAfter :exec
+DELETE
+FROM atbat
+WHERE game_id = $1
+  AND number > $2;
+
+-- name: DeleteInningsAfter :exec
+DELETE
+FROM inning
+WHERE game_id = $1
+  AND number > $2;
+
+-- name: DeleteAtbatRunners :exec
+DELETE
+FROM atbat_runners
+WHERE atbat_id = $1;
+
+-- name: CreateAtbatRunner :one
+INSERT INTO atbat_runners (atbat_id, number, runner_id, from_base, to_base, out, reason, scored, rbi, earned,
+                           pitcher_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING *;
+
+-- name: ListAtbatRunners :many
+SELECT *
+FROM atbat_runners
+WHERE atbat_id = $1
+ORDER BY number;
+
+-- name: ListGameRunners :many
+SELECT r.id,
+       r.atbat_id,
+       r.number,
+       r.runner_id,
+       r.from_base,
+       r.to_base,
+       r.out,
+       r.reason,
+       r.scored,
+       r.rbi,
+       r.earned,
+       r.pitcher_id
+FROM atbat_runners r
+         JOIN atbat a ON a.id = r.atbat_id
+WHERE a.game_id = $1
+ORDER BY a.number, r.number;
+
+-- name: GetAtbat :one
+SELECT *
+FROM atbat
+WHERE id = $1;
+
+-- name: ListGameAtbats :many
+SELECT a.id,
+       a.game_id,
+       a.number,
+       a.inning_id,
+       i.number AS inning,
+       a.half,
+       a.batter_id,
+       a.pitcher_id,
+       a.balls,
+       a.strikes,
+       a.pitches,
+       a.result,
+       a.out,
+       a.rbi,
+       a.runner_on_first,
+       a.runner_on_second,
+       a.runner_on_third,
+       a.created_at,
+       a.ended_at
+FROM atbat a
+         JOIN inning i ON i.id = a.inning_id
+WHERE a.game_id = $1
+ORDER BY a.number;
+
+-- name: ListPitches :many
+SELECT *
+FROM pitches
+WHERE atbat_id = $1
+ORDER BY number;
@@ -0,0 +1,10 @@
+-- name: CreateAuditLog :one
+INSERT INTO audit_logs (actor_id, action, subject_id, details)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListAuditLogs :many
+SELECT *
+FROM audit_logs
+ORDER BY created_at DESC
+LIMIT $1 OFFSET $2;
\ No newline at end of file
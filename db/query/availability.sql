@@ -0,0 +1,42 @@
+-- name: GetPlayerGameTeam :one
+SELECT tm.team_id
+FROM game g
+         JOIN team_members tm ON tm.team_id IN (g.home_team_id, g.away_team_id)
+WHERE g.id = $1
+  AND tm.user_id = $2
+ORDER BY tm.team_id = g.home_team_id DESC
+LIMIT 1;
+
+-- name: SetGameAvailability :one
+INSERT INTO game_availability (game_id, team_id, user_id, response, note, responded_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (game_id, user_id) DO UPDATE
+    SET team_id      = EXCLUDED.team_id,
+        response     = EXCLUDED.response,
+        note         = EXCLUDED.note,
+        responded_by = EXCLUDED.responded_by,
+        updated_at   = now()
+RETURNING *;
+
+-- name: GetGameAvailability :one
+SELECT *
+FROM game_availability
+WHERE game_id = $1
+  AND user_id = $2
+LIMIT 1;
+
+-- name: ListGameAvailability :many
+SELECT tm.team_id,
+       tm.user_id,
+       u.first_name,
+       u.last_name,
+       tm.number,
+       a.response,
+       a.note,
+       a.updated_at
+FROM game g
+         JOIN team_members tm ON tm.team_id IN (g.home_team_id, g.away_team_id)
+         JOIN users u ON u.id = tm.user_id
+         LEFT JOIN game_availability a ON a.game_id = g.id AND a.user_id = tm.user_id
+WHERE g.id = $1
+ORDER BY tm.team_id, tm.number;
\ No newline at end of file
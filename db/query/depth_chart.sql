@@ -0,0 +1,50 @@
+-- name: CreatePlayerPosition :one
+INSERT INTO player_positions (team_id, user_id, position, rank)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListPlayerPositions :many
+SELECT *
+FROM player_positions
+WHERE team_id = $1
+  AND user_id = $2
+ORDER BY rank;
+
+-- name: DeletePlayerPositions :exec
+DELETE
+FROM player_positions
+WHERE team_id = $1
+  AND user_id = $2;
+
+-- name: IsEligibleForPosition :one
+SELECT (EXISTS(SELECT 1
+               FROM team_members tm
+               WHERE tm.team_id = sqlc.arg(team_id)
+                 AND tm.user_id = sqlc.arg(user_id)
+                 AND tm.primary_position = sqlc.arg(position)::varchar)
+    OR EXISTS(SELECT 1
+              FROM player_positions pp
+              WHERE pp.team_id = sqlc.arg(team_id)
+                AND pp.user_id = sqlc.arg(user_id)
+                AND pp.position = sqlc.arg(position)::varchar))::boolean AS eligible;
+
+-- name: CreateDepthChartEntry :one
+INSERT INTO depth_chart_entries (team_id, position, user_id, rank)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: DeleteDepthChartPosition :exec
+DELETE
+FROM depth_chart_entries
+WHERE team_id = $1
+  AND position = $2;
+
+-- name: ListDepthChart :many
+SELECT d.position, d.rank, d.user_id, u.first_name, u.last_name, tm.number,
+       (NOT profile_visible(sqlc.arg(viewer_id)::uuid, u.id))::boolean AS hidden
+FROM depth_chart_entries d
+         JOIN team_members tm ON tm.team_id = d.team_id AND tm.user_id = d.user_id
+         JOIN users u ON u.id = d.user_id
+WHERE d.team_id = $1
+  AND (sqlc.arg(include_private)::boolean OR profile_visible(sqlc.arg(viewer_id)::uuid, u.id))
+ORDER BY d.position, d.rank;
\ No newline at end of file
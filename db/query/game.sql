@@ -1,6 +1,7 @@
 -- name: CreateGame :one
-INSERT INTO game (home_team_id, away_team_id, home_score, away_score)
-VALUES ($1, $2, $3, $4)
+INSERT INTO game (home_team_id, away_team_id, home_score, away_score, scheduled_at, time_zone, venue_id, field_id,
+                  reentry_rule, rules)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 RETURNING *;
 
 -- name: GetGame :one
@@ -13,13 +14,88 @@ SELECT *
 FROM game g
 WHERE (sqlc.narg(home_team_id)::UUID IS NULL OR g.home_team_id = sqlc.narg(home_team_id)::UUID)
   AND (sqlc.narg(away_team_id)::UUID IS NULL OR g.away_team_id = sqlc.narg(away_team_id)::UUID)
-ORDER BY g.created_at DESC
+  AND (sqlc.narg(status)::varchar IS NULL OR g.status = sqlc.narg(status)::varchar)
+  AND (sqlc.narg(team_id)::UUID IS NULL OR sqlc.narg(team_id)::UUID IN (g.home_team_id, g.away_team_id))
+  AND (sqlc.narg(venue_id)::UUID IS NULL OR g.venue_id = sqlc.narg(venue_id)::UUID)
+  AND (sqlc.narg(field_id)::UUID IS NULL OR g.field_id = sqlc.narg(field_id)::UUID)
+  AND (sqlc.narg(from_time)::timestamptz IS NULL OR g.scheduled_at >= sqlc.narg(from_time)::timestamptz)
+  AND (sqlc.narg(to_time)::timestamptz IS NULL OR g.scheduled_at < sqlc.narg(to_time)::timestamptz)
+ORDER BY CASE WHEN sqlc.narg(sort)::varchar = 'scheduled_at' THEN g.scheduled_at END NULLS LAST,
+         CASE WHEN sqlc.narg(sort)::varchar = '-scheduled_at' THEN g.scheduled_at END DESC NULLS LAST,
+         g.created_at DESC
 LIMIT $1 OFFSET $2;
 
--- name: UpdateGame :one
+-- name: SetGameScore :one
 UPDATE game
 SET home_score = $1,
     away_score = $2,
     updated_at = NOW()
 WHERE id = $3
-RETURNING *;
\ No newline at end of file
+  AND status <> 'final'
+RETURNING *;
+
+-- name: ListGamesOfUser :many
+SELECT *
+FROM game g
+WHERE (EXISTS(SELECT 1
+              FROM team_members tm
+              WHERE tm.user_id = sqlc.arg(user_id)::uuid
+                AND tm.team_id IN (g.home_team_id, g.away_team_id))
+    OR EXISTS(SELECT 1
+              FROM game_participant gp
+              WHERE gp.game_id = g.id
+                AND gp.player_id = sqlc.arg(user_id)::uuid))
+  AND (sqlc.narg(status)::varchar IS NULL OR g.status = sqlc.narg(status)::varchar)
+  AND (sqlc.narg(from_time)::timestamptz IS NULL OR COALESCE(g.scheduled_at, g.created_at) >= sqlc.narg(from_time)::timestamptz)
+  AND (sqlc.narg(to_time)::timestamptz IS NULL OR COALESCE(g.scheduled_at, g.created_at) < sqlc.narg(to_time)::timestamptz)
+ORDER BY COALESCE(g.scheduled_at, g.created_at) DESC
+LIMIT $1 OFFSET $2;
+
+-- name: ListScheduleConflicts :many
+SELECT *
+FROM game g
+WHERE g.id <> sqlc.arg(game_id)::uuid
+  AND g.status NOT IN ('postponed', 'cancelled')
+  AND g.scheduled_at > sqlc.arg(window_start)::timestamptz
+  AND g.scheduled_at < sqlc.arg(window_end)::timestamptz
+  AND (g.home_team_id IN (sqlc.arg(home_team_id)::uuid, sqlc.arg(away_team_id)::uuid)
+    OR g.away_team_id IN (sqlc.arg(home_team_id)::uuid, sqlc.arg(away_team_id)::uuid)
+    OR (g.venue_id = sqlc.narg(venue_id)::uuid
+        AND (sqlc.narg(field_id)::uuid IS NULL OR g.field_id IS NULL OR g.field_id = sqlc.narg(field_id)::uuid)))
+ORDER BY g.scheduled_at;
+
+-- name: LockScheduleTeams :exec
+SELECT id
+FROM teams
+WHERE id IN (sqlc.arg(home_team_id)::uuid, sqlc.arg(away_team_id)::uuid)
+ORDER BY id
+    FOR UPDATE;
+
+-- name: LockVenue :exec
+SELECT id
+FROM venues
+WHERE id = $1
+    FOR UPDATE;
+
+-- name: UpdateGameSchedule :one
+UPDATE game
+SET scheduled_at = sqlc.arg(scheduled_at)::timestamptz,
+    time_zone    = COALESCE(sqlc.narg(time_zone), time_zone),
+    venue_id     = sqlc.narg(venue_id),
+    field_id     = sqlc.narg(field_id),
+    updated_at   = now()
+WHERE id = sqlc.arg(id)
+  AND status IN ('scheduled', 'postponed')
+RETURNING *;
+
+-- name: CreateGameScheduleChange :one
+INSERT INTO game_schedule_changes (game_id, old_scheduled_at, new_scheduled_at, old_time_zone, new_time_zone,
+                                   old_venue_id, new_venue_id, old_field_id, new_field_id, reason, changed_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING *;
+
+-- name: ListGameScheduleChanges :many
+SELECT *
+FROM game_schedule_changes
+WHERE game_id = $1
+ORDER BY changed_at;
\ No newline at end of file
@@ -0,0 +1,41 @@
+-- name: CreateGameEvent :one
+INSERT INTO game_events (game_id, sequence, type, payload, created_by, corrects_id)
+VALUES ($1, $2, $3, $4, $5, sqlc.narg(corrects_id))
+RETURNING *;
+
+-- name: ListGameEvents :many
+SELECT *
+FROM game_events
+WHERE game_id = $1
+  AND voided_at IS NULL
+ORDER BY sequence;
+
+-- name: ListGameEventHistory :many
+SELECT *
+FROM game_events
+WHERE game_id = $1
+ORDER BY sequence, created_at;
+
+-- name: GetNextEventSequence :one
+SELECT (COALESCE(MAX(sequence), 0) + 1)::bigint AS next_sequence
+FROM game_events
+WHERE game_id = $1
+  AND voided_at IS NULL;
+
+-- name: VoidGameEvents :many
+UPDATE game_events
+SET voided_by = sqlc.arg(voided_by)::uuid,
+    voided_at = now()
+WHERE game_id = sqlc.arg(game_id)
+  AND sequence >= sqlc.arg(from_sequence)
+  AND voided_at IS NULL
+RETURNING *;
+
+-- name: VoidGameEvent :one
+UPDATE game_events
+SET voided_by = sqlc.arg(voided_by)::uuid,
+    voided_at = now()
+WHERE game_id = sqlc.arg(game_id)
+  AND sequence = sqlc.arg(sequence)
+  AND voided_at IS NULL
+RETURNING *;
@@ -0,0 +1,21 @@
+-- name: DeleteAtbatStats :exec
+DELETE
+FROM game_stat
+WHERE atbat_id = sqlc.arg(atbat_id)::uuid;
+
+-- name: CreateGameStat :one
+INSERT INTO game_stat (atbat_id, type, participant_id)
+VALUES (sqlc.arg(atbat_id)::uuid, sqlc.arg(type)::varchar, sqlc.arg(participant_id)::uuid)
+RETURNING *;
+
+-- name: ListGameStats :many
+SELECT s.id,
+       s.atbat_id,
+       s.type,
+       s.participant_id,
+       i.number AS inning
+FROM game_stat s
+         JOIN atbat a ON a.id = s.atbat_id
+         JOIN inning i ON i.id = a.inning_id
+WHERE a.game_id = $1
+ORDER BY a.number, s.id;
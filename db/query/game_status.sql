@@ -0,0 +1,31 @@
+-- name: UpdateGameStatus :one
+UPDATE game
+SET status     = sqlc.arg(status),
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+  AND status = sqlc.arg(from_status)
+RETURNING *;
+
+-- name: CreateGameStatusChange :one
+INSERT INTO game_status_changes (game_id, from_status, to_status, reason, changed_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListGameStatusChanges :many
+SELECT *
+FROM game_status_changes
+WHERE game_id = $1
+ORDER BY changed_at;
+
+-- name: HasGameReachedStatus :one
+SELECT EXISTS(SELECT 1
+              FROM game_status_changes
+              WHERE game_id = $1
+                AND to_status = $2)::boolean AS reached;
+
+-- name: LockGameInProgress :one
+SELECT *
+FROM game
+WHERE id = $1
+  AND status = 'in_progress'
+    FOR UPDATE;
\ No newline at end of file
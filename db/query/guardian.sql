@@ -0,0 +1,39 @@
+-- name: CreateGuardian :one
+INSERT INTO guardians (guardian_id, player_id)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetGuardian :one
+SELECT *
+FROM guardians
+WHERE guardian_id = $1
+  AND player_id = $2
+LIMIT 1;
+
+-- name: ListGuardiansOfPlayer :many
+SELECT g.id, g.guardian_id, u.first_name, u.last_name, u.email, g.status, g.approved_at
+FROM guardians g
+         JOIN users u ON g.guardian_id = u.id
+WHERE g.player_id = $1
+ORDER BY g.created_at;
+
+-- name: ListApprovedGuardians :many
+SELECT *
+FROM guardians
+WHERE status = 'approved';
+
+-- name: UpdateGuardianStatus :one
+UPDATE guardians
+SET status      = sqlc.arg(status),
+    approved_by = sqlc.narg(approved_by),
+    approved_at = sqlc.narg(approved_at),
+    updated_at  = now()
+WHERE guardian_id = sqlc.arg(guardian_id)
+  AND player_id = sqlc.arg(player_id)
+RETURNING *;
+
+-- name: DeleteGuardian :exec
+DELETE
+FROM guardians
+WHERE guardian_id = $1
+  AND player_id = $2;
\ No newline at end of file
@@ -0,0 +1,23 @@
+-- name: ListGameInnings :many
+SELECT i.id,
+       i.game_id,
+       i.number,
+       i.home_runs,
+       i.home_hits,
+       i.home_errors,
+       i.home_last_bat,
+       i.away_runs,
+       i.away_hits,
+       i.away_errors,
+       i.away_last_bat,
+       EXISTS(SELECT 1
+              FROM atbat a
+              WHERE a.inning_id = i.id
+                AND a.half = 'top')::boolean    AS away_batted,
+       EXISTS(SELECT 1
+              FROM atbat a
+              WHERE a.inning_id = i.id
+                AND a.half = 'bottom')::boolean AS home_batted
+FROM inning i
+WHERE i.game_id = $1
+ORDER BY i.number;
@@ -0,0 +1,22 @@
+-- name: CreateJoinRequest :one
+INSERT INTO join_requests (team_id, user_id, number, primary_position, message)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListJoinRequests :many
+SELECT *
+FROM join_requests
+WHERE team_id = sqlc.arg(team_id)::uuid
+  AND status = sqlc.arg(status)::varchar
+ORDER BY created_at
+LIMIT $1 OFFSET $2;
+
+-- name: DecideJoinRequest :one
+UPDATE join_requests
+SET status     = sqlc.arg(status)::varchar,
+    decided_by = sqlc.arg(decided_by)::uuid,
+    decided_at = now()
+WHERE id = sqlc.arg(id)
+  AND team_id = sqlc.arg(team_id)
+  AND status = 'pending'
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,73 @@
+-- name: LockGameForLineup :one
+SELECT *
+FROM game
+WHERE id = $1
+  AND status IN ('scheduled', 'postponed')
+    FOR UPDATE;
+
+-- name: DeleteLineup :exec
+DELETE
+FROM game_participant
+WHERE game_id = $1
+  AND home_team = $2;
+
+-- name: CreateGameParticipant :one
+INSERT INTO game_participant (id, game_id, player_id, home_team, bat_position, position, entry, entered_inning,
+                              replaced_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: ListLineup :many
+SELECT gp.id,
+       gp.game_id,
+       gp.player_id,
+       gp.home_team,
+       gp.bat_position,
+       gp.position,
+       u.first_name,
+       u.last_name
+FROM game_participant gp
+         JOIN users u ON u.id = gp.player_id
+WHERE gp.game_id = $1
+  AND gp.home_team = $2
+  AND gp.exited_inning IS NULL
+ORDER BY gp.bat_position = 0, gp.bat_position;
+
+-- name: ListGameParticipants :many
+SELECT gp.id,
+       gp.game_id,
+       gp.player_id,
+       gp.home_team,
+       gp.bat_position,
+       gp.position,
+       gp.entry,
+       gp.entered_inning,
+       gp.exited_inning,
+       gp.replaced_id,
+       gp.created_at,
+       u.first_name,
+       u.last_name
+FROM game_participant gp
+         JOIN users u ON u.id = gp.player_id
+WHERE gp.game_id = $1
+ORDER BY gp.home_team, gp.bat_position = 0, gp.bat_position, gp.created_at;
+
+-- name: ExitGameParticipant :one
+UPDATE game_participant
+SET exited_inning = $2
+WHERE id = $1
+  AND exited_inning IS NULL
+RETURNING *;
+
+-- name: ReenterGameParticipant :one
+UPDATE game_participant
+SET exited_inning = NULL
+WHERE id = $1
+  AND exited_inning IS NOT NULL
+RETURNING *;
+
+-- name: DeleteGameParticipant :one
+DELETE
+FROM game_participant
+WHERE id = $1
+RETURNING *;
\ No newline at end of file
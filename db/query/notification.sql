@@ -0,0 +1,27 @@
+-- name: NotifyGamePlayers :exec
+INSERT INTO notifications (user_id, player_id, game_id, kind, message)
+SELECT r.user_id, m.user_id, g.id, sqlc.arg(kind)::varchar, sqlc.arg(message)::varchar
+FROM game g
+         JOIN team_members m ON m.team_id IN (g.home_team_id, g.away_team_id)
+         CROSS JOIN LATERAL (SELECT m.user_id
+                             UNION
+                             SELECT gd.guardian_id
+                             FROM guardians gd
+                             WHERE gd.player_id = m.user_id
+                               AND gd.status = 'approved') r (user_id)
+WHERE g.id = sqlc.arg(game_id)::uuid;
+
+-- name: ListNotifications :many
+SELECT *
+FROM notifications
+WHERE user_id = sqlc.arg(user_id)::uuid
+  AND (NOT sqlc.arg(unread_only)::boolean OR read_at IS NULL)
+ORDER BY created_at DESC
+LIMIT sqlc.arg(page_limit)::int OFFSET sqlc.arg(page_offset)::int;
+
+-- name: MarkNotificationRead :one
+UPDATE notifications
+SET read_at = COALESCE(read_at, now())
+WHERE id = $1
+  AND user_id = $2
+RETURNING *;
@@ -0,0 +1,48 @@
+-- name: SetPitchCount :one
+INSERT INTO pitch_counts (game_id, player_id, pitches, entered_by)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (game_id, player_id) DO UPDATE
+    SET pitches    = EXCLUDED.pitches,
+        entered_by = EXCLUDED.entered_by,
+        updated_at = now()
+RETURNING *;
+
+-- name: DeletePitchCount :exec
+DELETE
+FROM pitch_counts
+WHERE game_id = $1
+  AND player_id = $2;
+
+-- name: ListGamePitchCounts :many
+SELECT *
+FROM pitch_counts
+WHERE game_id = $1
+ORDER BY created_at;
+
+-- name: ListGameRecordedPitches :many
+SELECT p.player_id, COUNT(*)::bigint AS pitches
+FROM pitches pi
+         JOIN atbat a ON a.id = pi.atbat_id
+         JOIN game_participant p ON p.id = pi.pitcher_id
+WHERE a.game_id = $1
+GROUP BY p.player_id
+ORDER BY MIN(a.number), MIN(pi.created_at);
+
+-- name: ListPitchingAppearances :many
+SELECT g.id, g.scheduled_at, g.created_at, g.time_zone, g.rules, COUNT(*)::bigint AS pitches
+FROM pitches pi
+         JOIN atbat a ON a.id = pi.atbat_id
+         JOIN game_participant p ON p.id = pi.pitcher_id
+         JOIN game g ON g.id = a.game_id
+WHERE p.player_id = sqlc.arg(player_id)
+  AND COALESCE(g.scheduled_at, g.created_at) >= sqlc.arg(since)::timestamptz
+GROUP BY g.id
+ORDER BY COALESCE(g.scheduled_at, g.created_at);
+
+-- name: ListPlayerPitchCounts :many
+SELECT g.id, g.scheduled_at, g.created_at, g.time_zone, g.rules, c.pitches
+FROM pitch_counts c
+         JOIN game g ON g.id = c.game_id
+WHERE c.player_id = sqlc.arg(player_id)
+  AND COALESCE(g.scheduled_at, g.created_at) >= sqlc.arg(since)::timestamptz
+ORDER BY COALESCE(g.scheduled_at, g.created_at);
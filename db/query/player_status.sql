@@ -0,0 +1,43 @@
+-- name: CreatePlayerStatus :one
+INSERT INTO player_statuses (team_id, user_id, status, reason, starts_at, ends_at, games_remaining, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: ListPlayerStatuses :many
+SELECT *
+FROM player_statuses
+WHERE team_id = $1
+  AND user_id = $2
+ORDER BY starts_at DESC;
+
+-- name: GetCurrentPlayerStatus :one
+SELECT *
+FROM player_statuses
+WHERE team_id = $1
+  AND user_id = $2
+  AND starts_at <= now()
+  AND (ends_at IS NULL OR ends_at > now())
+ORDER BY starts_at DESC
+LIMIT 1;
+
+-- name: ClearPlayerStatus :one
+UPDATE player_statuses
+SET ends_at    = now(),
+    updated_at = now()
+WHERE id = $1
+  AND team_id = $2
+  AND user_id = $3
+  AND (ends_at IS NULL OR ends_at > now())
+RETURNING *;
+
+-- name: ServeSuspensionGame :many
+UPDATE player_statuses
+SET games_remaining = games_remaining - 1,
+    ends_at         = CASE WHEN games_remaining = 1 THEN now() ELSE ends_at END,
+    updated_at      = now()
+WHERE team_id = $1
+  AND status = 'suspended'
+  AND games_remaining > 0
+  AND starts_at <= now()
+  AND (ends_at IS NULL OR ends_at > now())
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,51 @@
+-- name: OpenTeamMemberStint :one
+INSERT INTO team_member_stints (team_id, user_id, number, primary_position, status, started_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: CloseTeamMemberStint :one
+UPDATE team_member_stints
+SET ended_at = sqlc.arg(ended_at)::timestamptz
+WHERE team_id = sqlc.arg(team_id)
+  AND user_id = sqlc.arg(user_id)
+  AND ended_at IS NULL
+  AND started_at <= sqlc.arg(ended_at)::timestamptz
+RETURNING *;
+
+-- name: CreateRosterTransaction :one
+INSERT INTO roster_transactions (type, user_id, from_team_id, to_team_id, effective_at, note, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: ListRosterTransactions :many
+SELECT *
+FROM roster_transactions
+WHERE from_team_id = sqlc.arg(team_id)::uuid
+   OR to_team_id = sqlc.arg(team_id)::uuid
+ORDER BY effective_at DESC, created_at DESC
+LIMIT $1 OFFSET $2;
+
+-- name: ListRosterAsOf :many
+SELECT s.user_id,
+       u.first_name,
+       u.last_name,
+       s.number,
+       s.primary_position,
+       COALESCE((SELECT ps.status
+                 FROM player_statuses ps
+                 WHERE ps.team_id = s.team_id
+                   AND ps.user_id = s.user_id
+                   AND ps.starts_at <= sqlc.arg(as_of)::timestamptz
+                   AND (ps.ends_at IS NULL OR ps.ends_at > sqlc.arg(as_of)::timestamptz)
+                 ORDER BY ps.starts_at DESC
+                 LIMIT 1), s.status)::varchar AS status,
+       s.started_at,
+       s.ended_at,
+       (NOT profile_visible(sqlc.arg(viewer_id)::uuid, u.id))::boolean AS hidden
+FROM team_member_stints s
+         JOIN users u ON u.id = s.user_id
+WHERE s.team_id = sqlc.arg(team_id)
+  AND s.started_at <= sqlc.arg(as_of)::timestamptz
+  AND (s.ended_at IS NULL OR s.ended_at > sqlc.arg(as_of)::timestamptz)
+  AND (sqlc.arg(include_private)::boolean OR profile_visible(sqlc.arg(viewer_id)::uuid, u.id))
+ORDER BY s.number;
\ No newline at end of file
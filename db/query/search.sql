@@ -0,0 +1,27 @@
+-- name: SearchUsers :many
+SELECT u.id,
+       u.username,
+       u.first_name,
+       u.last_name,
+       GREATEST(similarity(u.username, sqlc.arg(query)::varchar),
+                similarity(u.first_name || ' ' || u.last_name, sqlc.arg(query)::varchar))::real AS rank,
+       (NOT profile_visible(sqlc.arg(viewer_id)::uuid, u.id))::boolean AS hidden
+FROM users u
+WHERE (u.username % sqlc.arg(query)::varchar
+    OR (u.first_name || ' ' || u.last_name) % sqlc.arg(query)::varchar
+    OR (u.first_name || ' ' || u.last_name) ILIKE
+       '%' || replace(replace(replace(sqlc.arg(query)::varchar, '\', '\\'), '%', '\%'), '_', '\_') || '%' ESCAPE '\')
+  AND (sqlc.arg(include_private)::boolean OR profile_visible(sqlc.arg(viewer_id)::uuid, u.id))
+ORDER BY rank DESC, u.username
+LIMIT sqlc.arg(max_results)::integer;
+
+-- name: SearchTeams :many
+SELECT t.id,
+       t.name,
+       similarity(t.name, sqlc.arg(query)::varchar)::real AS rank
+FROM teams t
+WHERE (t.name % sqlc.arg(query)::varchar
+    OR t.name ILIKE '%' || replace(replace(replace(sqlc.arg(query)::varchar, '\', '\\'), '%', '\%'), '_', '\_') || '%' ESCAPE '\')
+  AND t.archived_at IS NULL
+ORDER BY rank DESC, t.name
+LIMIT sqlc.arg(max_results)::integer;
\ No newline at end of file
@@ -11,41 +11,137 @@ LIMIT 1;
 
 -- name: UpdateTeam :one
 UPDATE teams
-SET name       = COALESCE(sqlc.narg(name), name),
-    updated_at = now()
+SET name            = COALESCE(sqlc.narg(name), name),
+    abbreviation    = COALESCE(sqlc.narg(abbreviation), abbreviation),
+    primary_color   = COALESCE(sqlc.narg(primary_color), primary_color),
+    secondary_color = COALESCE(sqlc.narg(secondary_color), secondary_color),
+    home_venue      = COALESCE(sqlc.narg(home_venue), home_venue),
+    city            = COALESCE(sqlc.narg(city), city),
+    division        = COALESCE(sqlc.narg(division), division),
+    logo_url        = COALESCE(sqlc.narg(logo_url), logo_url),
+    website_url     = COALESCE(sqlc.narg(website_url), website_url),
+    facebook_url    = COALESCE(sqlc.narg(facebook_url), facebook_url),
+    instagram_url   = COALESCE(sqlc.narg(instagram_url), instagram_url),
+    x_url           = COALESCE(sqlc.narg(x_url), x_url),
+    updated_at      = now()
 WHERE id = sqlc.arg(id)
 RETURNING *;
 
 -- name: ListTeams :many
 SELECT *
 FROM teams
+WHERE sqlc.arg(include_archived)::boolean
+   OR archived_at IS NULL
 ORDER BY id
 LIMIT $1 OFFSET $2;
 
 -- name: ListTeamMembers :many
-SELECT u.id, u.first_name, u.last_name, tm.primary_position, tm.number, t.name as team_name
-FROM users u
-         JOIN team_members tm on u.id = tm.user_id
+SELECT u.id,
+       u.first_name,
+       u.last_name,
+       tm.primary_position,
+       tm.number,
+       t.name as team_name,
+       COALESCE((SELECT ps.status
+                 FROM player_statuses ps
+                 WHERE ps.team_id = tm.team_id
+                   AND ps.user_id = tm.user_id
+                   AND ps.starts_at <= now()
+                   AND (ps.ends_at IS NULL OR ps.ends_at > now())
+                 ORDER BY ps.starts_at DESC
+                 LIMIT 1), 'active')::varchar AS status,
+       (NOT profile_visible(sqlc.arg(viewer_id)::uuid, u.id))::boolean AS hidden
+FROM team_members tm
+         JOIN users u ON u.id = tm.user_id
          JOIN teams t ON tm.team_id = t.id
-WHERE u.id IN (SELECT user_id
-               FROM team_members
-               WHERE tm.team_id = $1)
+WHERE tm.team_id = $1
+  AND (sqlc.arg(include_private)::boolean OR profile_visible(sqlc.arg(viewer_id)::uuid, u.id))
+ORDER BY tm.number
 LIMIT $2 OFFSET $3;
 
 -- name: ListTeamsOfUser :many
-SELECT t.id, t.name
-FROM teams t
-WHERE t.id IN (SELECT team_id
-               FROM team_members tm
-               WHERE user_id = $1)
+SELECT t.id,
+       t.name,
+       t.abbreviation,
+       t.primary_color,
+       t.secondary_color,
+       t.logo_url,
+       t.archived_at,
+       tm.role,
+       tm.number,
+       tm.primary_position
+FROM team_members tm
+         JOIN teams t ON t.id = tm.team_id
+WHERE tm.user_id = $1
+ORDER BY t.name
 LIMIT $2 OFFSET $3;
 
+-- name: ListTeamIDsOfUser :many
+SELECT team_id
+FROM team_members
+WHERE user_id = $1
+ORDER BY team_id;
+
 -- name: AddTeamMember :one
 INSERT INTO team_members (user_id, team_id, number, primary_position)
 VALUES ($1, $2, $3, $4)
 RETURNING *;
 
+-- name: GetTeamMember :one
+SELECT *
+FROM team_members
+WHERE team_id = $1
+  AND user_id = $2
+LIMIT 1;
+
+-- name: UpdateTeamMember :one
+UPDATE team_members
+SET number           = COALESCE(sqlc.narg(number), number),
+    primary_position = COALESCE(sqlc.narg(primary_position), primary_position),
+    role             = COALESCE(sqlc.narg(role), role),
+    updated_at       = now()
+WHERE team_id = sqlc.arg(team_id)
+  AND user_id = sqlc.arg(user_id)
+RETURNING *;
+
+-- name: RemoveTeamMember :one
+DELETE
+FROM team_members
+WHERE team_id = $1
+  AND user_id = $2
+RETURNING *;
+
 -- name: DeleteTeam :exec
 DELETE
 FROM teams
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: ArchiveTeam :one
+UPDATE teams
+SET archived_at = now(),
+    updated_at  = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: UnarchiveTeam :one
+UPDATE teams
+SET archived_at = NULL,
+    updated_at  = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: GetTeamDeleteBlockers :one
+SELECT (SELECT COUNT(*)
+        FROM team_members tm
+        WHERE tm.team_id = $1)::bigint AS member_count,
+       (SELECT COUNT(*)
+        FROM game g
+        WHERE g.home_team_id = $1
+           OR g.away_team_id = $1)::bigint AS game_count,
+       (SELECT COUNT(*)
+        FROM team_member_stints tms
+        WHERE tms.team_id = $1)::bigint AS stint_count,
+       (SELECT COUNT(*)
+        FROM roster_transactions rt
+        WHERE rt.from_team_id = $1
+           OR rt.to_team_id = $1)::bigint AS transaction_count;
\ No newline at end of file
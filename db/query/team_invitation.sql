@@ -0,0 +1,46 @@
+-- name: CreateTeamInvitation :one
+INSERT INTO team_invitations (team_id, email, code, number, primary_position, invited_by, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetTeamInvitationByCode :one
+SELECT *
+FROM team_invitations
+WHERE code = $1
+LIMIT 1;
+
+-- name: ListTeamInvitations :many
+SELECT *
+FROM team_invitations
+WHERE team_id = sqlc.arg(team_id)::uuid
+ORDER BY created_at DESC
+LIMIT $1 OFFSET $2;
+
+-- name: RevokeTeamInvitation :one
+UPDATE team_invitations
+SET status     = 'revoked',
+    updated_at = now()
+WHERE id = $1
+  AND team_id = $2
+  AND status = 'pending'
+RETURNING *;
+
+-- name: AcceptTeamInvitation :one
+UPDATE team_invitations
+SET status      = 'accepted',
+    accepted_by = sqlc.arg(accepted_by)::uuid,
+    updated_at  = now()
+WHERE id = sqlc.arg(id)
+  AND email IS NOT NULL
+  AND status = 'pending'
+  AND expires_at > now()
+RETURNING *;
+
+-- name: UseTeamJoinCode :one
+SELECT *
+FROM team_invitations
+WHERE id = $1
+  AND email IS NULL
+  AND status = 'pending'
+  AND expires_at > now()
+FOR SHARE;
\ No newline at end of file
@@ -10,8 +10,10 @@ WHERE id = $1
 LIMIT 1;
 
 -- name: ListUsers :many
-SELECT u.id, u.username, u.first_name, u.last_name
+SELECT u.id, u.username, u.first_name, u.last_name, (NOT profile_visible(sqlc.arg(viewer_id)::uuid, u.id))::boolean AS hidden
 FROM users u
+WHERE sqlc.arg(include_private)::boolean
+   OR profile_visible(sqlc.arg(viewer_id)::uuid, u.id)
 ORDER BY u.username
 LIMIT $1 OFFSET $2;
 
@@ -29,10 +31,27 @@ SET username          = COALESCE(sqlc.narg(username), username),
     email             = COALESCE(sqlc.narg(email), email),
     is_email_verified = COALESCE(sqlc.narg(is_email_verified), is_email_verified),
     hashed_password   = COALESCE(sqlc.narg(hashed_password), hashed_password),
+    is_minor          = COALESCE(sqlc.narg(is_minor), is_minor),
     updated_at        = now()
 WHERE id = sqlc.arg(id)
 RETURNING *;
 
+-- name: UpdateUserPrivacy :one
+UPDATE users
+SET profile_visibility = COALESCE(sqlc.narg(profile_visibility), profile_visibility),
+    email_visibility   = COALESCE(sqlc.narg(email_visibility), email_visibility),
+    stat_visibility    = COALESCE(sqlc.narg(stat_visibility), stat_visibility),
+    updated_at         = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: AreTeammates :one
+SELECT EXISTS(SELECT 1
+              FROM team_members a
+                       JOIN team_members b ON a.team_id = b.team_id
+              WHERE a.user_id = sqlc.arg(user_id)
+                AND b.user_id = sqlc.arg(viewer_id)) AS are_teammates;
+
 -- name: DeleteUser :exec
 DELETE
 FROM users
@@ -0,0 +1,117 @@
+-- name: CreateVenue :one
+INSERT INTO venues (name, address, city, latitude, longitude, created_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetVenue :one
+SELECT *
+FROM venues
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListVenues :many
+SELECT *
+FROM venues
+WHERE (sqlc.narg(city)::varchar IS NULL OR city ILIKE sqlc.narg(city)::varchar)
+ORDER BY name, id
+LIMIT $1 OFFSET $2;
+
+-- name: UpdateVenue :one
+UPDATE venues
+SET name       = COALESCE(sqlc.narg(name), name),
+    address    = COALESCE(sqlc.narg(address), address),
+    city       = COALESCE(sqlc.narg(city), city),
+    latitude   = COALESCE(sqlc.narg(latitude), latitude),
+    longitude  = COALESCE(sqlc.narg(longitude), longitude),
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: DeleteVenue :one
+DELETE
+FROM venues
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateField :one
+INSERT INTO fields (venue_id, name, surface, left_field_ft, center_field_ft, right_field_ft, has_lights)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetField :one
+SELECT *
+FROM fields
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListFields :many
+SELECT *
+FROM fields
+WHERE venue_id = $1
+ORDER BY name;
+
+-- name: UpdateField :one
+UPDATE fields
+SET name            = COALESCE(sqlc.narg(name), name),
+    surface         = COALESCE(sqlc.narg(surface), surface),
+    left_field_ft   = COALESCE(sqlc.narg(left_field_ft), left_field_ft),
+    center_field_ft = COALESCE(sqlc.narg(center_field_ft), center_field_ft),
+    right_field_ft  = COALESCE(sqlc.narg(right_field_ft), right_field_ft),
+    has_lights      = COALESCE(sqlc.narg(has_lights), has_lights),
+    updated_at      = now()
+WHERE id = sqlc.arg(id)
+  AND venue_id = sqlc.arg(venue_id)
+RETURNING *;
+
+-- name: DeleteField :one
+DELETE
+FROM fields
+WHERE id = $1
+  AND venue_id = $2
+RETURNING *;
+
+-- name: ListFieldsInBounds :many
+SELECT f.id,
+       f.venue_id,
+       f.name,
+       f.surface,
+       f.left_field_ft,
+       f.center_field_ft,
+       f.right_field_ft,
+       f.has_lights,
+       v.name      AS venue_name,
+       v.latitude  AS latitude,
+       v.longitude AS longitude
+FROM fields f
+         JOIN venues v ON v.id = f.venue_id
+WHERE v.latitude BETWEEN sqlc.arg(min_latitude)::double precision AND sqlc.arg(max_latitude)::double precision
+  AND v.longitude BETWEEN sqlc.arg(min_longitude)::double precision AND sqlc.arg(max_longitude)::double precision
+  AND (sqlc.narg(surface)::varchar IS NULL OR f.surface = sqlc.narg(surface)::varchar)
+  AND (sqlc.narg(has_lights)::boolean IS NULL OR f.has_lights = sqlc.narg(has_lights)::boolean);
+
+-- name: CreateFieldAvailability :one
+INSERT INTO field_availability (field_id, starts_at, ends_at, note, created_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListFieldAvailability :many
+SELECT *
+FROM field_availability
+WHERE field_id = sqlc.arg(field_id)
+  AND (sqlc.narg(from_time)::timestamptz IS NULL OR ends_at > sqlc.narg(from_time)::timestamptz)
+ORDER BY starts_at;
+
+-- name: DeleteFieldAvailability :one
+DELETE
+FROM field_availability
+WHERE id = $1
+  AND field_id = $2
+RETURNING *;
+
+-- name: IsFieldAvailable :one
+SELECT (NOT EXISTS(SELECT 1 FROM field_availability fa WHERE fa.field_id = sqlc.arg(field_id)::uuid)
+    OR EXISTS(SELECT 1
+              FROM field_availability fa
+              WHERE fa.field_id = sqlc.arg(field_id)::uuid
+                AND fa.starts_at <= sqlc.arg(starts_at)::timestamptz
+                AND fa.ends_at >= sqlc.arg(ends_at)::timestamptz))::boolean AS available;
\ No newline at end of file
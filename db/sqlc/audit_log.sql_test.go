@@ -0,0 +1,51 @@
+package db
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func createRandomAuditLog(t *testing.T) AuditLog {
+	actor := createRandomUser(t)
+	subject := createRandomUser(t)
+
+	arg := CreateAuditLogParams{
+		ActorID:   actor.ID,
+		Action:    string(util.AuditPrivacyBypass),
+		SubjectID: uuid.NullUUID{UUID: subject.ID, Valid: true},
+		Details:   "GET /api/v1/players/" + subject.ID.String(),
+	}
+	log, err := testQueries.CreateAuditLog(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, log)
+
+	require.Equal(t, arg.ActorID, log.ActorID)
+	require.Equal(t, arg.Action, log.Action)
+	require.Equal(t, arg.SubjectID, log.SubjectID)
+	require.Equal(t, arg.Details, log.Details)
+	require.NotZero(t, log.CreatedAt)
+	return log
+}
+
+func TestQueries_CreateAuditLog(t *testing.T) {
+	createRandomAuditLog(t)
+}
+
+func TestQueries_ListAuditLogs(t *testing.T) {
+	for i := 0; i < 3; i++ {
+		createRandomAuditLog(t)
+	}
+
+	logs, err := testQueries.ListAuditLogs(context.Background(), ListAuditLogsParams{
+		Limit:  3,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, logs, 3)
+	for i := 1; i < len(logs); i++ {
+		require.False(t, logs[i].CreatedAt.After(logs[i-1].CreatedAt))
+	}
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestQueries_SetGameAvailability(t *testing.T) {
+	home := createRandomTeam(t)
+	game := createRandomGame(t, &home, nil)
+	member := addRandomTeamMember(t, home, 1, util.Pitcher)
+
+	teamID, err := testQueries.GetPlayerGameTeam(context.Background(), GetPlayerGameTeamParams{ID: game.ID, UserID: member.UserID})
+	require.NoError(t, err)
+	require.Equal(t, home.ID, teamID)
+
+	arg := SetGameAvailabilityParams{
+		GameID:      game.ID,
+		TeamID:      teamID,
+		UserID:      member.UserID,
+		Response:    string(util.AvailabilityMaybe),
+		RespondedBy: member.UserID,
+	}
+	first, err := testQueries.SetGameAvailability(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Response, first.Response)
+
+	arg.Response = string(util.AvailabilityNo)
+	arg.Note = "sick"
+	second, err := testQueries.SetGameAvailability(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, first.ID, second.ID)
+	require.Equal(t, arg.Response, second.Response)
+	require.Equal(t, "sick", second.Note)
+
+	_, err = testQueries.GetPlayerGameTeam(context.Background(), GetPlayerGameTeamParams{ID: game.ID, UserID: createRandomUser(t).ID})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestQueries_ListGameAvailability(t *testing.T) {
+	home := createRandomTeam(t)
+	away := createRandomTeam(t)
+	game := createRandomGame(t, &home, &away)
+	answered := addRandomTeamMember(t, home, 1, util.Pitcher)
+	addRandomTeamMember(t, away, 2, util.Catcher)
+
+	_, err := testQueries.SetGameAvailability(context.Background(), SetGameAvailabilityParams{
+		GameID:      game.ID,
+		TeamID:      home.ID,
+		UserID:      answered.UserID,
+		Response:    string(util.AvailabilityYes),
+		RespondedBy: answered.UserID,
+	})
+	require.NoError(t, err)
+
+	rows, err := testQueries.ListGameAvailability(context.Background(), game.ID)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	for _, row := range rows {
+		if row.UserID == answered.UserID {
+			require.Equal(t, string(util.AvailabilityYes), row.Response.String)
+		} else {
+			require.Equal(t, away.ID, row.TeamID)
+			require.False(t, row.Response.Valid)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package db
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func addRandomTeamMember(t *testing.T, team Team, number int64, position util.BaseballPosition) TeamMember {
+	user := createRandomUser(t)
+	member, err := testQueries.AddTeamMember(context.Background(), AddTeamMemberParams{
+		UserID:          user.ID,
+		TeamID:          team.ID,
+		Number:          number,
+		PrimaryPosition: string(position),
+	})
+	require.NoError(t, err)
+	return member
+}
+
+func TestQueries_SetPlayerPositionsTx(t *testing.T) {
+	team := createRandomTeam(t)
+	member := addRandomTeamMember(t, team, 1, util.Catcher)
+
+	arg := SetPlayerPositionsTxParams{
+		TeamID:    team.ID,
+		UserID:    member.UserID,
+		Positions: []string{string(util.FirstBase), string(util.ThirdBase)},
+	}
+	positions, err := testStore.SetPlayerPositionsTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, positions, 2)
+
+	arg.Positions = []string{string(util.ThirdBase)}
+	_, err = testStore.SetPlayerPositionsTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	positions, err = testQueries.ListPlayerPositions(context.Background(), ListPlayerPositionsParams{
+		TeamID: team.ID,
+		UserID: member.UserID,
+	})
+	require.NoError(t, err)
+	require.Len(t, positions, 1)
+	require.Equal(t, string(util.ThirdBase), positions[0].Position)
+	require.Equal(t, int64(1), positions[0].Rank)
+}
+
+func TestQueries_SetPlayerPositionsTxNotMember(t *testing.T) {
+	team := createRandomTeam(t)
+	user := createRandomUser(t)
+
+	_, err := testStore.SetPlayerPositionsTx(context.Background(), SetPlayerPositionsTxParams{
+		TeamID:    team.ID,
+		UserID:    user.ID,
+		Positions: []string{string(util.Pitcher)},
+	})
+	require.Error(t, err)
+}
+
+func TestQueries_IsEligibleForPosition(t *testing.T) {
+	team := createRandomTeam(t)
+	member := addRandomTeamMember(t, team, 1, util.Catcher)
+	_, err := testStore.SetPlayerPositionsTx(context.Background(), SetPlayerPositionsTxParams{
+		TeamID:    team.ID,
+		UserID:    member.UserID,
+		Positions: []string{string(util.FirstBase)},
+	})
+	require.NoError(t, err)
+
+	eligible := func(position util.BaseballPosition) bool {
+		ok, err := testQueries.IsEligibleForPosition(context.Background(), IsEligibleForPositionParams{
+			TeamID:   team.ID,
+			UserID:   member.UserID,
+			Position: string(position),
+		})
+		require.NoError(t, err)
+		return ok
+	}
+	require.True(t, eligible(util.Catcher))
+	require.True(t, eligible(util.FirstBase))
+	require.False(t, eligible(util.Pitcher))
+}
+
+func TestQueries_SetDepthChartTx(t *testing.T) {
+	team := createRandomTeam(t)
+	starter := addRandomTeamMember(t, team, 1, util.Pitcher)
+	backup := addRandomTeamMember(t, team, 2, util.Pitcher)
+
+	_, err := testStore.SetDepthChartTx(context.Background(), SetDepthChartTxParams{
+		TeamID:   team.ID,
+		Position: string(util.Pitcher),
+		UserIDs:  []uuid.UUID{backup.UserID, starter.UserID},
+	})
+	require.NoError(t, err)
+
+	entries, err := testStore.SetDepthChartTx(context.Background(), SetDepthChartTxParams{
+		TeamID:   team.ID,
+		Position: string(util.Pitcher),
+		UserIDs:  []uuid.UUID{starter.UserID, backup.UserID},
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	rows, err := testQueries.ListDepthChart(context.Background(), ListDepthChartParams{
+		TeamID:   team.ID,
+		ViewerID: starter.UserID,
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	require.Equal(t, starter.UserID, rows[0].UserID)
+	require.Equal(t, int64(1), rows[0].Rank)
+	require.Equal(t, backup.UserID, rows[1].UserID)
+
+	_, err = testQueries.RemoveTeamMember(context.Background(), RemoveTeamMemberParams{
+		TeamID: team.ID,
+		UserID: starter.UserID,
+	})
+	require.NoError(t, err)
+
+	rows, err = testQueries.ListDepthChart(context.Background(), ListDepthChartParams{
+		TeamID:   team.ID,
+		ViewerID: backup.UserID,
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, backup.UserID, rows[0].UserID)
+}
@@ -7,21 +7,31 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 const createGame = `-- name: CreateGame :one
-INSERT INTO game (home_team_id, away_team_id, home_score, away_score)
-VALUES ($1, $2, $3, $4)
-RETURNING id, home_team_id, away_team_id, home_score, away_score, created_at, updated_at
+INSERT INTO game (home_team_id, away_team_id, home_score, away_score, scheduled_at, time_zone, venue_id, field_id,
+                  reentry_rule, rules)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING id, home_team_id, away_team_id, home_score, away_score, created_at, updated_at, status, scheduled_at, time_zone, venue_id, field_id, reentry_rule, rules
 `
 
 type CreateGameParams struct {
-	HomeTeamID uuid.UUID `json:"home_team_id"`
-	AwayTeamID uuid.UUID `json:"away_team_id"`
-	HomeScore  int64     `json:"home_score"`
-	AwayScore  int64     `json:"away_score"`
+	HomeTeamID  uuid.UUID       `json:"home_team_id"`
+	AwayTeamID  uuid.UUID       `json:"away_team_id"`
+	HomeScore   int64           `json:"home_score"`
+	AwayScore   int64           `json:"away_score"`
+	ScheduledAt sql.NullTime    `json:"scheduled_at"`
+	TimeZone    string          `json:"time_zone"`
+	VenueID     uuid.NullUUID   `json:"venue_id"`
+	FieldID     uuid.NullUUID   `json:"field_id"`
+	ReentryRule string          `json:"reentry_rule"`
+	Rules       json.RawMessage `json:"rules"`
 }
 
 func (q *Queries) CreateGame(ctx context.Context, arg CreateGameParams) (Game, error) {
@@ -30,6 +40,12 @@ func (q *Queries) CreateGame(ctx context.Context, arg CreateGameParams) (Game, e
 		arg.AwayTeamID,
 		arg.HomeScore,
 		arg.AwayScore,
+		arg.ScheduledAt,
+		arg.TimeZone,
+		arg.VenueID,
+		arg.FieldID,
+		arg.ReentryRule,
+		arg.Rules,
 	)
 	var i Game
 	err := row.Scan(
@@ -40,12 +56,73 @@ func (q *Queries) CreateGame(ctx context.Context, arg CreateGameParams) (Game, e
 		&i.AwayScore,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
+		&i.ScheduledAt,
+		&i.TimeZone,
+		&i.VenueID,
+		&i.FieldID,
+		&i.ReentryRule,
+		&i.Rules,
+	)
+	return i, err
+}
+
+const createGameScheduleChange = `-- name: CreateGameScheduleChange :one
+INSERT INTO game_schedule_changes (game_id, old_scheduled_at, new_scheduled_at, old_time_zone, new_time_zone,
+                                   old_venue_id, new_venue_id, old_field_id, new_field_id, reason, changed_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING id, game_id, old_scheduled_at, new_scheduled_at, old_time_zone, new_time_zone, old_venue_id, new_venue_id, reason, changed_by, changed_at, old_field_id, new_field_id
+`
+
+type CreateGameScheduleChangeParams struct {
+	GameID         uuid.UUID     `json:"game_id"`
+	OldScheduledAt sql.NullTime  `json:"old_scheduled_at"`
+	NewScheduledAt time.Time     `json:"new_scheduled_at"`
+	OldTimeZone    string        `json:"old_time_zone"`
+	NewTimeZone    string        `json:"new_time_zone"`
+	OldVenueID     uuid.NullUUID `json:"old_venue_id"`
+	NewVenueID     uuid.NullUUID `json:"new_venue_id"`
+	OldFieldID     uuid.NullUUID `json:"old_field_id"`
+	NewFieldID     uuid.NullUUID `json:"new_field_id"`
+	Reason         string        `json:"reason"`
+	ChangedBy      uuid.UUID     `json:"changed_by"`
+}
+
+func (q *Queries) CreateGameScheduleChange(ctx context.Context, arg CreateGameScheduleChangeParams) (GameScheduleChange, error) {
+	row := q.db.QueryRowContext(ctx, createGameScheduleChange,
+		arg.GameID,
+		arg.OldScheduledAt,
+		arg.NewScheduledAt,
+		arg.OldTimeZone,
+		arg.NewTimeZone,
+		arg.OldVenueID,
+		arg.NewVenueID,
+		arg.OldFieldID,
+		arg.NewFieldID,
+		arg.Reason,
+		arg.ChangedBy,
+	)
+	var i GameScheduleChange
+	err := row.Scan(
+		&i.ID,
+		&i.GameID,
+		&i.OldScheduledAt,
+		&i.NewScheduledAt,
+		&i.OldTimeZone,
+		&i.NewTimeZone,
+		&i.OldVenueID,
+		&i.NewVenueID,
+		&i.Reason,
+		&i.ChangedBy,
+		&i.ChangedAt,
+		&i.OldFieldID,
+		&i.NewFieldID,
 	)
 	return i, err
 }
 
 const getGame = `-- name: GetGame :one
-SELECT id, home_team_id, away_team_id, home_score, away_score, created_at, updated_at
+SELECT id, home_team_id, away_team_id, home_score, away_score, created_at, updated_at, status, scheduled_at, time_zone, venue_id, field_id, reentry_rule, rules
 FROM game
 WHERE id = $1
 `
@@ -61,24 +138,90 @@ func (q *Queries) GetGame(ctx context.Context, id uuid.UUID) (Game, error) {
 		&i.AwayScore,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
+		&i.ScheduledAt,
+		&i.TimeZone,
+		&i.VenueID,
+		&i.FieldID,
+		&i.ReentryRule,
+		&i.Rules,
 	)
 	return i, err
 }
 
+const listGameScheduleChanges = `-- name: ListGameScheduleChanges :many
+SELECT id, game_id, old_scheduled_at, new_scheduled_at, old_time_zone, new_time_zone, old_venue_id, new_venue_id, reason, changed_by, changed_at, old_field_id, new_field_id
+FROM game_schedule_changes
+WHERE game_id = $1
+ORDER BY changed_at
+`
+
+func (q *Queries) ListGameScheduleChanges(ctx context.Context, gameID uuid.UUID) ([]GameScheduleChange, error) {
+	rows, err := q.db.QueryContext(ctx, listGameScheduleChanges, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GameScheduleChange{}
+	for rows.Next() {
+		var i GameScheduleChange
+		if err := rows.Scan(
+			&i.ID,
+			&i.GameID,
+			&i.OldScheduledAt,
+			&i.NewScheduledAt,
+			&i.OldTimeZone,
+			&i.NewTimeZone,
+			&i.OldVenueID,
+			&i.NewVenueID,
+			&i.Reason,
+			&i.ChangedBy,
+			&i.ChangedAt,
+			&i.OldFieldID,
+			&i.NewFieldID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listGames = `-- name: ListGames :many
-SELECT id, home_team_id, away_team_id, home_score, away_score, created_at, updated_at
+SELECT id, home_team_id, away_team_id, home_score, away_score, created_at, updated_at, status, scheduled_at, time_zone, venue_id, field_id, reentry_rule, rules
 FROM game g
 WHERE ($3::UUID IS NULL OR g.home_team_id = $3::UUID)
   AND ($4::UUID IS NULL OR g.away_team_id = $4::UUID)
-ORDER BY g.created_at DESC
+  AND ($5::varchar IS NULL OR g.status = $5::varchar)
+  AND ($6::UUID IS NULL OR $6::UUID IN (g.home_team_id, g.away_team_id))
+  AND ($7::UUID IS NULL OR g.venue_id = $7::UUID)
+  AND ($8::UUID IS NULL OR g.field_id = $8::UUID)
+  AND ($9::timestamptz IS NULL OR g.scheduled_at >= $9::timestamptz)
+  AND ($10::timestamptz IS NULL OR g.scheduled_at < $10::timestamptz)
+ORDER BY CASE WHEN $11::varchar = 'scheduled_at' THEN g.scheduled_at END NULLS LAST,
+         CASE WHEN $11::varchar = '-scheduled_at' THEN g.scheduled_at END DESC NULLS LAST,
+         g.created_at DESC
 LIMIT $1 OFFSET $2
 `
 
 type ListGamesParams struct {
-	Limit      int32         `json:"limit"`
-	Offset     int32         `json:"offset"`
-	HomeTeamID uuid.NullUUID `json:"home_team_id"`
-	AwayTeamID uuid.NullUUID `json:"away_team_id"`
+	Limit      int32          `json:"limit"`
+	Offset     int32          `json:"offset"`
+	HomeTeamID uuid.NullUUID  `json:"home_team_id"`
+	AwayTeamID uuid.NullUUID  `json:"away_team_id"`
+	Status     sql.NullString `json:"status"`
+	TeamID     uuid.NullUUID  `json:"team_id"`
+	VenueID    uuid.NullUUID  `json:"venue_id"`
+	FieldID    uuid.NullUUID  `json:"field_id"`
+	FromTime   sql.NullTime   `json:"from_time"`
+	ToTime     sql.NullTime   `json:"to_time"`
+	Sort       sql.NullString `json:"sort"`
 }
 
 func (q *Queries) ListGames(ctx context.Context, arg ListGamesParams) ([]Game, error) {
@@ -87,6 +230,13 @@ func (q *Queries) ListGames(ctx context.Context, arg ListGamesParams) ([]Game, e
 		arg.Offset,
 		arg.HomeTeamID,
 		arg.AwayTeamID,
+		arg.Status,
+		arg.TeamID,
+		arg.VenueID,
+		arg.FieldID,
+		arg.FromTime,
+		arg.ToTime,
+		arg.Sort,
 	)
 	if err != nil {
 		return nil, err
@@ -103,6 +253,13 @@ func (q *Queries) ListGames(ctx context.Context, arg ListGamesParams) ([]Game, e
 			&i.AwayScore,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Status,
+			&i.ScheduledAt,
+			&i.TimeZone,
+			&i.VenueID,
+			&i.FieldID,
+			&i.ReentryRule,
+			&i.Rules,
 		); err != nil {
 			return nil, err
 		}
@@ -117,23 +274,244 @@ func (q *Queries) ListGames(ctx context.Context, arg ListGamesParams) ([]Game, e
 	return items, nil
 }
 
-const updateGame = `-- name: UpdateGame :one
+const listGamesOfUser = `-- name: ListGamesOfUser :many
+SELECT id, home_team_id, away_team_id, home_score, away_score, created_at, updated_at, status, scheduled_at, time_zone, venue_id, field_id, reentry_rule, rules
+FROM game g
+WHERE (EXISTS(SELECT 1
+              FROM team_members tm
+              WHERE tm.user_id = $3::uuid
+                AND tm.team_id IN (g.home_team_id, g.away_team_id))
+    OR EXISTS(SELECT 1
+              FROM game_participant gp
+              WHERE gp.game_id = g.id
+                AND gp.player_id = $3::uuid))
+  AND ($4::varchar IS NULL OR g.status = $4::varchar)
+  AND ($5::timestamptz IS NULL OR COALESCE(g.scheduled_at, g.created_at) >= $5::timestamptz)
+  AND ($6::timestamptz IS NULL OR COALESCE(g.scheduled_at, g.created_at) < $6::timestamptz)
+ORDER BY COALESCE(g.scheduled_at, g.created_at) DESC
+LIMIT $1 OFFSET $2
+`
+
+type ListGamesOfUserParams struct {
+	Limit    int32          `json:"limit"`
+	Offset   int32          `json:"offset"`
+	UserID   uuid.UUID      `json:"user_id"`
+	Status   sql.NullString `json:"status"`
+	FromTime sql.NullTime   `json:"from_time"`
+	ToTime   sql.NullTime   `json:"to_time"`
+}
+
+func (q *Queries) ListGamesOfUser(ctx context.Context, arg ListGamesOfUserParams) ([]Game, error) {
+	rows, err := q.db.QueryContext(ctx, listGamesOfUser,
+		arg.Limit,
+		arg.Offset,
+		arg.UserID,
+		arg.Status,
+		arg.FromTime,
+		arg.ToTime,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Game{}
+	for rows.Next() {
+		var i Game
+		if err := rows.Scan(
+			&i.ID,
+			&i.HomeTeamID,
+			&i.AwayTeamID,
+			&i.HomeScore,
+			&i.AwayScore,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Status,
+			&i.ScheduledAt,
+			&i.TimeZone,
+			&i.VenueID,
+			&i.FieldID,
+			&i.ReentryRule,
+			&i.Rules,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listScheduleConflicts = `-- name: ListScheduleConflicts :many
+SELECT id, home_team_id, away_team_id, home_score, away_score, created_at, updated_at, status, scheduled_at, time_zone, venue_id, field_id, reentry_rule, rules
+FROM game g
+WHERE g.id <> $1::uuid
+  AND g.status NOT IN ('postponed', 'cancelled')
+  AND g.scheduled_at > $2::timestamptz
+  AND g.scheduled_at < $3::timestamptz
+  AND (g.home_team_id IN ($4::uuid, $5::uuid)
+    OR g.away_team_id IN ($4::uuid, $5::uuid)
+    OR (g.venue_id = $6::uuid
+        AND ($7::uuid IS NULL OR g.field_id IS NULL OR g.field_id = $7::uuid)))
+ORDER BY g.scheduled_at
+`
+
+type ListScheduleConflictsParams struct {
+	GameID      uuid.UUID     `json:"game_id"`
+	WindowStart time.Time     `json:"window_start"`
+	WindowEnd   time.Time     `json:"window_end"`
+	HomeTeamID  uuid.UUID     `json:"home_team_id"`
+	AwayTeamID  uuid.UUID     `json:"away_team_id"`
+	VenueID     uuid.NullUUID `json:"venue_id"`
+	FieldID     uuid.NullUUID `json:"field_id"`
+}
+
+func (q *Queries) ListScheduleConflicts(ctx context.Context, arg ListScheduleConflictsParams) ([]Game, error) {
+	rows, err := q.db.QueryContext(ctx, listScheduleConflicts,
+		arg.GameID,
+		arg.WindowStart,
+		arg.WindowEnd,
+		arg.HomeTeamID,
+		arg.AwayTeamID,
+		arg.VenueID,
+		arg.FieldID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Game{}
+	for rows.Next() {
+		var i Game
+		if err := rows.Scan(
+			&i.ID,
+			&i.HomeTeamID,
+			&i.AwayTeamID,
+			&i.HomeScore,
+			&i.AwayScore,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Status,
+			&i.ScheduledAt,
+			&i.TimeZone,
+			&i.VenueID,
+			&i.FieldID,
+			&i.ReentryRule,
+			&i.Rules,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockScheduleTeams = `-- name: LockScheduleTeams :exec
+SELECT id
+FROM teams
+WHERE id IN ($1::uuid, $2::uuid)
+ORDER BY id
+    FOR UPDATE
+`
+
+type LockScheduleTeamsParams struct {
+	HomeTeamID uuid.UUID `json:"home_team_id"`
+	AwayTeamID uuid.UUID `json:"away_team_id"`
+}
+
+func (q *Queries) LockScheduleTeams(ctx context.Context, arg LockScheduleTeamsParams) error {
+	_, err := q.db.ExecContext(ctx, lockScheduleTeams, arg.HomeTeamID, arg.AwayTeamID)
+	return err
+}
+
+const lockVenue = `-- name: LockVenue :exec
+SELECT id
+FROM venues
+WHERE id = $1
+    FOR UPDATE
+`
+
+func (q *Queries) LockVenue(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, lockVenue, id)
+	return err
+}
+
+const setGameScore = `-- name: SetGameScore :one
 UPDATE game
 SET home_score = $1,
     away_score = $2,
     updated_at = NOW()
 WHERE id = $3
-RETURNING id, home_team_id, away_team_id, home_score, away_score, created_at, updated_at
+  AND status <> 'final'
+RETURNING id, home_team_id, away_team_id, home_score, away_score, created_at, updated_at, status, scheduled_at, time_zone, venue_id, field_id, reentry_rule, rules
 `
 
-type UpdateGameParams struct {
+type SetGameScoreParams struct {
 	HomeScore int64     `json:"home_score"`
 	AwayScore int64     `json:"away_score"`
 	ID        uuid.UUID `json:"id"`
 }
 
-func (q *Queries) UpdateGame(ctx context.Context, arg UpdateGameParams) (Game, error) {
-	row := q.db.QueryRowContext(ctx, updateGame, arg.HomeScore, arg.AwayScore, arg.ID)
+func (q *Queries) SetGameScore(ctx context.Context, arg SetGameScoreParams) (Game, error) {
+	row := q.db.QueryRowContext(ctx, setGameScore, arg.HomeScore, arg.AwayScore, arg.ID)
+	var i Game
+	err := row.Scan(
+		&i.ID,
+		&i.HomeTeamID,
+		&i.AwayTeamID,
+		&i.HomeScore,
+		&i.AwayScore,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.ScheduledAt,
+		&i.TimeZone,
+		&i.VenueID,
+		&i.FieldID,
+		&i.ReentryRule,
+		&i.Rules,
+	)
+	return i, err
+}
+
+const updateGameSchedule = `-- name: UpdateGameSchedule :one
+UPDATE game
+SET scheduled_at = $1::timestamptz,
+    time_zone    = COALESCE($2, time_zone),
+    venue_id     = $3,
+    field_id     = $4,
+    updated_at   = now()
+WHERE id = $5
+  AND status IN ('scheduled', 'postponed')
+RETURNING id, home_team_id, away_team_id, home_score, away_score, created_at, updated_at, status, scheduled_at, time_zone, venue_id, field_id, reentry_rule, rules
+`
+
+type UpdateGameScheduleParams struct {
+	ScheduledAt time.Time      `json:"scheduled_at"`
+	TimeZone    sql.NullString `json:"time_zone"`
+	VenueID     uuid.NullUUID  `json:"venue_id"`
+	FieldID     uuid.NullUUID  `json:"field_id"`
+	ID          uuid.UUID      `json:"id"`
+}
+
+func (q *Queries) UpdateGameSchedule(ctx context.Context, arg UpdateGameScheduleParams) (Game, error) {
+	row := q.db.QueryRowContext(ctx, updateGameSchedule,
+		arg.ScheduledAt,
+		arg.TimeZone,
+		arg.VenueID,
+		arg.FieldID,
+		arg.ID,
+	)
 	var i Game
 	err := row.Scan(
 		&i.ID,
@@ -143,6 +521,13 @@ func (q *Queries) UpdateGame(ctx context.Context, arg UpdateGameParams) (Game, e
 		&i.AwayScore,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
+		&i.ScheduledAt,
+		&i.TimeZone,
+		&i.VenueID,
+		&i.FieldID,
+		&i.ReentryRule,
+		&i.Rules,
 	)
 	return i, err
 }
@@ -2,8 +2,11 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/util"
 	"github.com/stretchr/testify/require"
 	"testing"
 	"time"
@@ -23,10 +26,13 @@ func createRandomGame(t *testing.T, homeTeam *Team, awayTeam *Team) Game {
 		team2 = *awayTeam
 	}
 	arg := CreateGameParams{
-		HomeTeamID: team1.ID,
-		AwayTeamID: team2.ID,
-		HomeScore:  0,
-		AwayScore:  0,
+		HomeTeamID:  team1.ID,
+		AwayTeamID:  team2.ID,
+		HomeScore:   0,
+		AwayScore:   0,
+		TimeZone:    "UTC",
+		ReentryRule: "none",
+		Rules:       json.RawMessage(`{}`),
 	}
 	game, err := testQueries.CreateGame(context.Background(), arg)
 	require.NoError(t, err)
@@ -36,6 +42,7 @@ func createRandomGame(t *testing.T, homeTeam *Team, awayTeam *Team) Game {
 	require.Equal(t, team2.ID, game.AwayTeamID)
 	require.Equal(t, int64(0), game.HomeScore)
 	require.Equal(t, int64(0), game.AwayScore)
+	require.JSONEq(t, `{}`, string(game.Rules))
 
 	return game
 }
@@ -141,3 +148,37 @@ func TestQueriesGetGamesWithoutTeamId(t *testing.T) {
 		require.NotEmpty(t, game)
 	}
 }
+
+func TestQueries_ListGamesOfUser(t *testing.T) {
+	user := createRandomUser(t)
+	team := createRandomTeam(t)
+	_, err := testQueries.AddTeamMember(context.Background(), AddTeamMemberParams{
+		UserID:          user.ID,
+		TeamID:          team.ID,
+		Number:          7,
+		PrimaryPosition: string(util.Pitcher),
+	})
+	require.NoError(t, err)
+
+	home := createRandomGame(t, &team, nil)
+	away := createRandomGame(t, nil, &team)
+	createRandomGame(t, nil, nil)
+
+	games, err := testQueries.ListGamesOfUser(context.Background(), ListGamesOfUserParams{
+		UserID: user.ID,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, games, 2)
+	require.Equal(t, away.ID, games[0].ID)
+	require.Equal(t, home.ID, games[1].ID)
+	require.Equal(t, string(util.GameScheduled), games[0].Status)
+
+	games, err = testQueries.ListGamesOfUser(context.Background(), ListGamesOfUserParams{
+		UserID: user.ID,
+		Status: sql.NullString{String: string(util.GameFinal), Valid: true},
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, games)
+}
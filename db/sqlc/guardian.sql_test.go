@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func createRandomGuardian(t *testing.T) (Guardian, User, User) {
+	guardian := createRandomUser(t)
+	player := createRandomUser(t)
+
+	arg := CreateGuardianParams{
+		GuardianID: guardian.ID,
+		PlayerID:   player.ID,
+	}
+	link, err := testQueries.CreateGuardian(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, link)
+
+	require.Equal(t, guardian.ID, link.GuardianID)
+	require.Equal(t, player.ID, link.PlayerID)
+	require.Equal(t, string(util.GuardianPending), link.Status)
+	require.False(t, link.ApprovedBy.Valid)
+	require.False(t, link.ApprovedAt.Valid)
+	return link, guardian, player
+}
+
+func TestQueries_CreateGuardian(t *testing.T) {
+	createRandomGuardian(t)
+}
+
+func TestQueries_GetGuardian(t *testing.T) {
+	link, guardian, player := createRandomGuardian(t)
+
+	link2, err := testQueries.GetGuardian(context.Background(), GetGuardianParams{
+		GuardianID: guardian.ID,
+		PlayerID:   player.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, link.ID, link2.ID)
+	require.Equal(t, link.Status, link2.Status)
+}
+
+func TestQueries_ListGuardiansOfPlayer(t *testing.T) {
+	_, guardian, player := createRandomGuardian(t)
+
+	guardians, err := testQueries.ListGuardiansOfPlayer(context.Background(), player.ID)
+	require.NoError(t, err)
+	require.Len(t, guardians, 1)
+	require.Equal(t, guardian.ID, guardians[0].GuardianID)
+	require.Equal(t, guardian.Email, guardians[0].Email)
+}
+
+func TestQueries_ApproveGuardianTx(t *testing.T) {
+	_, guardian, player := createRandomGuardian(t)
+	approver := createRandomUser(t)
+
+	result, err := testStore.ApproveGuardianTx(context.Background(), ApproveGuardianTxParams{
+		UpdateGuardianStatusParams: UpdateGuardianStatusParams{
+			Status:     string(util.GuardianApproved),
+			ApprovedBy: uuid.NullUUID{UUID: approver.ID, Valid: true},
+			ApprovedAt: sql.NullTime{Time: time.Now(), Valid: true},
+			GuardianID: guardian.ID,
+			PlayerID:   player.ID,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, string(util.GuardianApproved), result.Guardian.Status)
+	require.Equal(t, approver.ID, result.Guardian.ApprovedBy.UUID)
+	require.True(t, result.Player.IsMinor)
+
+	links, err := testQueries.ListApprovedGuardians(context.Background())
+	require.NoError(t, err)
+	require.NotEmpty(t, links)
+}
+
+func TestQueries_DeleteGuardian(t *testing.T) {
+	_, guardian, player := createRandomGuardian(t)
+
+	arg := DeleteGuardianParams{GuardianID: guardian.ID, PlayerID: player.ID}
+	err := testQueries.DeleteGuardian(context.Background(), arg)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetGuardian(context.Background(), GetGuardianParams{
+		GuardianID: guardian.ID,
+		PlayerID:   player.ID,
+	})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
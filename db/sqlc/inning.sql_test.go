@@ -0,0 +1,49 @@
+package db
+
+import (
+	"context"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestQueries_ListGameInnings(t *testing.T) {
+	game := createRandomGame(t, nil, nil)
+
+	away, err := testStore.SetLineupTx(context.Background(), SetLineupTxParams{
+		GameID: game.ID,
+		Spots:  []LineupSpotParams{{PlayerID: createRandomUser(t).ID, BatPosition: 1, Position: string(util.Pitcher)}},
+	})
+	require.NoError(t, err)
+	home, err := testStore.SetLineupTx(context.Background(), SetLineupTxParams{
+		GameID:   game.ID,
+		HomeTeam: true,
+		Spots:    []LineupSpotParams{{PlayerID: createRandomUser(t).ID, BatPosition: 1, Position: string(util.Pitcher)}},
+	})
+	require.NoError(t, err)
+
+	// the second inning is projected first to check the order
+	_, err = testQueries.ProjectInning(context.Background(), ProjectInningParams{GameID: game.ID, Number: 2})
+	require.NoError(t, err)
+	first, err := testQueries.ProjectInning(context.Background(), ProjectInningParams{GameID: game.ID, Number: 1, AwayRuns: 1, AwayHits: 1})
+	require.NoError(t, err)
+
+	_, err = testQueries.ProjectAtbat(context.Background(), ProjectAtbatParams{
+		GameID:    game.ID,
+		Number:    1,
+		InningID:  first.ID,
+		Half:      string(util.HalfTop),
+		BatterID:  away.Participants[0].ID,
+		PitcherID: home.Participants[0].ID,
+	})
+	require.NoError(t, err)
+
+	innings, err := testQueries.ListGameInnings(context.Background(), game.ID)
+	require.NoError(t, err)
+	require.Len(t, innings, 2)
+	require.Equal(t, int64(1), innings[0].Number)
+	require.Equal(t, int64(1), innings[0].AwayRuns)
+	require.True(t, innings[0].AwayBatted)
+	require.False(t, innings[0].HomeBatted)
+	require.False(t, innings[1].AwayBatted)
+}
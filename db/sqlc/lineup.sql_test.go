@@ -0,0 +1,49 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestStore_SetLineupTx(t *testing.T) {
+	home := createRandomTeam(t)
+	game := createRandomGame(t, &home, nil)
+
+	pitcher := createRandomUser(t)
+	designated := createRandomUser(t)
+	spots := []LineupSpotParams{
+		{PlayerID: pitcher.ID, BatPosition: 0, Position: string(util.Pitcher)},
+		{PlayerID: designated.ID, BatPosition: 1, Position: string(util.DesignatedHitter)},
+	}
+
+	result, err := testStore.SetLineupTx(context.Background(), SetLineupTxParams{GameID: game.ID, HomeTeam: true, Spots: spots})
+	require.NoError(t, err)
+	require.Len(t, result.Participants, 2)
+
+	// the pitcher who does not bat is listed after the batting order
+	lineup, err := testQueries.ListLineup(context.Background(), ListLineupParams{GameID: game.ID, HomeTeam: true})
+	require.NoError(t, err)
+	require.Len(t, lineup, 2)
+	require.Equal(t, designated.ID, lineup[0].PlayerID)
+	require.Equal(t, designated.FirstName, lineup[0].FirstName)
+	require.Equal(t, pitcher.ID, lineup[1].PlayerID)
+
+	// submitting again replaces the lineup
+	result, err = testStore.SetLineupTx(context.Background(), SetLineupTxParams{GameID: game.ID, HomeTeam: true, Spots: spots[:1]})
+	require.NoError(t, err)
+	lineup, err = testQueries.ListLineup(context.Background(), ListLineupParams{GameID: game.ID, HomeTeam: true})
+	require.NoError(t, err)
+	require.Len(t, lineup, 1)
+
+	away, err := testQueries.ListLineup(context.Background(), ListLineupParams{GameID: game.ID, HomeTeam: false})
+	require.NoError(t, err)
+	require.Empty(t, away)
+
+	// lineups lock once the game starts
+	moveGame(t, game, util.GameScheduled, util.GameInProgress)
+	_, err = testStore.SetLineupTx(context.Background(), SetLineupTxParams{GameID: game.ID, HomeTeam: true, Spots: spots})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -6,59 +6,284 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type Atbat struct {
-	ID         uuid.UUID     `json:"id"`
-	InningID   uuid.NullUUID `json:"inning_id"`
-	BatterID   uuid.NullUUID `json:"batter_id"`
-	PitcherID  uuid.NullUUID `json:"pitcher_id"`
-	Balls      int64         `json:"balls"`
-	Strikes    int64         `json:"strikes"`
-	InitBases  int64         `json:"init_bases"`
-	TotalBases int64         `json:"total_bases"`
-	Out        bool          `json:"out"`
+	ID             uuid.UUID      `json:"id"`
+	InningID       uuid.UUID      `json:"inning_id"`
+	BatterID       uuid.UUID      `json:"batter_id"`
+	PitcherID      uuid.UUID      `json:"pitcher_id"`
+	Balls          int64          `json:"balls"`
+	Strikes        int64          `json:"strikes"`
+	InitBases      int64          `json:"init_bases"`
+	TotalBases     int64          `json:"total_bases"`
+	Out            bool           `json:"out"`
+	GameID         uuid.UUID      `json:"game_id"`
+	Half           string         `json:"half"`
+	Pitches        int64          `json:"pitches"`
+	Result         sql.NullString `json:"result"`
+	CreatedAt      time.Time      `json:"created_at"`
+	EndedAt        sql.NullTime   `json:"ended_at"`
+	Number         int64          `json:"number"`
+	RunnerOnFirst  uuid.NullUUID  `json:"runner_on_first"`
+	RunnerOnSecond uuid.NullUUID  `json:"runner_on_second"`
+	RunnerOnThird  uuid.NullUUID  `json:"runner_on_third"`
+	Rbi            int64          `json:"rbi"`
+}
+
+type AtbatRunner struct {
+	ID        uuid.UUID     `json:"id"`
+	AtbatID   uuid.UUID     `json:"atbat_id"`
+	Number    int64         `json:"number"`
+	RunnerID  uuid.UUID     `json:"runner_id"`
+	FromBase  int64         `json:"from_base"`
+	ToBase    int64         `json:"to_base"`
+	Out       bool          `json:"out"`
+	Reason    string        `json:"reason"`
+	Scored    bool          `json:"scored"`
+	Rbi       bool          `json:"rbi"`
+	Earned    bool          `json:"earned"`
+	PitcherID uuid.NullUUID `json:"pitcher_id"`
+}
+
+type AuditLog struct {
+	ID        uuid.UUID     `json:"id"`
+	ActorID   uuid.UUID     `json:"actor_id"`
+	Action    string        `json:"action"`
+	SubjectID uuid.NullUUID `json:"subject_id"`
+	Details   string        `json:"details"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type DepthChartEntry struct {
+	ID        uuid.UUID `json:"id"`
+	TeamID    uuid.UUID `json:"team_id"`
+	Position  string    `json:"position"`
+	UserID    uuid.UUID `json:"user_id"`
+	Rank      int64     `json:"rank"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Field struct {
+	ID            uuid.UUID     `json:"id"`
+	VenueID       uuid.UUID     `json:"venue_id"`
+	Name          string        `json:"name"`
+	Surface       string        `json:"surface"`
+	LeftFieldFt   sql.NullInt64 `json:"left_field_ft"`
+	CenterFieldFt sql.NullInt64 `json:"center_field_ft"`
+	RightFieldFt  sql.NullInt64 `json:"right_field_ft"`
+	HasLights     bool          `json:"has_lights"`
+	CreatedAt     time.Time     `json:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+}
+
+type FieldAvailability struct {
+	ID        uuid.UUID `json:"id"`
+	FieldID   uuid.UUID `json:"field_id"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Note      string    `json:"note"`
+	CreatedBy uuid.UUID `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type Game struct {
-	ID         uuid.UUID `json:"id"`
-	HomeTeamID uuid.UUID `json:"home_team_id"`
-	AwayTeamID uuid.UUID `json:"away_team_id"`
-	HomeScore  int64     `json:"home_score"`
-	AwayScore  int64     `json:"away_score"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID          uuid.UUID       `json:"id"`
+	HomeTeamID  uuid.UUID       `json:"home_team_id"`
+	AwayTeamID  uuid.UUID       `json:"away_team_id"`
+	HomeScore   int64           `json:"home_score"`
+	AwayScore   int64           `json:"away_score"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	Status      string          `json:"status"`
+	ScheduledAt sql.NullTime    `json:"scheduled_at"`
+	TimeZone    string          `json:"time_zone"`
+	VenueID     uuid.NullUUID   `json:"venue_id"`
+	FieldID     uuid.NullUUID   `json:"field_id"`
+	ReentryRule string          `json:"reentry_rule"`
+	Rules       json.RawMessage `json:"rules"`
+}
+
+type GameAvailability struct {
+	ID          uuid.UUID `json:"id"`
+	GameID      uuid.UUID `json:"game_id"`
+	TeamID      uuid.UUID `json:"team_id"`
+	UserID      uuid.UUID `json:"user_id"`
+	Response    string    `json:"response"`
+	Note        string    `json:"note"`
+	RespondedBy uuid.UUID `json:"responded_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type GameEvent struct {
+	ID         uuid.UUID       `json:"id"`
+	GameID     uuid.UUID       `json:"game_id"`
+	Sequence   int64           `json:"sequence"`
+	Type       string          `json:"type"`
+	Payload    json.RawMessage `json:"payload"`
+	CreatedBy  uuid.UUID       `json:"created_by"`
+	CreatedAt  time.Time       `json:"created_at"`
+	CorrectsID uuid.NullUUID   `json:"corrects_id"`
+	VoidedBy   uuid.NullUUID   `json:"voided_by"`
+	VoidedAt   sql.NullTime    `json:"voided_at"`
 }
 
 type GameParticipant struct {
-	ID          uuid.UUID     `json:"id"`
-	GameID      uuid.NullUUID `json:"game_id"`
-	PlayerID    uuid.NullUUID `json:"player_id"`
-	HomeTeam    bool          `json:"home_team"`
-	BatPosition int64         `json:"bat_position"`
+	ID            uuid.UUID     `json:"id"`
+	GameID        uuid.UUID     `json:"game_id"`
+	PlayerID      uuid.UUID     `json:"player_id"`
+	HomeTeam      bool          `json:"home_team"`
+	BatPosition   int64         `json:"bat_position"`
+	Position      string        `json:"position"`
+	CreatedAt     time.Time     `json:"created_at"`
+	Entry         string        `json:"entry"`
+	EnteredInning int64         `json:"entered_inning"`
+	ExitedInning  sql.NullInt64 `json:"exited_inning"`
+	ReplacedID    uuid.NullUUID `json:"replaced_id"`
+}
+
+type GameScheduleChange struct {
+	ID             uuid.UUID     `json:"id"`
+	GameID         uuid.UUID     `json:"game_id"`
+	OldScheduledAt sql.NullTime  `json:"old_scheduled_at"`
+	NewScheduledAt time.Time     `json:"new_scheduled_at"`
+	OldTimeZone    string        `json:"old_time_zone"`
+	NewTimeZone    string        `json:"new_time_zone"`
+	OldVenueID     uuid.NullUUID `json:"old_venue_id"`
+	NewVenueID     uuid.NullUUID `json:"new_venue_id"`
+	Reason         string        `json:"reason"`
+	ChangedBy      uuid.UUID     `json:"changed_by"`
+	ChangedAt      time.Time     `json:"changed_at"`
+	OldFieldID     uuid.NullUUID `json:"old_field_id"`
+	NewFieldID     uuid.NullUUID `json:"new_field_id"`
 }
 
 type GameStat struct {
-	ID      uuid.UUID      `json:"id"`
-	AtbatID uuid.NullUUID  `json:"atbat_id"`
-	Type    sql.NullString `json:"type"`
+	ID            uuid.UUID      `json:"id"`
+	AtbatID       uuid.NullUUID  `json:"atbat_id"`
+	Type          sql.NullString `json:"type"`
+	ParticipantID uuid.NullUUID  `json:"participant_id"`
+}
+
+type GameStatusChange struct {
+	ID         uuid.UUID `json:"id"`
+	GameID     uuid.UUID `json:"game_id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Reason     string    `json:"reason"`
+	ChangedBy  uuid.UUID `json:"changed_by"`
+	ChangedAt  time.Time `json:"changed_at"`
+}
+
+type Guardian struct {
+	ID         uuid.UUID     `json:"id"`
+	GuardianID uuid.UUID     `json:"guardian_id"`
+	PlayerID   uuid.UUID     `json:"player_id"`
+	Status     string        `json:"status"`
+	ApprovedBy uuid.NullUUID `json:"approved_by"`
+	ApprovedAt sql.NullTime  `json:"approved_at"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
 }
 
 type Inning struct {
 	ID          uuid.UUID     `json:"id"`
-	GameID      uuid.NullUUID `json:"game_id"`
+	GameID      uuid.UUID     `json:"game_id"`
 	Number      int64         `json:"number"`
 	HomeRuns    int64         `json:"home_runs"`
 	HomeHits    int64         `json:"home_hits"`
 	HomeErrors  int64         `json:"home_errors"`
-	HomeLastBat uuid.UUID     `json:"home_last_bat"`
+	HomeLastBat uuid.NullUUID `json:"home_last_bat"`
 	AwayRuns    int64         `json:"away_runs"`
 	AwayHits    int64         `json:"away_hits"`
 	AwayErrors  int64         `json:"away_errors"`
-	AwayLastBat uuid.UUID     `json:"away_last_bat"`
+	AwayLastBat uuid.NullUUID `json:"away_last_bat"`
+}
+
+type JoinRequest struct {
+	ID              uuid.UUID     `json:"id"`
+	TeamID          uuid.UUID     `json:"team_id"`
+	UserID          uuid.UUID     `json:"user_id"`
+	Number          int64         `json:"number"`
+	PrimaryPosition string        `json:"primary_position"`
+	Message         string        `json:"message"`
+	Status          string        `json:"status"`
+	DecidedBy       uuid.NullUUID `json:"decided_by"`
+	DecidedAt       sql.NullTime  `json:"decided_at"`
+	CreatedAt       time.Time     `json:"created_at"`
+}
+
+type Notification struct {
+	ID        uuid.UUID     `json:"id"`
+	UserID    uuid.UUID     `json:"user_id"`
+	PlayerID  uuid.UUID     `json:"player_id"`
+	GameID    uuid.NullUUID `json:"game_id"`
+	Kind      string        `json:"kind"`
+	Message   string        `json:"message"`
+	ReadAt    sql.NullTime  `json:"read_at"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type Pitch struct {
+	ID        uuid.UUID `json:"id"`
+	AtbatID   uuid.UUID `json:"atbat_id"`
+	Number    int64     `json:"number"`
+	Type      string    `json:"type"`
+	Balls     int64     `json:"balls"`
+	Strikes   int64     `json:"strikes"`
+	CreatedBy uuid.UUID `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	PitcherID uuid.UUID `json:"pitcher_id"`
+}
+
+type PitchCount struct {
+	ID        uuid.UUID `json:"id"`
+	GameID    uuid.UUID `json:"game_id"`
+	PlayerID  uuid.UUID `json:"player_id"`
+	Pitches   int64     `json:"pitches"`
+	EnteredBy uuid.UUID `json:"entered_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type PlayerPosition struct {
+	ID        uuid.UUID `json:"id"`
+	TeamID    uuid.UUID `json:"team_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Position  string    `json:"position"`
+	Rank      int64     `json:"rank"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type PlayerStatus struct {
+	ID             uuid.UUID     `json:"id"`
+	TeamID         uuid.UUID     `json:"team_id"`
+	UserID         uuid.UUID     `json:"user_id"`
+	Status         string        `json:"status"`
+	Reason         string        `json:"reason"`
+	StartsAt       time.Time     `json:"starts_at"`
+	EndsAt         sql.NullTime  `json:"ends_at"`
+	GamesRemaining sql.NullInt64 `json:"games_remaining"`
+	CreatedBy      uuid.UUID     `json:"created_by"`
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
+
+type RosterTransaction struct {
+	ID          uuid.UUID     `json:"id"`
+	Type        string        `json:"type"`
+	UserID      uuid.UUID     `json:"user_id"`
+	FromTeamID  uuid.NullUUID `json:"from_team_id"`
+	ToTeamID    uuid.NullUUID `json:"to_team_id"`
+	EffectiveAt time.Time     `json:"effective_at"`
+	Note        string        `json:"note"`
+	CreatedBy   uuid.UUID     `json:"created_by"`
+	CreatedAt   time.Time     `json:"created_at"`
 }
 
 type Session struct {
@@ -73,10 +298,37 @@ type Session struct {
 }
 
 type Team struct {
-	ID        uuid.UUID `json:"id"`
-	Name      string    `json:"name"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID             uuid.UUID    `json:"id"`
+	Name           string       `json:"name"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+	ArchivedAt     sql.NullTime `json:"archived_at"`
+	Abbreviation   string       `json:"abbreviation"`
+	PrimaryColor   string       `json:"primary_color"`
+	SecondaryColor string       `json:"secondary_color"`
+	HomeVenue      string       `json:"home_venue"`
+	City           string       `json:"city"`
+	Division       string       `json:"division"`
+	LogoUrl        string       `json:"logo_url"`
+	WebsiteUrl     string       `json:"website_url"`
+	FacebookUrl    string       `json:"facebook_url"`
+	InstagramUrl   string       `json:"instagram_url"`
+	XUrl           string       `json:"x_url"`
+}
+
+type TeamInvitation struct {
+	ID              uuid.UUID      `json:"id"`
+	TeamID          uuid.UUID      `json:"team_id"`
+	Email           sql.NullString `json:"email"`
+	Code            string         `json:"code"`
+	Number          sql.NullInt64  `json:"number"`
+	PrimaryPosition sql.NullString `json:"primary_position"`
+	Status          string         `json:"status"`
+	InvitedBy       uuid.UUID      `json:"invited_by"`
+	AcceptedBy      uuid.NullUUID  `json:"accepted_by"`
+	ExpiresAt       time.Time      `json:"expires_at"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
 }
 
 type TeamMember struct {
@@ -87,6 +339,18 @@ type TeamMember struct {
 	TeamID          uuid.UUID `json:"team_id"`
 	CreatedAt       time.Time `json:"created_at"`
 	UpdatedAt       time.Time `json:"updated_at"`
+	Role            string    `json:"role"`
+}
+
+type TeamMemberStint struct {
+	ID              uuid.UUID    `json:"id"`
+	TeamID          uuid.UUID    `json:"team_id"`
+	UserID          uuid.UUID    `json:"user_id"`
+	Number          int64        `json:"number"`
+	PrimaryPosition string       `json:"primary_position"`
+	Status          string       `json:"status"`
+	StartedAt       time.Time    `json:"started_at"`
+	EndedAt         sql.NullTime `json:"ended_at"`
 }
 
 type User struct {
@@ -100,6 +364,10 @@ type User struct {
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	CreatedAt         time.Time `json:"created_at"`
 	UpdatedAt         time.Time `json:"updated_at"`
+	IsMinor           bool      `json:"is_minor"`
+	ProfileVisibility string    `json:"profile_visibility"`
+	EmailVisibility   string    `json:"email_visibility"`
+	StatVisibility    string    `json:"stat_visibility"`
 }
 
 type UserRole struct {
@@ -110,6 +378,18 @@ type UserRole struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+type Venue struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Address   string    `json:"address"`
+	City      string    `json:"city"`
+	Latitude  float64   `json:"latitude"`
+	Longitude float64   `json:"longitude"`
+	CreatedBy uuid.UUID `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
 type VerifyEmail struct {
 	ID         uuid.UUID `json:"id"`
 	UserID     string    `json:"user_id"`
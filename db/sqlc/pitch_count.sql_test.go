@@ -0,0 +1,137 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestQueries_SetPitchCount(t *testing.T) {
+	home := createRandomTeam(t)
+	game := createRandomGame(t, &home, nil)
+	pitcher := createRandomUser(t)
+	coach := createRandomUser(t)
+
+	arg := SetPitchCountParams{
+		GameID:    game.ID,
+		PlayerID:  pitcher.ID,
+		Pitches:   45,
+		EnteredBy: coach.ID,
+	}
+	first, err := testQueries.SetPitchCount(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(45), first.Pitches)
+
+	// entering the count again corrects it
+	arg.Pitches = 52
+	second, err := testQueries.SetPitchCount(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, first.ID, second.ID)
+	require.Equal(t, int64(52), second.Pitches)
+
+	counts, err := testQueries.ListGamePitchCounts(context.Background(), game.ID)
+	require.NoError(t, err)
+	require.Len(t, counts, 1)
+	require.Equal(t, pitcher.ID, counts[0].PlayerID)
+
+	rows, err := testQueries.ListPlayerPitchCounts(context.Background(), ListPlayerPitchCountsParams{
+		PlayerID: pitcher.ID,
+		Since:    time.Now().AddDate(0, 0, -31),
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, game.ID, rows[0].ID)
+	require.Equal(t, int64(52), rows[0].Pitches)
+
+	err = testQueries.DeletePitchCount(context.Background(), DeletePitchCountParams{GameID: game.ID, PlayerID: pitcher.ID})
+	require.NoError(t, err)
+
+	counts, err = testQueries.ListGamePitchCounts(context.Background(), game.ID)
+	require.NoError(t, err)
+	require.Empty(t, counts)
+}
+
+func TestQueries_ListGameRecordedPitches(t *testing.T) {
+	game := createRandomGame(t, nil, nil)
+	scorer := createRandomUser(t)
+
+	away, err := testStore.SetLineupTx(context.Background(), SetLineupTxParams{
+		GameID: game.ID,
+		Spots:  []LineupSpotParams{{PlayerID: createRandomUser(t).ID, BatPosition: 1, Position: string(util.Pitcher)}},
+	})
+	require.NoError(t, err)
+	home, err := testStore.SetLineupTx(context.Background(), SetLineupTxParams{
+		GameID:   game.ID,
+		HomeTeam: true,
+		Spots:    []LineupSpotParams{{PlayerID: createRandomUser(t).ID, BatPosition: 1, Position: string(util.Pitcher)}},
+	})
+	require.NoError(t, err)
+	starter := home.Participants[0]
+	moveGame(t, game, util.GameScheduled, util.GameInProgress)
+
+	// the starter is relieved with two balls on the first batter
+	relief, err := testStore.SubstituteTx(context.Background(), SubstituteTxParams{
+		GameID:   game.ID,
+		HomeTeam: true,
+		Inning:   1,
+		Steps: []SubstitutionStepParams{{
+			ID:          uuid.New(),
+			Replaces:    starter.ID,
+			PlayerID:    createRandomUser(t).ID,
+			Entry:       string(util.EntryPitchingChange),
+			BatPosition: 1,
+			Position:    string(util.Pitcher),
+		}},
+		Sequence:  1,
+		Event:     GameEventParams{Type: "substitution", Payload: json.RawMessage(`{"type":"substitution"}`)},
+		CreatedBy: scorer.ID,
+	})
+	require.NoError(t, err)
+	reliever := relief.Entered[0]
+
+	_, err = testStore.RecordGameEventTx(context.Background(), RecordGameEventTxParams{
+		GameID:    game.ID,
+		Sequence:  2,
+		Event:     GameEventParams{Type: "pitch", Payload: json.RawMessage(`{"type":"pitch","pitch":{"type":"ball"}}`)},
+		CreatedBy: scorer.ID,
+		Projection: GameProjection{
+			LastInning: 1,
+			LastAtbat:  1,
+			Innings:    []ProjectInningParams{{Number: 1}},
+			Atbats: []AtbatProjection{{
+				Number:    1,
+				Inning:    1,
+				Half:      string(util.HalfTop),
+				BatterID:  away.Participants[0].ID,
+				PitcherID: starter.ID,
+				Balls:     3,
+				Pitches: []PitchProjection{
+					{Type: string(util.PitchBall), Balls: 1, PitcherID: starter.ID, CreatedBy: scorer.ID},
+					{Type: string(util.PitchBall), Balls: 2, PitcherID: starter.ID, CreatedBy: scorer.ID},
+					{Type: string(util.PitchBall), Balls: 3, PitcherID: reliever.ID, CreatedBy: scorer.ID},
+				},
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	rows, err := testQueries.ListGameRecordedPitches(context.Background(), game.ID)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	require.Equal(t, starter.PlayerID, rows[0].PlayerID)
+	require.Equal(t, int64(2), rows[0].Pitches)
+	require.Equal(t, reliever.PlayerID, rows[1].PlayerID)
+	require.Equal(t, int64(1), rows[1].Pitches)
+
+	appearances, err := testQueries.ListPitchingAppearances(context.Background(), ListPitchingAppearancesParams{
+		PlayerID: reliever.PlayerID,
+		Since:    time.Now().AddDate(0, 0, -31),
+	})
+	require.NoError(t, err)
+	require.Len(t, appearances, 1)
+	require.Equal(t, int64(1), appearances[0].Pitches)
+}
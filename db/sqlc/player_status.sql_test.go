@@ -0,0 +1,108 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestQueries_CreatePlayerStatus(t *testing.T) {
+	team := createRandomTeam(t)
+	member := addRandomTeamMember(t, team, 1, util.Pitcher)
+
+	status, err := testQueries.CreatePlayerStatus(context.Background(), CreatePlayerStatusParams{
+		TeamID:    team.ID,
+		UserID:    member.UserID,
+		Status:    string(util.RosterStatusInjured),
+		Reason:    "sprained ankle",
+		StartsAt:  time.Now().Add(-time.Hour),
+		CreatedBy: member.UserID,
+	})
+	require.NoError(t, err)
+
+	current, err := testQueries.GetCurrentPlayerStatus(context.Background(), GetCurrentPlayerStatusParams{TeamID: team.ID, UserID: member.UserID})
+	require.NoError(t, err)
+	require.Equal(t, status.ID, current.ID)
+
+	members, err := testQueries.ListTeamMembers(context.Background(), ListTeamMembersParams{TeamID: team.ID, Limit: 5, IncludePrivate: true})
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	require.Equal(t, string(util.RosterStatusInjured), members[0].Status)
+
+	cleared, err := testQueries.ClearPlayerStatus(context.Background(), ClearPlayerStatusParams{ID: status.ID, TeamID: team.ID, UserID: member.UserID})
+	require.NoError(t, err)
+	require.True(t, cleared.EndsAt.Valid)
+
+	_, err = testQueries.GetCurrentPlayerStatus(context.Background(), GetCurrentPlayerStatusParams{TeamID: team.ID, UserID: member.UserID})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	members, err = testQueries.ListTeamMembers(context.Background(), ListTeamMembersParams{TeamID: team.ID, Limit: 5, IncludePrivate: true})
+	require.NoError(t, err)
+	require.Equal(t, string(util.RosterStatusActive), members[0].Status)
+}
+
+func TestQueries_ServeSuspensionGame(t *testing.T) {
+	team := createRandomTeam(t)
+	member := addRandomTeamMember(t, team, 1, util.Pitcher)
+
+	_, err := testQueries.CreatePlayerStatus(context.Background(), CreatePlayerStatusParams{
+		TeamID:         team.ID,
+		UserID:         member.UserID,
+		Status:         string(util.RosterStatusSuspended),
+		StartsAt:       time.Now().Add(-time.Hour),
+		GamesRemaining: sql.NullInt64{Int64: 2, Valid: true},
+		CreatedBy:      member.UserID,
+	})
+	require.NoError(t, err)
+
+	served, err := testQueries.ServeSuspensionGame(context.Background(), team.ID)
+	require.NoError(t, err)
+	require.Len(t, served, 1)
+	require.Equal(t, int64(1), served[0].GamesRemaining.Int64)
+	require.False(t, served[0].EndsAt.Valid)
+
+	served, err = testQueries.ServeSuspensionGame(context.Background(), team.ID)
+	require.NoError(t, err)
+	require.Len(t, served, 1)
+	require.Zero(t, served[0].GamesRemaining.Int64)
+	require.True(t, served[0].EndsAt.Valid)
+
+	served, err = testQueries.ServeSuspensionGame(context.Background(), team.ID)
+	require.NoError(t, err)
+	require.Empty(t, served)
+}
+
+func TestQueries_PlayerStatusSurvivesRelease(t *testing.T) {
+	team := createRandomTeam(t)
+	member := addRandomTeamMember(t, team, 1, util.Pitcher)
+
+	status, err := testQueries.CreatePlayerStatus(context.Background(), CreatePlayerStatusParams{
+		TeamID:         team.ID,
+		UserID:         member.UserID,
+		Status:         string(util.RosterStatusSuspended),
+		StartsAt:       time.Now().Add(-time.Hour),
+		GamesRemaining: sql.NullInt64{Int64: 3, Valid: true},
+		CreatedBy:      member.UserID,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.RemoveTeamMember(context.Background(), RemoveTeamMemberParams{TeamID: team.ID, UserID: member.UserID})
+	require.NoError(t, err)
+
+	// re-signing the player does not clear the suspension
+	_, err = testQueries.AddTeamMember(context.Background(), AddTeamMemberParams{
+		UserID:          member.UserID,
+		TeamID:          team.ID,
+		Number:          1,
+		PrimaryPosition: string(util.Pitcher),
+	})
+	require.NoError(t, err)
+
+	current, err := testQueries.GetCurrentPlayerStatus(context.Background(), GetCurrentPlayerStatusParams{TeamID: team.ID, UserID: member.UserID})
+	require.NoError(t, err)
+	require.Equal(t, status.ID, current.ID)
+	require.Equal(t, int64(3), current.GamesRemaining.Int64)
+}
@@ -11,33 +11,149 @@ import (
 )
 
 type Querier interface {
+	AcceptTeamInvitation(ctx context.Context, arg AcceptTeamInvitationParams) (TeamInvitation, error)
 	AddTeamMember(ctx context.Context, arg AddTeamMemberParams) (TeamMember, error)
+	ArchiveTeam(ctx context.Context, id uuid.UUID) (Team, error)
+	AreTeammates(ctx context.Context, arg AreTeammatesParams) (bool, error)
+	ClearPlayerStatus(ctx context.Context, arg ClearPlayerStatusParams) (PlayerStatus, error)
+	CloseTeamMemberStint(ctx context.Context, arg CloseTeamMemberStintParams) (TeamMemberStint, error)
+	CreateAtbatRunner(ctx context.Context, arg CreateAtbatRunnerParams) (AtbatRunner, error)
+	CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) (AuditLog, error)
+	CreateDepthChartEntry(ctx context.Context, arg CreateDepthChartEntryParams) (DepthChartEntry, error)
+	CreateField(ctx context.Context, arg CreateFieldParams) (Field, error)
+	CreateFieldAvailability(ctx context.Context, arg CreateFieldAvailabilityParams) (FieldAvailability, error)
 	CreateGame(ctx context.Context, arg CreateGameParams) (Game, error)
+	CreateGameEvent(ctx context.Context, arg CreateGameEventParams) (GameEvent, error)
+	CreateGameParticipant(ctx context.Context, arg CreateGameParticipantParams) (GameParticipant, error)
+	CreateGameScheduleChange(ctx context.Context, arg CreateGameScheduleChangeParams) (GameScheduleChange, error)
+	CreateGameStat(ctx context.Context, arg CreateGameStatParams) (GameStat, error)
+	CreateGameStatusChange(ctx context.Context, arg CreateGameStatusChangeParams) (GameStatusChange, error)
+	CreateGuardian(ctx context.Context, arg CreateGuardianParams) (Guardian, error)
+	CreateJoinRequest(ctx context.Context, arg CreateJoinRequestParams) (JoinRequest, error)
+	CreatePlayerPosition(ctx context.Context, arg CreatePlayerPositionParams) (PlayerPosition, error)
+	CreatePlayerStatus(ctx context.Context, arg CreatePlayerStatusParams) (PlayerStatus, error)
 	CreateRole(ctx context.Context, arg CreateRoleParams) (UserRole, error)
+	CreateRosterTransaction(ctx context.Context, arg CreateRosterTransactionParams) (RosterTransaction, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
 	CreateTeam(ctx context.Context, name string) (Team, error)
+	CreateTeamInvitation(ctx context.Context, arg CreateTeamInvitationParams) (TeamInvitation, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateVenue(ctx context.Context, arg CreateVenueParams) (Venue, error)
+	DecideJoinRequest(ctx context.Context, arg DecideJoinRequestParams) (JoinRequest, error)
+	DeleteAtbatRunners(ctx context.Context, atbatID uuid.UUID) error
+	DeleteAtbatStats(ctx context.Context, atbatID uuid.UUID) error
+	DeleteAtbatsAfter(ctx context.Context, arg DeleteAtbatsAfterParams) error
+	DeleteDepthChartPosition(ctx context.Context, arg DeleteDepthChartPositionParams) error
+	DeleteField(ctx context.Context, arg DeleteFieldParams) (Field, error)
+	DeleteFieldAvailability(ctx context.Context, arg DeleteFieldAvailabilityParams) (FieldAvailability, error)
+	DeleteGameParticipant(ctx context.Context, id uuid.UUID) (GameParticipant, error)
+	DeleteGuardian(ctx context.Context, arg DeleteGuardianParams) error
+	DeleteInningsAfter(ctx context.Context, arg DeleteInningsAfterParams) error
+	DeleteLineup(ctx context.Context, arg DeleteLineupParams) error
+	DeletePitchCount(ctx context.Context, arg DeletePitchCountParams) error
+	DeletePitchesAfter(ctx context.Context, arg DeletePitchesAfterParams) error
+	DeletePlayerPositions(ctx context.Context, arg DeletePlayerPositionsParams) error
 	DeleteRole(ctx context.Context, id uuid.UUID) error
 	DeleteTeam(ctx context.Context, id uuid.UUID) error
 	DeleteUser(ctx context.Context, id uuid.UUID) error
+	DeleteVenue(ctx context.Context, id uuid.UUID) (Venue, error)
+	ExitGameParticipant(ctx context.Context, arg ExitGameParticipantParams) (GameParticipant, error)
+	GetAtbat(ctx context.Context, id uuid.UUID) (Atbat, error)
+	GetCurrentPlayerStatus(ctx context.Context, arg GetCurrentPlayerStatusParams) (PlayerStatus, error)
+	GetField(ctx context.Context, id uuid.UUID) (Field, error)
 	GetGame(ctx context.Context, id uuid.UUID) (Game, error)
+	GetGameAvailability(ctx context.Context, arg GetGameAvailabilityParams) (GameAvailability, error)
+	GetGuardian(ctx context.Context, arg GetGuardianParams) (Guardian, error)
+	GetNextEventSequence(ctx context.Context, gameID uuid.UUID) (int64, error)
+	GetPlayerGameTeam(ctx context.Context, arg GetPlayerGameTeamParams) (uuid.UUID, error)
 	GetRole(ctx context.Context, id uuid.UUID) (UserRole, error)
 	GetRoles(ctx context.Context, userID uuid.UUID) ([]UserRole, error)
 	GetRolesByName(ctx context.Context, name string) ([]UserRole, error)
 	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
 	GetTeam(ctx context.Context, id uuid.UUID) (Team, error)
+	GetTeamDeleteBlockers(ctx context.Context, teamID uuid.UUID) (GetTeamDeleteBlockersRow, error)
+	GetTeamInvitationByCode(ctx context.Context, code string) (TeamInvitation, error)
+	GetTeamMember(ctx context.Context, arg GetTeamMemberParams) (TeamMember, error)
 	GetUser(ctx context.Context, id uuid.UUID) (User, error)
 	GetUserByUsername(ctx context.Context, username string) (User, error)
+	GetVenue(ctx context.Context, id uuid.UUID) (Venue, error)
+	HasGameReachedStatus(ctx context.Context, arg HasGameReachedStatusParams) (bool, error)
+	IsEligibleForPosition(ctx context.Context, arg IsEligibleForPositionParams) (bool, error)
+	IsFieldAvailable(ctx context.Context, arg IsFieldAvailableParams) (bool, error)
+	ListApprovedGuardians(ctx context.Context) ([]Guardian, error)
+	ListAtbatRunners(ctx context.Context, atbatID uuid.UUID) ([]AtbatRunner, error)
+	ListAuditLogs(ctx context.Context, arg ListAuditLogsParams) ([]AuditLog, error)
+	ListDepthChart(ctx context.Context, arg ListDepthChartParams) ([]ListDepthChartRow, error)
+	ListFieldAvailability(ctx context.Context, arg ListFieldAvailabilityParams) ([]FieldAvailability, error)
+	ListFields(ctx context.Context, venueID uuid.UUID) ([]Field, error)
+	ListFieldsInBounds(ctx context.Context, arg ListFieldsInBoundsParams) ([]ListFieldsInBoundsRow, error)
+	ListGameAtbats(ctx context.Context, gameID uuid.UUID) ([]ListGameAtbatsRow, error)
+	ListGameAvailability(ctx context.Context, id uuid.UUID) ([]ListGameAvailabilityRow, error)
+	ListGameEventHistory(ctx context.Context, gameID uuid.UUID) ([]GameEvent, error)
+	ListGameEvents(ctx context.Context, gameID uuid.UUID) ([]GameEvent, error)
+	ListGameInnings(ctx context.Context, gameID uuid.UUID) ([]ListGameInningsRow, error)
+	ListGameParticipants(ctx context.Context, gameID uuid.UUID) ([]ListGameParticipantsRow, error)
+	ListGamePitchCounts(ctx context.Context, gameID uuid.UUID) ([]PitchCount, error)
+	ListGameRecordedPitches(ctx context.Context, gameID uuid.UUID) ([]ListGameRecordedPitchesRow, error)
+	ListGameRunners(ctx context.Context, gameID uuid.UUID) ([]AtbatRunner, error)
+	ListGameScheduleChanges(ctx context.Context, gameID uuid.UUID) ([]GameScheduleChange, error)
+	ListGameStats(ctx context.Context, gameID uuid.UUID) ([]ListGameStatsRow, error)
+	ListGameStatusChanges(ctx context.Context, gameID uuid.UUID) ([]GameStatusChange, error)
 	ListGames(ctx context.Context, arg ListGamesParams) ([]Game, error)
+	ListGamesOfUser(ctx context.Context, arg ListGamesOfUserParams) ([]Game, error)
+	ListGuardiansOfPlayer(ctx context.Context, playerID uuid.UUID) ([]ListGuardiansOfPlayerRow, error)
+	ListJoinRequests(ctx context.Context, arg ListJoinRequestsParams) ([]JoinRequest, error)
+	ListLineup(ctx context.Context, arg ListLineupParams) ([]ListLineupRow, error)
+	ListNotifications(ctx context.Context, arg ListNotificationsParams) ([]Notification, error)
+	ListPitches(ctx context.Context, atbatID uuid.UUID) ([]Pitch, error)
+	ListPitchingAppearances(ctx context.Context, arg ListPitchingAppearancesParams) ([]ListPitchingAppearancesRow, error)
+	ListPlayerPitchCounts(ctx context.Context, arg ListPlayerPitchCountsParams) ([]ListPlayerPitchCountsRow, error)
+	ListPlayerPositions(ctx context.Context, arg ListPlayerPositionsParams) ([]PlayerPosition, error)
+	ListPlayerStatuses(ctx context.Context, arg ListPlayerStatusesParams) ([]PlayerStatus, error)
 	ListRoles(ctx context.Context, arg ListRolesParams) ([]UserRole, error)
+	ListRosterAsOf(ctx context.Context, arg ListRosterAsOfParams) ([]ListRosterAsOfRow, error)
+	ListRosterTransactions(ctx context.Context, arg ListRosterTransactionsParams) ([]RosterTransaction, error)
+	ListScheduleConflicts(ctx context.Context, arg ListScheduleConflictsParams) ([]Game, error)
+	ListTeamIDsOfUser(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	ListTeamInvitations(ctx context.Context, arg ListTeamInvitationsParams) ([]TeamInvitation, error)
 	ListTeamMembers(ctx context.Context, arg ListTeamMembersParams) ([]ListTeamMembersRow, error)
 	ListTeams(ctx context.Context, arg ListTeamsParams) ([]Team, error)
 	ListTeamsOfUser(ctx context.Context, arg ListTeamsOfUserParams) ([]ListTeamsOfUserRow, error)
 	ListUsers(ctx context.Context, arg ListUsersParams) ([]ListUsersRow, error)
-	UpdateGame(ctx context.Context, arg UpdateGameParams) (Game, error)
+	ListVenues(ctx context.Context, arg ListVenuesParams) ([]Venue, error)
+	LockGameForLineup(ctx context.Context, id uuid.UUID) (Game, error)
+	LockGameInProgress(ctx context.Context, id uuid.UUID) (Game, error)
+	LockScheduleTeams(ctx context.Context, arg LockScheduleTeamsParams) error
+	LockVenue(ctx context.Context, id uuid.UUID) error
+	MarkNotificationRead(ctx context.Context, arg MarkNotificationReadParams) (Notification, error)
+	NotifyGamePlayers(ctx context.Context, arg NotifyGamePlayersParams) error
+	OpenTeamMemberStint(ctx context.Context, arg OpenTeamMemberStintParams) (TeamMemberStint, error)
+	ProjectAtbat(ctx context.Context, arg ProjectAtbatParams) (Atbat, error)
+	ProjectInning(ctx context.Context, arg ProjectInningParams) (Inning, error)
+	ProjectPitch(ctx context.Context, arg ProjectPitchParams) (Pitch, error)
+	ReenterGameParticipant(ctx context.Context, id uuid.UUID) (GameParticipant, error)
+	RemoveTeamMember(ctx context.Context, arg RemoveTeamMemberParams) (TeamMember, error)
+	RevokeTeamInvitation(ctx context.Context, arg RevokeTeamInvitationParams) (TeamInvitation, error)
+	SearchTeams(ctx context.Context, arg SearchTeamsParams) ([]SearchTeamsRow, error)
+	SearchUsers(ctx context.Context, arg SearchUsersParams) ([]SearchUsersRow, error)
+	ServeSuspensionGame(ctx context.Context, teamID uuid.UUID) ([]PlayerStatus, error)
+	SetGameAvailability(ctx context.Context, arg SetGameAvailabilityParams) (GameAvailability, error)
+	SetGameScore(ctx context.Context, arg SetGameScoreParams) (Game, error)
+	SetPitchCount(ctx context.Context, arg SetPitchCountParams) (PitchCount, error)
+	UnarchiveTeam(ctx context.Context, id uuid.UUID) (Team, error)
+	UpdateField(ctx context.Context, arg UpdateFieldParams) (Field, error)
+	UpdateGameSchedule(ctx context.Context, arg UpdateGameScheduleParams) (Game, error)
+	UpdateGameStatus(ctx context.Context, arg UpdateGameStatusParams) (Game, error)
+	UpdateGuardianStatus(ctx context.Context, arg UpdateGuardianStatusParams) (Guardian, error)
 	UpdateSession(ctx context.Context, arg UpdateSessionParams) (Session, error)
 	UpdateTeam(ctx context.Context, arg UpdateTeamParams) (Team, error)
+	UpdateTeamMember(ctx context.Context, arg UpdateTeamMemberParams) (TeamMember, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateUserPrivacy(ctx context.Context, arg UpdateUserPrivacyParams) (User, error)
+	UpdateVenue(ctx context.Context, arg UpdateVenueParams) (Venue, error)
+	UseTeamJoinCode(ctx context.Context, id uuid.UUID) (TeamInvitation, error)
+	VoidGameEvent(ctx context.Context, arg VoidGameEventParams) (GameEvent, error)
+	VoidGameEvents(ctx context.Context, arg VoidGameEventsParams) ([]GameEvent, error)
 }
 
 var _ Querier = (*Queries)(nil)
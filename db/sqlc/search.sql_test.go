@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestQueries_SearchUsers(t *testing.T) {
+	viewer := createRandomUser(t)
+	user := createRandomUser(t)
+
+	arg := SearchUsersParams{
+		Query:      user.FirstName + " " + user.LastName,
+		ViewerID:   viewer.ID,
+		MaxResults: 5,
+	}
+	users, err := testQueries.SearchUsers(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, users)
+	require.Equal(t, user.ID, users[0].ID)
+	require.Greater(t, users[0].Rank, float32(0))
+
+	_, err = testQueries.UpdateUserPrivacy(context.Background(), UpdateUserPrivacyParams{
+		ID:                user.ID,
+		ProfileVisibility: sql.NullString{String: string(util.VisibilityPrivate), Valid: true},
+	})
+	require.NoError(t, err)
+
+	users, err = testQueries.SearchUsers(context.Background(), arg)
+	require.NoError(t, err)
+	for _, u := range users {
+		require.NotEqual(t, user.ID, u.ID)
+	}
+
+	arg.IncludePrivate = true
+	users, err = testQueries.SearchUsers(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, users)
+	require.Equal(t, user.ID, users[0].ID)
+}
+
+func TestQueries_SearchTeams(t *testing.T) {
+	team := createRandomTeam(t)
+
+	teams, err := testQueries.SearchTeams(context.Background(), SearchTeamsParams{
+		Query:      team.Name,
+		MaxResults: 5,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, teams)
+	require.Equal(t, team.ID, teams[0].ID)
+	require.Equal(t, float32(1), teams[0].Rank)
+}
+
+func TestQueries_SearchTeamsWildcards(t *testing.T) {
+	createRandomTeam(t)
+
+	// LIKE wildcards in the query are matched literally, not against every name
+	for _, query := range []string{"%", "_", `\`} {
+		teams, err := testQueries.SearchTeams(context.Background(), SearchTeamsParams{
+			Query:      query,
+			MaxResults: 5,
+		})
+		require.NoError(t, err)
+		require.Empty(t, teams, query)
+	}
+}
@@ -9,6 +9,21 @@ import (
 type Store interface {
 	Querier
 	CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error)
+	ApproveGuardianTx(ctx context.Context, arg ApproveGuardianTxParams) (ApproveGuardianTxResult, error)
+	SetPlayerPositionsTx(ctx context.Context, arg SetPlayerPositionsTxParams) ([]PlayerPosition, error)
+	SetDepthChartTx(ctx context.Context, arg SetDepthChartTxParams) ([]DepthChartEntry, error)
+	RosterTransactionTx(ctx context.Context, arg RosterTransactionTxParams) (RosterTransactionTxResult, error)
+	UpdateTeamMemberTx(ctx context.Context, arg UpdateTeamMemberTxParams) (UpdateTeamMemberTxResult, error)
+	AcceptInvitationTx(ctx context.Context, arg AcceptInvitationTxParams) (AcceptInvitationTxResult, error)
+	ApproveJoinRequestTx(ctx context.Context, arg ApproveJoinRequestTxParams) (ApproveJoinRequestTxResult, error)
+	GameStatusTx(ctx context.Context, arg GameStatusTxParams) (GameStatusTxResult, error)
+	CreateGameTx(ctx context.Context, arg CreateGameTxParams) (CreateGameTxResult, error)
+	RescheduleGameTx(ctx context.Context, arg RescheduleGameTxParams) (RescheduleGameTxResult, error)
+	SetLineupTx(ctx context.Context, arg SetLineupTxParams) (SetLineupTxResult, error)
+	SubstituteTx(ctx context.Context, arg SubstituteTxParams) (SubstituteTxResult, error)
+	RecordGameEventTx(ctx context.Context, arg RecordGameEventTxParams) (RecordGameEventTxResult, error)
+	UndoGameEventsTx(ctx context.Context, arg UndoGameEventsTxParams) (UndoGameEventsTxResult, error)
+	CorrectGameEventTx(ctx context.Context, arg CorrectGameEventTxParams) (CorrectGameEventTxResult, error)
 }
 
 // SQLStore provides all functions to execute SQL queries and transactions
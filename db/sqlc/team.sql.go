@@ -15,7 +15,7 @@ import (
 const addTeamMember = `-- name: AddTeamMember :one
 INSERT INTO team_members (user_id, team_id, number, primary_position)
 VALUES ($1, $2, $3, $4)
-RETURNING id, number, primary_position, user_id, team_id, created_at, updated_at
+RETURNING id, number, primary_position, user_id, team_id, created_at, updated_at, role
 `
 
 type AddTeamMemberParams struct {
@@ -41,6 +41,39 @@ func (q *Queries) AddTeamMember(ctx context.Context, arg AddTeamMemberParams) (T
 		&i.TeamID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Role,
+	)
+	return i, err
+}
+
+const archiveTeam = `-- name: ArchiveTeam :one
+UPDATE teams
+SET archived_at = now(),
+    updated_at  = now()
+WHERE id = $1
+RETURNING id, name, created_at, updated_at, archived_at, abbreviation, primary_color, secondary_color, home_venue, city, division, logo_url, website_url, facebook_url, instagram_url, x_url
+`
+
+func (q *Queries) ArchiveTeam(ctx context.Context, id uuid.UUID) (Team, error) {
+	row := q.db.QueryRowContext(ctx, archiveTeam, id)
+	var i Team
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ArchivedAt,
+		&i.Abbreviation,
+		&i.PrimaryColor,
+		&i.SecondaryColor,
+		&i.HomeVenue,
+		&i.City,
+		&i.Division,
+		&i.LogoUrl,
+		&i.WebsiteUrl,
+		&i.FacebookUrl,
+		&i.InstagramUrl,
+		&i.XUrl,
 	)
 	return i, err
 }
@@ -48,7 +81,7 @@ func (q *Queries) AddTeamMember(ctx context.Context, arg AddTeamMemberParams) (T
 const createTeam = `-- name: CreateTeam :one
 INSERT INTO teams (name)
 VALUES ($1)
-RETURNING id, name, created_at, updated_at
+RETURNING id, name, created_at, updated_at, archived_at, abbreviation, primary_color, secondary_color, home_venue, city, division, logo_url, website_url, facebook_url, instagram_url, x_url
 `
 
 func (q *Queries) CreateTeam(ctx context.Context, name string) (Team, error) {
@@ -59,6 +92,18 @@ func (q *Queries) CreateTeam(ctx context.Context, name string) (Team, error) {
 		&i.Name,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ArchivedAt,
+		&i.Abbreviation,
+		&i.PrimaryColor,
+		&i.SecondaryColor,
+		&i.HomeVenue,
+		&i.City,
+		&i.Division,
+		&i.LogoUrl,
+		&i.WebsiteUrl,
+		&i.FacebookUrl,
+		&i.InstagramUrl,
+		&i.XUrl,
 	)
 	return i, err
 }
@@ -75,7 +120,7 @@ func (q *Queries) DeleteTeam(ctx context.Context, id uuid.UUID) error {
 }
 
 const getTeam = `-- name: GetTeam :one
-SELECT id, name, created_at, updated_at
+SELECT id, name, created_at, updated_at, archived_at, abbreviation, primary_color, secondary_color, home_venue, city, division, logo_url, website_url, facebook_url, instagram_url, x_url
 FROM teams
 WHERE id = $1
 LIMIT 1
@@ -89,25 +134,148 @@ func (q *Queries) GetTeam(ctx context.Context, id uuid.UUID) (Team, error) {
 		&i.Name,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ArchivedAt,
+		&i.Abbreviation,
+		&i.PrimaryColor,
+		&i.SecondaryColor,
+		&i.HomeVenue,
+		&i.City,
+		&i.Division,
+		&i.LogoUrl,
+		&i.WebsiteUrl,
+		&i.FacebookUrl,
+		&i.InstagramUrl,
+		&i.XUrl,
 	)
 	return i, err
 }
 
+const getTeamDeleteBlockers = `-- name: GetTeamDeleteBlockers :one
+SELECT (SELECT COUNT(*)
+        FROM team_members tm
+        WHERE tm.team_id = $1)::bigint AS member_count,
+       (SELECT COUNT(*)
+        FROM game g
+        WHERE g.home_team_id = $1
+           OR g.away_team_id = $1)::bigint AS game_count,
+       (SELECT COUNT(*)
+        FROM team_member_stints tms
+        WHERE tms.team_id = $1)::bigint AS stint_count,
+       (SELECT COUNT(*)
+        FROM roster_transactions rt
+        WHERE rt.from_team_id = $1
+           OR rt.to_team_id = $1)::bigint AS transaction_count
+`
+
+type GetTeamDeleteBlockersRow struct {
+	MemberCount      int64 `json:"member_count"`
+	GameCount        int64 `json:"game_count"`
+	StintCount       int64 `json:"stint_count"`
+	TransactionCount int64 `json:"transaction_count"`
+}
+
+func (q *Queries) GetTeamDeleteBlockers(ctx context.Context, teamID uuid.UUID) (GetTeamDeleteBlockersRow, error) {
+	row := q.db.QueryRowContext(ctx, getTeamDeleteBlockers, teamID)
+	var i GetTeamDeleteBlockersRow
+	err := row.Scan(
+		&i.MemberCount,
+		&i.GameCount,
+		&i.StintCount,
+		&i.TransactionCount,
+	)
+	return i, err
+}
+
+const getTeamMember = `-- name: GetTeamMember :one
+SELECT id, number, primary_position, user_id, team_id, created_at, updated_at, role
+FROM team_members
+WHERE team_id = $1
+  AND user_id = $2
+LIMIT 1
+`
+
+type GetTeamMemberParams struct {
+	TeamID uuid.UUID `json:"team_id"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) GetTeamMember(ctx context.Context, arg GetTeamMemberParams) (TeamMember, error) {
+	row := q.db.QueryRowContext(ctx, getTeamMember, arg.TeamID, arg.UserID)
+	var i TeamMember
+	err := row.Scan(
+		&i.ID,
+		&i.Number,
+		&i.PrimaryPosition,
+		&i.UserID,
+		&i.TeamID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Role,
+	)
+	return i, err
+}
+
+const listTeamIDsOfUser = `-- name: ListTeamIDsOfUser :many
+SELECT team_id
+FROM team_members
+WHERE user_id = $1
+ORDER BY team_id
+`
+
+func (q *Queries) ListTeamIDsOfUser(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, listTeamIDsOfUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var team_id uuid.UUID
+		if err := rows.Scan(&team_id); err != nil {
+			return nil, err
+		}
+		items = append(items, team_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listTeamMembers = `-- name: ListTeamMembers :many
-SELECT u.id, u.first_name, u.last_name, tm.primary_position, tm.number, t.name as team_name
-FROM users u
-         JOIN team_members tm on u.id = tm.user_id
+SELECT u.id,
+       u.first_name,
+       u.last_name,
+       tm.primary_position,
+       tm.number,
+       t.name as team_name,
+       COALESCE((SELECT ps.status
+                 FROM player_statuses ps
+                 WHERE ps.team_id = tm.team_id
+                   AND ps.user_id = tm.user_id
+                   AND ps.starts_at <= now()
+                   AND (ps.ends_at IS NULL OR ps.ends_at > now())
+                 ORDER BY ps.starts_at DESC
+                 LIMIT 1), 'active')::varchar AS status,
+       (NOT profile_visible($4::uuid, u.id))::boolean AS hidden
+FROM team_members tm
+         JOIN users u ON u.id = tm.user_id
          JOIN teams t ON tm.team_id = t.id
-WHERE u.id IN (SELECT user_id
-               FROM team_members
-               WHERE tm.team_id = $1)
+WHERE tm.team_id = $1
+  AND ($5::boolean OR profile_visible($4::uuid, u.id))
+ORDER BY tm.number
 LIMIT $2 OFFSET $3
 `
 
 type ListTeamMembersParams struct {
-	TeamID uuid.UUID `json:"team_id"`
-	Limit  int32     `json:"limit"`
-	Offset int32     `json:"offset"`
+	TeamID         uuid.UUID `json:"team_id"`
+	Limit          int32     `json:"limit"`
+	Offset         int32     `json:"offset"`
+	ViewerID       uuid.UUID `json:"viewer_id"`
+	IncludePrivate bool      `json:"include_private"`
 }
 
 type ListTeamMembersRow struct {
@@ -117,10 +285,18 @@ type ListTeamMembersRow struct {
 	PrimaryPosition string    `json:"primary_position"`
 	Number          int64     `json:"number"`
 	TeamName        string    `json:"team_name"`
+	Status          string    `json:"status"`
+	Hidden          bool      `json:"hidden"`
 }
 
 func (q *Queries) ListTeamMembers(ctx context.Context, arg ListTeamMembersParams) ([]ListTeamMembersRow, error) {
-	rows, err := q.db.QueryContext(ctx, listTeamMembers, arg.TeamID, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listTeamMembers,
+		arg.TeamID,
+		arg.Limit,
+		arg.Offset,
+		arg.ViewerID,
+		arg.IncludePrivate,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -135,6 +311,8 @@ func (q *Queries) ListTeamMembers(ctx context.Context, arg ListTeamMembersParams
 			&i.PrimaryPosition,
 			&i.Number,
 			&i.TeamName,
+			&i.Status,
+			&i.Hidden,
 		); err != nil {
 			return nil, err
 		}
@@ -150,19 +328,22 @@ func (q *Queries) ListTeamMembers(ctx context.Context, arg ListTeamMembersParams
 }
 
 const listTeams = `-- name: ListTeams :many
-SELECT id, name, created_at, updated_at
+SELECT id, name, created_at, updated_at, archived_at, abbreviation, primary_color, secondary_color, home_venue, city, division, logo_url, website_url, facebook_url, instagram_url, x_url
 FROM teams
+WHERE $3::boolean
+   OR archived_at IS NULL
 ORDER BY id
 LIMIT $1 OFFSET $2
 `
 
 type ListTeamsParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	Limit           int32 `json:"limit"`
+	Offset          int32 `json:"offset"`
+	IncludeArchived bool  `json:"include_archived"`
 }
 
 func (q *Queries) ListTeams(ctx context.Context, arg ListTeamsParams) ([]Team, error) {
-	rows, err := q.db.QueryContext(ctx, listTeams, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listTeams, arg.Limit, arg.Offset, arg.IncludeArchived)
 	if err != nil {
 		return nil, err
 	}
@@ -175,6 +356,18 @@ func (q *Queries) ListTeams(ctx context.Context, arg ListTeamsParams) ([]Team, e
 			&i.Name,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ArchivedAt,
+			&i.Abbreviation,
+			&i.PrimaryColor,
+			&i.SecondaryColor,
+			&i.HomeVenue,
+			&i.City,
+			&i.Division,
+			&i.LogoUrl,
+			&i.WebsiteUrl,
+			&i.FacebookUrl,
+			&i.InstagramUrl,
+			&i.XUrl,
 		); err != nil {
 			return nil, err
 		}
@@ -190,11 +383,20 @@ func (q *Queries) ListTeams(ctx context.Context, arg ListTeamsParams) ([]Team, e
 }
 
 const listTeamsOfUser = `-- name: ListTeamsOfUser :many
-SELECT t.id, t.name
-FROM teams t
-WHERE t.id IN (SELECT team_id
-               FROM team_members tm
-               WHERE user_id = $1)
+SELECT t.id,
+       t.name,
+       t.abbreviation,
+       t.primary_color,
+       t.secondary_color,
+       t.logo_url,
+       t.archived_at,
+       tm.role,
+       tm.number,
+       tm.primary_position
+FROM team_members tm
+         JOIN teams t ON t.id = tm.team_id
+WHERE tm.user_id = $1
+ORDER BY t.name
 LIMIT $2 OFFSET $3
 `
 
@@ -205,8 +407,16 @@ type ListTeamsOfUserParams struct {
 }
 
 type ListTeamsOfUserRow struct {
-	ID   uuid.UUID `json:"id"`
-	Name string    `json:"name"`
+	ID              uuid.UUID    `json:"id"`
+	Name            string       `json:"name"`
+	Abbreviation    string       `json:"abbreviation"`
+	PrimaryColor    string       `json:"primary_color"`
+	SecondaryColor  string       `json:"secondary_color"`
+	LogoUrl         string       `json:"logo_url"`
+	ArchivedAt      sql.NullTime `json:"archived_at"`
+	Role            string       `json:"role"`
+	Number          int64        `json:"number"`
+	PrimaryPosition string       `json:"primary_position"`
 }
 
 func (q *Queries) ListTeamsOfUser(ctx context.Context, arg ListTeamsOfUserParams) ([]ListTeamsOfUserRow, error) {
@@ -218,7 +428,18 @@ func (q *Queries) ListTeamsOfUser(ctx context.Context, arg ListTeamsOfUserParams
 	items := []ListTeamsOfUserRow{}
 	for rows.Next() {
 		var i ListTeamsOfUserRow
-		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Abbreviation,
+			&i.PrimaryColor,
+			&i.SecondaryColor,
+			&i.LogoUrl,
+			&i.ArchivedAt,
+			&i.Role,
+			&i.Number,
+			&i.PrimaryPosition,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -232,27 +453,177 @@ func (q *Queries) ListTeamsOfUser(ctx context.Context, arg ListTeamsOfUserParams
 	return items, nil
 }
 
+const removeTeamMember = `-- name: RemoveTeamMember :one
+DELETE
+FROM team_members
+WHERE team_id = $1
+  AND user_id = $2
+RETURNING id, number, primary_position, user_id, team_id, created_at, updated_at, role
+`
+
+type RemoveTeamMemberParams struct {
+	TeamID uuid.UUID `json:"team_id"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) RemoveTeamMember(ctx context.Context, arg RemoveTeamMemberParams) (TeamMember, error) {
+	row := q.db.QueryRowContext(ctx, removeTeamMember, arg.TeamID, arg.UserID)
+	var i TeamMember
+	err := row.Scan(
+		&i.ID,
+		&i.Number,
+		&i.PrimaryPosition,
+		&i.UserID,
+		&i.TeamID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Role,
+	)
+	return i, err
+}
+
+const unarchiveTeam = `-- name: UnarchiveTeam :one
+UPDATE teams
+SET archived_at = NULL,
+    updated_at  = now()
+WHERE id = $1
+RETURNING id, name, created_at, updated_at, archived_at, abbreviation, primary_color, secondary_color, home_venue, city, division, logo_url, website_url, facebook_url, instagram_url, x_url
+`
+
+func (q *Queries) UnarchiveTeam(ctx context.Context, id uuid.UUID) (Team, error) {
+	row := q.db.QueryRowContext(ctx, unarchiveTeam, id)
+	var i Team
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ArchivedAt,
+		&i.Abbreviation,
+		&i.PrimaryColor,
+		&i.SecondaryColor,
+		&i.HomeVenue,
+		&i.City,
+		&i.Division,
+		&i.LogoUrl,
+		&i.WebsiteUrl,
+		&i.FacebookUrl,
+		&i.InstagramUrl,
+		&i.XUrl,
+	)
+	return i, err
+}
+
 const updateTeam = `-- name: UpdateTeam :one
 UPDATE teams
-SET name       = COALESCE($1, name),
-    updated_at = now()
-WHERE id = $2
-RETURNING id, name, created_at, updated_at
+SET name            = COALESCE($1, name),
+    abbreviation    = COALESCE($2, abbreviation),
+    primary_color   = COALESCE($3, primary_color),
+    secondary_color = COALESCE($4, secondary_color),
+    home_venue      = COALESCE($5, home_venue),
+    city            = COALESCE($6, city),
+    division        = COALESCE($7, division),
+    logo_url        = COALESCE($8, logo_url),
+    website_url     = COALESCE($9, website_url),
+    facebook_url    = COALESCE($10, facebook_url),
+    instagram_url   = COALESCE($11, instagram_url),
+    x_url           = COALESCE($12, x_url),
+    updated_at      = now()
+WHERE id = $13
+RETURNING id, name, created_at, updated_at, archived_at, abbreviation, primary_color, secondary_color, home_venue, city, division, logo_url, website_url, facebook_url, instagram_url, x_url
 `
 
 type UpdateTeamParams struct {
-	Name sql.NullString `json:"name"`
-	ID   uuid.UUID      `json:"id"`
+	Name           sql.NullString `json:"name"`
+	Abbreviation   sql.NullString `json:"abbreviation"`
+	PrimaryColor   sql.NullString `json:"primary_color"`
+	SecondaryColor sql.NullString `json:"secondary_color"`
+	HomeVenue      sql.NullString `json:"home_venue"`
+	City           sql.NullString `json:"city"`
+	Division       sql.NullString `json:"division"`
+	LogoUrl        sql.NullString `json:"logo_url"`
+	WebsiteUrl     sql.NullString `json:"website_url"`
+	FacebookUrl    sql.NullString `json:"facebook_url"`
+	InstagramUrl   sql.NullString `json:"instagram_url"`
+	XUrl           sql.NullString `json:"x_url"`
+	ID             uuid.UUID      `json:"id"`
 }
 
 func (q *Queries) UpdateTeam(ctx context.Context, arg UpdateTeamParams) (Team, error) {
-	row := q.db.QueryRowContext(ctx, updateTeam, arg.Name, arg.ID)
+	row := q.db.QueryRowContext(ctx, updateTeam,
+		arg.Name,
+		arg.Abbreviation,
+		arg.PrimaryColor,
+		arg.SecondaryColor,
+		arg.HomeVenue,
+		arg.City,
+		arg.Division,
+		arg.LogoUrl,
+		arg.WebsiteUrl,
+		arg.FacebookUrl,
+		arg.InstagramUrl,
+		arg.XUrl,
+		arg.ID,
+	)
 	var i Team
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ArchivedAt,
+		&i.Abbreviation,
+		&i.PrimaryColor,
+		&i.SecondaryColor,
+		&i.HomeVenue,
+		&i.City,
+		&i.Division,
+		&i.LogoUrl,
+		&i.WebsiteUrl,
+		&i.FacebookUrl,
+		&i.InstagramUrl,
+		&i.XUrl,
+	)
+	return i, err
+}
+
+const updateTeamMember = `-- name: UpdateTeamMember :one
+UPDATE team_members
+SET number           = COALESCE($1, number),
+    primary_position = COALESCE($2, primary_position),
+    role             = COALESCE($3, role),
+    updated_at       = now()
+WHERE team_id = $4
+  AND user_id = $5
+RETURNING id, number, primary_position, user_id, team_id, created_at, updated_at, role
+`
+
+type UpdateTeamMemberParams struct {
+	Number          sql.NullInt64  `json:"number"`
+	PrimaryPosition sql.NullString `json:"primary_position"`
+	Role            sql.NullString `json:"role"`
+	TeamID          uuid.UUID      `json:"team_id"`
+	UserID          uuid.UUID      `json:"user_id"`
+}
+
+func (q *Queries) UpdateTeamMember(ctx context.Context, arg UpdateTeamMemberParams) (TeamMember, error) {
+	row := q.db.QueryRowContext(ctx, updateTeamMember,
+		arg.Number,
+		arg.PrimaryPosition,
+		arg.Role,
+		arg.TeamID,
+		arg.UserID,
+	)
+	var i TeamMember
+	err := row.Scan(
+		&i.ID,
+		&i.Number,
+		&i.PrimaryPosition,
+		&i.UserID,
+		&i.TeamID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Role,
 	)
 	return i, err
 }
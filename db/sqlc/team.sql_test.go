@@ -3,10 +3,12 @@ package db
 import (
 	"context"
 	"database/sql"
+	"github.com/google/uuid"
 	"github.com/kwalter26/scoreit-api-go/util"
 	"github.com/stretchr/testify/require"
 
 	"testing"
+	"time"
 )
 
 func createRandomTeam(t *testing.T) Team {
@@ -52,6 +54,29 @@ func TestQueries_UpdateTeam(t *testing.T) {
 	require.NotEqual(t, team.UpdatedAt, updatedTeam.UpdatedAt)
 }
 
+func TestQueries_UpdateTeamBranding(t *testing.T) {
+	team := createRandomTeam(t)
+
+	updated, err := testQueries.UpdateTeam(context.Background(), UpdateTeamParams{
+		ID:           team.ID,
+		Abbreviation: sql.NullString{String: "NYY", Valid: true},
+		PrimaryColor: sql.NullString{String: "#003366", Valid: true},
+		City:         sql.NullString{String: "New York", Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, team.Name, updated.Name)
+	require.Equal(t, "NYY", updated.Abbreviation)
+	require.Equal(t, "#003366", updated.PrimaryColor)
+	require.Equal(t, "New York", updated.City)
+	require.Empty(t, updated.SecondaryColor)
+
+	_, err = testQueries.UpdateTeam(context.Background(), UpdateTeamParams{
+		ID:           team.ID,
+		PrimaryColor: sql.NullString{String: "navy", Valid: true},
+	})
+	require.Error(t, err)
+}
+
 func TestQueries_DeleteTeam(t *testing.T) {
 	team := createRandomTeam(t)
 
@@ -83,6 +108,74 @@ func TestQueries_ListTeams(t *testing.T) {
 	}
 }
 
+func TestQueries_ArchiveTeam(t *testing.T) {
+	team := createRandomTeam(t)
+	require.False(t, team.ArchivedAt.Valid)
+
+	archived, err := testQueries.ArchiveTeam(context.Background(), team.ID)
+	require.NoError(t, err)
+	require.True(t, archived.ArchivedAt.Valid)
+
+	teams, err := testQueries.ListTeams(context.Background(), ListTeamsParams{Limit: 1000000, Offset: 0})
+	require.NoError(t, err)
+	for _, listed := range teams {
+		require.NotEqual(t, team.ID, listed.ID)
+	}
+
+	unarchived, err := testQueries.UnarchiveTeam(context.Background(), team.ID)
+	require.NoError(t, err)
+	require.False(t, unarchived.ArchivedAt.Valid)
+}
+
+func TestQueries_GetTeamDeleteBlockers(t *testing.T) {
+	team := createRandomTeam(t)
+
+	blockers, err := testQueries.GetTeamDeleteBlockers(context.Background(), team.ID)
+	require.NoError(t, err)
+	require.Zero(t, blockers.MemberCount)
+	require.Zero(t, blockers.GameCount)
+
+	_, err = testQueries.AddTeamMember(context.Background(), AddTeamMemberParams{
+		UserID:          createRandomUser(t).ID,
+		TeamID:          team.ID,
+		Number:          util.RandomInt(1, 99),
+		PrimaryPosition: string(util.RandomBaseballPosition()),
+	})
+	require.NoError(t, err)
+	createRandomGame(t, &team, nil)
+	createRandomGame(t, nil, &team)
+
+	blockers, err = testQueries.GetTeamDeleteBlockers(context.Background(), team.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), blockers.MemberCount)
+	require.Equal(t, int64(2), blockers.GameCount)
+
+	// a released player leaves the team's roster history behind
+	former := createRandomUser(t)
+	_, err = testQueries.OpenTeamMemberStint(context.Background(), OpenTeamMemberStintParams{
+		TeamID:          team.ID,
+		UserID:          former.ID,
+		Number:          util.RandomInt(1, 99),
+		PrimaryPosition: string(util.RandomBaseballPosition()),
+		Status:          string(util.RosterStatusActive),
+		StartedAt:       time.Now(),
+	})
+	require.NoError(t, err)
+	_, err = testQueries.CreateRosterTransaction(context.Background(), CreateRosterTransactionParams{
+		Type:        string(util.RosterRelease),
+		UserID:      former.ID,
+		FromTeamID:  uuid.NullUUID{UUID: team.ID, Valid: true},
+		EffectiveAt: time.Now(),
+		CreatedBy:   former.ID,
+	})
+	require.NoError(t, err)
+
+	blockers, err = testQueries.GetTeamDeleteBlockers(context.Background(), team.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), blockers.StintCount)
+	require.Equal(t, int64(1), blockers.TransactionCount)
+}
+
 func TestQueries_AddUserToTeam(t *testing.T) {
 	user := createRandomUser(t)
 	team := createRandomTeam(t)
@@ -112,7 +205,7 @@ func TestQueries_ListUsersInTeam(t *testing.T) {
 		arg := AddTeamMemberParams{
 			UserID:          user.ID,
 			TeamID:          team.ID,
-			Number:          util.RandomInt(1, 99),
+			Number:          int64(i + 1),
 			PrimaryPosition: string(util.RandomBaseballPosition()),
 		}
 		userTeam, err := testQueries.AddTeamMember(context.Background(), arg)
@@ -147,6 +240,72 @@ func TestQueries_ListUsersInTeam(t *testing.T) {
 	}
 }
 
+func TestQueries_AddTeamMemberUnique(t *testing.T) {
+	team := createRandomTeam(t)
+	user := createRandomUser(t)
+
+	arg := AddTeamMemberParams{
+		UserID:          user.ID,
+		TeamID:          team.ID,
+		Number:          7,
+		PrimaryPosition: string(util.Pitcher),
+	}
+	_, err := testQueries.AddTeamMember(context.Background(), arg)
+	require.NoError(t, err)
+
+	arg.Number = 8
+	_, err = testQueries.AddTeamMember(context.Background(), arg)
+	require.Error(t, err)
+
+	arg.UserID = createRandomUser(t).ID
+	arg.Number = 7
+	_, err = testQueries.AddTeamMember(context.Background(), arg)
+	require.Error(t, err)
+}
+
+func TestQueries_UpdateTeamMember(t *testing.T) {
+	team := createRandomTeam(t)
+	user := createRandomUser(t)
+
+	member, err := testQueries.AddTeamMember(context.Background(), AddTeamMemberParams{
+		UserID:          user.ID,
+		TeamID:          team.ID,
+		Number:          7,
+		PrimaryPosition: string(util.Pitcher),
+	})
+	require.NoError(t, err)
+
+	updated, err := testQueries.UpdateTeamMember(context.Background(), UpdateTeamMemberParams{
+		Number: sql.NullInt64{Int64: 21, Valid: true},
+		TeamID: team.ID,
+		UserID: user.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, member.ID, updated.ID)
+	require.Equal(t, int64(21), updated.Number)
+	require.Equal(t, member.PrimaryPosition, updated.PrimaryPosition)
+}
+
+func TestQueries_RemoveTeamMember(t *testing.T) {
+	team := createRandomTeam(t)
+	user := createRandomUser(t)
+
+	member, err := testQueries.AddTeamMember(context.Background(), AddTeamMemberParams{
+		UserID:          user.ID,
+		TeamID:          team.ID,
+		Number:          7,
+		PrimaryPosition: string(util.Pitcher),
+	})
+	require.NoError(t, err)
+
+	removed, err := testQueries.RemoveTeamMember(context.Background(), RemoveTeamMemberParams{TeamID: team.ID, UserID: user.ID})
+	require.NoError(t, err)
+	require.Equal(t, member.ID, removed.ID)
+
+	_, err = testQueries.RemoveTeamMember(context.Background(), RemoveTeamMemberParams{TeamID: team.ID, UserID: user.ID})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
 func TestQueries_ListTeamsOfUser(t *testing.T) {
 	user := createRandomUser(t)
 	var teams []struct {
@@ -186,7 +345,49 @@ func TestQueries_ListTeamsOfUser(t *testing.T) {
 			if team.ID == u.team.ID {
 				require.Equal(t, team.ID, u.team.ID)
 				require.Equal(t, team.Name, u.team.Name)
+				require.Equal(t, u.teamMember.Number, team.Number)
+				require.Equal(t, "player", team.Role)
 			}
 		}
 	}
 }
+
+func TestQueries_ListTeamMembersTeammatesVisibility(t *testing.T) {
+	team := createRandomTeam(t)
+	other := createRandomTeam(t)
+	player := createRandomUser(t)
+	teammate := createRandomUser(t)
+	outsider := createRandomUser(t)
+
+	_, err := testQueries.UpdateUserPrivacy(context.Background(), UpdateUserPrivacyParams{
+		ID:                player.ID,
+		ProfileVisibility: sql.NullString{String: string(util.VisibilityTeammates), Valid: true},
+	})
+	require.NoError(t, err)
+
+	// the teammate shares the player's other team, which is enough to see them on any roster
+	for _, member := range []AddTeamMemberParams{
+		{UserID: player.ID, TeamID: team.ID, Number: 1},
+		{UserID: player.ID, TeamID: other.ID, Number: 1},
+		{UserID: teammate.ID, TeamID: other.ID, Number: 2},
+	} {
+		member.PrimaryPosition = string(util.RandomBaseballPosition())
+		_, err := testQueries.AddTeamMember(context.Background(), member)
+		require.NoError(t, err)
+	}
+
+	hidden := func(viewer User) bool {
+		members, err := testQueries.ListTeamMembers(context.Background(), ListTeamMembersParams{
+			TeamID:         team.ID,
+			Limit:          10,
+			ViewerID:       viewer.ID,
+			IncludePrivate: true,
+		})
+		require.NoError(t, err)
+		require.Len(t, members, 1)
+		return members[0].Hidden
+	}
+	require.False(t, hidden(player))
+	require.False(t, hidden(teammate))
+	require.True(t, hidden(outsider))
+}
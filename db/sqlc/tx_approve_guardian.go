@@ -0,0 +1,40 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+// ApproveGuardianTxParams contains the input parameters of the ApproveGuardian transaction
+type ApproveGuardianTxParams struct {
+	UpdateGuardianStatusParams UpdateGuardianStatusParams
+}
+
+// ApproveGuardianTxResult is the result of the ApproveGuardian transaction
+type ApproveGuardianTxResult struct {
+	Guardian Guardian
+	Player   User
+}
+
+// ApproveGuardianTx approves a pending guardian link and marks the linked player as a minor.
+// The guardian's delegated rights come from the approved links when the authorization policy is next loaded.
+func (store *SQLStore) ApproveGuardianTx(ctx context.Context, arg ApproveGuardianTxParams) (ApproveGuardianTxResult, error) {
+	var result ApproveGuardianTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Guardian, err = q.UpdateGuardianStatus(ctx, arg.UpdateGuardianStatusParams)
+		if err != nil {
+			return err
+		}
+
+		result.Player, err = q.UpdateUser(ctx, UpdateUserParams{
+			ID:      result.Guardian.PlayerID,
+			IsMinor: sql.NullBool{Bool: true, Valid: true},
+		})
+		return err
+	})
+
+	return result, err
+}
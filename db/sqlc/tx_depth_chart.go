@@ -0,0 +1,84 @@
+package db
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+// SetPlayerPositionsTxParams contains the input parameters of the SetPlayerPositions transaction
+type SetPlayerPositionsTxParams struct {
+	TeamID    uuid.UUID
+	UserID    uuid.UUID
+	Positions []string
+}
+
+// SetPlayerPositionsTx replaces every position a team member can play. Positions are ranked by their order.
+func (store *SQLStore) SetPlayerPositionsTx(ctx context.Context, arg SetPlayerPositionsTxParams) ([]PlayerPosition, error) {
+	result := []PlayerPosition{}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		err := q.DeletePlayerPositions(ctx, DeletePlayerPositionsParams{
+			TeamID: arg.TeamID,
+			UserID: arg.UserID,
+		})
+		if err != nil {
+			return err
+		}
+
+		for i, position := range arg.Positions {
+			playerPosition, err := q.CreatePlayerPosition(ctx, CreatePlayerPositionParams{
+				TeamID:   arg.TeamID,
+				UserID:   arg.UserID,
+				Position: position,
+				Rank:     int64(i + 1),
+			})
+			if err != nil {
+				return err
+			}
+			result = append(result, playerPosition)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// SetDepthChartTxParams contains the input parameters of the SetDepthChart transaction
+type SetDepthChartTxParams struct {
+	TeamID   uuid.UUID
+	Position string
+	UserIDs  []uuid.UUID
+}
+
+// SetDepthChartTx replaces the depth chart of a single position. Players are ranked by their order.
+func (store *SQLStore) SetDepthChartTx(ctx context.Context, arg SetDepthChartTxParams) ([]DepthChartEntry, error) {
+	result := []DepthChartEntry{}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		err := q.DeleteDepthChartPosition(ctx, DeleteDepthChartPositionParams{
+			TeamID:   arg.TeamID,
+			Position: arg.Position,
+		})
+		if err != nil {
+			return err
+		}
+
+		for i, userID := range arg.UserIDs {
+			entry, err := q.CreateDepthChartEntry(ctx, CreateDepthChartEntryParams{
+				TeamID:   arg.TeamID,
+				Position: arg.Position,
+				UserID:   userID,
+				Rank:     int64(i + 1),
+			})
+			if err != nil {
+				return err
+			}
+			result = append(result, entry)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
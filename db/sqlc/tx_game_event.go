@@ -0,0 +1,426 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+)
+
+// GameEventParams is an event appended to a game's event log
+type GameEventParams struct {
+	Type    string
+	Payload json.RawMessage
+}
+
+// PitchProjection is one pitch of a projected at-bat. PitcherID is who threw it, which may not be the
+// at-bat's pitcher when they were relieved during it.
+type PitchProjection struct {
+	Type      string
+	Balls     int64
+	Strikes   int64
+	PitcherID uuid.UUID
+	CreatedBy uuid.UUID
+}
+
+// RunnerProjection is one runner's movement during a projected at-bat. PitcherID is the pitcher charged
+// with the run when the runner scored.
+type RunnerProjection struct {
+	RunnerID  uuid.UUID
+	FromBase  int64
+	ToBase    int64
+	Out       bool
+	Reason    string
+	Scored    bool
+	RBI       bool
+	Earned    bool
+	PitcherID uuid.NullUUID
+}
+
+// StatProjection is a notable event during a projected at-bat, credited or charged to one participant
+type StatProjection struct {
+	Type          string
+	ParticipantID uuid.UUID
+}
+
+// AtbatProjection is a plate appearance as the scoring engine sees it. Result is empty while it is in progress.
+type AtbatProjection struct {
+	Number     int64
+	Inning     int64
+	Half       string
+	BatterID   uuid.UUID
+	PitcherID  uuid.UUID
+	Balls      int64
+	Strikes    int64
+	Result     string
+	Out        bool
+	InitBases  int64
+	TotalBases int64
+	// RunnerOnFirst, RunnerOnSecond and RunnerOnThird are who was on base when the batter came up
+	RunnerOnFirst  uuid.NullUUID
+	RunnerOnSecond uuid.NullUUID
+	RunnerOnThird  uuid.NullUUID
+	RBI            int64
+	Pitches        []PitchProjection
+	Runners        []RunnerProjection
+	Stats          []StatProjection
+}
+
+// GameProjection is the part of a game's derived state that changed with an event.
+// Every at-bat's inning must be among Innings.
+type GameProjection struct {
+	HomeScore int64
+	AwayScore int64
+	// LastInning and LastAtbat are the numbers of the game's last inning and at-bat. Rows after them
+	// are left over from undone or corrected events and are deleted.
+	LastInning int64
+	LastAtbat  int64
+	Innings    []ProjectInningParams
+	Atbats     []AtbatProjection
+	// Final is set when the events end the game. The game then goes from in_progress to FinalStatus,
+	// or final when it is empty, as GameStatusTx would move it, with FinalReason logged as the reason.
+	Final       bool
+	FinalStatus string
+	FinalReason string
+}
+
+// RecordGameEventTxParams contains the input parameters of the RecordGameEvent transaction
+type RecordGameEventTxParams struct {
+	GameID uuid.UUID
+	// Sequence is the position of the event in the log, one past the last event the projection was computed from
+	Sequence   int64
+	Event      GameEventParams
+	CreatedBy  uuid.UUID
+	Projection GameProjection
+}
+
+// RecordGameEventTxResult is the result of the RecordGameEvent transaction
+type RecordGameEventTxResult struct {
+	Event  GameEvent
+	Game   Game
+	Atbats []Atbat
+}
+
+// RecordGameEventTx appends an event to the log of a game in progress and writes the state it leads to
+// into the game, inning, atbat, pitches, atbat_runners and game_stat tables, moving the game to final when the
+// event ends it. It fails with sql.ErrNoRows if the game is not in progress, and with a unique violation if
+// another event already took the sequence.
+func (store *SQLStore) RecordGameEventTx(ctx context.Context, arg RecordGameEventTxParams) (RecordGameEventTxResult, error) {
+	var result RecordGameEventTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		_, err := q.LockGameInProgress(ctx, arg.GameID)
+		if err != nil {
+			return err
+		}
+
+		result.Event, err = q.CreateGameEvent(ctx, CreateGameEventParams{
+			GameID:    arg.GameID,
+			Sequence:  arg.Sequence,
+			Type:      arg.Event.Type,
+			Payload:   arg.Event.Payload,
+			CreatedBy: arg.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Game, result.Atbats, err = projectGame(ctx, q, arg.GameID, arg.Projection)
+		if err != nil {
+			return err
+		}
+		result.Game, err = finishGame(ctx, q, result.Game, arg.Projection, arg.CreatedBy)
+		return err
+	})
+
+	return result, err
+}
+
+// UndoGameEventsTxParams contains the input parameters of the UndoGameEvents transaction
+type UndoGameEventsTxParams struct {
+	GameID uuid.UUID
+	// FromSequence is the first event undone; it and every event after it are voided
+	FromSequence int64
+	// LastSequence is the last event in the log the projection was computed from
+	LastSequence int64
+	VoidedBy     uuid.UUID
+	Projection   GameProjection
+	// Substitutions are the changes made by the undone substitution events, latest first
+	Substitutions []UndoSubstitutionParams
+}
+
+// UndoSubstitutionParams takes back one change of a substitution: the participant who entered is
+// removed and the one they replaced is back in the game
+type UndoSubstitutionParams struct {
+	EnteredID  uuid.UUID
+	ReplacedID uuid.UUID
+}
+
+// UndoGameEventsTxResult is the result of the UndoGameEvents transaction
+type UndoGameEventsTxResult struct {
+	Events []GameEvent
+	Game   Game
+	Atbats []Atbat
+}
+
+// UndoGameEventsTx voids the last events of a game in progress and writes the state the rest lead to.
+// Voided events stay in the log with who undid them. Participants brought in by undone substitutions are
+// deleted once the projection no longer refers to them, and those they replaced go back in the game.
+// It fails with sql.ErrNoRows if the game is not in progress, another event was recorded after LastSequence,
+// or a substitution's participants are not as it left them.
+func (store *SQLStore) UndoGameEventsTx(ctx context.Context, arg UndoGameEventsTxParams) (UndoGameEventsTxResult, error) {
+	var result UndoGameEventsTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if err := lockEventLog(ctx, q, arg.GameID, arg.LastSequence); err != nil {
+			return err
+		}
+
+		var err error
+		result.Events, err = q.VoidGameEvents(ctx, VoidGameEventsParams{
+			VoidedBy:     arg.VoidedBy,
+			GameID:       arg.GameID,
+			FromSequence: arg.FromSequence,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Game, result.Atbats, err = projectGame(ctx, q, arg.GameID, arg.Projection)
+		if err != nil {
+			return err
+		}
+
+		for _, sub := range arg.Substitutions {
+			if _, err := q.DeleteGameParticipant(ctx, sub.EnteredID); err != nil {
+				return err
+			}
+			if _, err := q.ReenterGameParticipant(ctx, sub.ReplacedID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// CorrectGameEventTxParams contains the input parameters of the CorrectGameEvent transaction
+type CorrectGameEventTxParams struct {
+	GameID   uuid.UUID
+	Sequence int64
+	// LastSequence is the last event in the log the projection was computed from
+	LastSequence int64
+	Event        GameEventParams
+	CreatedBy    uuid.UUID
+	Projection   GameProjection
+}
+
+// CorrectGameEventTxResult is the result of the CorrectGameEvent transaction
+type CorrectGameEventTxResult struct {
+	Original GameEvent
+	Event    GameEvent
+	Game     Game
+	Atbats   []Atbat
+}
+
+// CorrectGameEventTx replaces an event of a game in progress and writes the state the corrected log
+// leads to, moving the game to final when the corrected log ends it. The original is voided and kept,
+// and the correction takes its place in the sequence.
+// It fails with sql.ErrNoRows if the game is not in progress or another event was recorded after LastSequence.
+func (store *SQLStore) CorrectGameEventTx(ctx context.Context, arg CorrectGameEventTxParams) (CorrectGameEventTxResult, error) {
+	var result CorrectGameEventTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if err := lockEventLog(ctx, q, arg.GameID, arg.LastSequence); err != nil {
+			return err
+		}
+
+		var err error
+		result.Original, err = q.VoidGameEvent(ctx, VoidGameEventParams{
+			VoidedBy: arg.CreatedBy,
+			GameID:   arg.GameID,
+			Sequence: arg.Sequence,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Event, err = q.CreateGameEvent(ctx, CreateGameEventParams{
+			GameID:     arg.GameID,
+			Sequence:   arg.Sequence,
+			Type:       arg.Event.Type,
+			Payload:    arg.Event.Payload,
+			CreatedBy:  arg.CreatedBy,
+			CorrectsID: uuid.NullUUID{UUID: result.Original.ID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Game, result.Atbats, err = projectGame(ctx, q, arg.GameID, arg.Projection)
+		if err != nil {
+			return err
+		}
+		result.Game, err = finishGame(ctx, q, result.Game, arg.Projection, arg.CreatedBy)
+		return err
+	})
+
+	return result, err
+}
+
+// lockEventLog locks a game in progress and checks that no event was recorded after lastSequence
+func lockEventLog(ctx context.Context, q *Queries, gameID uuid.UUID, lastSequence int64) error {
+	_, err := q.LockGameInProgress(ctx, gameID)
+	if err != nil {
+		return err
+	}
+
+	next, err := q.GetNextEventSequence(ctx, gameID)
+	if err != nil {
+		return err
+	}
+	if next != lastSequence+1 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// finishGame moves a game whose projection is final from in_progress to its final status, logging the change,
+// serving suspensions and notifying players as GameStatusTx does. Other games are returned as they are.
+func finishGame(ctx context.Context, q *Queries, game Game, p GameProjection, changedBy uuid.UUID) (Game, error) {
+	if !p.Final {
+		return game, nil
+	}
+	status := p.FinalStatus
+	if status == "" {
+		status = "final"
+	}
+	result, err := gameStatus(ctx, q, GameStatusTxParams{
+		GameID:     game.ID,
+		FromStatus: "in_progress",
+		ToStatus:   status,
+		Reason:     p.FinalReason,
+		ChangedBy:  changedBy,
+	})
+	return result.Game, err
+}
+
+// projectGame writes the score, innings and at-bats of a projection
+func projectGame(ctx context.Context, q *Queries, gameID uuid.UUID, p GameProjection) (Game, []Atbat, error) {
+	game, err := q.SetGameScore(ctx, SetGameScoreParams{
+		ID:        gameID,
+		HomeScore: p.HomeScore,
+		AwayScore: p.AwayScore,
+	})
+	if err != nil {
+		return game, nil, err
+	}
+
+	err = q.DeleteAtbatsAfter(ctx, DeleteAtbatsAfterParams{GameID: gameID, Number: p.LastAtbat})
+	if err != nil {
+		return game, nil, err
+	}
+	err = q.DeleteInningsAfter(ctx, DeleteInningsAfterParams{GameID: gameID, Number: p.LastInning})
+	if err != nil {
+		return game, nil, err
+	}
+
+	innings := make(map[int64]uuid.UUID, len(p.Innings))
+	for _, line := range p.Innings {
+		line.GameID = gameID
+		inning, err := q.ProjectInning(ctx, line)
+		if err != nil {
+			return game, nil, err
+		}
+		innings[inning.Number] = inning.ID
+	}
+
+	var atbats []Atbat
+	for _, pa := range p.Atbats {
+		inningID, ok := innings[pa.Inning]
+		if !ok {
+			return game, nil, fmt.Errorf("at-bat %d is in inning %d, which is not projected", pa.Number, pa.Inning)
+		}
+		atbat, err := q.ProjectAtbat(ctx, ProjectAtbatParams{
+			GameID:         gameID,
+			Number:         pa.Number,
+			InningID:       inningID,
+			Half:           pa.Half,
+			BatterID:       pa.BatterID,
+			PitcherID:      pa.PitcherID,
+			Balls:          pa.Balls,
+			Strikes:        pa.Strikes,
+			Pitches:        int64(len(pa.Pitches)),
+			Result:         sql.NullString{String: pa.Result, Valid: pa.Result != ""},
+			Out:            pa.Out,
+			InitBases:      pa.InitBases,
+			TotalBases:     pa.TotalBases,
+			RunnerOnFirst:  pa.RunnerOnFirst,
+			RunnerOnSecond: pa.RunnerOnSecond,
+			RunnerOnThird:  pa.RunnerOnThird,
+			Rbi:            pa.RBI,
+		})
+		if err != nil {
+			return game, nil, err
+		}
+		for i, pitch := range pa.Pitches {
+			_, err := q.ProjectPitch(ctx, ProjectPitchParams{
+				AtbatID:   atbat.ID,
+				Number:    int64(i + 1),
+				Type:      pitch.Type,
+				Balls:     pitch.Balls,
+				Strikes:   pitch.Strikes,
+				CreatedBy: pitch.CreatedBy,
+				PitcherID: pitch.PitcherID,
+			})
+			if err != nil {
+				return game, nil, err
+			}
+		}
+		err = q.DeletePitchesAfter(ctx, DeletePitchesAfterParams{AtbatID: atbat.ID, Number: int64(len(pa.Pitches))})
+		if err != nil {
+			return game, nil, err
+		}
+
+		// runner movement is rewritten whole, since a later event can change it
+		if err := q.DeleteAtbatRunners(ctx, atbat.ID); err != nil {
+			return game, nil, err
+		}
+		for i, runner := range pa.Runners {
+			_, err := q.CreateAtbatRunner(ctx, CreateAtbatRunnerParams{
+				AtbatID:   atbat.ID,
+				Number:    int64(i + 1),
+				RunnerID:  runner.RunnerID,
+				FromBase:  runner.FromBase,
+				ToBase:    runner.ToBase,
+				Out:       runner.Out,
+				Reason:    runner.Reason,
+				Scored:    runner.Scored,
+				Rbi:       runner.RBI,
+				Earned:    runner.Earned,
+				PitcherID: runner.PitcherID,
+			})
+			if err != nil {
+				return game, nil, err
+			}
+		}
+
+		if err := q.DeleteAtbatStats(ctx, atbat.ID); err != nil {
+			return game, nil, err
+		}
+		for _, stat := range pa.Stats {
+			_, err := q.CreateGameStat(ctx, CreateGameStatParams{
+				AtbatID:       atbat.ID,
+				Type:          stat.Type,
+				ParticipantID: stat.ParticipantID,
+			})
+			if err != nil {
+				return game, nil, err
+			}
+		}
+		atbats = append(atbats, atbat)
+	}
+	return game, atbats, nil
+}
@@ -0,0 +1,250 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestStore_RecordGameEventTx(t *testing.T) {
+	game := createRandomGame(t, nil, nil)
+	scorer := createRandomUser(t)
+
+	away, err := testStore.SetLineupTx(context.Background(), SetLineupTxParams{
+		GameID: game.ID,
+		Spots:  []LineupSpotParams{{PlayerID: createRandomUser(t).ID, BatPosition: 1, Position: string(util.Pitcher)}},
+	})
+	require.NoError(t, err)
+	home, err := testStore.SetLineupTx(context.Background(), SetLineupTxParams{
+		GameID:   game.ID,
+		HomeTeam: true,
+		Spots:    []LineupSpotParams{{PlayerID: createRandomUser(t).ID, BatPosition: 1, Position: string(util.Pitcher)}},
+	})
+	require.NoError(t, err)
+
+	atbat := AtbatProjection{
+		Number:    1,
+		Inning:    1,
+		Half:      string(util.HalfTop),
+		BatterID:  away.Participants[0].ID,
+		PitcherID: home.Participants[0].ID,
+		Balls:     1,
+		Pitches:   []PitchProjection{{Type: string(util.PitchBall), Balls: 1, CreatedBy: scorer.ID}},
+	}
+	arg := RecordGameEventTxParams{
+		GameID:    game.ID,
+		Sequence:  1,
+		Event:     GameEventParams{Type: "pitch", Payload: json.RawMessage(`{"type":"pitch","pitch":{"type":"ball"}}`)},
+		CreatedBy: scorer.ID,
+		Projection: GameProjection{
+			LastInning: 1,
+			LastAtbat:  1,
+			Innings:    []ProjectInningParams{{Number: 1}},
+			Atbats:     []AtbatProjection{atbat},
+		},
+	}
+
+	// events wait for the game to start
+	_, err = testStore.RecordGameEventTx(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	moveGame(t, game, util.GameScheduled, util.GameInProgress)
+	result, err := testStore.RecordGameEventTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.Event.Sequence)
+	require.Len(t, result.Atbats, 1)
+	require.Equal(t, int64(1), result.Atbats[0].Pitches)
+	require.False(t, result.Atbats[0].Result.Valid)
+
+	// a second event computed from the same log loses the race for the sequence
+	_, err = testStore.RecordGameEventTx(context.Background(), arg)
+	var pqErr *pq.Error
+	require.ErrorAs(t, err, &pqErr)
+	require.Equal(t, "unique_violation", pqErr.Code.Name())
+
+	// the next event rewrites the open at-bat in place
+	atbat.Balls = 0
+	atbat.Result = string(util.AtBatHitByPitch)
+	atbat.InitBases = 1
+	atbat.Pitches = append(atbat.Pitches, PitchProjection{Type: string(util.PitchHitByPitch), Balls: 1, PitcherID: home.Participants[0].ID, CreatedBy: scorer.ID})
+	atbat.Runners = []RunnerProjection{{RunnerID: atbat.BatterID, FromBase: 0, ToBase: 1, Reason: "hit_by_pitch"}}
+	arg.Sequence = 2
+	arg.Event.Payload = json.RawMessage(`{"type":"pitch","pitch":{"type":"hit_by_pitch"}}`)
+	arg.Projection.Atbats = []AtbatProjection{atbat}
+	result, err = testStore.RecordGameEventTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, string(util.AtBatHitByPitch), result.Atbats[0].Result.String)
+	require.True(t, result.Atbats[0].EndedAt.Valid)
+
+	atbats, err := testQueries.ListGameAtbats(context.Background(), game.ID)
+	require.NoError(t, err)
+	require.Len(t, atbats, 1)
+	require.Equal(t, int64(2), atbats[0].Pitches)
+
+	pitches, err := testQueries.ListPitches(context.Background(), atbats[0].ID)
+	require.NoError(t, err)
+	require.Len(t, pitches, 2)
+	require.Equal(t, string(util.PitchHitByPitch), pitches[1].Type)
+
+	runners, err := testQueries.ListAtbatRunners(context.Background(), atbats[0].ID)
+	require.NoError(t, err)
+	require.Len(t, runners, 1)
+	require.Equal(t, atbat.BatterID, runners[0].RunnerID)
+	require.Equal(t, int64(1), runners[0].ToBase)
+
+	gameRunners, err := testQueries.ListGameRunners(context.Background(), game.ID)
+	require.NoError(t, err)
+	require.Equal(t, runners, gameRunners)
+
+	stats, err := testQueries.ListGameStats(context.Background(), game.ID)
+	require.NoError(t, err)
+	require.Empty(t, stats)
+
+	events, err := testQueries.ListGameEvents(context.Background(), game.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	next, err := testQueries.GetNextEventSequence(context.Background(), game.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), next)
+
+	// an event that ends the game moves it to final with the change logged
+	arg.Sequence = 3
+	arg.Event = GameEventParams{Type: "game_end", Payload: json.RawMessage(`{"type":"game_end"}`)}
+	arg.Projection.Final = true
+	arg.Projection.FinalReason = "ended by the mercy rule"
+	result, err = testStore.RecordGameEventTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, string(util.GameFinal), result.Game.Status)
+
+	changes, err := testQueries.ListGameStatusChanges(context.Background(), game.ID)
+	require.NoError(t, err)
+	last := changes[len(changes)-1]
+	require.Equal(t, string(util.GameInProgress), last.FromStatus)
+	require.Equal(t, string(util.GameFinal), last.ToStatus)
+	require.Equal(t, "ended by the mercy rule", last.Reason)
+	require.Equal(t, scorer.ID, last.ChangedBy)
+
+	// a final game takes no more events
+	arg.Sequence = 4
+	_, err = testStore.RecordGameEventTx(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestStore_UndoAndCorrectGameEvents(t *testing.T) {
+	game := createRandomGame(t, nil, nil)
+	scorer := createRandomUser(t)
+
+	away, err := testStore.SetLineupTx(context.Background(), SetLineupTxParams{
+		GameID: game.ID,
+		Spots:  []LineupSpotParams{{PlayerID: createRandomUser(t).ID, BatPosition: 1, Position: string(util.Pitcher)}},
+	})
+	require.NoError(t, err)
+	home, err := testStore.SetLineupTx(context.Background(), SetLineupTxParams{
+		GameID:   game.ID,
+		HomeTeam: true,
+		Spots:    []LineupSpotParams{{PlayerID: createRandomUser(t).ID, BatPosition: 1, Position: string(util.Pitcher)}},
+	})
+	require.NoError(t, err)
+	moveGame(t, game, util.GameScheduled, util.GameInProgress)
+
+	// projection returns the game after the given pitches to the leadoff hitter
+	projection := func(pitches ...PitchProjection) GameProjection {
+		atbat := AtbatProjection{
+			Number:    1,
+			Inning:    1,
+			Half:      string(util.HalfTop),
+			BatterID:  away.Participants[0].ID,
+			PitcherID: home.Participants[0].ID,
+			Pitches:   pitches,
+		}
+		for _, pitch := range pitches {
+			atbat.Balls, atbat.Strikes = pitch.Balls, pitch.Strikes
+		}
+		return GameProjection{LastInning: 1, LastAtbat: 1, Innings: []ProjectInningParams{{Number: 1}}, Atbats: []AtbatProjection{atbat}}
+	}
+	ball := PitchProjection{Type: string(util.PitchBall), Balls: 1, PitcherID: home.Participants[0].ID, CreatedBy: scorer.ID}
+	twoBalls := PitchProjection{Type: string(util.PitchBall), Balls: 2, PitcherID: home.Participants[0].ID, CreatedBy: scorer.ID}
+	payload := json.RawMessage(`{"type":"pitch","pitch":{"type":"ball"}}`)
+
+	for i, pitches := range [][]PitchProjection{{ball}, {ball, twoBalls}} {
+		_, err := testStore.RecordGameEventTx(context.Background(), RecordGameEventTxParams{
+			GameID:     game.ID,
+			Sequence:   int64(i + 1),
+			Event:      GameEventParams{Type: "pitch", Payload: payload},
+			CreatedBy:  scorer.ID,
+			Projection: projection(pitches...),
+		})
+		require.NoError(t, err)
+	}
+
+	// undoing the second ball removes its pitch but keeps the event in the history
+	undo := UndoGameEventsTxParams{
+		GameID:       game.ID,
+		FromSequence: 2,
+		LastSequence: 2,
+		VoidedBy:     scorer.ID,
+		Projection:   projection(ball),
+	}
+	undone, err := testStore.UndoGameEventsTx(context.Background(), undo)
+	require.NoError(t, err)
+	require.Len(t, undone.Events, 1)
+	require.Equal(t, scorer.ID, undone.Events[0].VoidedBy.UUID)
+	require.True(t, undone.Events[0].VoidedAt.Valid)
+	require.Equal(t, int64(1), undone.Atbats[0].Pitches)
+
+	pitches, err := testQueries.ListPitches(context.Background(), undone.Atbats[0].ID)
+	require.NoError(t, err)
+	require.Len(t, pitches, 1)
+
+	next, err := testQueries.GetNextEventSequence(context.Background(), game.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), next)
+
+	// an undo computed from the log before the first undo finds it changed
+	_, err = testStore.UndoGameEventsTx(context.Background(), undo)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// the first pitch was a strike, not a ball
+	strike := PitchProjection{Type: string(util.PitchCalledStrike), Strikes: 1, PitcherID: home.Participants[0].ID, CreatedBy: scorer.ID}
+	corrected, err := testStore.CorrectGameEventTx(context.Background(), CorrectGameEventTxParams{
+		GameID:       game.ID,
+		Sequence:     1,
+		LastSequence: 1,
+		Event:        GameEventParams{Type: "pitch", Payload: json.RawMessage(`{"type":"pitch","pitch":{"type":"called_strike"}}`)},
+		CreatedBy:    scorer.ID,
+		Projection:   projection(strike),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), corrected.Event.Sequence)
+	require.Equal(t, corrected.Original.ID, corrected.Event.CorrectsID.UUID)
+	require.True(t, corrected.Original.VoidedAt.Valid)
+	require.Equal(t, int64(1), corrected.Atbats[0].Strikes)
+
+	events, err := testQueries.ListGameEvents(context.Background(), game.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, corrected.Event.ID, events[0].ID)
+
+	history, err := testQueries.ListGameEventHistory(context.Background(), game.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+
+	// undoing everything leaves no at-bats
+	_, err = testStore.UndoGameEventsTx(context.Background(), UndoGameEventsTxParams{
+		GameID:       game.ID,
+		FromSequence: 1,
+		LastSequence: 1,
+		VoidedBy:     scorer.ID,
+		Projection:   GameProjection{LastInning: 1, Innings: []ProjectInningParams{{Number: 1}}},
+	})
+	require.NoError(t, err)
+
+	atbats, err := testQueries.ListGameAtbats(context.Background(), game.ID)
+	require.NoError(t, err)
+	require.Empty(t, atbats)
+}
@@ -0,0 +1,197 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/google/uuid"
+	"time"
+)
+
+// ScheduleConflictError is returned when a game would start within the conflict window of another
+// game of either team or at its venue
+type ScheduleConflictError struct {
+	Conflicts []Game
+}
+
+func (e *ScheduleConflictError) Error() string {
+	return fmt.Sprintf("the game conflicts with %d other games", len(e.Conflicts))
+}
+
+// CreateGameTxParams contains the input parameters of the CreateGame transaction
+type CreateGameTxParams struct {
+	CreateGameParams CreateGameParams
+	// ConflictWindow is how close to the other games of its teams and venue a scheduled game may start
+	ConflictWindow time.Duration
+}
+
+// CreateGameTxResult is the result of the CreateGame transaction
+type CreateGameTxResult struct {
+	Game Game
+}
+
+// CreateGameTx creates a game. A game with a start time is checked against the other games of its teams
+// and venue as they stand when it is created, and fails with a *ScheduleConflictError if it conflicts.
+func (store *SQLStore) CreateGameTx(ctx context.Context, arg CreateGameTxParams) (CreateGameTxResult, error) {
+	var result CreateGameTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		game := arg.CreateGameParams
+		if game.ScheduledAt.Valid {
+			err := checkSchedule(ctx, q, ListScheduleConflictsParams{
+				WindowStart: game.ScheduledAt.Time.Add(-arg.ConflictWindow),
+				WindowEnd:   game.ScheduledAt.Time.Add(arg.ConflictWindow),
+				HomeTeamID:  game.HomeTeamID,
+				AwayTeamID:  game.AwayTeamID,
+				VenueID:     game.VenueID,
+				FieldID:     game.FieldID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		var err error
+		result.Game, err = q.CreateGame(ctx, game)
+		return err
+	})
+
+	return result, err
+}
+
+// checkSchedule locks the teams and venue a game is being scheduled for, so that other games being
+// scheduled for them wait until this transaction ends, then fails with a *ScheduleConflictError if
+// another of their games is within the window.
+func checkSchedule(ctx context.Context, q *Queries, arg ListScheduleConflictsParams) error {
+	err := q.LockScheduleTeams(ctx, LockScheduleTeamsParams{HomeTeamID: arg.HomeTeamID, AwayTeamID: arg.AwayTeamID})
+	if err != nil {
+		return err
+	}
+	if arg.VenueID.Valid {
+		if err := q.LockVenue(ctx, arg.VenueID.UUID); err != nil {
+			return err
+		}
+	}
+
+	conflicts, err := q.ListScheduleConflicts(ctx, arg)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		return &ScheduleConflictError{Conflicts: conflicts}
+	}
+	return nil
+}
+
+// RescheduleGameTxParams contains the input parameters of the RescheduleGame transaction
+type RescheduleGameTxParams struct {
+	Game        Game
+	ScheduledAt time.Time
+	TimeZone    sql.NullString
+	// VenueID and FieldID replace the game's venue and field; pass the game's own to keep them
+	VenueID   uuid.NullUUID
+	FieldID   uuid.NullUUID
+	Reason    string
+	ChangedBy uuid.UUID
+	// ConflictWindow is how close to the other games of its teams and venue the game may start,
+	// or 0 to move it without checking
+	ConflictWindow time.Duration
+}
+
+// RescheduleGameTxResult is the result of the RescheduleGame transaction
+type RescheduleGameTxResult struct {
+	Game   Game
+	Change GameScheduleChange
+	// StatusChange is set when a postponed game was put back on the schedule
+	StatusChange *GameStatusChange
+}
+
+// RescheduleGameTx moves a scheduled or postponed game to a new time, zone, venue or field and logs the change.
+// A postponed game goes back to scheduled. It fails with sql.ErrNoRows if the game has started or ended,
+// and with a *ScheduleConflictError if the new time conflicts with another game of its teams or venue.
+// Both teams' players and their guardians are notified of the new time.
+func (store *SQLStore) RescheduleGameTx(ctx context.Context, arg RescheduleGameTxParams) (RescheduleGameTxResult, error) {
+	var result RescheduleGameTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if arg.ConflictWindow > 0 {
+			err := checkSchedule(ctx, q, ListScheduleConflictsParams{
+				GameID:      arg.Game.ID,
+				WindowStart: arg.ScheduledAt.Add(-arg.ConflictWindow),
+				WindowEnd:   arg.ScheduledAt.Add(arg.ConflictWindow),
+				HomeTeamID:  arg.Game.HomeTeamID,
+				AwayTeamID:  arg.Game.AwayTeamID,
+				VenueID:     arg.VenueID,
+				FieldID:     arg.FieldID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		var err error
+
+		result.Game, err = q.UpdateGameSchedule(ctx, UpdateGameScheduleParams{
+			ScheduledAt: arg.ScheduledAt,
+			TimeZone:    arg.TimeZone,
+			VenueID:     arg.VenueID,
+			FieldID:     arg.FieldID,
+			ID:          arg.Game.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if result.Game.Status == "postponed" {
+			result.Game, err = q.UpdateGameStatus(ctx, UpdateGameStatusParams{
+				Status:     "scheduled",
+				ID:         arg.Game.ID,
+				FromStatus: "postponed",
+			})
+			if err != nil {
+				return err
+			}
+
+			change, err := q.CreateGameStatusChange(ctx, CreateGameStatusChangeParams{
+				GameID:     arg.Game.ID,
+				FromStatus: "postponed",
+				ToStatus:   "scheduled",
+				Reason:     arg.Reason,
+				ChangedBy:  arg.ChangedBy,
+			})
+			if err != nil {
+				return err
+			}
+			result.StatusChange = &change
+		}
+
+		result.Change, err = q.CreateGameScheduleChange(ctx, CreateGameScheduleChangeParams{
+			GameID:         arg.Game.ID,
+			OldScheduledAt: arg.Game.ScheduledAt,
+			NewScheduledAt: result.Game.ScheduledAt.Time,
+			OldTimeZone:    arg.Game.TimeZone,
+			NewTimeZone:    result.Game.TimeZone,
+			OldVenueID:     arg.Game.VenueID,
+			NewVenueID:     result.Game.VenueID,
+			OldFieldID:     arg.Game.FieldID,
+			NewFieldID:     result.Game.FieldID,
+			Reason:         arg.Reason,
+			ChangedBy:      arg.ChangedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		scheduledAt := result.Game.ScheduledAt.Time
+		if location, err := time.LoadLocation(result.Game.TimeZone); err == nil {
+			scheduledAt = scheduledAt.In(location)
+		}
+		return q.NotifyGamePlayers(ctx, NotifyGamePlayersParams{
+			Kind:    "game_rescheduled",
+			Message: gameNotice(fmt.Sprintf("the game was moved to %s", scheduledAt.Format("Mon Jan 2 2006 3:04 PM MST")), arg.Reason),
+			GameID:  arg.Game.ID,
+		})
+	})
+
+	return result, err
+}
@@ -0,0 +1,116 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestStore_RescheduleGameTx(t *testing.T) {
+	home := createRandomTeam(t)
+	game := createRandomGame(t, &home, nil)
+	coach := createRandomUser(t)
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Second)
+
+	result, err := testStore.RescheduleGameTx(context.Background(), RescheduleGameTxParams{
+		Game:        game,
+		ScheduledAt: start,
+		TimeZone:    sql.NullString{String: "America/Chicago", Valid: true},
+		Reason:      "rain",
+		ChangedBy:   coach.ID,
+	})
+	require.NoError(t, err)
+	require.WithinDuration(t, start, result.Game.ScheduledAt.Time, time.Second)
+	require.Equal(t, "America/Chicago", result.Game.TimeZone)
+	require.False(t, result.Change.OldScheduledAt.Valid)
+	require.Equal(t, "UTC", result.Change.OldTimeZone)
+	require.Nil(t, result.StatusChange)
+
+	conflicts, err := testQueries.ListScheduleConflicts(context.Background(), ListScheduleConflictsParams{
+		GameID:      createRandomGame(t, nil, nil).ID,
+		WindowStart: start.Add(-time.Hour),
+		WindowEnd:   start.Add(time.Hour),
+		HomeTeamID:  home.ID,
+		AwayTeamID:  home.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, conflicts, 1)
+	require.Equal(t, game.ID, conflicts[0].ID)
+
+	moveGame(t, result.Game, util.GameScheduled, util.GamePostponed)
+	result, err = testStore.RescheduleGameTx(context.Background(), RescheduleGameTxParams{
+		Game:        result.Game,
+		ScheduledAt: start.Add(24 * time.Hour),
+		ChangedBy:   coach.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, string(util.GameScheduled), result.Game.Status)
+	require.NotNil(t, result.StatusChange)
+	require.Equal(t, "America/Chicago", result.Game.TimeZone)
+
+	moveGame(t, result.Game, util.GameScheduled, util.GameInProgress)
+	_, err = testStore.RescheduleGameTx(context.Background(), RescheduleGameTxParams{
+		Game:        result.Game,
+		ScheduledAt: start,
+		ChangedBy:   coach.ID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	changes, err := testQueries.ListGameScheduleChanges(context.Background(), game.ID)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+}
+
+func TestStore_CreateGameTx(t *testing.T) {
+	home := createRandomTeam(t)
+	start := time.Now().Add(72 * time.Hour).Truncate(time.Second)
+	arg := func() CreateGameTxParams {
+		away := createRandomTeam(t)
+		return CreateGameTxParams{
+			CreateGameParams: CreateGameParams{
+				HomeTeamID:  home.ID,
+				AwayTeamID:  away.ID,
+				ScheduledAt: sql.NullTime{Time: start, Valid: true},
+				TimeZone:    "UTC",
+				ReentryRule: "none",
+				Rules:       json.RawMessage(`{}`),
+			},
+			ConflictWindow: 3 * time.Hour,
+		}
+	}
+
+	// both games book the home team at the same time; only one of them may be created
+	n := 2
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		params := arg()
+		go func() {
+			_, err := testStore.CreateGameTx(context.Background(), params)
+			errs <- err
+		}()
+	}
+
+	created := 0
+	for i := 0; i < n; i++ {
+		err := <-errs
+		if err == nil {
+			created++
+			continue
+		}
+		var conflict *ScheduleConflictError
+		require.True(t, errors.As(err, &conflict))
+		require.Len(t, conflict.Conflicts, 1)
+	}
+	require.Equal(t, 1, created)
+
+	later := arg()
+	later.CreateGameParams.ScheduledAt.Time = start.Add(4 * time.Hour)
+	result, err := testStore.CreateGameTx(context.Background(), later)
+	require.NoError(t, err)
+	require.Equal(t, home.ID, result.Game.HomeTeamID)
+}
@@ -0,0 +1,101 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"strings"
+)
+
+// GameStatusTxParams contains the input parameters of the GameStatus transaction
+type GameStatusTxParams struct {
+	GameID     uuid.UUID
+	FromStatus string
+	ToStatus   string
+	Reason     string
+	ChangedBy  uuid.UUID
+}
+
+// GameStatusTxResult is the result of the GameStatus transaction
+type GameStatusTxResult struct {
+	Game   Game
+	Change GameStatusChange
+	// Served holds the suspensions that counted this game, the first time it went final
+	Served []PlayerStatus
+}
+
+// GameStatusTx moves a game from one status to another and logs who made the change.
+// It fails with sql.ErrNoRows if the game is no longer in FromStatus.
+// The first time a game goes final, every game-count suspension on either team serves one game.
+// Both teams' players and their guardians are notified of the change.
+func (store *SQLStore) GameStatusTx(ctx context.Context, arg GameStatusTxParams) (GameStatusTxResult, error) {
+	var result GameStatusTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = gameStatus(ctx, q, arg)
+		return err
+	})
+
+	return result, err
+}
+
+// gameStatus changes a game's status using q, so other transactions can end a game.
+func gameStatus(ctx context.Context, q *Queries, arg GameStatusTxParams) (GameStatusTxResult, error) {
+	var result GameStatusTxResult
+	var err error
+
+	result.Game, err = q.UpdateGameStatus(ctx, UpdateGameStatusParams{
+		Status:     arg.ToStatus,
+		ID:         arg.GameID,
+		FromStatus: arg.FromStatus,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	if arg.ToStatus == "final" {
+		finished, err := q.HasGameReachedStatus(ctx, HasGameReachedStatusParams{
+			GameID:   arg.GameID,
+			ToStatus: arg.ToStatus,
+		})
+		if err != nil {
+			return result, err
+		}
+		if !finished {
+			for _, teamID := range []uuid.UUID{result.Game.HomeTeamID, result.Game.AwayTeamID} {
+				served, err := q.ServeSuspensionGame(ctx, teamID)
+				if err != nil {
+					return result, err
+				}
+				result.Served = append(result.Served, served...)
+			}
+		}
+	}
+
+	result.Change, err = q.CreateGameStatusChange(ctx, CreateGameStatusChangeParams{
+		GameID:     arg.GameID,
+		FromStatus: arg.FromStatus,
+		ToStatus:   arg.ToStatus,
+		Reason:     arg.Reason,
+		ChangedBy:  arg.ChangedBy,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	err = q.NotifyGamePlayers(ctx, NotifyGamePlayersParams{
+		Kind:    "game_status",
+		Message: gameNotice(fmt.Sprintf("the game is now %s", strings.ReplaceAll(arg.ToStatus, "_", " ")), arg.Reason),
+		GameID:  arg.GameID,
+	})
+	return result, err
+}
+
+// gameNotice is the message players are sent about a change to their game, with the reason if one was given
+func gameNotice(change string, reason string) string {
+	if reason == "" {
+		return change
+	}
+	return fmt.Sprintf("%s: %s", change, reason)
+}
@@ -0,0 +1,115 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func moveGame(t *testing.T, game Game, from, to util.GameStatus) GameStatusTxResult {
+	result, err := testStore.GameStatusTx(context.Background(), GameStatusTxParams{
+		GameID:     game.ID,
+		FromStatus: string(from),
+		ToStatus:   string(to),
+		ChangedBy:  createRandomUser(t).ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, string(to), result.Game.Status)
+	require.Equal(t, string(from), result.Change.FromStatus)
+	require.Equal(t, string(to), result.Change.ToStatus)
+	return result
+}
+
+func TestStore_GameStatusTx(t *testing.T) {
+	home := createRandomTeam(t)
+	game := createRandomGame(t, &home, nil)
+	require.Equal(t, string(util.GameScheduled), game.Status)
+
+	member := addRandomTeamMember(t, home, 1, util.Pitcher)
+	_, err := testQueries.CreatePlayerStatus(context.Background(), CreatePlayerStatusParams{
+		TeamID:         home.ID,
+		UserID:         member.UserID,
+		Status:         string(util.RosterStatusSuspended),
+		StartsAt:       time.Now().Add(-time.Hour),
+		GamesRemaining: sql.NullInt64{Int64: 2, Valid: true},
+		CreatedBy:      member.UserID,
+	})
+	require.NoError(t, err)
+
+	moveGame(t, game, util.GameScheduled, util.GameInProgress)
+
+	// a stale from status is rejected
+	_, err = testStore.GameStatusTx(context.Background(), GameStatusTxParams{
+		GameID:     game.ID,
+		FromStatus: string(util.GameScheduled),
+		ToStatus:   string(util.GamePostponed),
+		ChangedBy:  member.UserID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	result := moveGame(t, game, util.GameInProgress, util.GameFinal)
+	require.Len(t, result.Served, 1)
+	require.Equal(t, int64(1), result.Served[0].GamesRemaining.Int64)
+
+	_, err = testQueries.SetGameScore(context.Background(), SetGameScoreParams{ID: game.ID, HomeScore: 9, AwayScore: 0})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// reopening and finishing again does not serve the suspension twice
+	moveGame(t, game, util.GameFinal, util.GameInProgress)
+	result = moveGame(t, game, util.GameInProgress, util.GameFinal)
+	require.Empty(t, result.Served)
+
+	changes, err := testQueries.ListGameStatusChanges(context.Background(), game.ID)
+	require.NoError(t, err)
+	require.Len(t, changes, 4)
+}
+
+func TestStore_GameStatusTxNotifies(t *testing.T) {
+	home := createRandomTeam(t)
+	game := createRandomGame(t, &home, nil)
+
+	// the guardian gets a copy once approved
+	link, guardian, player := createRandomGuardian(t)
+	_, err := testQueries.UpdateGuardianStatus(context.Background(), UpdateGuardianStatusParams{
+		Status:     string(util.GuardianApproved),
+		GuardianID: link.GuardianID,
+		PlayerID:   link.PlayerID,
+	})
+	require.NoError(t, err)
+	_, err = testQueries.AddTeamMember(context.Background(), AddTeamMemberParams{
+		UserID:          player.ID,
+		TeamID:          home.ID,
+		Number:          1,
+		PrimaryPosition: string(util.Pitcher),
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.GameStatusTx(context.Background(), GameStatusTxParams{
+		GameID:     game.ID,
+		FromStatus: string(util.GameScheduled),
+		ToStatus:   string(util.GamePostponed),
+		Reason:     "rain",
+		ChangedBy:  player.ID,
+	})
+	require.NoError(t, err)
+
+	for _, userID := range []uuid.UUID{player.ID, guardian.ID} {
+		notifications, err := testQueries.ListNotifications(context.Background(), ListNotificationsParams{
+			UserID:     userID,
+			UnreadOnly: true,
+			PageLimit:  10,
+		})
+		require.NoError(t, err)
+		require.Len(t, notifications, 1)
+		require.Equal(t, player.ID, notifications[0].PlayerID)
+		require.Equal(t, "the game is now postponed: rain", notifications[0].Message)
+
+		read, err := testQueries.MarkNotificationRead(context.Background(), MarkNotificationReadParams{ID: notifications[0].ID, UserID: userID})
+		require.NoError(t, err)
+		require.True(t, read.ReadAt.Valid)
+	}
+}
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+// LineupSpotParams is one player's place in a lineup submitted to the SetLineup transaction
+type LineupSpotParams struct {
+	PlayerID    uuid.UUID
+	BatPosition int64
+	Position    string
+}
+
+// SetLineupTxParams contains the input parameters of the SetLineup transaction
+type SetLineupTxParams struct {
+	GameID   uuid.UUID
+	HomeTeam bool
+	Spots    []LineupSpotParams
+}
+
+// SetLineupTxResult is the result of the SetLineup transaction
+type SetLineupTxResult struct {
+	Participants []GameParticipant
+}
+
+// SetLineupTx replaces one side's lineup for a game. The game row is locked for the duration,
+// and the transaction fails with sql.ErrNoRows once the game is no longer scheduled or postponed.
+func (store *SQLStore) SetLineupTx(ctx context.Context, arg SetLineupTxParams) (SetLineupTxResult, error) {
+	var result SetLineupTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		_, err := q.LockGameForLineup(ctx, arg.GameID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteLineup(ctx, DeleteLineupParams{
+			GameID:   arg.GameID,
+			HomeTeam: arg.HomeTeam,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, spot := range arg.Spots {
+			participant, err := q.CreateGameParticipant(ctx, CreateGameParticipantParams{
+				ID:            uuid.New(),
+				GameID:        arg.GameID,
+				PlayerID:      spot.PlayerID,
+				HomeTeam:      arg.HomeTeam,
+				BatPosition:   spot.BatPosition,
+				Position:      spot.Position,
+				Entry:         "starter",
+				EnteredInning: 1,
+			})
+			if err != nil {
+				return err
+			}
+			result.Participants = append(result.Participants, participant)
+		}
+		return nil
+	})
+
+	return result, err
+}
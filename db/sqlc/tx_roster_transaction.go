@@ -0,0 +1,122 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// ErrMissingJersey is returned when a player joins a team without a number or position to carry over
+var ErrMissingJersey = errors.New("number and primary_position are required to join a team")
+
+// RosterTransactionTxParams contains the input parameters of the RosterTransaction transaction
+type RosterTransactionTxParams struct {
+	CreateRosterTransactionParams CreateRosterTransactionParams
+	// Number and PrimaryPosition are used when the player joins ToTeamID.
+	// When unset they carry over from the player's current membership.
+	Number          sql.NullInt64
+	PrimaryPosition sql.NullString
+	// Status is the status of the stint opened on ToTeamID. When unset it carries over from the stint closed on FromTeamID.
+	Status string
+}
+
+// RosterTransactionTxResult is the result of the RosterTransaction transaction
+type RosterTransactionTxResult struct {
+	Transaction RosterTransaction
+	// Member is the membership created on ToTeamID, or the one removed from FromTeamID when the player leaves.
+	Member TeamMember
+	// Stint is the stint opened on ToTeamID, or the one closed on FromTeamID when the player leaves.
+	Stint TeamMemberStint
+}
+
+// RosterTransactionTx moves a player off FromTeamID and onto ToTeamID at EffectiveAt and logs the move.
+// Leaving a team closes the open stint and removes the membership; joining one adds the membership and opens a stint.
+// When FromTeamID and ToTeamID are the same team only the stint is replaced, which is how status, number and position changes are recorded.
+func (store *SQLStore) RosterTransactionTx(ctx context.Context, arg RosterTransactionTxParams) (RosterTransactionTxResult, error) {
+	var result RosterTransactionTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = rosterTransaction(ctx, q, arg)
+		return err
+	})
+
+	return result, err
+}
+
+// rosterTransaction applies a roster transaction using q, so other transactions can sign or release players.
+func rosterTransaction(ctx context.Context, q *Queries, arg RosterTransactionTxParams) (RosterTransactionTxResult, error) {
+	var result RosterTransactionTxResult
+	var err error
+	tx := arg.CreateRosterTransactionParams
+	from, to := tx.FromTeamID, tx.ToTeamID
+	sameTeam := from.Valid && to.Valid && from.UUID == to.UUID
+
+	var current TeamMember
+	if from.Valid {
+		result.Stint, err = q.CloseTeamMemberStint(ctx, CloseTeamMemberStintParams{
+			EndedAt: tx.EffectiveAt,
+			TeamID:  from.UUID,
+			UserID:  tx.UserID,
+		})
+		if err != nil {
+			return result, err
+		}
+
+		if sameTeam {
+			current, err = q.GetTeamMember(ctx, GetTeamMemberParams{TeamID: from.UUID, UserID: tx.UserID})
+		} else {
+			current, err = q.RemoveTeamMember(ctx, RemoveTeamMemberParams{TeamID: from.UUID, UserID: tx.UserID})
+		}
+		if err != nil {
+			return result, err
+		}
+		result.Member = current
+	}
+
+	if to.Valid {
+		number, position := arg.Number, arg.PrimaryPosition
+		if from.Valid {
+			if !number.Valid {
+				number = sql.NullInt64{Int64: current.Number, Valid: true}
+			}
+			if !position.Valid {
+				position = sql.NullString{String: current.PrimaryPosition, Valid: true}
+			}
+		}
+		if !number.Valid || !position.Valid {
+			return result, ErrMissingJersey
+		}
+		status := arg.Status
+		if status == "" && from.Valid {
+			status = result.Stint.Status
+		}
+
+		if !sameTeam {
+			result.Member, err = q.AddTeamMember(ctx, AddTeamMemberParams{
+				UserID:          tx.UserID,
+				TeamID:          to.UUID,
+				Number:          number.Int64,
+				PrimaryPosition: position.String,
+			})
+			if err != nil {
+				return result, err
+			}
+		}
+
+		result.Stint, err = q.OpenTeamMemberStint(ctx, OpenTeamMemberStintParams{
+			TeamID:          to.UUID,
+			UserID:          tx.UserID,
+			Number:          number.Int64,
+			PrimaryPosition: position.String,
+			Status:          status,
+			StartedAt:       tx.EffectiveAt,
+		})
+		if err != nil {
+			return result, err
+		}
+	}
+
+	result.Transaction, err = q.CreateRosterTransaction(ctx, tx)
+	return result, err
+}
@@ -0,0 +1,135 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func rosterAsOf(t *testing.T, team Team, asOf time.Time) []ListRosterAsOfRow {
+	rows, err := testQueries.ListRosterAsOf(context.Background(), ListRosterAsOfParams{
+		TeamID:         team.ID,
+		AsOf:           asOf,
+		IncludePrivate: true,
+	})
+	require.NoError(t, err)
+	return rows
+}
+
+func TestQueries_RosterTransactionTx(t *testing.T) {
+	coach := createRandomUser(t)
+	player := createRandomUser(t)
+	oldTeam := createRandomTeam(t)
+	newTeam := createRandomTeam(t)
+
+	signedAt := time.Now().Add(-72 * time.Hour).UTC().Truncate(time.Second)
+	injuredAt := signedAt.Add(24 * time.Hour)
+	tradedAt := injuredAt.Add(24 * time.Hour)
+
+	signing, err := testStore.RosterTransactionTx(context.Background(), RosterTransactionTxParams{
+		CreateRosterTransactionParams: CreateRosterTransactionParams{
+			Type:        string(util.RosterSigning),
+			UserID:      player.ID,
+			ToTeamID:    uuid.NullUUID{UUID: oldTeam.ID, Valid: true},
+			EffectiveAt: signedAt,
+			CreatedBy:   coach.ID,
+		},
+		Number:          sql.NullInt64{Int64: 7, Valid: true},
+		PrimaryPosition: sql.NullString{String: string(util.ShortStop), Valid: true},
+		Status:          string(util.RosterStatusActive),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(7), signing.Member.Number)
+	require.Equal(t, signedAt, signing.Stint.StartedAt.UTC())
+
+	injury, err := testStore.RosterTransactionTx(context.Background(), RosterTransactionTxParams{
+		CreateRosterTransactionParams: CreateRosterTransactionParams{
+			Type:        string(util.RosterInjuredList),
+			UserID:      player.ID,
+			FromTeamID:  uuid.NullUUID{UUID: oldTeam.ID, Valid: true},
+			ToTeamID:    uuid.NullUUID{UUID: oldTeam.ID, Valid: true},
+			EffectiveAt: injuredAt,
+			CreatedBy:   coach.ID,
+		},
+		Status: string(util.RosterStatusInjured),
+	})
+	require.NoError(t, err)
+	require.Equal(t, string(util.RosterStatusInjured), injury.Stint.Status)
+	require.Equal(t, int64(7), injury.Stint.Number)
+
+	trade, err := testStore.RosterTransactionTx(context.Background(), RosterTransactionTxParams{
+		CreateRosterTransactionParams: CreateRosterTransactionParams{
+			Type:        string(util.RosterTrade),
+			UserID:      player.ID,
+			FromTeamID:  uuid.NullUUID{UUID: oldTeam.ID, Valid: true},
+			ToTeamID:    uuid.NullUUID{UUID: newTeam.ID, Valid: true},
+			EffectiveAt: tradedAt,
+			CreatedBy:   coach.ID,
+		},
+		Status: string(util.RosterStatusActive),
+	})
+	require.NoError(t, err)
+	require.Equal(t, newTeam.ID, trade.Member.TeamID)
+	require.Equal(t, int64(7), trade.Member.Number)
+
+	_, err = testQueries.GetTeamMember(context.Background(), GetTeamMemberParams{TeamID: oldTeam.ID, UserID: player.ID})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	roster := rosterAsOf(t, oldTeam, signedAt.Add(time.Hour))
+	require.Len(t, roster, 1)
+	require.Equal(t, string(util.RosterStatusActive), roster[0].Status)
+
+	roster = rosterAsOf(t, oldTeam, injuredAt.Add(time.Hour))
+	require.Len(t, roster, 1)
+	require.Equal(t, string(util.RosterStatusInjured), roster[0].Status)
+
+	require.Empty(t, rosterAsOf(t, oldTeam, tradedAt.Add(time.Hour)))
+	require.Empty(t, rosterAsOf(t, newTeam, injuredAt))
+	require.Len(t, rosterAsOf(t, newTeam, tradedAt.Add(time.Hour)), 1)
+
+	transactions, err := testQueries.ListRosterTransactions(context.Background(), ListRosterTransactionsParams{
+		TeamID: oldTeam.ID,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, transactions, 3)
+	require.Equal(t, string(util.RosterTrade), transactions[0].Type)
+}
+
+func TestQueries_RosterTransactionTxBeforeStint(t *testing.T) {
+	coach := createRandomUser(t)
+	player := createRandomUser(t)
+	team := createRandomTeam(t)
+
+	_, err := testStore.RosterTransactionTx(context.Background(), RosterTransactionTxParams{
+		CreateRosterTransactionParams: CreateRosterTransactionParams{
+			Type:        string(util.RosterSigning),
+			UserID:      player.ID,
+			ToTeamID:    uuid.NullUUID{UUID: team.ID, Valid: true},
+			EffectiveAt: time.Now(),
+			CreatedBy:   coach.ID,
+		},
+		Number:          sql.NullInt64{Int64: 3, Valid: true},
+		PrimaryPosition: sql.NullString{String: string(util.Pitcher), Valid: true},
+		Status:          string(util.RosterStatusActive),
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.RosterTransactionTx(context.Background(), RosterTransactionTxParams{
+		CreateRosterTransactionParams: CreateRosterTransactionParams{
+			Type:        string(util.RosterRelease),
+			UserID:      player.ID,
+			FromTeamID:  uuid.NullUUID{UUID: team.ID, Valid: true},
+			EffectiveAt: time.Now().Add(-time.Hour),
+			CreatedBy:   coach.ID,
+		},
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = testQueries.GetTeamMember(context.Background(), GetTeamMemberParams{TeamID: team.ID, UserID: player.ID})
+	require.NoError(t, err)
+}
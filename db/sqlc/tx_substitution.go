@@ -0,0 +1,99 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+)
+
+// SubstitutionStepParams ends one participant's stint and starts another in the Substitute transaction
+type SubstitutionStepParams struct {
+	// ID is the new participant's ID, chosen up front so Events can refer to it
+	ID          uuid.UUID
+	Replaces    uuid.UUID
+	PlayerID    uuid.UUID
+	Entry       string
+	BatPosition int64
+	Position    string
+}
+
+// SubstituteTxParams contains the input parameters of the Substitute transaction
+type SubstituteTxParams struct {
+	GameID   uuid.UUID
+	HomeTeam bool
+	Inning   int64
+	Steps    []SubstitutionStepParams
+	// Sequence is the position of Event in the log, one past the last event the projection was computed from
+	Sequence   int64
+	Event      GameEventParams
+	CreatedBy  uuid.UUID
+	Projection GameProjection
+}
+
+// SubstituteTxResult is the result of the Substitute transaction
+type SubstituteTxResult struct {
+	Exited  []GameParticipant
+	Entered []GameParticipant
+	Event   GameEvent
+	Game    Game
+	Atbats  []Atbat
+}
+
+// SubstituteTx applies substitutions to one side of a game in progress. Each step marks the
+// replaced participant as exited in the inning and adds a participant for the player coming in.
+// The event is appended to the game's log and the state it leads to is written as RecordGameEventTx does.
+// It fails with sql.ErrNoRows if the game is not in progress, another event was recorded after the one
+// the projection was computed from, or a replaced participant already left.
+func (store *SQLStore) SubstituteTx(ctx context.Context, arg SubstituteTxParams) (SubstituteTxResult, error) {
+	var result SubstituteTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if err := lockEventLog(ctx, q, arg.GameID, arg.Sequence-1); err != nil {
+			return err
+		}
+
+		for _, step := range arg.Steps {
+			exited, err := q.ExitGameParticipant(ctx, ExitGameParticipantParams{
+				ID:           step.Replaces,
+				ExitedInning: sql.NullInt64{Int64: arg.Inning, Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+			result.Exited = append(result.Exited, exited)
+
+			entered, err := q.CreateGameParticipant(ctx, CreateGameParticipantParams{
+				ID:            step.ID,
+				GameID:        arg.GameID,
+				PlayerID:      step.PlayerID,
+				HomeTeam:      arg.HomeTeam,
+				BatPosition:   step.BatPosition,
+				Position:      step.Position,
+				Entry:         step.Entry,
+				EnteredInning: arg.Inning,
+				ReplacedID:    uuid.NullUUID{UUID: exited.ID, Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+			result.Entered = append(result.Entered, entered)
+		}
+
+		var err error
+		result.Event, err = q.CreateGameEvent(ctx, CreateGameEventParams{
+			GameID:    arg.GameID,
+			Sequence:  arg.Sequence,
+			Type:      arg.Event.Type,
+			Payload:   arg.Event.Payload,
+			CreatedBy: arg.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Game, result.Atbats, err = projectGame(ctx, q, arg.GameID, arg.Projection)
+		return err
+	})
+
+	return result, err
+}
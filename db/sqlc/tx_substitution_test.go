@@ -0,0 +1,135 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestStore_SubstituteTx(t *testing.T) {
+	home := createRandomTeam(t)
+	game := createRandomGame(t, &home, nil)
+
+	starter := createRandomUser(t)
+	lineup, err := testStore.SetLineupTx(context.Background(), SetLineupTxParams{
+		GameID:   game.ID,
+		HomeTeam: true,
+		Spots:    []LineupSpotParams{{PlayerID: starter.ID, BatPosition: 1, Position: string(util.Pitcher)}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, string(util.EntryStarter), lineup.Participants[0].Entry)
+
+	reliever := createRandomUser(t)
+	arg := SubstituteTxParams{
+		GameID:   game.ID,
+		HomeTeam: true,
+		Inning:   4,
+		Steps: []SubstitutionStepParams{{
+			ID:          uuid.New(),
+			Replaces:    lineup.Participants[0].ID,
+			PlayerID:    reliever.ID,
+			Entry:       string(util.EntryPitchingChange),
+			BatPosition: 1,
+			Position:    string(util.Pitcher),
+		}},
+		Sequence:  1,
+		Event:     GameEventParams{Type: "substitution", Payload: json.RawMessage(`{"type":"substitution"}`)},
+		CreatedBy: starter.ID,
+	}
+
+	// substitutions wait for the game to start
+	_, err = testStore.SubstituteTx(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	moveGame(t, game, util.GameScheduled, util.GameInProgress)
+	result, err := testStore.SubstituteTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), result.Exited[0].ExitedInning.Int64)
+	require.Equal(t, int64(4), result.Entered[0].EnteredInning)
+	require.Equal(t, lineup.Participants[0].ID, result.Entered[0].ReplacedID.UUID)
+	require.Equal(t, arg.Steps[0].ID, result.Entered[0].ID)
+	require.Equal(t, int64(1), result.Event.Sequence)
+
+	// the log moved past the sequence the substitution was checked against
+	_, err = testStore.SubstituteTx(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// the replaced pitcher can only leave once
+	arg.Sequence = 2
+	arg.Steps[0].ID = uuid.New()
+	_, err = testStore.SubstituteTx(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	current, err := testQueries.ListLineup(context.Background(), ListLineupParams{GameID: game.ID, HomeTeam: true})
+	require.NoError(t, err)
+	require.Len(t, current, 1)
+	require.Equal(t, reliever.ID, current[0].PlayerID)
+
+	history, err := testQueries.ListGameParticipants(context.Background(), game.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, starter.ID, history[0].PlayerID)
+	require.True(t, history[0].ExitedInning.Valid)
+	require.Equal(t, reliever.ID, history[1].PlayerID)
+}
+
+func TestStore_UndoSubstitution(t *testing.T) {
+	home := createRandomTeam(t)
+	game := createRandomGame(t, &home, nil)
+
+	starter := createRandomUser(t)
+	lineup, err := testStore.SetLineupTx(context.Background(), SetLineupTxParams{
+		GameID:   game.ID,
+		HomeTeam: true,
+		Spots:    []LineupSpotParams{{PlayerID: starter.ID, BatPosition: 1, Position: string(util.Pitcher)}},
+	})
+	require.NoError(t, err)
+	moveGame(t, game, util.GameScheduled, util.GameInProgress)
+
+	reliever := createRandomUser(t)
+	sub, err := testStore.SubstituteTx(context.Background(), SubstituteTxParams{
+		GameID:   game.ID,
+		HomeTeam: true,
+		Inning:   1,
+		Steps: []SubstitutionStepParams{{
+			ID:          uuid.New(),
+			Replaces:    lineup.Participants[0].ID,
+			PlayerID:    reliever.ID,
+			Entry:       string(util.EntryPitchingChange),
+			BatPosition: 1,
+			Position:    string(util.Pitcher),
+		}},
+		Sequence:  1,
+		Event:     GameEventParams{Type: "substitution", Payload: json.RawMessage(`{"type":"substitution"}`)},
+		CreatedBy: starter.ID,
+	})
+	require.NoError(t, err)
+
+	arg := UndoGameEventsTxParams{
+		GameID:        game.ID,
+		FromSequence:  1,
+		LastSequence:  1,
+		VoidedBy:      starter.ID,
+		Projection:    GameProjection{LastInning: 1},
+		Substitutions: []UndoSubstitutionParams{{EnteredID: sub.Entered[0].ID, ReplacedID: lineup.Participants[0].ID}},
+	}
+	result, err := testStore.UndoGameEventsTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, result.Events, 1)
+
+	// the starter is back in and the reliever never entered
+	history, err := testQueries.ListGameParticipants(context.Background(), game.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.Equal(t, starter.ID, history[0].PlayerID)
+	require.False(t, history[0].ExitedInning.Valid)
+
+	// undoing it twice finds the reliever gone
+	arg.LastSequence = 0
+	_, err = testStore.UndoGameEventsTx(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,124 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"time"
+)
+
+// AcceptInvitationTxParams contains the input parameters of the AcceptInvitation transaction
+type AcceptInvitationTxParams struct {
+	Invitation TeamInvitation
+	UserID     uuid.UUID
+	// Number and PrimaryPosition are chosen by the player when the invitation is a join code.
+	Number          sql.NullInt64
+	PrimaryPosition sql.NullString
+}
+
+// AcceptInvitationTxResult is the result of the AcceptInvitation transaction
+type AcceptInvitationTxResult struct {
+	Invitation TeamInvitation
+	Member     TeamMember
+	Stint      TeamMemberStint
+}
+
+// AcceptInvitationTx signs the user to the invitation's team. An invitation sent to an email is marked
+// accepted and signs the user with the number and position it proposed. A join code has no email and
+// stays pending until it expires or is revoked, so every player it is shared with can use it, each
+// with the number and position they chose.
+func (store *SQLStore) AcceptInvitationTx(ctx context.Context, arg AcceptInvitationTxParams) (AcceptInvitationTxResult, error) {
+	var result AcceptInvitationTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		number, position, note := arg.Number, arg.PrimaryPosition, "used join code"
+		if arg.Invitation.Email.Valid {
+			result.Invitation, err = q.AcceptTeamInvitation(ctx, AcceptTeamInvitationParams{
+				ID:         arg.Invitation.ID,
+				AcceptedBy: arg.UserID,
+			})
+			number, position, note = result.Invitation.Number, result.Invitation.PrimaryPosition, "accepted invitation"
+		} else {
+			// holding the row keeps the code from being revoked until the player is signed
+			result.Invitation, err = q.UseTeamJoinCode(ctx, arg.Invitation.ID)
+		}
+		if err != nil {
+			return err
+		}
+
+		signing, err := rosterTransaction(ctx, q, RosterTransactionTxParams{
+			CreateRosterTransactionParams: CreateRosterTransactionParams{
+				Type:        "signing",
+				UserID:      arg.UserID,
+				ToTeamID:    uuid.NullUUID{UUID: result.Invitation.TeamID, Valid: true},
+				EffectiveAt: time.Now(),
+				Note:        note,
+				CreatedBy:   arg.UserID,
+			},
+			Number:          number,
+			PrimaryPosition: position,
+			Status:          "active",
+		})
+		result.Member, result.Stint = signing.Member, signing.Stint
+		return err
+	})
+
+	return result, err
+}
+
+// ApproveJoinRequestTxParams contains the input parameters of the ApproveJoinRequest transaction
+type ApproveJoinRequestTxParams struct {
+	DecideJoinRequestParams DecideJoinRequestParams
+	// Number and PrimaryPosition override what the player asked for when set.
+	Number          sql.NullInt64
+	PrimaryPosition sql.NullString
+}
+
+// ApproveJoinRequestTxResult is the result of the ApproveJoinRequest transaction
+type ApproveJoinRequestTxResult struct {
+	JoinRequest JoinRequest
+	Member      TeamMember
+	Stint       TeamMemberStint
+}
+
+// ApproveJoinRequestTx approves a pending join request and signs the player to the team.
+func (store *SQLStore) ApproveJoinRequestTx(ctx context.Context, arg ApproveJoinRequestTxParams) (ApproveJoinRequestTxResult, error) {
+	var result ApproveJoinRequestTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.JoinRequest, err = q.DecideJoinRequest(ctx, arg.DecideJoinRequestParams)
+		if err != nil {
+			return err
+		}
+
+		number, position := arg.Number, arg.PrimaryPosition
+		if !number.Valid {
+			number = sql.NullInt64{Int64: result.JoinRequest.Number, Valid: true}
+		}
+		if !position.Valid {
+			position = sql.NullString{String: result.JoinRequest.PrimaryPosition, Valid: true}
+		}
+
+		signing, err := rosterTransaction(ctx, q, RosterTransactionTxParams{
+			CreateRosterTransactionParams: CreateRosterTransactionParams{
+				Type:        "signing",
+				UserID:      result.JoinRequest.UserID,
+				ToTeamID:    uuid.NullUUID{UUID: result.JoinRequest.TeamID, Valid: true},
+				EffectiveAt: time.Now(),
+				Note:        "approved join request",
+				CreatedBy:   arg.DecideJoinRequestParams.DecidedBy,
+			},
+			Number:          number,
+			PrimaryPosition: position,
+			Status:          "active",
+		})
+		result.Member, result.Stint = signing.Member, signing.Stint
+		return err
+	})
+
+	return result, err
+}
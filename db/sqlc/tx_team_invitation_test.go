@@ -0,0 +1,151 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/kwalter26/scoreit-api-go/security"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func createRandomInvitation(t *testing.T, team Team, coach User, email string) TeamInvitation {
+	code, err := security.NewJoinCode()
+	require.NoError(t, err)
+
+	arg := CreateTeamInvitationParams{
+		TeamID:    team.ID,
+		Code:      code,
+		InvitedBy: coach.ID,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	if email != "" {
+		arg.Email = sql.NullString{String: email, Valid: true}
+		arg.Number = sql.NullInt64{Int64: 9, Valid: true}
+		arg.PrimaryPosition = sql.NullString{String: string(util.LeftField), Valid: true}
+	}
+
+	invitation, err := testQueries.CreateTeamInvitation(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, string(util.InvitationPending), invitation.Status)
+	return invitation
+}
+
+func TestQueries_AcceptInvitationTx(t *testing.T) {
+	coach := createRandomUser(t)
+	player := createRandomUser(t)
+	team := createRandomTeam(t)
+	invitation := createRandomInvitation(t, team, coach, player.Email)
+
+	result, err := testStore.AcceptInvitationTx(context.Background(), AcceptInvitationTxParams{
+		Invitation: invitation,
+		UserID:     player.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, string(util.InvitationAccepted), result.Invitation.Status)
+	require.Equal(t, player.ID, result.Invitation.AcceptedBy.UUID)
+	require.Equal(t, invitation.Number.Int64, result.Member.Number)
+	require.Equal(t, invitation.PrimaryPosition.String, result.Member.PrimaryPosition)
+
+	_, err = testStore.AcceptInvitationTx(context.Background(), AcceptInvitationTxParams{
+		Invitation: invitation,
+		UserID:     createRandomUser(t).ID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestQueries_UseJoinCode(t *testing.T) {
+	coach := createRandomUser(t)
+	team := createRandomTeam(t)
+	code := createRandomInvitation(t, team, coach, "")
+
+	join := func(number int64) (AcceptInvitationTxResult, error) {
+		return testStore.AcceptInvitationTx(context.Background(), AcceptInvitationTxParams{
+			Invitation:      code,
+			UserID:          createRandomUser(t).ID,
+			Number:          sql.NullInt64{Int64: number, Valid: true},
+			PrimaryPosition: sql.NullString{String: string(util.ShortStop), Valid: true},
+		})
+	}
+
+	// every player holding the code can use it, each with their own number
+	for _, number := range []int64{4, 6} {
+		result, err := join(number)
+		require.NoError(t, err)
+		require.Equal(t, string(util.InvitationPending), result.Invitation.Status)
+		require.Equal(t, number, result.Member.Number)
+		require.Equal(t, string(util.ShortStop), result.Member.PrimaryPosition)
+	}
+
+	_, err := join(4)
+	require.Error(t, err)
+
+	_, err = testQueries.RevokeTeamInvitation(context.Background(), RevokeTeamInvitationParams{ID: code.ID, TeamID: team.ID})
+	require.NoError(t, err)
+	_, err = join(8)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestQueries_RevokeTeamInvitation(t *testing.T) {
+	coach := createRandomUser(t)
+	team := createRandomTeam(t)
+	invitation := createRandomInvitation(t, team, coach, "")
+
+	revoked, err := testQueries.RevokeTeamInvitation(context.Background(), RevokeTeamInvitationParams{
+		ID:     invitation.ID,
+		TeamID: team.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, string(util.InvitationRevoked), revoked.Status)
+
+	_, err = testStore.AcceptInvitationTx(context.Background(), AcceptInvitationTxParams{
+		Invitation: invitation,
+		UserID:     createRandomUser(t).ID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestQueries_ApproveJoinRequestTx(t *testing.T) {
+	coach := createRandomUser(t)
+	player := createRandomUser(t)
+	team := createRandomTeam(t)
+
+	joinRequest, err := testQueries.CreateJoinRequest(context.Background(), CreateJoinRequestParams{
+		TeamID:          team.ID,
+		UserID:          player.ID,
+		Number:          21,
+		PrimaryPosition: string(util.RightField),
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.CreateJoinRequest(context.Background(), CreateJoinRequestParams{
+		TeamID:          team.ID,
+		UserID:          player.ID,
+		Number:          22,
+		PrimaryPosition: string(util.RightField),
+	})
+	require.Error(t, err)
+
+	result, err := testStore.ApproveJoinRequestTx(context.Background(), ApproveJoinRequestTxParams{
+		DecideJoinRequestParams: DecideJoinRequestParams{
+			Status:    string(util.JoinRequestApproved),
+			DecidedBy: coach.ID,
+			ID:        joinRequest.ID,
+			TeamID:    team.ID,
+		},
+		Number: sql.NullInt64{Int64: 31, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, string(util.JoinRequestApproved), result.JoinRequest.Status)
+	require.Equal(t, int64(31), result.Member.Number)
+	require.Equal(t, string(util.RightField), result.Member.PrimaryPosition)
+
+	pending, err := testQueries.ListJoinRequests(context.Background(), ListJoinRequestsParams{
+		TeamID: team.ID,
+		Status: string(util.JoinRequestPending),
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, pending)
+}
@@ -0,0 +1,57 @@
+package db
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+// UpdateTeamMemberTxParams contains the input parameters of the UpdateTeamMember transaction
+type UpdateTeamMemberTxParams struct {
+	UpdateTeamMemberParams UpdateTeamMemberParams
+	ChangedBy              uuid.UUID
+}
+
+// UpdateTeamMemberTxResult is the result of the UpdateTeamMember transaction
+type UpdateTeamMemberTxResult struct {
+	Member TeamMember
+	// Transaction and Stint are the member_update logged and the stint opened when the number or position changed
+	Transaction RosterTransaction
+	Stint       TeamMemberStint
+}
+
+// UpdateTeamMemberTx changes a member's number, primary position or team role. A new number or position
+// replaces the member's open stint as a member_update roster transaction, so the roster history keeps
+// the number and position each player had; a role change alone leaves the history as it is.
+func (store *SQLStore) UpdateTeamMemberTx(ctx context.Context, arg UpdateTeamMemberTxParams) (UpdateTeamMemberTxResult, error) {
+	var result UpdateTeamMemberTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		update := arg.UpdateTeamMemberParams
+		if update.Number.Valid || update.PrimaryPosition.Valid {
+			team := uuid.NullUUID{UUID: update.TeamID, Valid: true}
+			change, err := rosterTransaction(ctx, q, RosterTransactionTxParams{
+				CreateRosterTransactionParams: CreateRosterTransactionParams{
+					Type:        "member_update",
+					UserID:      update.UserID,
+					FromTeamID:  team,
+					ToTeamID:    team,
+					EffectiveAt: time.Now(),
+					CreatedBy:   arg.ChangedBy,
+				},
+				Number:          update.Number,
+				PrimaryPosition: update.PrimaryPosition,
+			})
+			if err != nil {
+				return err
+			}
+			result.Transaction, result.Stint = change.Transaction, change.Stint
+		}
+
+		result.Member, err = q.UpdateTeamMember(ctx, update)
+		return err
+	})
+
+	return result, err
+}
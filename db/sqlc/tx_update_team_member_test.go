@@ -0,0 +1,65 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestQueries_UpdateTeamMemberTx(t *testing.T) {
+	coach := createRandomUser(t)
+	player := createRandomUser(t)
+	team := createRandomTeam(t)
+
+	signedAt := time.Now().Add(-24 * time.Hour).UTC().Truncate(time.Second)
+	_, err := testStore.RosterTransactionTx(context.Background(), RosterTransactionTxParams{
+		CreateRosterTransactionParams: CreateRosterTransactionParams{
+			Type:        string(util.RosterSigning),
+			UserID:      player.ID,
+			ToTeamID:    uuid.NullUUID{UUID: team.ID, Valid: true},
+			EffectiveAt: signedAt,
+			CreatedBy:   coach.ID,
+		},
+		Number:          sql.NullInt64{Int64: 7, Valid: true},
+		PrimaryPosition: sql.NullString{String: string(util.ShortStop), Valid: true},
+		Status:          string(util.RosterStatusInjured),
+	})
+	require.NoError(t, err)
+
+	result, err := testStore.UpdateTeamMemberTx(context.Background(), UpdateTeamMemberTxParams{
+		UpdateTeamMemberParams: UpdateTeamMemberParams{
+			Number: sql.NullInt64{Int64: 21, Valid: true},
+			TeamID: team.ID,
+			UserID: player.ID,
+		},
+		ChangedBy: coach.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(21), result.Member.Number)
+	require.Equal(t, string(util.ShortStop), result.Member.PrimaryPosition)
+	require.Equal(t, string(util.RosterMemberUpdate), result.Transaction.Type)
+	require.Equal(t, int64(21), result.Stint.Number)
+	require.Equal(t, string(util.RosterStatusInjured), result.Stint.Status)
+
+	// The roster as of the signing still shows the old number.
+	rows := rosterAsOf(t, team, signedAt)
+	require.Len(t, rows, 1)
+	require.Equal(t, int64(7), rows[0].Number)
+
+	// A role change alone does not touch the roster history.
+	result, err = testStore.UpdateTeamMemberTx(context.Background(), UpdateTeamMemberTxParams{
+		UpdateTeamMemberParams: UpdateTeamMemberParams{
+			Role:   sql.NullString{String: string(util.TeamRoleCoach), Valid: true},
+			TeamID: team.ID,
+			UserID: player.ID,
+		},
+		ChangedBy: coach.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, string(util.TeamRoleCoach), result.Member.Role)
+	require.Empty(t, result.Transaction.ID)
+}
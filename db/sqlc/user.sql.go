@@ -12,10 +12,30 @@ import (
 	"github.com/google/uuid"
 )
 
+const areTeammates = `-- name: AreTeammates :one
+SELECT EXISTS(SELECT 1
+              FROM team_members a
+                       JOIN team_members b ON a.team_id = b.team_id
+              WHERE a.user_id = $1
+                AND b.user_id = $2) AS are_teammates
+`
+
+type AreTeammatesParams struct {
+	UserID   uuid.UUID `json:"user_id"`
+	ViewerID uuid.UUID `json:"viewer_id"`
+}
+
+func (q *Queries) AreTeammates(ctx context.Context, arg AreTeammatesParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, areTeammates, arg.UserID, arg.ViewerID)
+	var are_teammates bool
+	err := row.Scan(&are_teammates)
+	return are_teammates, err
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (username, first_name, last_name, email, hashed_password)
 VALUES ($1, $2, $3, $4, $5)
-RETURNING id, username, first_name, last_name, email, is_email_verified, hashed_password, password_changed_at, created_at, updated_at
+RETURNING id, username, first_name, last_name, email, is_email_verified, hashed_password, password_changed_at, created_at, updated_at, is_minor, profile_visibility, email_visibility, stat_visibility
 `
 
 type CreateUserParams struct {
@@ -46,6 +66,10 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.IsMinor,
+		&i.ProfileVisibility,
+		&i.EmailVisibility,
+		&i.StatVisibility,
 	)
 	return i, err
 }
@@ -62,7 +86,7 @@ func (q *Queries) DeleteUser(ctx context.Context, id uuid.UUID) error {
 }
 
 const getUser = `-- name: GetUser :one
-SELECT id, username, first_name, last_name, email, is_email_verified, hashed_password, password_changed_at, created_at, updated_at
+SELECT id, username, first_name, last_name, email, is_email_verified, hashed_password, password_changed_at, created_at, updated_at, is_minor, profile_visibility, email_visibility, stat_visibility
 FROM users
 WHERE id = $1
 LIMIT 1
@@ -82,12 +106,16 @@ func (q *Queries) GetUser(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.IsMinor,
+		&i.ProfileVisibility,
+		&i.EmailVisibility,
+		&i.StatVisibility,
 	)
 	return i, err
 }
 
 const getUserByUsername = `-- name: GetUserByUsername :one
-SELECT id, username, first_name, last_name, email, is_email_verified, hashed_password, password_changed_at, created_at, updated_at
+SELECT id, username, first_name, last_name, email, is_email_verified, hashed_password, password_changed_at, created_at, updated_at, is_minor, profile_visibility, email_visibility, stat_visibility
 FROM users
 WHERE username = $1
 LIMIT 1
@@ -107,20 +135,28 @@ func (q *Queries) GetUserByUsername(ctx context.Context, username string) (User,
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.IsMinor,
+		&i.ProfileVisibility,
+		&i.EmailVisibility,
+		&i.StatVisibility,
 	)
 	return i, err
 }
 
 const listUsers = `-- name: ListUsers :many
-SELECT u.id, u.username, u.first_name, u.last_name
+SELECT u.id, u.username, u.first_name, u.last_name, (NOT profile_visible($3::uuid, u.id))::boolean AS hidden
 FROM users u
+WHERE $4::boolean
+   OR profile_visible($3::uuid, u.id)
 ORDER BY u.username
 LIMIT $1 OFFSET $2
 `
 
 type ListUsersParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	Limit          int32     `json:"limit"`
+	Offset         int32     `json:"offset"`
+	ViewerID       uuid.UUID `json:"viewer_id"`
+	IncludePrivate bool      `json:"include_private"`
 }
 
 type ListUsersRow struct {
@@ -128,10 +164,16 @@ type ListUsersRow struct {
 	Username  string    `json:"username"`
 	FirstName string    `json:"first_name"`
 	LastName  string    `json:"last_name"`
+	Hidden    bool      `json:"hidden"`
 }
 
 func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]ListUsersRow, error) {
-	rows, err := q.db.QueryContext(ctx, listUsers, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listUsers,
+		arg.Limit,
+		arg.Offset,
+		arg.ViewerID,
+		arg.IncludePrivate,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -144,6 +186,7 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]ListUse
 			&i.Username,
 			&i.FirstName,
 			&i.LastName,
+			&i.Hidden,
 		); err != nil {
 			return nil, err
 		}
@@ -166,9 +209,10 @@ SET username          = COALESCE($1, username),
     email             = COALESCE($4, email),
     is_email_verified = COALESCE($5, is_email_verified),
     hashed_password   = COALESCE($6, hashed_password),
+    is_minor          = COALESCE($7, is_minor),
     updated_at        = now()
-WHERE id = $7
-RETURNING id, username, first_name, last_name, email, is_email_verified, hashed_password, password_changed_at, created_at, updated_at
+WHERE id = $8
+RETURNING id, username, first_name, last_name, email, is_email_verified, hashed_password, password_changed_at, created_at, updated_at, is_minor, profile_visibility, email_visibility, stat_visibility
 `
 
 type UpdateUserParams struct {
@@ -178,6 +222,7 @@ type UpdateUserParams struct {
 	Email           sql.NullString `json:"email"`
 	IsEmailVerified sql.NullBool   `json:"is_email_verified"`
 	HashedPassword  sql.NullString `json:"hashed_password"`
+	IsMinor         sql.NullBool   `json:"is_minor"`
 	ID              uuid.UUID      `json:"id"`
 }
 
@@ -189,6 +234,51 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		arg.Email,
 		arg.IsEmailVerified,
 		arg.HashedPassword,
+		arg.IsMinor,
+		arg.ID,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.IsEmailVerified,
+		&i.HashedPassword,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsMinor,
+		&i.ProfileVisibility,
+		&i.EmailVisibility,
+		&i.StatVisibility,
+	)
+	return i, err
+}
+
+const updateUserPrivacy = `-- name: UpdateUserPrivacy :one
+UPDATE users
+SET profile_visibility = COALESCE($1, profile_visibility),
+    email_visibility   = COALESCE($2, email_visibility),
+    stat_visibility    = COALESCE($3, stat_visibility),
+    updated_at         = now()
+WHERE id = $4
+RETURNING id, username, first_name, last_name, email, is_email_verified, hashed_password, password_changed_at, created_at, updated_at, is_minor, profile_visibility, email_visibility, stat_visibility
+`
+
+type UpdateUserPrivacyParams struct {
+	ProfileVisibility sql.NullString `json:"profile_visibility"`
+	EmailVisibility   sql.NullString `json:"email_visibility"`
+	StatVisibility    sql.NullString `json:"stat_visibility"`
+	ID                uuid.UUID      `json:"id"`
+}
+
+func (q *Queries) UpdateUserPrivacy(ctx context.Context, arg UpdateUserPrivacyParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserPrivacy,
+		arg.ProfileVisibility,
+		arg.EmailVisibility,
+		arg.StatVisibility,
 		arg.ID,
 	)
 	var i User
@@ -203,6 +293,10 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.IsMinor,
+		&i.ProfileVisibility,
+		&i.EmailVisibility,
+		&i.StatVisibility,
 	)
 	return i, err
 }
@@ -98,15 +98,84 @@ func TestQueriesListUsers(t *testing.T) {
 	}
 }
 
-func TestQueriesUpdateGame(t *testing.T) {
+func TestQueriesUpdateUserPrivacy(t *testing.T) {
+	user := createRandomUser(t)
+	require.Equal(t, string(util.VisibilityPublic), user.ProfileVisibility)
+	require.Equal(t, string(util.VisibilityPrivate), user.EmailVisibility)
+	require.Equal(t, string(util.VisibilityPublic), user.StatVisibility)
+
+	updated, err := testQueries.UpdateUserPrivacy(context.Background(), UpdateUserPrivacyParams{
+		ID:                user.ID,
+		ProfileVisibility: sql.NullString{String: string(util.VisibilityTeammates), Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, string(util.VisibilityTeammates), updated.ProfileVisibility)
+	require.Equal(t, user.EmailVisibility, updated.EmailVisibility)
+	require.Equal(t, user.StatVisibility, updated.StatVisibility)
+}
+
+func TestQueriesListUsersHidesPrivate(t *testing.T) {
+	viewer := createRandomUser(t)
+	hidden := createRandomUser(t)
+	_, err := testQueries.UpdateUserPrivacy(context.Background(), UpdateUserPrivacyParams{
+		ID:                hidden.ID,
+		ProfileVisibility: sql.NullString{String: string(util.VisibilityPrivate), Valid: true},
+	})
+	require.NoError(t, err)
+
+	contains := func(includePrivate bool) bool {
+		users, err := testQueries.ListUsers(context.Background(), ListUsersParams{
+			Limit:          1000000,
+			Offset:         0,
+			IncludePrivate: includePrivate,
+			ViewerID:       viewer.ID,
+		})
+		require.NoError(t, err)
+		for _, u := range users {
+			if u.ID == hidden.ID {
+				return true
+			}
+		}
+		return false
+	}
+	require.False(t, contains(false))
+	require.True(t, contains(true))
+}
+
+func TestQueriesAreTeammates(t *testing.T) {
+	team := createRandomTeam(t)
+	user1 := createRandomUser(t)
+	user2 := createRandomUser(t)
+	outsider := createRandomUser(t)
+
+	for i, user := range []User{user1, user2} {
+		_, err := testQueries.AddTeamMember(context.Background(), AddTeamMemberParams{
+			UserID:          user.ID,
+			TeamID:          team.ID,
+			Number:          int64(i + 1),
+			PrimaryPosition: string(util.RandomBaseballPosition()),
+		})
+		require.NoError(t, err)
+	}
+
+	ok, err := testQueries.AreTeammates(context.Background(), AreTeammatesParams{UserID: user1.ID, ViewerID: user2.ID})
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = testQueries.AreTeammates(context.Background(), AreTeammatesParams{UserID: user1.ID, ViewerID: outsider.ID})
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestQueriesSetGameScore(t *testing.T) {
 	game := createRandomGame(t, nil, nil)
 
-	arg := UpdateGameParams{
+	arg := SetGameScoreParams{
 		ID:        game.ID,
 		HomeScore: 2,
 		AwayScore: 1,
 	}
-	updatedGame, err := testQueries.UpdateGame(context.Background(), arg)
+	updatedGame, err := testQueries.SetGameScore(context.Background(), arg)
 	require.NoError(t, err)
 	require.NotEmpty(t, updatedGame)
 
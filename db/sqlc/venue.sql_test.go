@@ -0,0 +1,147 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func createRandomField(t *testing.T) (Venue, Field) {
+	venue, err := testQueries.CreateVenue(context.Background(), CreateVenueParams{
+		Name:      util.RandomName(),
+		City:      "Chicago",
+		Latitude:  41.9484,
+		Longitude: -87.6553,
+		CreatedBy: createRandomUser(t).ID,
+	})
+	require.NoError(t, err)
+
+	field, err := testQueries.CreateField(context.Background(), CreateFieldParams{
+		VenueID:       venue.ID,
+		Name:          "Diamond 1",
+		Surface:       string(util.SurfaceGrass),
+		CenterFieldFt: sql.NullInt64{Int64: 400, Valid: true},
+		HasLights:     true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, venue.ID, field.VenueID)
+	return venue, field
+}
+
+func TestQueries_ListFieldsInBounds(t *testing.T) {
+	venue, field := createRandomField(t)
+
+	minLat, maxLat, minLng, maxLng := util.BoundingBox(venue.Latitude, venue.Longitude, 1)
+	rows, err := testQueries.ListFieldsInBounds(context.Background(), ListFieldsInBoundsParams{
+		MinLatitude:  minLat,
+		MaxLatitude:  maxLat,
+		MinLongitude: minLng,
+		MaxLongitude: maxLng,
+		HasLights:    sql.NullBool{Bool: true, Valid: true},
+	})
+	require.NoError(t, err)
+
+	found := false
+	for _, row := range rows {
+		if row.ID == field.ID {
+			found = true
+			require.Equal(t, venue.Name, row.VenueName)
+		}
+	}
+	require.True(t, found)
+
+	_, err = testQueries.CreateField(context.Background(), CreateFieldParams{
+		VenueID: venue.ID,
+		Name:    field.Name,
+		Surface: string(util.SurfaceDirt),
+	})
+	require.Error(t, err)
+}
+
+func TestQueries_IsFieldAvailable(t *testing.T) {
+	_, field := createRandomField(t)
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+
+	// a field without windows is always available
+	available, err := testQueries.IsFieldAvailable(context.Background(), IsFieldAvailableParams{
+		FieldID:  field.ID,
+		StartsAt: start,
+		EndsAt:   start.Add(3 * time.Hour),
+	})
+	require.NoError(t, err)
+	require.True(t, available)
+
+	_, err = testQueries.CreateFieldAvailability(context.Background(), CreateFieldAvailabilityParams{
+		FieldID:   field.ID,
+		StartsAt:  start,
+		EndsAt:    start.Add(4 * time.Hour),
+		CreatedBy: createRandomUser(t).ID,
+	})
+	require.NoError(t, err)
+
+	available, err = testQueries.IsFieldAvailable(context.Background(), IsFieldAvailableParams{
+		FieldID:  field.ID,
+		StartsAt: start.Add(time.Hour),
+		EndsAt:   start.Add(4 * time.Hour),
+	})
+	require.NoError(t, err)
+	require.True(t, available)
+
+	available, err = testQueries.IsFieldAvailable(context.Background(), IsFieldAvailableParams{
+		FieldID:  field.ID,
+		StartsAt: start.Add(2 * time.Hour),
+		EndsAt:   start.Add(5 * time.Hour),
+	})
+	require.NoError(t, err)
+	require.False(t, available)
+}
+
+func TestQueries_ListScheduleConflictsByField(t *testing.T) {
+	venue, field := createRandomField(t)
+	other, err := testQueries.CreateField(context.Background(), CreateFieldParams{
+		VenueID: venue.ID,
+		Name:    "Diamond 2",
+		Surface: string(util.SurfaceDirt),
+	})
+	require.NoError(t, err)
+
+	start := time.Now().Add(72 * time.Hour).Truncate(time.Second)
+	home := createRandomTeam(t)
+	away := createRandomTeam(t)
+	game, err := testQueries.CreateGame(context.Background(), CreateGameParams{
+		HomeTeamID:  home.ID,
+		AwayTeamID:  away.ID,
+		ScheduledAt: sql.NullTime{Time: start, Valid: true},
+		TimeZone:    "America/Chicago",
+		VenueID:     uuid.NullUUID{UUID: venue.ID, Valid: true},
+		FieldID:     uuid.NullUUID{UUID: field.ID, Valid: true},
+		Rules:       json.RawMessage(`{}`),
+	})
+	require.NoError(t, err)
+
+	arg := ListScheduleConflictsParams{
+		WindowStart: start.Add(-time.Hour),
+		WindowEnd:   start.Add(time.Hour),
+		HomeTeamID:  createRandomTeam(t).ID,
+		AwayTeamID:  createRandomTeam(t).ID,
+		VenueID:     uuid.NullUUID{UUID: venue.ID, Valid: true},
+		FieldID:     uuid.NullUUID{UUID: other.ID, Valid: true},
+	}
+	conflicts, err := testQueries.ListScheduleConflicts(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, conflicts)
+
+	arg.FieldID = uuid.NullUUID{UUID: field.ID, Valid: true}
+	conflicts, err = testQueries.ListScheduleConflicts(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, conflicts, 1)
+	require.Equal(t, game.ID, conflicts[0].ID)
+
+	_, err = testQueries.DeleteField(context.Background(), DeleteFieldParams{ID: field.ID, VenueID: venue.ID})
+	require.Error(t, err)
+}
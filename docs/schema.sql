@@ -2,6 +2,8 @@
 -- Database: PostgreSQL
 -- Generated at: 2023-07-27T04:17:04.228Z
 
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
 CREATE TABLE "users"
 (
     "id"                  uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
@@ -13,7 +15,33 @@ CREATE TABLE "users"
     "hashed_password"     varchar          NOT NULL,
     "password_changed_at" timestamptz      NOT NULL DEFAULT '0001-01-01 00:00:00Z',
     "created_at"          timestamptz      NOT NULL DEFAULT (now()),
-    "updated_at"          timestamptz      NOT NULL DEFAULT (now())
+    "updated_at"          timestamptz      NOT NULL DEFAULT (now()),
+    "is_minor"            boolean          NOT NULL DEFAULT false,
+    "profile_visibility"  varchar          NOT NULL DEFAULT 'public',
+    "email_visibility"    varchar          NOT NULL DEFAULT 'private',
+    "stat_visibility"     varchar          NOT NULL DEFAULT 'public'
+);
+
+CREATE TABLE "audit_logs"
+(
+    "id"         uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "actor_id"   uuid             NOT NULL,
+    "action"     varchar          NOT NULL,
+    "subject_id" uuid,
+    "details"    varchar          NOT NULL,
+    "created_at" timestamptz      NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "guardians"
+(
+    "id"          uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "guardian_id" uuid             NOT NULL,
+    "player_id"   uuid             NOT NULL,
+    "status"      varchar          NOT NULL DEFAULT 'pending',
+    "approved_by" uuid,
+    "approved_at" timestamptz,
+    "created_at"  timestamptz      NOT NULL DEFAULT (now()),
+    "updated_at"  timestamptz      NOT NULL DEFAULT (now())
 );
 
 CREATE TABLE "user_roles"
@@ -38,10 +66,22 @@ CREATE TABLE "verify_emails"
 
 CREATE TABLE "teams"
 (
-    "id"         uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
-    "name"       varchar          NOT NULL,
-    "created_at" timestamptz      NOT NULL DEFAULT (now()),
-    "updated_at" timestamptz      NOT NULL DEFAULT (now())
+    "id"              uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "name"            varchar          NOT NULL,
+    "abbreviation"    varchar(5)       NOT NULL DEFAULT '',
+    "primary_color"   varchar(7)       NOT NULL DEFAULT '' CHECK ("primary_color" = '' OR "primary_color" ~ '^#[0-9a-f]{6}$'),
+    "secondary_color" varchar(7)       NOT NULL DEFAULT '' CHECK ("secondary_color" = '' OR "secondary_color" ~ '^#[0-9a-f]{6}$'),
+    "home_venue"      varchar          NOT NULL DEFAULT '',
+    "city"            varchar          NOT NULL DEFAULT '',
+    "division"        varchar          NOT NULL DEFAULT '',
+    "logo_url"        varchar          NOT NULL DEFAULT '',
+    "website_url"     varchar          NOT NULL DEFAULT '',
+    "facebook_url"    varchar          NOT NULL DEFAULT '',
+    "instagram_url"   varchar          NOT NULL DEFAULT '',
+    "x_url"           varchar          NOT NULL DEFAULT '',
+    "created_at"      timestamptz      NOT NULL DEFAULT (now()),
+    "updated_at"      timestamptz      NOT NULL DEFAULT (now()),
+    "archived_at"     timestamptz
 );
 
 CREATE TABLE "team_members"
@@ -51,10 +91,184 @@ CREATE TABLE "team_members"
     "primary_position" varchar          NOT NULL,
     "user_id"          uuid             NOT NULL,
     "team_id"          uuid             NOT NULL,
+    "role"             varchar          NOT NULL DEFAULT 'player',
     "created_at"       timestamptz      NOT NULL DEFAULT (now()),
     "updated_at"       timestamptz      NOT NULL DEFAULT (now())
 );
 
+CREATE TABLE "player_positions"
+(
+    "id"         uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "team_id"    uuid             NOT NULL,
+    "user_id"    uuid             NOT NULL,
+    "position"   varchar          NOT NULL,
+    "rank"       bigint           NOT NULL,
+    "created_at" timestamptz      NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "depth_chart_entries"
+(
+    "id"         uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "team_id"    uuid             NOT NULL,
+    "position"   varchar          NOT NULL,
+    "user_id"    uuid             NOT NULL,
+    "rank"       bigint           NOT NULL,
+    "created_at" timestamptz      NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "team_member_stints"
+(
+    "id"               uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "team_id"          uuid             NOT NULL,
+    "user_id"          uuid             NOT NULL,
+    "number"           bigint           NOT NULL,
+    "primary_position" varchar          NOT NULL,
+    "status"           varchar          NOT NULL DEFAULT 'active',
+    "started_at"       timestamptz      NOT NULL DEFAULT (now()),
+    "ended_at"         timestamptz
+);
+
+CREATE TABLE "roster_transactions"
+(
+    "id"           uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "type"         varchar          NOT NULL,
+    "user_id"      uuid             NOT NULL,
+    "from_team_id" uuid,
+    "to_team_id"   uuid,
+    "effective_at" timestamptz      NOT NULL,
+    "note"         varchar          NOT NULL DEFAULT '',
+    "created_by"   uuid             NOT NULL,
+    "created_at"   timestamptz      NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "team_invitations"
+(
+    "id"               uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "team_id"          uuid             NOT NULL,
+    "email"            varchar,
+    "code"             varchar          NOT NULL,
+    "number"           bigint,
+    "primary_position" varchar,
+    "status"           varchar          NOT NULL DEFAULT 'pending',
+    "invited_by"       uuid             NOT NULL,
+    "accepted_by"      uuid,
+    "expires_at"       timestamptz      NOT NULL,
+    "created_at"       timestamptz      NOT NULL DEFAULT (now()),
+    "updated_at"       timestamptz      NOT NULL DEFAULT (now()),
+    CONSTRAINT "team_invitations_proposal_check" CHECK (
+        ("email" IS NOT NULL AND "number" IS NOT NULL AND "primary_position" IS NOT NULL) OR
+        ("email" IS NULL AND "number" IS NULL AND "primary_position" IS NULL))
+);
+
+CREATE TABLE "join_requests"
+(
+    "id"               uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "team_id"          uuid             NOT NULL,
+    "user_id"          uuid             NOT NULL,
+    "number"           bigint           NOT NULL,
+    "primary_position" varchar          NOT NULL,
+    "message"          varchar          NOT NULL DEFAULT '',
+    "status"           varchar          NOT NULL DEFAULT 'pending',
+    "decided_by"       uuid,
+    "decided_at"       timestamptz,
+    "created_at"       timestamptz      NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "game_status_changes"
+(
+    "id"          uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "game_id"     uuid             NOT NULL,
+    "from_status" varchar          NOT NULL,
+    "to_status"   varchar          NOT NULL,
+    "reason"      varchar          NOT NULL DEFAULT '',
+    "changed_by"  uuid             NOT NULL,
+    "changed_at"  timestamptz      NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "game_schedule_changes"
+(
+    "id"               uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "game_id"          uuid             NOT NULL,
+    "old_scheduled_at" timestamptz,
+    "new_scheduled_at" timestamptz      NOT NULL,
+    "old_time_zone"    varchar          NOT NULL,
+    "new_time_zone"    varchar          NOT NULL,
+    "old_venue_id"     uuid,
+    "new_venue_id"     uuid,
+    "old_field_id"     uuid,
+    "new_field_id"     uuid,
+    "reason"           varchar          NOT NULL DEFAULT '',
+    "changed_by"       uuid             NOT NULL,
+    "changed_at"       timestamptz      NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "game_availability"
+(
+    "id"           uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "game_id"      uuid             NOT NULL,
+    "team_id"      uuid             NOT NULL,
+    "user_id"      uuid             NOT NULL,
+    "response"     varchar          NOT NULL,
+    "note"         varchar          NOT NULL DEFAULT '',
+    "responded_by" uuid             NOT NULL,
+    "created_at"   timestamptz      NOT NULL DEFAULT (now()),
+    "updated_at"   timestamptz      NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "player_statuses"
+(
+    "id"              uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "team_id"         uuid             NOT NULL,
+    "user_id"         uuid             NOT NULL,
+    "status"          varchar          NOT NULL,
+    "reason"          varchar          NOT NULL DEFAULT '',
+    "starts_at"       timestamptz      NOT NULL DEFAULT (now()),
+    "ends_at"         timestamptz,
+    "games_remaining" bigint,
+    "created_by"      uuid             NOT NULL,
+    "created_at"      timestamptz      NOT NULL DEFAULT (now()),
+    "updated_at"      timestamptz      NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "venues"
+(
+    "id"         uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "name"       varchar          NOT NULL,
+    "address"    varchar          NOT NULL DEFAULT '',
+    "city"       varchar          NOT NULL DEFAULT '',
+    "latitude"   double precision NOT NULL CHECK ("latitude" BETWEEN -90 AND 90),
+    "longitude"  double precision NOT NULL CHECK ("longitude" BETWEEN -180 AND 180),
+    "created_by" uuid             NOT NULL,
+    "created_at" timestamptz      NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz      NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "fields"
+(
+    "id"              uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "venue_id"        uuid             NOT NULL,
+    "name"            varchar          NOT NULL,
+    "surface"         varchar          NOT NULL DEFAULT 'grass',
+    "left_field_ft"   bigint,
+    "center_field_ft" bigint,
+    "right_field_ft"  bigint,
+    "has_lights"      boolean          NOT NULL DEFAULT false,
+    "created_at"      timestamptz      NOT NULL DEFAULT (now()),
+    "updated_at"      timestamptz      NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "field_availability"
+(
+    "id"         uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "field_id"   uuid             NOT NULL,
+    "starts_at"  timestamptz      NOT NULL,
+    "ends_at"    timestamptz      NOT NULL,
+    "note"       varchar          NOT NULL DEFAULT '',
+    "created_by" uuid             NOT NULL,
+    "created_at" timestamptz      NOT NULL DEFAULT (now()),
+    CHECK ("ends_at" > "starts_at")
+);
+
 CREATE TABLE "sessions"
 (
     "id"            uuid PRIMARY KEY,
@@ -74,6 +288,13 @@ CREATE TABLE "game"
     "away_team_id" uuid             NOT NULL,
     "home_score"   bigint           NOT NULL,
     "away_score"   bigint           NOT NULL,
+    "status"       varchar          NOT NULL DEFAULT 'scheduled',
+    "scheduled_at" timestamptz,
+    "time_zone"    varchar          NOT NULL DEFAULT 'UTC',
+    "venue_id"     uuid,
+    "field_id"     uuid,
+    "reentry_rule" varchar          NOT NULL DEFAULT 'none',
+    "rules"        jsonb            NOT NULL DEFAULT '{}',
     "created_at"   timestamptz      NOT NULL DEFAULT (now()),
     "updated_at"   timestamptz      NOT NULL DEFAULT (now())
 );
@@ -81,55 +302,235 @@ CREATE TABLE "game"
 CREATE TABLE "inning"
 (
     "id"            uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
-    "game_id"       uuid,
+    "game_id"       uuid             NOT NULL,
     "number"        bigint           NOT NULL,
-    "home_runs"     bigint           NOT NULL,
-    "home_hits"     bigint           NOT NULL,
-    "home_errors"   bigint           NOT NULL,
-    "home_last_bat" uuid             NOT NULL,
-    "away_runs"     bigint           NOT NULL,
-    "away_hits"     bigint           NOT NULL,
-    "away_errors"   bigint           NOT NULL,
-    "away_last_bat" uuid             NOT NULL
+    "home_runs"     bigint           NOT NULL DEFAULT 0,
+    "home_hits"     bigint           NOT NULL DEFAULT 0,
+    "home_errors"   bigint           NOT NULL DEFAULT 0,
+    "home_last_bat" uuid,
+    "away_runs"     bigint           NOT NULL DEFAULT 0,
+    "away_hits"     bigint           NOT NULL DEFAULT 0,
+    "away_errors"   bigint           NOT NULL DEFAULT 0,
+    "away_last_bat" uuid
 );
 
 CREATE TABLE "atbat"
 (
     "id"          uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
-    "inning_id"   uuid,
-    "batter_id"   uuid,
-    "pitcher_id"  uuid,
-    "balls"       bigint           NOT NULL,
-    "strikes"     bigint           NOT NULL,
-    "init_bases"  bigint           NOT NULL,
-    "total_bases" bigint           NOT NULL,
-    "out"         boolean          NOT NULL
+    "inning_id"   uuid             NOT NULL,
+    "batter_id"   uuid             NOT NULL,
+    "pitcher_id"  uuid             NOT NULL,
+    "balls"       bigint           NOT NULL DEFAULT 0,
+    "strikes"     bigint           NOT NULL DEFAULT 0,
+    "init_bases"  bigint           NOT NULL DEFAULT 0,
+    "total_bases" bigint           NOT NULL DEFAULT 0,
+    "out"         boolean          NOT NULL DEFAULT false,
+    "game_id"     uuid             NOT NULL,
+    "half"        varchar          NOT NULL,
+    "pitches"     bigint           NOT NULL DEFAULT 0,
+    "result"      varchar,
+    "created_at"  timestamptz      NOT NULL DEFAULT (now()),
+    "ended_at"    timestamptz,
+    "number"      bigint           NOT NULL,
+    "runner_on_first"  uuid,
+    "runner_on_second" uuid,
+    "runner_on_third"  uuid,
+    "rbi"         bigint           NOT NULL DEFAULT 0
+);
+
+CREATE TABLE "pitches"
+(
+    "id"         uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "atbat_id"   uuid             NOT NULL,
+    "number"     bigint           NOT NULL,
+    "type"       varchar          NOT NULL,
+    "balls"      bigint           NOT NULL,
+    "strikes"    bigint           NOT NULL,
+    "created_by" uuid             NOT NULL,
+    "created_at" timestamptz      NOT NULL DEFAULT (now()),
+    "pitcher_id" uuid             NOT NULL
+);
+
+CREATE TABLE "atbat_runners"
+(
+    "id"         uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "atbat_id"   uuid             NOT NULL,
+    "number"     bigint           NOT NULL,
+    "runner_id"  uuid             NOT NULL,
+    "from_base"  bigint           NOT NULL,
+    "to_base"    bigint           NOT NULL,
+    "out"        boolean          NOT NULL DEFAULT false,
+    "reason"     varchar          NOT NULL,
+    "scored"     boolean          NOT NULL DEFAULT false,
+    "rbi"        boolean          NOT NULL DEFAULT false,
+    "earned"     boolean          NOT NULL DEFAULT false,
+    "pitcher_id" uuid
+);
+
+CREATE TABLE "game_events"
+(
+    "id"         uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "game_id"    uuid             NOT NULL,
+    "sequence"   bigint           NOT NULL,
+    "type"       varchar          NOT NULL,
+    "payload"    jsonb            NOT NULL,
+    "created_by" uuid             NOT NULL,
+    "created_at" timestamptz      NOT NULL DEFAULT (now()),
+    "corrects_id" uuid,
+    "voided_by"  uuid,
+    "voided_at"  timestamptz
 );
 
 CREATE TABLE "game_participant"
 (
-    "id"           uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
-    "game_id"      uuid,
-    "player_id"    uuid,
-    "home_team"    boolean          NOT NULL,
-    "bat_position" bigint           NOT NULL
+    "id"             uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "game_id"        uuid             NOT NULL,
+    "player_id"      uuid             NOT NULL,
+    "home_team"      boolean          NOT NULL,
+    "bat_position"   bigint           NOT NULL,
+    "position"       varchar          NOT NULL DEFAULT '',
+    "created_at"     timestamptz      NOT NULL DEFAULT (now()),
+    "entry"          varchar          NOT NULL DEFAULT 'starter',
+    "entered_inning" bigint           NOT NULL DEFAULT 1,
+    "exited_inning"  bigint,
+    "replaced_id"    uuid
 );
 
 CREATE TABLE "game_stat"
 (
-    "id"       uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
-    "atbat_id" uuid,
-    "type"     varchar
+    "id"             uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "atbat_id"       uuid,
+    "type"           varchar,
+    "participant_id" uuid
+);
+
+CREATE TABLE "pitch_counts"
+(
+    "id"         uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "game_id"    uuid             NOT NULL,
+    "player_id"  uuid             NOT NULL,
+    "pitches"    bigint           NOT NULL,
+    "entered_by" uuid             NOT NULL,
+    "created_at" timestamptz      NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz      NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "notifications"
+(
+    "id"         uuid PRIMARY KEY NOT NULL DEFAULT (uuid_generate_v4()),
+    "user_id"    uuid             NOT NULL,
+    "player_id"  uuid             NOT NULL,
+    "game_id"    uuid,
+    "kind"       varchar          NOT NULL,
+    "message"    varchar          NOT NULL,
+    "read_at"    timestamptz,
+    "created_at" timestamptz      NOT NULL DEFAULT (now())
 );
 
 CREATE UNIQUE INDEX ON "users" ("username");
 
+CREATE UNIQUE INDEX ON "guardians" ("guardian_id", "player_id");
+
+CREATE INDEX ON "audit_logs" ("created_at");
+
 CREATE UNIQUE INDEX ON "user_roles" ("name", "user_id");
 
 CREATE UNIQUE INDEX ON "verify_emails" ("secret_code");
 
 CREATE UNIQUE INDEX ON "teams" ("name");
 
+CREATE UNIQUE INDEX "team_members_team_id_user_id_idx" ON "team_members" ("team_id", "user_id");
+
+CREATE UNIQUE INDEX "team_members_team_id_number_idx" ON "team_members" ("team_id", "number");
+
+CREATE UNIQUE INDEX ON "player_positions" ("team_id", "user_id", "position");
+
+CREATE UNIQUE INDEX ON "depth_chart_entries" ("team_id", "position", "user_id");
+
+CREATE INDEX ON "team_member_stints" ("team_id", "started_at");
+
+CREATE UNIQUE INDEX "team_member_stints_open_idx" ON "team_member_stints" ("team_id", "user_id") WHERE "ended_at" IS NULL;
+
+CREATE INDEX ON "roster_transactions" ("from_team_id", "effective_at");
+
+CREATE INDEX ON "roster_transactions" ("to_team_id", "effective_at");
+
+CREATE UNIQUE INDEX ON "team_invitations" ("code");
+
+CREATE INDEX ON "team_invitations" ("team_id", "created_at");
+
+CREATE UNIQUE INDEX "join_requests_pending_idx" ON "join_requests" ("team_id", "user_id") WHERE "status" = 'pending';
+
+CREATE INDEX ON "game" ("status");
+
+CREATE INDEX ON "game" ("scheduled_at");
+
+CREATE INDEX ON "game" ("venue_id", "scheduled_at");
+
+CREATE INDEX ON "game" ("field_id", "scheduled_at");
+
+CREATE INDEX ON "venues" ("latitude", "longitude");
+
+CREATE UNIQUE INDEX ON "fields" ("venue_id", "name");
+
+CREATE INDEX ON "field_availability" ("field_id", "starts_at");
+
+CREATE INDEX ON "game_status_changes" ("game_id", "changed_at");
+
+CREATE INDEX ON "game_schedule_changes" ("game_id", "changed_at");
+
+CREATE INDEX ON "game_participant" ("game_id", "home_team", "bat_position");
+
+CREATE UNIQUE INDEX ON "inning" ("game_id", "number");
+
+CREATE INDEX ON "atbat" ("game_id", "created_at");
+
+CREATE UNIQUE INDEX ON "atbat" ("game_id", "number");
+
+CREATE INDEX ON "atbat" ("pitcher_id");
+
+CREATE UNIQUE INDEX ON "pitches" ("atbat_id", "number");
+
+CREATE INDEX ON "pitches" ("pitcher_id");
+
+CREATE UNIQUE INDEX ON "atbat_runners" ("atbat_id", "number");
+
+CREATE INDEX ON "atbat_runners" ("runner_id");
+
+CREATE INDEX ON "game_stat" ("atbat_id");
+
+CREATE UNIQUE INDEX ON "pitch_counts" ("game_id", "player_id");
+
+CREATE INDEX ON "pitch_counts" ("player_id");
+
+CREATE INDEX ON "notifications" ("user_id", "created_at");
+
+CREATE UNIQUE INDEX "game_events_active_idx" ON "game_events" ("game_id", "sequence") WHERE "voided_at" IS NULL;
+
+CREATE INDEX ON "game_events" ("game_id", "sequence");
+
+CREATE UNIQUE INDEX ON "game_availability" ("game_id", "user_id");
+
+CREATE INDEX ON "player_statuses" ("team_id", "user_id", "starts_at");
+
+CREATE INDEX "users_username_trgm_idx" ON "users" USING gin ("username" gin_trgm_ops);
+
+CREATE INDEX "users_full_name_trgm_idx" ON "users" USING gin (("first_name" || ' ' || "last_name") gin_trgm_ops);
+
+CREATE INDEX "teams_name_trgm_idx" ON "teams" USING gin ("name" gin_trgm_ops);
+
+ALTER TABLE "guardians"
+    ADD FOREIGN KEY ("guardian_id") REFERENCES "users" ("id");
+
+ALTER TABLE "guardians"
+    ADD FOREIGN KEY ("player_id") REFERENCES "users" ("id");
+
+ALTER TABLE "guardians"
+    ADD FOREIGN KEY ("approved_by") REFERENCES "users" ("id");
+
+ALTER TABLE "audit_logs"
+    ADD FOREIGN KEY ("actor_id") REFERENCES "users" ("id");
+
 ALTER TABLE "user_roles"
     ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
 
@@ -142,6 +543,99 @@ ALTER TABLE "team_members"
 ALTER TABLE "team_members"
     ADD FOREIGN KEY ("team_id") REFERENCES "teams" ("id");
 
+ALTER TABLE "player_positions"
+    ADD FOREIGN KEY ("team_id", "user_id") REFERENCES "team_members" ("team_id", "user_id") ON DELETE CASCADE;
+
+ALTER TABLE "depth_chart_entries"
+    ADD FOREIGN KEY ("team_id", "user_id") REFERENCES "team_members" ("team_id", "user_id") ON DELETE CASCADE;
+
+ALTER TABLE "team_member_stints"
+    ADD FOREIGN KEY ("team_id") REFERENCES "teams" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "team_member_stints"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+ALTER TABLE "roster_transactions"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+ALTER TABLE "roster_transactions"
+    ADD FOREIGN KEY ("from_team_id") REFERENCES "teams" ("id") ON DELETE SET NULL;
+
+ALTER TABLE "roster_transactions"
+    ADD FOREIGN KEY ("to_team_id") REFERENCES "teams" ("id") ON DELETE SET NULL;
+
+ALTER TABLE "roster_transactions"
+    ADD FOREIGN KEY ("created_by") REFERENCES "users" ("id");
+
+ALTER TABLE "team_invitations"
+    ADD FOREIGN KEY ("team_id") REFERENCES "teams" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "team_invitations"
+    ADD FOREIGN KEY ("invited_by") REFERENCES "users" ("id");
+
+ALTER TABLE "team_invitations"
+    ADD FOREIGN KEY ("accepted_by") REFERENCES "users" ("id");
+
+ALTER TABLE "join_requests"
+    ADD FOREIGN KEY ("team_id") REFERENCES "teams" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "join_requests"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+ALTER TABLE "join_requests"
+    ADD FOREIGN KEY ("decided_by") REFERENCES "users" ("id");
+
+ALTER TABLE "venues"
+    ADD FOREIGN KEY ("created_by") REFERENCES "users" ("id");
+
+ALTER TABLE "fields"
+    ADD FOREIGN KEY ("venue_id") REFERENCES "venues" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "field_availability"
+    ADD FOREIGN KEY ("field_id") REFERENCES "fields" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "field_availability"
+    ADD FOREIGN KEY ("created_by") REFERENCES "users" ("id");
+
+ALTER TABLE "game"
+    ADD FOREIGN KEY ("venue_id") REFERENCES "venues" ("id");
+
+ALTER TABLE "game"
+    ADD FOREIGN KEY ("field_id") REFERENCES "fields" ("id");
+
+ALTER TABLE "game_status_changes"
+    ADD FOREIGN KEY ("game_id") REFERENCES "game" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "game_status_changes"
+    ADD FOREIGN KEY ("changed_by") REFERENCES "users" ("id");
+
+ALTER TABLE "game_schedule_changes"
+    ADD FOREIGN KEY ("game_id") REFERENCES "game" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "game_schedule_changes"
+    ADD FOREIGN KEY ("changed_by") REFERENCES "users" ("id");
+
+ALTER TABLE "game_availability"
+    ADD FOREIGN KEY ("game_id") REFERENCES "game" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "game_availability"
+    ADD FOREIGN KEY ("team_id") REFERENCES "teams" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "game_availability"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+ALTER TABLE "game_availability"
+    ADD FOREIGN KEY ("responded_by") REFERENCES "users" ("id");
+
+ALTER TABLE "player_statuses"
+    ADD FOREIGN KEY ("team_id") REFERENCES "teams" ("id");
+
+ALTER TABLE "player_statuses"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+ALTER TABLE "player_statuses"
+    ADD FOREIGN KEY ("created_by") REFERENCES "users" ("id");
+
 ALTER TABLE "sessions"
     ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
 
@@ -169,11 +663,99 @@ ALTER TABLE "atbat"
 ALTER TABLE "atbat"
     ADD FOREIGN KEY ("pitcher_id") REFERENCES "game_participant" ("id");
 
+ALTER TABLE "atbat"
+    ADD FOREIGN KEY ("game_id") REFERENCES "game" ("id");
+
+ALTER TABLE "atbat"
+    ADD FOREIGN KEY ("runner_on_first") REFERENCES "game_participant" ("id");
+
+ALTER TABLE "atbat"
+    ADD FOREIGN KEY ("runner_on_second") REFERENCES "game_participant" ("id");
+
+ALTER TABLE "atbat"
+    ADD FOREIGN KEY ("runner_on_third") REFERENCES "game_participant" ("id");
+
+ALTER TABLE "pitches"
+    ADD FOREIGN KEY ("atbat_id") REFERENCES "atbat" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "pitches"
+    ADD FOREIGN KEY ("created_by") REFERENCES "users" ("id");
+
+ALTER TABLE "pitches"
+    ADD FOREIGN KEY ("pitcher_id") REFERENCES "game_participant" ("id");
+
+ALTER TABLE "atbat_runners"
+    ADD FOREIGN KEY ("atbat_id") REFERENCES "atbat" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "atbat_runners"
+    ADD FOREIGN KEY ("runner_id") REFERENCES "game_participant" ("id");
+
+ALTER TABLE "atbat_runners"
+    ADD FOREIGN KEY ("pitcher_id") REFERENCES "game_participant" ("id");
+
+ALTER TABLE "game_events"
+    ADD FOREIGN KEY ("game_id") REFERENCES "game" ("id");
+
+ALTER TABLE "game_events"
+    ADD FOREIGN KEY ("created_by") REFERENCES "users" ("id");
+
+ALTER TABLE "game_events"
+    ADD FOREIGN KEY ("corrects_id") REFERENCES "game_events" ("id");
+
+ALTER TABLE "game_events"
+    ADD FOREIGN KEY ("voided_by") REFERENCES "users" ("id");
+
 ALTER TABLE "game_participant"
     ADD FOREIGN KEY ("game_id") REFERENCES "game" ("id");
 
 ALTER TABLE "game_participant"
     ADD FOREIGN KEY ("player_id") REFERENCES "users" ("id");
 
+ALTER TABLE "game_participant"
+    ADD FOREIGN KEY ("replaced_id") REFERENCES "game_participant" ("id");
+
 ALTER TABLE "game_stat"
-    ADD FOREIGN KEY ("atbat_id") REFERENCES "atbat" ("id");
+    ADD FOREIGN KEY ("atbat_id") REFERENCES "atbat" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "game_stat"
+    ADD FOREIGN KEY ("participant_id") REFERENCES "game_participant" ("id");
+
+ALTER TABLE "pitch_counts"
+    ADD FOREIGN KEY ("game_id") REFERENCES "game" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "pitch_counts"
+    ADD FOREIGN KEY ("player_id") REFERENCES "users" ("id");
+
+ALTER TABLE "pitch_counts"
+    ADD FOREIGN KEY ("entered_by") REFERENCES "users" ("id");
+
+ALTER TABLE "notifications"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "notifications"
+    ADD FOREIGN KEY ("player_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "notifications"
+    ADD FOREIGN KEY ("game_id") REFERENCES "game" ("id") ON DELETE CASCADE;
+
+
+CREATE FUNCTION "profile_visible"("viewer" uuid, "target" uuid) RETURNS boolean
+    LANGUAGE sql
+    STABLE
+AS
+$$
+SELECT u."id" = "viewer"
+           OR u."profile_visibility" = 'public'
+           OR (u."profile_visibility" = 'teammates' AND EXISTS(SELECT 1
+                                                               FROM "team_members" a
+                                                                        JOIN "team_members" b ON a."team_id" = b."team_id"
+                                                               WHERE a."user_id" = u."id"
+                                                                 AND b."user_id" = "viewer"))
+           OR EXISTS(SELECT 1
+                     FROM "guardians" g
+                     WHERE g."player_id" = u."id"
+                       AND g."guardian_id" = "viewer"
+                       AND g."status" = 'approved')
+FROM "users" u
+WHERE u."id" = "target"
+$$;
\ No newline at end of file
@@ -60,6 +60,11 @@ func runGINServer(config util.Config, store db.Store) {
 		log.Fatal().Err(err).Msg("cannot create gin server")
 	}
 
+	err = server.LoadGuardianPolicies()
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot load guardian policies")
+	}
+
 	err = server.Start(config.HttpServerAddress)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot start server")
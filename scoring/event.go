@@ -0,0 +1,131 @@
+// Package scoring replays a game's play-by-play event log into the current state of the game.
+// Events are the only way the score changes; the state is derived from them and never edited directly.
+package scoring
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kwalter26/scoreit-api-go/util"
+)
+
+// EventType is the kind of thing that happened in a game
+type EventType string
+
+// Constants representing event types
+const (
+	EventPitch        EventType = "pitch"
+	EventPlay         EventType = "play"
+	EventSubstitution EventType = "substitution"
+	EventInningEnd    EventType = "inning_end"
+	EventGameEnd      EventType = "game_end"
+)
+
+// GameEndReason is why a game_end event ended a game before it was decided
+type GameEndReason string
+
+// Constants representing reasons to end a game early
+const (
+	EndSuspended GameEndReason = "suspended"
+	EndForfeit   GameEndReason = "forfeit"
+	EndTimeLimit GameEndReason = "time_limit"
+)
+
+// Event is one entry in a game's event log. Only the field matching Type is set.
+type Event struct {
+	Type         EventType     `json:"type"`
+	Pitch        *Pitch        `json:"pitch,omitempty"`
+	Play         *Play         `json:"play,omitempty"`
+	Substitution *Substitution `json:"substitution,omitempty"`
+	GameEnd      *GameEnd      `json:"game_end,omitempty"`
+}
+
+// Pitch is a single pitch to the batter
+type Pitch struct {
+	Type util.PitchType `json:"type"`
+}
+
+// GameEnd says why a game_end event ended the game. A game_end without a reason ends a game
+// that is still tied after its regulation innings.
+type GameEnd struct {
+	Reason GameEndReason `json:"reason"`
+}
+
+// Substitution is a set of lineup changes made together for one team, such as a double switch.
+type Substitution struct {
+	Home    bool     `json:"home"`
+	Changes []Change `json:"changes"`
+}
+
+// Change puts a participant in the place of another. The participant coming in bats in
+// BatPosition, or not at all when it is 0. Position changes are changes where both
+// participants are the same player.
+type Change struct {
+	ReplacesID  string                `json:"replaces_id"`
+	InID        string                `json:"in_id"`
+	BatPosition int64                 `json:"bat_position"`
+	Position    util.BaseballPosition `json:"position"`
+}
+
+// Validate checks that an event has the details its type needs
+func (e Event) Validate() error {
+	switch e.Type {
+	case EventPitch:
+		if e.Pitch == nil {
+			return fmt.Errorf("a %s event needs a pitch", e.Type)
+		}
+	case EventPlay:
+		if e.Play == nil {
+			return fmt.Errorf("a %s event needs a play", e.Type)
+		}
+		if !e.Play.Kind.valid() {
+			return fmt.Errorf("%s is not a kind of play", e.Play.Kind)
+		}
+		for _, advance := range e.Play.Advances {
+			if advance.Reason != "" && !advance.Reason.valid() {
+				return fmt.Errorf("%s is not a reason to advance", advance.Reason)
+			}
+		}
+		if e.Play.Errors > 0 && int64(len(e.Play.ErrorsBy)) > e.Play.Errors {
+			return fmt.Errorf("%d errors cannot be charged to %d fielders", e.Play.Errors, len(e.Play.ErrorsBy))
+		}
+	case EventSubstitution:
+		if e.Substitution == nil || len(e.Substitution.Changes) == 0 {
+			return fmt.Errorf("a %s event needs changes", e.Type)
+		}
+		for _, change := range e.Substitution.Changes {
+			if change.ReplacesID == "" || change.InID == "" {
+				return fmt.Errorf("a %s event needs the participants going out and coming in", e.Type)
+			}
+		}
+	case EventGameEnd:
+		if e.GameEnd != nil && !e.GameEnd.Reason.valid() {
+			return fmt.Errorf("%s is not a reason to end a game", e.GameEnd.Reason)
+		}
+	case EventInningEnd:
+	default:
+		return fmt.Errorf("%s is not an event type", e.Type)
+	}
+	return nil
+}
+
+func (r GameEndReason) valid() bool {
+	switch r {
+	case EndSuspended, EndForfeit, EndTimeLimit:
+		return true
+	}
+	return false
+}
+
+// Encode returns the event as stored in the event log
+func Encode(e Event) ([]byte, error) {
+	return json.Marshal(e)
+}
+
+// Decode reads an event stored in the event log
+func Decode(payload []byte) (Event, error) {
+	var e Event
+	if err := json.Unmarshal(payload, &e); err != nil {
+		return e, err
+	}
+	return e, e.Validate()
+}
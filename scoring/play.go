@@ -0,0 +1,161 @@
+package scoring
+
+import "fmt"
+
+// PlayKind is what happened on a play. Batting plays end the plate appearance;
+// the others move runners while the batter is still up.
+type PlayKind string
+
+// Constants representing batting plays
+const (
+	PlaySingle         PlayKind = "single"
+	PlayDouble         PlayKind = "double"
+	PlayTriple         PlayKind = "triple"
+	PlayHomeRun        PlayKind = "home_run"
+	PlayOut            PlayKind = "out"
+	PlayError          PlayKind = "error"
+	PlayFieldersChoice PlayKind = "fielders_choice"
+	PlaySacrifice      PlayKind = "sacrifice"
+)
+
+// Constants representing plays made while the batter is still up
+const (
+	PlayStolenBase     PlayKind = "stolen_base"
+	PlayCaughtStealing PlayKind = "caught_stealing"
+	PlayWildPitch      PlayKind = "wild_pitch"
+	PlayPassedBall     PlayKind = "passed_ball"
+	PlayBalk           PlayKind = "balk"
+)
+
+// Base is where a runner starts or ends a play. The batter starts at Batter and a runner who scores ends at Home.
+type Base int64
+
+// Constants representing bases
+const (
+	Batter Base = 0
+	First  Base = 1
+	Second Base = 2
+	Third  Base = 3
+	Home   Base = 4
+)
+
+// Play is a ball in play or a baserunning play
+type Play struct {
+	Kind PlayKind `json:"kind"`
+	// Advances moves runners, including the batter. When it is empty, hits move every runner
+	// as many bases as the batter, outs leave runners where they are and a balk moves every runner up one.
+	Advances []Advance `json:"advances,omitempty"`
+	// Errors is how many errors the fielding team made on the play
+	Errors int64 `json:"errors,omitempty"`
+	// ErrorsBy lists the fielders charged with the errors, one entry per error. Errors defaults to its length.
+	ErrorsBy []string `json:"errors_by,omitempty"`
+}
+
+// charged returns how many errors the fielding team made on a play
+func (p Play) charged() int64 {
+	charged := p.Errors
+	if n := int64(len(p.ErrorsBy)); n > charged {
+		charged = n
+	}
+	if p.Kind == PlayError && charged == 0 {
+		charged = 1
+	}
+	return charged
+}
+
+// Advance moves one runner. A runner put out is marked Out, and To is the base they were heading for.
+// Reason defaults to the play's; a runner who takes an extra base on a throwing error during a hit is
+// the usual reason to give one.
+type Advance struct {
+	From   Base          `json:"from"`
+	To     Base          `json:"to"`
+	Out    bool          `json:"out,omitempty"`
+	Reason AdvanceReason `json:"reason,omitempty"`
+}
+
+// bases returns how many bases a hit is worth, or 0 if kind is not a hit
+func (kind PlayKind) bases() Base {
+	switch kind {
+	case PlaySingle:
+		return First
+	case PlayDouble:
+		return Second
+	case PlayTriple:
+		return Third
+	case PlayHomeRun:
+		return Home
+	}
+	return 0
+}
+
+// batting reports whether kind ends the batter's plate appearance
+func (kind PlayKind) batting() bool {
+	switch kind {
+	case PlaySingle, PlayDouble, PlayTriple, PlayHomeRun, PlayOut, PlayError, PlayFieldersChoice, PlaySacrifice:
+		return true
+	}
+	return false
+}
+
+func (kind PlayKind) valid() bool {
+	switch kind {
+	case PlayStolenBase, PlayCaughtStealing, PlayWildPitch, PlayPassedBall, PlayBalk:
+		return true
+	}
+	return kind.batting()
+}
+
+// advances returns the runner movement for a play, filling in the usual movement when none was given
+// and the play's reason for any advance without one.
+func (p Play) advances(bases [3]string) ([]Advance, error) {
+	if len(p.Advances) > 0 {
+		return withReason(p.Advances, p.Kind.reason()), nil
+	}
+
+	var advances []Advance
+	occupied := func(move Base) {
+		for base := Third; base >= First; base-- {
+			if bases[base-1] != "" {
+				advances = append(advances, Advance{From: base, To: min(base+move, Home)})
+			}
+		}
+	}
+
+	switch p.Kind {
+	case PlaySingle, PlayDouble, PlayTriple, PlayHomeRun:
+		occupied(p.Kind.bases())
+		advances = append(advances, Advance{From: Batter, To: p.Kind.bases()})
+	case PlayOut:
+		advances = append(advances, Advance{From: Batter, To: First, Out: true})
+	case PlaySacrifice:
+		occupied(1)
+		advances = append(advances, Advance{From: Batter, To: First, Out: true})
+	case PlayError:
+		advances = forcedAdvances(bases)
+	case PlayBalk:
+		occupied(1)
+	default:
+		return nil, fmt.Errorf("a %s needs the runners' advances", p.Kind)
+	}
+	return withReason(advances, p.Kind.reason()), nil
+}
+
+// forcedAdvances puts the batter on first and moves up only the runners who are forced
+func forcedAdvances(bases [3]string) []Advance {
+	advances := []Advance{{From: Batter, To: First}}
+	for base := First; base <= Third && bases[base-1] != ""; base++ {
+		advances = append(advances, Advance{From: base, To: base + 1})
+	}
+	return advances
+}
+
+// withReason returns a copy of advances with reason given to those that have none
+func withReason(advances []Advance, reason AdvanceReason) []Advance {
+	filled := append([]Advance{}, advances...)
+	for i := range filled {
+		if filled[i].Reason == "" {
+			filled[i].Reason = reason
+		}
+	}
+	return filled
+}
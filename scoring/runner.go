@@ -0,0 +1,92 @@
+package scoring
+
+// AdvanceReason is why a runner moved
+type AdvanceReason string
+
+// Constants representing advance reasons
+const (
+	ReasonHit            AdvanceReason = "hit"
+	ReasonWalk           AdvanceReason = "walk"
+	ReasonHitByPitch     AdvanceReason = "hit_by_pitch"
+	ReasonError          AdvanceReason = "error"
+	ReasonFieldersChoice AdvanceReason = "fielders_choice"
+	ReasonSacrifice      AdvanceReason = "sacrifice"
+	ReasonOut            AdvanceReason = "out"
+	ReasonStolenBase     AdvanceReason = "stolen_base"
+	ReasonCaughtStealing AdvanceReason = "caught_stealing"
+	ReasonWildPitch      AdvanceReason = "wild_pitch"
+	ReasonPassedBall     AdvanceReason = "passed_ball"
+	ReasonBalk           AdvanceReason = "balk"
+)
+
+// reason returns why runners move on a play unless the scorer says otherwise
+func (kind PlayKind) reason() AdvanceReason {
+	switch kind {
+	case PlaySingle, PlayDouble, PlayTriple, PlayHomeRun:
+		return ReasonHit
+	case PlayOut:
+		return ReasonOut
+	case PlayError:
+		return ReasonError
+	case PlayFieldersChoice:
+		return ReasonFieldersChoice
+	case PlaySacrifice:
+		return ReasonSacrifice
+	case PlayStolenBase:
+		return ReasonStolenBase
+	case PlayCaughtStealing:
+		return ReasonCaughtStealing
+	case PlayWildPitch:
+		return ReasonWildPitch
+	case PlayPassedBall:
+		return ReasonPassedBall
+	}
+	return ReasonBalk
+}
+
+func (reason AdvanceReason) valid() bool {
+	switch reason {
+	case ReasonHit, ReasonWalk, ReasonHitByPitch, ReasonError, ReasonFieldersChoice, ReasonSacrifice, ReasonOut,
+		ReasonStolenBase, ReasonCaughtStealing, ReasonWildPitch, ReasonPassedBall, ReasonBalk:
+		return true
+	}
+	return false
+}
+
+// drivesIn reports whether a run scored for this reason can be credited to the batter as an RBI
+func (reason AdvanceReason) drivesIn() bool {
+	switch reason {
+	case ReasonHit, ReasonWalk, ReasonHitByPitch, ReasonFieldersChoice, ReasonSacrifice, ReasonOut:
+		return true
+	}
+	return false
+}
+
+// earned reports whether a run scored for this reason can be earned. Runs that score on an error
+// or a passed ball are the fielders' fault, not the pitcher's.
+func (reason AdvanceReason) earned() bool {
+	return reason != ReasonError && reason != ReasonPassedBall
+}
+
+// Runner is how the runner on a base got there
+type Runner struct {
+	// PitcherID is the pitcher charged if the runner scores: the one pitching when the runner reached base
+	PitcherID string `json:"pitcher_id,omitempty"`
+	// ReachedOnError is set when the runner reached base because of an error, so any run they score is unearned
+	ReachedOnError bool `json:"reached_on_error,omitempty"`
+}
+
+// RunnerMove is one runner's movement during a plate appearance, including the batter's.
+// Runs are credited as RBIs to the batter and charged to PitcherID as earned or unearned.
+type RunnerMove struct {
+	RunnerID string        `json:"runner_id"`
+	From     Base          `json:"from"`
+	To       Base          `json:"to"`
+	Out      bool          `json:"out,omitempty"`
+	Reason   AdvanceReason `json:"reason"`
+	Scored   bool          `json:"scored,omitempty"`
+	RBI      bool          `json:"rbi,omitempty"`
+	Earned   bool          `json:"earned,omitempty"`
+	// PitcherID is the pitcher charged with the run; it is only set when the runner scored
+	PitcherID string `json:"pitcher_id,omitempty"`
+}
@@ -0,0 +1,122 @@
+package scoring
+
+import (
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// scored returns the runs scored during a plate appearance
+func scored(pa PlateAppearance) []RunnerMove {
+	var runs []RunnerMove
+	for _, move := range pa.Runners {
+		if move.Scored {
+			runs = append(runs, move)
+		}
+	}
+	return runs
+}
+
+func TestState_RBIs(t *testing.T) {
+	s := newTestState()
+	for i := 0; i < 4; i++ {
+		walk(t, s)
+	}
+
+	// a bases-loaded walk forces in a run
+	pa := s.PlateAppearances[3]
+	require.Equal(t, int64(1), pa.RBI)
+	require.Equal(t, [3]string{"a3", "a2", "a1"}, pa.StartBases)
+	require.Equal(t, [3]string{"a4", "a3", "a2"}, pa.EndBases)
+	require.Equal(t, []RunnerMove{{RunnerID: "a1", From: Third, To: Home, Reason: ReasonWalk, Scored: true, RBI: true, Earned: true, PitcherID: "h9"}}, scored(pa))
+
+	// a grand slam drives in the batter too
+	apply(t, s, play(PlayHomeRun))
+	require.Equal(t, int64(4), s.PlateAppearances[4].RBI)
+	require.Len(t, s.PlateAppearances[4].Runners, 4)
+
+	// no RBI for a run that scores while the batter hits into a double play
+	walk(t, s)
+	walk(t, s)
+	walk(t, s)
+	apply(t, s, play(PlayOut,
+		Advance{From: Third, To: Home},
+		Advance{From: Second, To: Third, Out: true},
+		Advance{From: Batter, To: First, Out: true},
+		Advance{From: First, To: Second},
+	))
+	pa = s.PlateAppearances[8]
+	require.Equal(t, int64(0), pa.RBI)
+	require.Len(t, scored(pa), 1)
+	require.True(t, scored(pa)[0].Earned)
+	require.Equal(t, int64(6), s.AwayScore)
+}
+
+func TestState_UnearnedRuns(t *testing.T) {
+	s := newTestState()
+
+	// the leadoff hitter reaches on an error and scores on a home run
+	apply(t, s, play(PlayError), play(PlayHomeRun))
+	require.Equal(t, RunnerMove{RunnerID: "a1", From: Batter, To: First, Reason: ReasonError}, s.PlateAppearances[0].Runners[0])
+	runs := scored(s.PlateAppearances[1])
+	require.Len(t, runs, 2)
+	require.Equal(t, "a1", runs[0].RunnerID)
+	require.False(t, runs[0].Earned)
+	require.True(t, runs[0].RBI)
+	require.Equal(t, "a2", runs[1].RunnerID)
+	require.True(t, runs[1].Earned)
+
+	// two strikeouts would have been the third out without the error, so no more runs are earned
+	strikeout(t, s)
+	strikeout(t, s)
+	apply(t, s, play(PlaySingle), play(PlayHomeRun))
+	runs = scored(s.PlateAppearances[5])
+	require.Len(t, runs, 2)
+	require.False(t, runs[0].Earned)
+	require.False(t, runs[1].Earned)
+
+	// the next half inning starts clean
+	strikeout(t, s)
+	apply(t, s, Event{Type: EventInningEnd}, play(PlayHomeRun))
+	require.True(t, scored(s.PlateAppearances[7])[0].Earned)
+}
+
+func TestState_BaserunningMoves(t *testing.T) {
+	s := newTestState()
+	apply(t, s, play(PlayTriple))
+
+	// the runner scores on a passed ball during the next batter's turn
+	apply(t, s, pitch(util.PitchBall), play(PlayPassedBall, Advance{From: Third, To: Home}))
+	pa := s.PlateAppearances[1]
+	require.Equal(t, [3]string{"", "", "a1"}, pa.StartBases)
+	require.Equal(t, []RunnerMove{{RunnerID: "a1", From: Third, To: Home, Reason: ReasonPassedBall, Scored: true, PitcherID: "h9"}}, pa.Runners)
+	require.Equal(t, int64(0), pa.RBI)
+
+	// a runner going first to third on a single can take the extra base on an error
+	apply(t, s, play(PlaySingle), play(PlaySingle, Advance{From: First, To: Third, Reason: ReasonError}, Advance{From: Batter, To: First}))
+	pa = s.PlateAppearances[2]
+	require.Equal(t, ReasonError, pa.Runners[0].Reason)
+	require.Equal(t, ReasonHit, pa.Runners[1].Reason)
+	require.Equal(t, [3]string{"a3", "", "a2"}, pa.EndBases)
+
+	_, err := Decode([]byte(`{"type":"play","play":{"kind":"single","advances":[{"from":0,"to":1,"reason":"luck"}]}}`))
+	require.EqualError(t, err, "luck is not a reason to advance")
+}
+
+func TestState_InheritedRunners(t *testing.T) {
+	s := newTestState()
+	walk(t, s)
+
+	// the reliever inherits the runner on first, who stays charged to the starter
+	apply(t, s, substitution(true, Change{ReplacesID: "h9", InID: "rp", BatPosition: 9, Position: util.Pitcher}))
+	require.Equal(t, Runner{PitcherID: "h9"}, s.Runners[0])
+
+	apply(t, s, play(PlayDouble, Advance{From: First, To: Home}, Advance{From: Batter, To: Second}))
+	apply(t, s, play(PlaySingle, Advance{From: Second, To: Home}, Advance{From: Batter, To: First}))
+
+	runs := append(scored(s.PlateAppearances[1]), scored(s.PlateAppearances[2])...)
+	require.Len(t, runs, 2)
+	require.Equal(t, "h9", runs[0].PitcherID)
+	require.Equal(t, "rp", runs[1].PitcherID)
+	require.Equal(t, "rp", s.PlateAppearances[1].PitcherID)
+}
@@ -0,0 +1,725 @@
+package scoring
+
+import (
+	"fmt"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"slices"
+	"sort"
+)
+
+// outsPerHalf is how many outs end a half inning
+const outsPerHalf int64 = 3
+
+// ResultIncomplete ends a plate appearance cut short by the third out on the bases or the end of the game.
+// The batter leads off the next inning.
+const ResultIncomplete = "incomplete"
+
+// LineupEntry is a starting participant. IDs are game participant IDs.
+type LineupEntry struct {
+	ID          string
+	BatPosition int64
+	Position    util.BaseballPosition
+}
+
+// Lineup is one team's batting order and current pitcher
+type Lineup struct {
+	// Order is the batting order; Order[i] bats in slot i+1
+	Order   []string `json:"order"`
+	Pitcher string   `json:"pitcher"`
+	// Due is the index in Order of the next batter
+	Due int `json:"due"`
+}
+
+// InningLine is the runs, hits and errors for each team in one inning, with the last batter of each half
+type InningLine struct {
+	Number      int64  `json:"number"`
+	AwayRuns    int64  `json:"away_runs"`
+	AwayHits    int64  `json:"away_hits"`
+	AwayErrors  int64  `json:"away_errors"`
+	AwayLastBat string `json:"away_last_bat,omitempty"`
+	HomeRuns    int64  `json:"home_runs"`
+	HomeHits    int64  `json:"home_hits"`
+	HomeErrors  int64  `json:"home_errors"`
+	HomeLastBat string `json:"home_last_bat,omitempty"`
+}
+
+// PitchRecord is a pitch in a plate appearance, with the count after it and the event that recorded it
+type PitchRecord struct {
+	Sequence int64          `json:"sequence"`
+	Type     util.PitchType `json:"type"`
+	Balls    int64          `json:"balls"`
+	Strikes  int64          `json:"strikes"`
+	// PitcherID is who threw the pitch, which differs from the plate appearance's pitcher after a change mid at-bat
+	PitcherID string `json:"pitcher_id"`
+}
+
+// PlateAppearance is one batter's turn at the plate. Result is empty while it is in progress.
+type PlateAppearance struct {
+	Number    int64           `json:"number"`
+	Inning    int64           `json:"inning"`
+	Half      util.InningHalf `json:"half"`
+	BatterID  string          `json:"batter_id"`
+	PitcherID string          `json:"pitcher_id"`
+	Balls     int64           `json:"balls"`
+	Strikes   int64           `json:"strikes"`
+	Pitches   []PitchRecord   `json:"pitches"`
+	// StartBases and EndBases are the runners on first, second and third when the batter came up and left
+	StartBases [3]string    `json:"start_bases"`
+	EndBases   [3]string    `json:"end_bases"`
+	Runners    []RunnerMove `json:"runners"`
+	RBI        int64        `json:"rbi"`
+	// Errors lists the fielders charged with an error during the plate appearance
+	Errors     []string `json:"errors,omitempty"`
+	Result     string   `json:"result,omitempty"`
+	Out        bool     `json:"out"`
+	InitBases  int64    `json:"init_bases"`
+	TotalBases int64    `json:"total_bases"`
+}
+
+// State is a game as it stands after replaying its events
+type State struct {
+	Inning    int64           `json:"inning"`
+	Half      util.InningHalf `json:"half"`
+	Outs      int64           `json:"outs"`
+	Balls     int64           `json:"balls"`
+	Strikes   int64           `json:"strikes"`
+	HomeScore int64           `json:"home_score"`
+	AwayScore int64           `json:"away_score"`
+	// Bases holds the participant on first, second and third, or "" when the base is empty
+	Bases [3]string `json:"bases"`
+	// Runners describes how each runner in Bases reached base
+	Runners [3]Runner `json:"runners"`
+	Home    Lineup    `json:"home"`
+	Away    Lineup    `json:"away"`
+	// AwaitingPlay is set after a pitch is put in play, until the play is recorded
+	AwaitingPlay bool `json:"awaiting_play"`
+	Final        bool `json:"final"`
+	// Mercy is set when the mercy rule ended the game
+	Mercy bool `json:"mercy,omitempty"`
+	// EndReason is why a game_end event ended the game before it was decided
+	EndReason        GameEndReason     `json:"end_reason,omitempty"`
+	Innings          []InningLine      `json:"innings"`
+	PlateAppearances []PlateAppearance `json:"plate_appearances"`
+	// Sequence is the number of events applied
+	Sequence int64 `json:"sequence"`
+	// Rules are the rules the game is played under
+	Rules util.RuleSet `json:"rules"`
+
+	// errorOuts is how many more outs the fielding team would have made in the half inning without
+	// its errors. Once they and the outs reach three, no more runs in the half inning are earned.
+	errorOuts int64
+}
+
+// NewState returns the state of a game played under rules that has not had a pitch, with the given starting lineups.
+func NewState(rules util.RuleSet, home, away []LineupEntry) *State {
+	return &State{
+		Inning:  1,
+		Half:    util.HalfTop,
+		Home:    newLineup(home),
+		Away:    newLineup(away),
+		Innings: []InningLine{{Number: 1}},
+		Rules:   rules,
+	}
+}
+
+// Replay applies events in order to a new game played under rules and returns the result.
+// The error names the first event that could not be applied.
+func Replay(rules util.RuleSet, home, away []LineupEntry, events []Event) (*State, error) {
+	state := NewState(rules, home, away)
+	for _, event := range events {
+		if err := state.Apply(event); err != nil {
+			return state, fmt.Errorf("event %d: %w", state.Sequence+1, err)
+		}
+	}
+	return state, nil
+}
+
+// Batter returns the participant due up, or "" if the batting team has no lineup
+func (s *State) Batter() string {
+	lineup := s.batting()
+	if len(lineup.Order) == 0 {
+		return ""
+	}
+	return lineup.Order[lineup.Due]
+}
+
+// Apply applies one event. The state is unchanged when an error is returned.
+func (s *State) Apply(e Event) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+	if s.Final {
+		return fmt.Errorf("the game is over")
+	}
+
+	next := s.clone()
+	var err error
+	switch e.Type {
+	case EventPitch:
+		err = next.pitch(*e.Pitch)
+	case EventPlay:
+		err = next.play(*e.Play)
+	case EventSubstitution:
+		err = next.substitute(*e.Substitution)
+	case EventInningEnd:
+		err = next.endInning()
+	case EventGameEnd:
+		err = next.end(e.GameEnd)
+	}
+	if err != nil {
+		return err
+	}
+
+	// the home team can win by the mercy rule in the middle of its half
+	if !next.Final && next.mercy(false) {
+		next.endByMercy()
+	}
+	// and wins as soon as it takes the lead in the bottom of the last regulation inning or later
+	if !next.Final && next.decided(false) {
+		next.finish()
+	}
+	// reaching the run cap ends the half inning without waiting for an inning_end
+	if !next.Final && next.capped() {
+		if err := next.endInning(); err != nil {
+			return err
+		}
+	}
+
+	next.Sequence++
+	*s = *next
+	return nil
+}
+
+func (s *State) pitch(p Pitch) error {
+	if err := s.ready(); err != nil {
+		return err
+	}
+	if s.AwaitingPlay {
+		return fmt.Errorf("the play on the last pitch has not been recorded")
+	}
+
+	pa, err := s.plateAppearance()
+	if err != nil {
+		return err
+	}
+	balls, strikes, result, err := util.ApplyPitch(s.Balls, s.Strikes, p.Type)
+	if err != nil {
+		return err
+	}
+	s.Balls, s.Strikes = balls, strikes
+	pa.Balls, pa.Strikes = balls, strikes
+	pitcher := s.fielding().Pitcher
+	if pitcher == "" {
+		return fmt.Errorf("the fielding team has no pitcher")
+	}
+	pa.Pitches = append(pa.Pitches, PitchRecord{Sequence: s.Sequence + 1, Type: p.Type, Balls: balls, Strikes: strikes, PitcherID: pitcher})
+
+	switch result {
+	case util.AtBatWalk, util.AtBatHitByPitch:
+		reason := ReasonWalk
+		if result == util.AtBatHitByPitch {
+			reason = ReasonHitByPitch
+		}
+		s.advance(withReason(forcedAdvances(s.Bases), reason), pa, true)
+		pa.InitBases = 1
+		s.endPlateAppearance(pa, string(result))
+	case util.AtBatStrikeout:
+		s.Outs++
+		pa.Out = true
+		s.endPlateAppearance(pa, string(result))
+	case util.AtBatInPlay:
+		s.AwaitingPlay = true
+	}
+	return nil
+}
+
+func (s *State) play(p Play) error {
+	if err := s.ready(); err != nil {
+		return err
+	}
+	if !p.Kind.batting() && s.AwaitingPlay {
+		return fmt.Errorf("the ball in play needs a batting play, not a %s", p.Kind)
+	}
+
+	advances, err := p.advances(s.Bases)
+	if err != nil {
+		return err
+	}
+	fielders := s.fielding()
+	for _, fielder := range p.ErrorsBy {
+		if fielder != fielders.Pitcher && !slices.Contains(fielders.Order, fielder) {
+			return fmt.Errorf("%s is not fielding", fielder)
+		}
+	}
+
+	// baserunning plays belong to the plate appearance going on when they happen
+	pa, err := s.plateAppearance()
+	if err != nil {
+		return err
+	}
+	if err := s.checkAdvances(advances, p.Kind.batting()); err != nil {
+		return err
+	}
+
+	// a batter who hits into a double play does not drive in a run
+	var outs int64
+	for _, advance := range advances {
+		if advance.Out {
+			outs++
+		}
+	}
+	s.advance(advances, pa, p.Kind.batting() && outs < 2)
+	s.addErrors(p.charged())
+	pa.Errors = append(pa.Errors, p.ErrorsBy...)
+
+	if p.Kind.batting() {
+		if hit := p.Kind.bases(); hit > 0 {
+			s.addHit()
+			pa.TotalBases = int64(hit)
+		}
+		for _, advance := range advances {
+			if advance.From == Batter {
+				pa.Out = advance.Out
+				if !advance.Out {
+					pa.InitBases = int64(advance.To)
+				}
+			}
+		}
+		s.AwaitingPlay = false
+		s.endPlateAppearance(pa, string(p.Kind))
+	}
+	return nil
+}
+
+// substitute takes every replaced participant out before putting anyone in, so players can swap
+// batting slots and a participant brought in can be replaced again within the same substitution.
+func (s *State) substitute(sub Substitution) error {
+	lineup := &s.Away
+	if sub.Home {
+		lineup = &s.Home
+	}
+	battingHome := s.battingHome() == sub.Home
+
+	coming := make(map[string]bool, len(sub.Changes))
+	for _, change := range sub.Changes {
+		replaced := lineup.Pitcher == change.ReplacesID || coming[change.ReplacesID]
+		for i, id := range lineup.Order {
+			if id == change.ReplacesID {
+				lineup.Order[i] = ""
+				replaced = true
+			}
+		}
+		if !replaced {
+			return fmt.Errorf("participant %s is not in the game", change.ReplacesID)
+		}
+		if lineup.Pitcher == change.ReplacesID {
+			lineup.Pitcher = ""
+		}
+		delete(coming, change.ReplacesID)
+		coming[change.InID] = true
+
+		// a pinch runner takes the runner's place on base
+		if battingHome {
+			for i, id := range s.Bases {
+				if id == change.ReplacesID {
+					s.Bases[i] = change.InID
+				}
+			}
+		}
+	}
+
+	for _, change := range sub.Changes {
+		if !coming[change.InID] {
+			continue
+		}
+		if change.BatPosition > 0 {
+			slot := change.BatPosition - 1
+			if slot >= int64(len(lineup.Order)) || lineup.Order[slot] != "" {
+				return fmt.Errorf("batting slot %d is not open", change.BatPosition)
+			}
+			lineup.Order[slot] = change.InID
+		}
+		if change.Position == util.Pitcher {
+			lineup.Pitcher = change.InID
+		}
+	}
+
+	for i, id := range lineup.Order {
+		if id == "" {
+			return fmt.Errorf("batting slot %d is empty", i+1)
+		}
+	}
+	return nil
+}
+
+func (s *State) endInning() error {
+	if s.Outs < outsPerHalf && !s.capped() {
+		return fmt.Errorf("the half inning has %d outs", s.Outs)
+	}
+
+	s.closePlateAppearance()
+	if s.mercy(true) {
+		s.endByMercy()
+		return nil
+	}
+	if s.decided(true) {
+		s.finish()
+		return nil
+	}
+	if s.Half == util.HalfTop {
+		s.Half = util.HalfBottom
+	} else {
+		s.Half = util.HalfTop
+		s.Inning++
+		s.Innings = append(s.Innings, InningLine{Number: s.Inning})
+	}
+	s.Outs, s.Balls, s.Strikes, s.errorOuts = 0, 0, 0, 0
+	s.Bases = [3]string{}
+	s.Runners = [3]Runner{}
+
+	// in extra innings under a tiebreaker, the batter before the leadoff hitter starts on second.
+	// A run they score is charged to the pitcher but is unearned, as if they had reached on an error.
+	if s.Rules.Tiebreaker(s.Inning) {
+		if lineup := s.batting(); len(lineup.Order) > 0 {
+			s.Bases[Second-1] = lineup.Order[(lineup.Due+len(lineup.Order)-1)%len(lineup.Order)]
+			s.Runners[Second-1] = Runner{PitcherID: s.fielding().Pitcher, ReachedOnError: true}
+		}
+	}
+	return nil
+}
+
+// ready reports whether the half inning can go on
+func (s *State) ready() error {
+	if s.Outs >= outsPerHalf || s.capped() {
+		return fmt.Errorf("the half inning is over")
+	}
+	return nil
+}
+
+// halfRuns returns the runs the batting team has scored in the half inning
+func (s *State) halfRuns() int64 {
+	if s.battingHome() {
+		return s.inningLine().HomeRuns
+	}
+	return s.inningLine().AwayRuns
+}
+
+// capped reports whether the batting team has scored the most runs the rules allow in the half inning
+func (s *State) capped() bool {
+	limit := s.Rules.RunCap(s.Inning)
+	return limit > 0 && s.halfRuns() >= limit
+}
+
+// mercy reports whether the mercy rule ends the game now. Until the half inning is over only
+// the home team can end it, since the visitors are owed their turn at bat.
+func (s *State) mercy(halfOver bool) bool {
+	lead := s.Rules.MercyLead(s.Inning)
+	if lead == 0 {
+		return false
+	}
+	diff := s.HomeScore - s.AwayScore
+	if s.Half == util.HalfBottom && halfOver && diff < 0 {
+		diff = -diff
+	}
+	return (halfOver || s.Half == util.HalfBottom) && diff >= lead
+}
+
+// endByMercy ends the game where it stands
+func (s *State) endByMercy() {
+	s.finish()
+	s.Mercy = true
+}
+
+// decided reports whether the game is over after its regulation innings: once the home team leads
+// in the last regulation inning or later, the home team does not need to bat, and any other lead
+// stands once both teams have batted in the inning. Games without regulation innings are never decided.
+func (s *State) decided(halfOver bool) bool {
+	if s.Rules.RegulationInnings == 0 || s.Inning < s.Rules.RegulationInnings {
+		return false
+	}
+	if s.HomeScore > s.AwayScore {
+		return halfOver || s.Half == util.HalfBottom
+	}
+	return halfOver && s.Half == util.HalfBottom && s.HomeScore != s.AwayScore
+}
+
+// end ends the game on a game_end event. Decided games end by themselves, so without a reason a game
+// can only be ended once its regulation innings are over, to call a tie. A time limit only ends games
+// whose rules have one.
+func (s *State) end(e *GameEnd) error {
+	if s.AwaitingPlay {
+		return fmt.Errorf("the play on the last pitch has not been recorded")
+	}
+
+	var reason GameEndReason
+	if e != nil {
+		reason = e.Reason
+	}
+	switch reason {
+	case "":
+		if s.Rules.RegulationInnings > 0 && s.Inning <= s.Rules.RegulationInnings {
+			return fmt.Errorf("the game has not played its %d regulation innings", s.Rules.RegulationInnings)
+		}
+	case EndTimeLimit:
+		if s.Rules.TimeLimitMinutes == 0 {
+			return fmt.Errorf("the game has no time limit")
+		}
+	}
+
+	s.finish()
+	s.EndReason = reason
+	return nil
+}
+
+// finish ends the game where it stands
+func (s *State) finish() {
+	s.closePlateAppearance()
+	s.AwaitingPlay = false
+	s.Final = true
+}
+
+// plateAppearance returns the plate appearance in progress, starting one if the last has ended.
+func (s *State) plateAppearance() (*PlateAppearance, error) {
+	if n := len(s.PlateAppearances); n > 0 && s.PlateAppearances[n-1].Result == "" {
+		return &s.PlateAppearances[n-1], nil
+	}
+
+	batter := s.Batter()
+	if batter == "" {
+		return nil, fmt.Errorf("the batting team has no lineup")
+	}
+	pitcher := s.fielding().Pitcher
+	if pitcher == "" {
+		return nil, fmt.Errorf("the fielding team has no pitcher")
+	}
+
+	s.PlateAppearances = append(s.PlateAppearances, PlateAppearance{
+		Number:     int64(len(s.PlateAppearances) + 1),
+		Inning:     s.Inning,
+		Half:       s.Half,
+		BatterID:   batter,
+		PitcherID:  pitcher,
+		Pitches:    []PitchRecord{},
+		StartBases: s.Bases,
+		Runners:    []RunnerMove{},
+	})
+	return &s.PlateAppearances[len(s.PlateAppearances)-1], nil
+}
+
+// closePlateAppearance marks a plate appearance still in progress as incomplete without bringing up the next batter
+func (s *State) closePlateAppearance() {
+	if n := len(s.PlateAppearances); n > 0 && s.PlateAppearances[n-1].Result == "" {
+		s.PlateAppearances[n-1].Result = ResultIncomplete
+		s.PlateAppearances[n-1].EndBases = s.Bases
+	}
+}
+
+// endPlateAppearance finishes the batter's turn and brings up the next batter
+func (s *State) endPlateAppearance(pa *PlateAppearance, result string) {
+	pa.Result = result
+	pa.EndBases = s.Bases
+	s.Balls, s.Strikes = 0, 0
+
+	lineup := s.batting()
+	lineup.Due = (lineup.Due + 1) % len(lineup.Order)
+
+	line := s.inningLine()
+	if s.battingHome() {
+		line.HomeLastBat = pa.BatterID
+	} else {
+		line.AwayLastBat = pa.BatterID
+	}
+}
+
+// checkAdvances rejects runner movement that does not fit the bases as they stand
+func (s *State) checkAdvances(advances []Advance, batting bool) error {
+	moved := make(map[Base]bool)
+	var outs int64
+	for _, advance := range advances {
+		if advance.From == Batter && !batting {
+			return fmt.Errorf("the batter can only move on a batting play")
+		}
+		if advance.From < Batter || advance.From > Third || advance.To < First || advance.To > Home {
+			return fmt.Errorf("a runner cannot move from %d to %d", advance.From, advance.To)
+		}
+		if advance.From != Batter && s.Bases[advance.From-1] == "" {
+			return fmt.Errorf("there is no runner on %d", advance.From)
+		}
+		if moved[advance.From] {
+			return fmt.Errorf("the runner on %d moves twice", advance.From)
+		}
+		moved[advance.From] = true
+		if advance.To < advance.From {
+			return fmt.Errorf("a runner cannot move back from %d to %d", advance.From, advance.To)
+		}
+		if advance.Out {
+			outs++
+		}
+	}
+	if batting && !moved[Batter] {
+		return fmt.Errorf("a batting play must say where the batter went")
+	}
+	if s.Outs+outs > outsPerHalf {
+		return fmt.Errorf("the play makes more than %d outs", outsPerHalf)
+	}
+
+	// runners who do not move stay put, and no two runners may end on the same base
+	taken := make(map[Base]bool)
+	for base := First; base <= Third; base++ {
+		if s.Bases[base-1] != "" && !moved[base] {
+			taken[base] = true
+		}
+	}
+	for _, advance := range advances {
+		if advance.Out || advance.To == Home {
+			continue
+		}
+		if taken[advance.To] {
+			return fmt.Errorf("two runners end on %d", advance.To)
+		}
+		taken[advance.To] = true
+	}
+	return nil
+}
+
+// advance moves runners, scoring those who reach home and counting those put out, and records
+// each movement in the plate appearance. Runs are RBIs when drivesIn is set and the reason allows,
+// and are earned unless the runner reached or scored because of an error or passed ball, or the
+// fielders would already have ended the half inning without their errors.
+// Runners who reach home after the run cap is reached leave the bases without scoring.
+// Runners are moved from the lead runner back so that they do not pass each other.
+func (s *State) advance(advances []Advance, pa *PlateAppearance, drivesIn bool) {
+	ordered := append([]Advance{}, advances...)
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].From > ordered[j].From })
+
+	for _, advance := range ordered {
+		if advance.From == Batter && !advance.Out && advance.Reason == ReasonError {
+			s.errorOuts++
+		}
+	}
+	inningOver := s.Outs+s.errorOuts >= outsPerHalf
+
+	bases, runners := s.Bases, s.Runners
+	limit := s.Rules.RunCap(s.Inning)
+	var runs int64
+	for _, advance := range ordered {
+		var id string
+		var runner Runner
+		if advance.From == Batter {
+			id = pa.BatterID
+			runner = Runner{PitcherID: pa.PitcherID, ReachedOnError: advance.Reason == ReasonError}
+		} else {
+			id, runner = s.Bases[advance.From-1], s.Runners[advance.From-1]
+			if bases[advance.From-1] == id {
+				bases[advance.From-1] = ""
+				runners[advance.From-1] = Runner{}
+			}
+		}
+
+		move := RunnerMove{RunnerID: id, From: advance.From, To: advance.To, Out: advance.Out, Reason: advance.Reason}
+		switch {
+		case advance.Out:
+			s.Outs++
+		case advance.To == Home && limit > 0 && s.halfRuns()+runs >= limit:
+		case advance.To == Home:
+			runs++
+			move.Scored = true
+			move.RBI = drivesIn && advance.Reason.drivesIn()
+			move.Earned = !inningOver && !runner.ReachedOnError && advance.Reason.earned()
+			move.PitcherID = runner.PitcherID
+			if move.RBI {
+				pa.RBI++
+			}
+		default:
+			bases[advance.To-1] = id
+			runners[advance.To-1] = runner
+		}
+		pa.Runners = append(pa.Runners, move)
+	}
+	s.Bases, s.Runners = bases, runners
+	s.addRuns(runs)
+}
+
+func (s *State) addRuns(runs int64) {
+	line := s.inningLine()
+	if s.battingHome() {
+		s.HomeScore += runs
+		line.HomeRuns += runs
+	} else {
+		s.AwayScore += runs
+		line.AwayRuns += runs
+	}
+}
+
+func (s *State) addHit() {
+	line := s.inningLine()
+	if s.battingHome() {
+		line.HomeHits++
+	} else {
+		line.AwayHits++
+	}
+}
+
+// addErrors charges errors to the fielding team
+func (s *State) addErrors(charged int64) {
+	line := s.inningLine()
+	if s.battingHome() {
+		line.AwayErrors += charged
+	} else {
+		line.HomeErrors += charged
+	}
+}
+
+func (s *State) inningLine() *InningLine {
+	return &s.Innings[len(s.Innings)-1]
+}
+
+func (s *State) battingHome() bool {
+	return util.BattingSide(s.Half)
+}
+
+func (s *State) batting() *Lineup {
+	if s.battingHome() {
+		return &s.Home
+	}
+	return &s.Away
+}
+
+func (s *State) fielding() *Lineup {
+	if s.battingHome() {
+		return &s.Away
+	}
+	return &s.Home
+}
+
+// clone returns a copy of the state that shares nothing that Apply changes
+func (s *State) clone() *State {
+	next := *s
+	next.Home.Order = append([]string{}, s.Home.Order...)
+	next.Away.Order = append([]string{}, s.Away.Order...)
+	next.Innings = append([]InningLine{}, s.Innings...)
+	next.PlateAppearances = make([]PlateAppearance, len(s.PlateAppearances))
+	for i, pa := range s.PlateAppearances {
+		pa.Pitches = append([]PitchRecord{}, pa.Pitches...)
+		pa.Runners = append([]RunnerMove{}, pa.Runners...)
+		pa.Errors = append([]string(nil), pa.Errors...)
+		next.PlateAppearances[i] = pa
+	}
+	return &next
+}
+
+func newLineup(entries []LineupEntry) Lineup {
+	sorted := append([]LineupEntry{}, entries...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].BatPosition < sorted[j].BatPosition })
+
+	var lineup Lineup
+	for _, entry := range sorted {
+		if entry.BatPosition > 0 {
+			lineup.Order = append(lineup.Order, entry.ID)
+		}
+		if entry.Position == util.Pitcher {
+			lineup.Pitcher = entry.ID
+		}
+	}
+	return lineup
+}
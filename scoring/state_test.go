@@ -0,0 +1,555 @@
+package scoring
+
+import (
+	"fmt"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// nineEntries returns a lineup of participants named prefix1 through prefix9, with prefix9 pitching
+func nineEntries(prefix string) []LineupEntry {
+	entries := make([]LineupEntry, 9)
+	for i := range entries {
+		entries[i] = LineupEntry{ID: fmt.Sprintf("%s%d", prefix, i+1), BatPosition: int64(i + 1), Position: util.CenterField}
+	}
+	entries[8].Position = util.Pitcher
+	return entries
+}
+
+func newTestState() *State {
+	return NewState(util.RuleSet{}, nineEntries("h"), nineEntries("a"))
+}
+
+func pitch(pitchType util.PitchType) Event {
+	return Event{Type: EventPitch, Pitch: &Pitch{Type: pitchType}}
+}
+
+func play(kind PlayKind, advances ...Advance) Event {
+	return Event{Type: EventPlay, Play: &Play{Kind: kind, Advances: advances}}
+}
+
+func apply(t *testing.T, s *State, events ...Event) {
+	for _, event := range events {
+		require.NoError(t, s.Apply(event))
+	}
+}
+
+func walk(t *testing.T, s *State) {
+	apply(t, s, pitch(util.PitchBall), pitch(util.PitchBall), pitch(util.PitchBall), pitch(util.PitchBall))
+}
+
+func strikeout(t *testing.T, s *State) {
+	apply(t, s, pitch(util.PitchCalledStrike), pitch(util.PitchCalledStrike), pitch(util.PitchSwingingStrike))
+}
+
+func TestState_BasesLoadedWalk(t *testing.T) {
+	s := newTestState()
+	require.Equal(t, "a1", s.Batter())
+
+	for i := 0; i < 4; i++ {
+		walk(t, s)
+	}
+	require.Equal(t, int64(1), s.AwayScore)
+	require.Equal(t, [3]string{"a4", "a3", "a2"}, s.Bases)
+	require.Equal(t, "a5", s.Batter())
+	require.Equal(t, int64(1), s.Innings[0].AwayRuns)
+	require.Len(t, s.PlateAppearances, 4)
+	require.Equal(t, string(util.AtBatWalk), s.PlateAppearances[3].Result)
+	require.Equal(t, "h9", s.PlateAppearances[3].PitcherID)
+	require.Len(t, s.PlateAppearances[3].Pitches, 4)
+	require.Equal(t, int64(16), s.Sequence)
+}
+
+func TestState_StrikeoutAfterFouls(t *testing.T) {
+	s := newTestState()
+	apply(t, s, pitch(util.PitchFoul), pitch(util.PitchFoul), pitch(util.PitchFoul), pitch(util.PitchBall))
+	require.Equal(t, int64(1), s.Balls)
+	require.Equal(t, int64(2), s.Strikes)
+
+	apply(t, s, pitch(util.PitchSwingingStrike))
+	require.Equal(t, int64(1), s.Outs)
+	require.Equal(t, int64(0), s.Balls)
+	require.Equal(t, int64(0), s.Strikes)
+	require.True(t, s.PlateAppearances[0].Out)
+	require.Equal(t, string(util.AtBatStrikeout), s.PlateAppearances[0].Result)
+	require.Len(t, s.PlateAppearances[0].Pitches, 5)
+}
+
+func TestState_Hits(t *testing.T) {
+	s := newTestState()
+	apply(t, s, pitch(util.PitchInPlay))
+	require.True(t, s.AwaitingPlay)
+
+	// a pitch cannot be thrown before the ball in play is scored
+	require.Error(t, s.Apply(pitch(util.PitchBall)))
+	require.Error(t, s.Apply(play(PlayStolenBase, Advance{From: First, To: Second})))
+
+	apply(t, s, play(PlayDouble))
+	require.Equal(t, [3]string{"", "a1", ""}, s.Bases)
+	require.Equal(t, int64(2), s.PlateAppearances[0].TotalBases)
+
+	apply(t, s, play(PlaySingle))
+	require.Equal(t, [3]string{"a2", "", "a1"}, s.Bases)
+
+	apply(t, s, play(PlayHomeRun))
+	require.Equal(t, int64(3), s.AwayScore)
+	require.Equal(t, [3]string{}, s.Bases)
+	require.Equal(t, int64(3), s.Innings[0].AwayHits)
+	require.Equal(t, int64(4), s.PlateAppearances[2].InitBases)
+}
+
+func TestState_ExplicitAdvances(t *testing.T) {
+	s := newTestState()
+	walk(t, s)
+	walk(t, s)
+
+	// the lead runner is thrown out at third and the batter reaches on a fielder's choice
+	require.Error(t, s.Apply(play(PlayFieldersChoice)))
+	apply(t, s, play(PlayFieldersChoice,
+		Advance{From: Second, To: Third, Out: true},
+		Advance{From: First, To: Second},
+		Advance{From: Batter, To: First},
+	))
+	require.Equal(t, [3]string{"a3", "a2", ""}, s.Bases)
+	require.Equal(t, int64(1), s.Outs)
+
+	// two runners cannot end on the same base
+	err := s.Apply(play(PlaySingle, Advance{From: Batter, To: Second}))
+	require.EqualError(t, err, "two runners end on 2")
+
+	// there is no runner on third to steal home
+	err = s.Apply(play(PlayStolenBase, Advance{From: Third, To: Home}))
+	require.EqualError(t, err, "there is no runner on 3")
+
+	apply(t, s, play(PlayError, Advance{From: Batter, To: Second}, Advance{From: First, To: Home}, Advance{From: Second, To: Home}))
+	require.Equal(t, int64(2), s.AwayScore)
+	require.Equal(t, int64(1), s.Innings[0].HomeErrors)
+	require.Equal(t, int64(0), s.Innings[0].AwayHits)
+}
+
+func TestState_InningEnd(t *testing.T) {
+	s := newTestState()
+	strikeout(t, s)
+	strikeout(t, s)
+	require.EqualError(t, s.Apply(Event{Type: EventInningEnd}), "the half inning has 2 outs")
+
+	walk(t, s)
+	apply(t, s, play(PlayOut))
+	require.Equal(t, int64(3), s.Outs)
+	require.EqualError(t, s.Apply(pitch(util.PitchBall)), "the half inning is over")
+
+	apply(t, s, Event{Type: EventInningEnd})
+	require.Equal(t, util.HalfBottom, s.Half)
+	require.Equal(t, int64(0), s.Outs)
+	require.Equal(t, [3]string{}, s.Bases)
+	require.Equal(t, "h1", s.Batter())
+	require.Equal(t, "a4", s.Innings[0].AwayLastBat)
+
+	for i := 0; i < 3; i++ {
+		strikeout(t, s)
+	}
+	apply(t, s, Event{Type: EventInningEnd})
+	require.Equal(t, int64(2), s.Inning)
+	require.Equal(t, util.HalfTop, s.Half)
+	require.Len(t, s.Innings, 2)
+	require.Equal(t, "a5", s.Batter())
+}
+
+func TestState_CaughtStealingEndsInning(t *testing.T) {
+	s := newTestState()
+	strikeout(t, s)
+	strikeout(t, s)
+	walk(t, s)
+	apply(t, s, pitch(util.PitchBall))
+	apply(t, s, play(PlayCaughtStealing, Advance{From: First, To: Second, Out: true}))
+	require.Equal(t, int64(3), s.Outs)
+
+	apply(t, s, Event{Type: EventInningEnd})
+	require.Equal(t, ResultIncomplete, s.PlateAppearances[3].Result)
+	require.Len(t, s.PlateAppearances[3].Pitches, 1)
+
+	// the batter who was up leads off the next time the team bats
+	for i := 0; i < 3; i++ {
+		strikeout(t, s)
+	}
+	apply(t, s, Event{Type: EventInningEnd})
+	require.Equal(t, "a4", s.Batter())
+}
+
+func TestState_ErrorsBy(t *testing.T) {
+	s := newTestState()
+
+	// the shortstop boots a grounder
+	apply(t, s, Event{Type: EventPlay, Play: &Play{Kind: PlayError, ErrorsBy: []string{"h6"}}})
+	require.Equal(t, []string{"h6"}, s.PlateAppearances[0].Errors)
+	require.Equal(t, int64(1), s.Innings[0].HomeErrors)
+
+	// two errors on one single, both named
+	apply(t, s, Event{Type: EventPlay, Play: &Play{Kind: PlaySingle, ErrorsBy: []string{"h7", "h2"}}})
+	require.Equal(t, int64(3), s.Innings[0].HomeErrors)
+
+	err := s.Apply(Event{Type: EventPlay, Play: &Play{Kind: PlayError, ErrorsBy: []string{"a4"}}})
+	require.EqualError(t, err, "a4 is not fielding")
+	err = s.Apply(Event{Type: EventPlay, Play: &Play{Kind: PlayError, Errors: 1, ErrorsBy: []string{"h1", "h2"}}})
+	require.EqualError(t, err, "1 errors cannot be charged to 2 fielders")
+}
+
+func substitution(home bool, changes ...Change) Event {
+	return Event{Type: EventSubstitution, Substitution: &Substitution{Home: home, Changes: changes}}
+}
+
+func TestState_Substitution(t *testing.T) {
+	s := newTestState()
+	walk(t, s)
+
+	apply(t, s, substitution(false, Change{ReplacesID: "a1", InID: "pr", BatPosition: 1, Position: util.CenterField}))
+	require.Equal(t, "pr", s.Bases[0])
+	require.Equal(t, "pr", s.Away.Order[0])
+
+	apply(t, s, substitution(true, Change{ReplacesID: "h9", InID: "rp", BatPosition: 9, Position: util.Pitcher}))
+	require.Equal(t, "rp", s.Home.Pitcher)
+
+	apply(t, s, pitch(util.PitchBall))
+	require.Equal(t, "rp", s.PlateAppearances[1].PitcherID)
+
+	err := s.Apply(substitution(false, Change{ReplacesID: "a1", InID: "x"}))
+	require.EqualError(t, err, "participant a1 is not in the game")
+
+	// a new player cannot take a slot without someone leaving it
+	err = s.Apply(substitution(false, Change{ReplacesID: "a2", InID: "x", BatPosition: 3}))
+	require.EqualError(t, err, "batting slot 3 is not open")
+}
+
+func TestState_PitchingChangeMidAtbat(t *testing.T) {
+	s := newTestState()
+	apply(t, s, pitch(util.PitchBall), pitch(util.PitchBall))
+	apply(t, s, substitution(true, Change{ReplacesID: "h9", InID: "rp", BatPosition: 9, Position: util.Pitcher}))
+	apply(t, s, pitch(util.PitchBall))
+
+	// the plate appearance stays the starter's, but the pitch is the reliever's
+	pa := s.PlateAppearances[0]
+	require.Equal(t, "h9", pa.PitcherID)
+	require.Equal(t, "h9", pa.Pitches[1].PitcherID)
+	require.Equal(t, "rp", pa.Pitches[2].PitcherID)
+}
+
+func TestState_DoubleSwitch(t *testing.T) {
+	s := newTestState()
+
+	// the new pitcher bats fifth and the new fifth-place hitter's replacement bats ninth
+	apply(t, s, substitution(true,
+		Change{ReplacesID: "h9", InID: "rp", BatPosition: 5, Position: util.Pitcher},
+		Change{ReplacesID: "h5", InID: "lf", BatPosition: 9, Position: util.LeftField},
+	))
+	require.Equal(t, "rp", s.Home.Order[4])
+	require.Equal(t, "lf", s.Home.Order[8])
+	require.Equal(t, "rp", s.Home.Pitcher)
+
+	// a participant brought in can be replaced again in the same substitution
+	apply(t, s, substitution(false,
+		Change{ReplacesID: "a1", InID: "ph", BatPosition: 1},
+		Change{ReplacesID: "ph", InID: "ph2", BatPosition: 1, Position: util.CenterField},
+	))
+	require.Equal(t, "ph2", s.Away.Order[0])
+
+	// leaving a slot empty is rejected and changes nothing
+	err := s.Apply(substitution(true, Change{ReplacesID: "h1", InID: "x", BatPosition: 0, Position: util.FirstBase}))
+	require.EqualError(t, err, "batting slot 1 is empty")
+	require.Equal(t, "h1", s.Home.Order[0])
+}
+
+func TestState_GameEnd(t *testing.T) {
+	s := newTestState()
+	apply(t, s, pitch(util.PitchInPlay))
+	require.Error(t, s.Apply(Event{Type: EventGameEnd}))
+
+	apply(t, s, play(PlayOut), pitch(util.PitchBall), Event{Type: EventGameEnd})
+	require.True(t, s.Final)
+	require.Equal(t, ResultIncomplete, s.PlateAppearances[1].Result)
+	require.EqualError(t, s.Apply(pitch(util.PitchBall)), "the game is over")
+}
+
+func TestState_RegulationEnd(t *testing.T) {
+	rules := util.RuleSet{RegulationInnings: 2}
+
+	t.Run("HomeLeadsAfterTop", func(t *testing.T) {
+		s := NewState(rules, nineEntries("h"), nineEntries("a"))
+		threeOutHalf(t, s)
+		apply(t, s, pitch(util.PitchInPlay), play(PlayHomeRun))
+		threeOutHalf(t, s)
+		require.False(t, s.Final)
+
+		// the home team does not bat in the last inning when it leads after the top half
+		threeOutHalf(t, s)
+		require.True(t, s.Final)
+		require.Equal(t, int64(2), s.Inning)
+		require.Equal(t, util.HalfTop, s.Half)
+		require.Len(t, s.Innings, 2)
+	})
+
+	t.Run("AfterFullInning", func(t *testing.T) {
+		s := NewState(rules, nineEntries("h"), nineEntries("a"))
+		threeOutHalf(t, s)
+		threeOutHalf(t, s)
+		apply(t, s, pitch(util.PitchInPlay), play(PlayHomeRun))
+		threeOutHalf(t, s)
+		require.False(t, s.Final)
+
+		threeOutHalf(t, s)
+		require.True(t, s.Final)
+		require.Equal(t, int64(1), s.AwayScore)
+		require.Equal(t, util.HalfBottom, s.Half)
+		require.EqualError(t, s.Apply(pitch(util.PitchBall)), "the game is over")
+	})
+
+	t.Run("TiedGoesToExtras", func(t *testing.T) {
+		s := NewState(rules, nineEntries("h"), nineEntries("a"))
+		threeOutHalf(t, s)
+		threeOutHalf(t, s)
+		threeOutHalf(t, s)
+		threeOutHalf(t, s)
+		require.False(t, s.Final)
+		require.Equal(t, int64(3), s.Inning)
+	})
+
+	t.Run("WalkOff", func(t *testing.T) {
+		s := NewState(rules, nineEntries("h"), nineEntries("a"))
+		threeOutHalf(t, s)
+
+		// a home lead before the last regulation inning does not end the game
+		apply(t, s, pitch(util.PitchInPlay), play(PlayHomeRun))
+		require.False(t, s.Final)
+		threeOutHalf(t, s)
+		apply(t, s, pitch(util.PitchInPlay), play(PlayHomeRun), pitch(util.PitchInPlay), play(PlayHomeRun))
+		threeOutHalf(t, s)
+		walk(t, s)
+		require.False(t, s.Final)
+
+		apply(t, s, pitch(util.PitchInPlay), play(PlayHomeRun))
+		require.True(t, s.Final)
+		require.False(t, s.Mercy)
+		require.False(t, s.AwaitingPlay)
+		require.Equal(t, int64(3), s.HomeScore)
+		require.Equal(t, util.HalfBottom, s.Half)
+	})
+}
+
+func TestState_GameEndBeforeRegulation(t *testing.T) {
+	rules := util.RuleSet{RegulationInnings: 2}
+
+	t.Run("NoReason", func(t *testing.T) {
+		s := NewState(rules, nineEntries("h"), nineEntries("a"))
+		threeOutHalf(t, s)
+		require.EqualError(t, s.Apply(Event{Type: EventGameEnd}), "the game has not played its 2 regulation innings")
+		require.False(t, s.Final)
+	})
+
+	t.Run("TiedAfterRegulation", func(t *testing.T) {
+		s := NewState(rules, nineEntries("h"), nineEntries("a"))
+		for i := 0; i < 4; i++ {
+			threeOutHalf(t, s)
+		}
+		apply(t, s, Event{Type: EventGameEnd})
+		require.True(t, s.Final)
+		require.Empty(t, s.EndReason)
+	})
+
+	t.Run("Suspended", func(t *testing.T) {
+		s := NewState(rules, nineEntries("h"), nineEntries("a"))
+		apply(t, s, pitch(util.PitchBall), Event{Type: EventGameEnd, GameEnd: &GameEnd{Reason: EndSuspended}})
+		require.True(t, s.Final)
+		require.Equal(t, EndSuspended, s.EndReason)
+		require.Equal(t, ResultIncomplete, s.PlateAppearances[0].Result)
+	})
+
+	t.Run("Forfeit", func(t *testing.T) {
+		s := NewState(rules, nineEntries("h"), nineEntries("a"))
+		apply(t, s, Event{Type: EventGameEnd, GameEnd: &GameEnd{Reason: EndForfeit}})
+		require.True(t, s.Final)
+		require.Equal(t, EndForfeit, s.EndReason)
+	})
+
+	t.Run("TimeLimit", func(t *testing.T) {
+		s := NewState(rules, nineEntries("h"), nineEntries("a"))
+		timeLimit := Event{Type: EventGameEnd, GameEnd: &GameEnd{Reason: EndTimeLimit}}
+		require.EqualError(t, s.Apply(timeLimit), "the game has no time limit")
+
+		rules := rules
+		rules.TimeLimitMinutes = 60
+		s = NewState(rules, nineEntries("h"), nineEntries("a"))
+		apply(t, s, timeLimit)
+		require.True(t, s.Final)
+	})
+
+	t.Run("UnknownReason", func(t *testing.T) {
+		s := NewState(rules, nineEntries("h"), nineEntries("a"))
+		err := s.Apply(Event{Type: EventGameEnd, GameEnd: &GameEnd{Reason: "rain"}})
+		require.EqualError(t, err, "rain is not a reason to end a game")
+	})
+}
+
+func TestState_NoLineup(t *testing.T) {
+	s := NewState(util.RuleSet{}, nil, nil)
+	require.EqualError(t, s.Apply(pitch(util.PitchBall)), "the batting team has no lineup")
+	require.Equal(t, int64(0), s.Sequence)
+}
+
+func TestReplay(t *testing.T) {
+	events := []Event{pitch(util.PitchInPlay), play(PlayHomeRun), pitch(util.PitchBall)}
+	s, err := Replay(util.RuleSet{}, nineEntries("h"), nineEntries("a"), events)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), s.AwayScore)
+	require.Equal(t, int64(3), s.Sequence)
+	require.Equal(t, int64(1), s.Balls)
+
+	_, err = Replay(util.RuleSet{}, nineEntries("h"), nineEntries("a"), append(events, play(PlayStolenBase)))
+	require.EqualError(t, err, "event 4: a stolen_base needs the runners' advances")
+}
+
+func TestEncodeDecode(t *testing.T) {
+	event := play(PlayFieldersChoice, Advance{From: First, To: Second, Out: true}, Advance{From: Batter, To: First})
+	payload, err := Encode(event)
+	require.NoError(t, err)
+
+	decoded, err := Decode(payload)
+	require.NoError(t, err)
+	require.Equal(t, event, decoded)
+
+	_, err = Decode([]byte(`{"type":"pitch"}`))
+	require.EqualError(t, err, "a pitch event needs a pitch")
+	_, err = Decode([]byte(`{"type":"play","play":{"kind":"bunt"}}`))
+	require.EqualError(t, err, "bunt is not a kind of play")
+	_, err = Decode([]byte(`{"type":"timeout"}`))
+	require.EqualError(t, err, "timeout is not an event type")
+}
+
+// threeOutHalf strikes out three batters and ends the half inning
+func threeOutHalf(t *testing.T, s *State) {
+	strikeout(t, s)
+	strikeout(t, s)
+	strikeout(t, s)
+	apply(t, s, Event{Type: EventInningEnd})
+}
+
+func TestState_RunCap(t *testing.T) {
+	s := NewState(util.RuleSet{MaxRunsPerInning: 2}, nineEntries("h"), nineEntries("a"))
+	walk(t, s)
+	walk(t, s)
+
+	// the lead runners reach the cap and the batter's run does not count
+	apply(t, s, play(PlayHomeRun))
+	require.Equal(t, int64(2), s.AwayScore)
+	require.Equal(t, int64(2), s.Innings[0].AwayRuns)
+	require.Equal(t, [3]string{}, s.Bases)
+	require.Len(t, scored(s.PlateAppearances[2]), 2)
+	require.Len(t, s.PlateAppearances[2].Runners, 3)
+
+	// the half ends with the cap, so the next pitch is to the home team
+	require.Equal(t, util.HalfBottom, s.Half)
+	require.Equal(t, int64(0), s.Outs)
+	require.EqualError(t, s.Apply(Event{Type: EventInningEnd}), "the half inning has 0 outs")
+
+	// a walk with the bases loaded forces in the run that reaches the cap
+	walk(t, s)
+	walk(t, s)
+	walk(t, s)
+	walk(t, s)
+	walk(t, s)
+	require.Equal(t, int64(2), s.HomeScore)
+	require.Equal(t, int64(2), s.Innings[0].HomeRuns)
+	require.Equal(t, util.HalfTop, s.Half)
+	require.Equal(t, int64(2), s.Inning)
+	require.Equal(t, [3]string{}, s.Bases)
+}
+
+func TestState_RunCapOpenLastInning(t *testing.T) {
+	rules := util.RuleSet{RegulationInnings: 2, MaxRunsPerInning: 1, OpenLastInning: true}
+	s := NewState(rules, nineEntries("h"), nineEntries("a"))
+	threeOutHalf(t, s)
+	threeOutHalf(t, s)
+
+	walk(t, s)
+	apply(t, s, play(PlayHomeRun))
+	require.Equal(t, int64(2), s.AwayScore)
+	require.NoError(t, s.Apply(pitch(util.PitchBall)))
+}
+
+func TestState_MercyRule(t *testing.T) {
+	rules := util.RuleSet{MercyRules: []util.MercyRule{{Inning: 2, Runs: 2}}}
+
+	t.Run("AfterFullInning", func(t *testing.T) {
+		s := NewState(rules, nineEntries("h"), nineEntries("a"))
+		threeOutHalf(t, s)
+		threeOutHalf(t, s)
+		walk(t, s)
+		apply(t, s, play(PlayHomeRun))
+
+		// the visitors' lead cannot end the game before the home team bats
+		threeOutHalf(t, s)
+		require.False(t, s.Final)
+		require.Equal(t, util.HalfBottom, s.Half)
+
+		threeOutHalf(t, s)
+		require.True(t, s.Final)
+		require.True(t, s.Mercy)
+		require.Equal(t, int64(2), s.Inning)
+		require.Len(t, s.Innings, 2)
+		require.EqualError(t, s.Apply(pitch(util.PitchBall)), "the game is over")
+	})
+
+	t.Run("HomeLeadsAfterTop", func(t *testing.T) {
+		s := NewState(rules, nineEntries("h"), nineEntries("a"))
+		threeOutHalf(t, s)
+		walk(t, s)
+		apply(t, s, play(PlayHomeRun))
+
+		// before the inning the rule starts in, a big lead does not end the game
+		threeOutHalf(t, s)
+		require.False(t, s.Final)
+
+		threeOutHalf(t, s)
+		require.True(t, s.Final)
+		require.True(t, s.Mercy)
+		require.Equal(t, util.HalfTop, s.Half)
+	})
+
+	t.Run("WalkOff", func(t *testing.T) {
+		s := NewState(rules, nineEntries("h"), nineEntries("a"))
+		threeOutHalf(t, s)
+		threeOutHalf(t, s)
+		threeOutHalf(t, s)
+		walk(t, s)
+		apply(t, s, pitch(util.PitchBall), play(PlayWildPitch, Advance{From: First, To: Second}))
+		require.False(t, s.Final)
+
+		apply(t, s, pitch(util.PitchInPlay), play(PlayHomeRun))
+		require.True(t, s.Final)
+		require.True(t, s.Mercy)
+		require.False(t, s.AwaitingPlay)
+		require.Equal(t, int64(2), s.HomeScore)
+		require.Equal(t, util.HalfBottom, s.Half)
+	})
+}
+
+func TestState_TiebreakerRunner(t *testing.T) {
+	s := NewState(util.RuleSet{RegulationInnings: 1, TiebreakerInning: 2}, nineEntries("h"), nineEntries("a"))
+	threeOutHalf(t, s)
+	require.Equal(t, [3]string{}, s.Bases)
+	threeOutHalf(t, s)
+
+	// the batter before the leadoff hitter starts the extra inning on second
+	require.Equal(t, [3]string{"", "a3", ""}, s.Bases)
+	apply(t, s, play(PlayHomeRun))
+	require.Equal(t, [3]string{"", "a3", ""}, s.PlateAppearances[6].StartBases)
+
+	runs := scored(s.PlateAppearances[6])
+	require.Len(t, runs, 2)
+	require.Equal(t, "a3", runs[0].RunnerID)
+	require.False(t, runs[0].Earned)
+	require.Equal(t, "h9", runs[0].PitcherID)
+	require.True(t, runs[1].Earned)
+
+	threeOutHalf(t, s)
+	require.Equal(t, [3]string{"", "h3", ""}, s.Bases)
+}
@@ -0,0 +1,76 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
+	"github.com/rs/zerolog/log"
+	"strings"
+)
+
+// errNotImplemented is what casbin expects from an adapter that does not save policy changes
+var errNotImplemented = errors.New("not implemented")
+
+// GuardianLinks lists the approved guardian links as guardian and player ID pairs.
+type GuardianLinks func(ctx context.Context) ([][2]string, error)
+
+// policyAdapter is a read-only casbin adapter. It loads the policies read from the policy file and,
+// once links is set, the approved guardian links from the database, so every reload of the policy
+// picks up links approved or removed on any server instance.
+type policyAdapter struct {
+	policies [][]string
+	links    GuardianLinks
+}
+
+// LoadPolicy loads the file policies and the guardian links into the model.
+// Policies that do not contain the required 4 params are skipped with a warning.
+func (a *policyAdapter) LoadPolicy(m model.Model) error {
+	for _, policy := range a.policies {
+		if len(policy) != 4 {
+			log.Warn().Msgf("Policy '%s' does not contain required 4 params", policy)
+			continue
+		}
+		rule := make([]string, len(policy))
+		for i, field := range policy {
+			rule[i] = strings.TrimSpace(field)
+		}
+		if err := persist.LoadPolicyArray(rule, m); err != nil {
+			return err
+		}
+	}
+
+	if a.links == nil {
+		return nil
+	}
+	links, err := a.links(context.Background())
+	if err != nil {
+		return err
+	}
+	for _, link := range links {
+		if err := persist.LoadPolicyArray([]string{GuardianGroupingType, link[0], link[1]}, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SavePolicy is not supported; guardian links are changed in the guardians table.
+func (a *policyAdapter) SavePolicy(model.Model) error {
+	return errNotImplemented
+}
+
+// AddPolicy is not supported; guardian links are changed in the guardians table.
+func (a *policyAdapter) AddPolicy(string, string, []string) error {
+	return errNotImplemented
+}
+
+// RemovePolicy is not supported; guardian links are changed in the guardians table.
+func (a *policyAdapter) RemovePolicy(string, string, []string) error {
+	return errNotImplemented
+}
+
+// RemoveFilteredPolicy is not supported; guardian links are changed in the guardians table.
+func (a *policyAdapter) RemoveFilteredPolicy(string, string, int, ...string) error {
+	return errNotImplemented
+}
@@ -0,0 +1,45 @@
+package security
+
+import (
+	"github.com/casbin/casbin/v2"
+)
+
+// GuardianGroupingType is the casbin grouping policy type that links a guardian (first param)
+// to a player they manage (second param). It is declared as g2 in the authz model.
+const GuardianGroupingType = "g2"
+
+// guardianContext enforces with the r2, p2 and m2 definitions of the authz model, which give
+// guardians delegated rights on their players' /v1/players/:id routes through g2.
+func guardianContext() casbin.EnforceContext {
+	ctx := casbin.NewEnforceContext("2")
+	ctx.EType = "e"
+	return ctx
+}
+
+// UseGuardianLinks has the enforcer load the approved guardian links from links, now and
+// every time its policy is reloaded.
+func UseGuardianLinks(e *casbin.SyncedEnforcer, links GuardianLinks) error {
+	adapter, ok := e.GetAdapter().(*policyAdapter)
+	if !ok {
+		return errNotImplemented
+	}
+	adapter.links = links
+	return e.LoadPolicy()
+}
+
+// IsGuardianOf reports whether guardianID has been granted rights over playerID.
+func IsGuardianOf(e *casbin.SyncedEnforcer, guardianID string, playerID string) bool {
+	ok, err := e.HasNamedGroupingPolicy(GuardianGroupingType, guardianID, playerID)
+	return err == nil && ok
+}
+
+// CanActForPlayer reports whether userID may act on behalf of playerID.
+// Users may always act for themselves and admins for anyone. Approved guardians are checked
+// against the player's /v1/players/:id route by the authz model.
+func CanActForPlayer(e *casbin.SyncedEnforcer, userID string, roles []Role, playerID string) bool {
+	if userID == playerID || HasRole(roles, AdminRole) {
+		return true
+	}
+	ok, err := e.Enforce(guardianContext(), userID, "/api/v1/players/"+playerID, "*")
+	return err == nil && ok
+}
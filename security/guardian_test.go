@@ -0,0 +1,35 @@
+package security
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/kwalter26/scoreit-api-go/test"
+	"github.com/kwalter26/scoreit-api-go/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCanActForPlayer(t *testing.T) {
+	enforcer, err := NewEnforcer(util.Config{}, test.SecurityResources())
+	require.NoError(t, err)
+
+	player := uuid.NewString()
+	guardian := uuid.NewString()
+	other := uuid.NewString()
+	links := [][2]string{{guardian, player}}
+	require.NoError(t, UseGuardianLinks(enforcer, func(ctx context.Context) ([][2]string, error) {
+		return links, nil
+	}))
+
+	require.True(t, CanActForPlayer(enforcer, player, UserRoles, player))
+	require.True(t, CanActForPlayer(enforcer, other, AdminRoles, player))
+	require.True(t, CanActForPlayer(enforcer, guardian, UserRoles, player))
+	require.False(t, CanActForPlayer(enforcer, guardian, UserRoles, other))
+	require.False(t, CanActForPlayer(enforcer, other, UserRoles, player))
+
+	// a link removed from the database is dropped on the next reload
+	links = nil
+	require.NoError(t, enforcer.LoadPolicy())
+	require.False(t, CanActForPlayer(enforcer, guardian, UserRoles, player))
+	require.False(t, IsGuardianOf(enforcer, guardian, player))
+}
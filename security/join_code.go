@@ -0,0 +1,18 @@
+package security
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+)
+
+// joinCodeBytes gives a 16 character code, enough that codes can't be guessed
+const joinCodeBytes = 10
+
+// NewJoinCode generates a random, URL-safe code for sharing a team invitation
+func NewJoinCode() (string, error) {
+	b := make([]byte, joinCodeBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base32.StdEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,17 @@
+package security
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNewJoinCode(t *testing.T) {
+	code1, err := NewJoinCode()
+	require.NoError(t, err)
+	require.Len(t, code1, 16)
+	require.NotContains(t, code1, "=")
+
+	code2, err := NewJoinCode()
+	require.NoError(t, err)
+	require.NotEqual(t, code1, code2)
+}
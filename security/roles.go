@@ -11,8 +11,6 @@ import (
 	"github.com/casbin/casbin/v2/model"
 	"github.com/kwalter26/scoreit-api-go/util"
 	"github.com/rs/zerolog/log"
-	"strings"
-
 	"os"
 )
 
@@ -103,40 +101,23 @@ func (sf aclFiles) getPolicyBytes(config util.Config) ([]byte, error) {
 
 // NewEnforcer creates a new casbin enforcer with the provided configuration.
 // It loads the model bytes and policy bytes from the specified paths in the config.
-// Then it initializes the casbin enforcer with the loaded model.
-// Next, it parses the policy bytes as a CSV file and adds the policies to the enforcer.
+// Then it parses the policy bytes as a CSV file and loads the policies into the enforcer through a
+// read-only adapter, which UseGuardianLinks later points at the approved guardian links.
 // If any policy does not contain the required 4 parameters, it logs a warning.
 // If any error occurs during the process, it returns nil and the error.
 // Otherwise, it returns the initialized enforcer and nil error.
-func NewEnforcer(config util.Config, fs *embed.FS) (*casbin.Enforcer, error) {
+func NewEnforcer(config util.Config, fs *embed.FS) (*casbin.SyncedEnforcer, error) {
 
 	securityFiles, err := newSecurityFiles(config, fs)
 	if err != nil {
 		return nil, err
 	}
 
-	e, err := casbin.NewEnforcer(securityFiles.Model())
+	e, err := casbin.NewSyncedEnforcer(securityFiles.Model(), &policyAdapter{policies: securityFiles.Policies()})
 	if err != nil {
 		return nil, err
 	}
-
-	for _, policy := range securityFiles.Policies() {
-		if len(policy) == 4 {
-			if ok, err := e.AddNamedPolicy(
-				strings.TrimSpace(policy[0]),
-				strings.TrimSpace(policy[1]),
-				strings.TrimSpace(policy[2]),
-				strings.TrimSpace(policy[3]),
-			); ok {
-				log.Info().Msgf("Added policy: %s", policy)
-			} else {
-				log.Warn().Msgf("Failed to add policy: %s with error %s", policy, err)
-				return nil, err
-			}
-		} else {
-			log.Warn().Msgf("Policy '%s' does not contain required 4 params", policy)
-		}
-	}
+	log.Info().Msgf("Loaded %d policies", len(securityFiles.Policies()))
 	return e, nil
 }
 
@@ -149,8 +130,21 @@ var UserRole Role = "user"
 // AdminRole is the role for admin users
 var AdminRole Role = "admin"
 
+// CoachRole is the role for coaches, who manage rosters and approve guardians
+var CoachRole Role = "coach"
+
 // UserRoles includes all roles a user can have
 var UserRoles = []Role{UserRole}
 
 // AdminRoles includes all roles an admin can have
 var AdminRoles = []Role{AdminRole}
+
+// HasRole reports whether role is present in roles.
+func HasRole(roles []Role, role Role) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
@@ -18,6 +18,7 @@ type Config struct {
 	HttpServerAddress    string        `mapstructure:"HTTP_SERVER_ADDRESS"`
 	AccessTokenDuration  time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
 	RefreshTokenDuration time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	GameConflictWindow   time.Duration `mapstructure:"GAME_CONFLICT_WINDOW"`
 
 	TokenSymmetricKey string `mapstructure:"TOKEN_SYMMETRIC_KEY"`
 	CasbinModelPath   string `mapstructure:"CASBIN_MODEL_PATH"`
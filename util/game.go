@@ -0,0 +1,60 @@
+package util
+
+import (
+	"time"
+	_ "time/tzdata"
+)
+
+// GameStatus is where a game is in its lifecycle
+type GameStatus string
+
+// Constants representing game statuses
+const (
+	GameScheduled  GameStatus = "scheduled"
+	GameInProgress GameStatus = "in_progress"
+	GameFinal      GameStatus = "final"
+	GamePostponed  GameStatus = "postponed"
+	GameSuspended  GameStatus = "suspended"
+	GameCancelled  GameStatus = "cancelled"
+	GameForfeit    GameStatus = "forfeit"
+)
+
+// Availability is a player's answer to whether they can play in a game
+type Availability string
+
+// Constants representing availability responses
+const (
+	AvailabilityYes   Availability = "yes"
+	AvailabilityNo    Availability = "no"
+	AvailabilityMaybe Availability = "maybe"
+)
+
+// gameTransitions lists the statuses a game may move to from each status.
+// Moving a final game back to in progress reopens it for scoring.
+var gameTransitions = map[GameStatus][]GameStatus{
+	GameScheduled:  {GameInProgress, GamePostponed, GameCancelled, GameForfeit},
+	GamePostponed:  {GameScheduled, GameCancelled, GameForfeit},
+	GameInProgress: {GameFinal, GameSuspended, GameCancelled, GameForfeit},
+	GameSuspended:  {GameInProgress, GameFinal, GameCancelled, GameForfeit},
+	GameFinal:      {GameInProgress},
+}
+
+// CanTransitionGame reports whether a game may move from one status to another
+func CanTransitionGame(from, to GameStatus) bool {
+	for _, next := range gameTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidTimeZone reports whether name is an IANA time zone such as "America/Chicago".
+// The server's own "Local" zone is rejected because it means different things on different hosts.
+func IsValidTimeZone(name string) bool {
+	if name == "" || name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
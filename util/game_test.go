@@ -0,0 +1,44 @@
+package util
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCanTransitionGame(t *testing.T) {
+	testCases := []struct {
+		from     GameStatus
+		to       GameStatus
+		expected bool
+	}{
+		{GameScheduled, GameInProgress, true},
+		{GameScheduled, GamePostponed, true},
+		{GameScheduled, GameFinal, false},
+		{GamePostponed, GameScheduled, true},
+		{GamePostponed, GameInProgress, false},
+		{GameInProgress, GameFinal, true},
+		{GameInProgress, GameSuspended, true},
+		{GameInProgress, GameScheduled, false},
+		{GameSuspended, GameInProgress, true},
+		{GameFinal, GameInProgress, true},
+		{GameFinal, GameCancelled, false},
+		{GameCancelled, GameScheduled, false},
+		{GameForfeit, GameFinal, false},
+		{GameScheduled, GameScheduled, false},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(string(tc.from)+"_"+string(tc.to), func(t *testing.T) {
+			require.Equal(t, tc.expected, CanTransitionGame(tc.from, tc.to))
+		})
+	}
+}
+
+func TestIsValidTimeZone(t *testing.T) {
+	require.True(t, IsValidTimeZone("UTC"))
+	require.True(t, IsValidTimeZone("America/Chicago"))
+	require.False(t, IsValidTimeZone(""))
+	require.False(t, IsValidTimeZone("Local"))
+	require.False(t, IsValidTimeZone("Mars/Olympus_Mons"))
+}
@@ -0,0 +1,11 @@
+package util
+
+// GuardianStatus is the approval state of a guardian link between two users
+type GuardianStatus string
+
+// Constants representing guardian link states
+const (
+	GuardianPending  GuardianStatus = "pending"
+	GuardianApproved GuardianStatus = "approved"
+	GuardianRejected GuardianStatus = "rejected"
+)
@@ -0,0 +1,21 @@
+package util
+
+// InvitationStatus is the state of an invitation to join a team
+type InvitationStatus string
+
+// Constants representing invitation states
+const (
+	InvitationPending  InvitationStatus = "pending"
+	InvitationAccepted InvitationStatus = "accepted"
+	InvitationRevoked  InvitationStatus = "revoked"
+)
+
+// JoinRequestStatus is the state of a player's request to join a team
+type JoinRequestStatus string
+
+// Constants representing join request states
+const (
+	JoinRequestPending  JoinRequestStatus = "pending"
+	JoinRequestApproved JoinRequestStatus = "approved"
+	JoinRequestRejected JoinRequestStatus = "rejected"
+)
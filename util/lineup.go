@@ -0,0 +1,78 @@
+package util
+
+import "fmt"
+
+// LineupSpot is one player's place in a lineup. A BatPosition of 0 means the player
+// fields but does not bat, which is only allowed for the pitcher when a DH bats for them.
+type LineupSpot struct {
+	PlayerID    string
+	BatPosition int64
+	Position    BaseballPosition
+}
+
+// ValidateLineup checks a lineup against the game's rules, without looking at the roster. Batting slots
+// must run from 1 with no gaps, no player or position may appear twice and a pitcher is required.
+// A designated hitter is only allowed when the rules have one; with one the pitcher does not bat,
+// and without one everyone does.
+func ValidateLineup(rules RuleSet, spots []LineupSpot) error {
+	players := make(map[string]bool)
+	positions := make(map[BaseballPosition]bool)
+	slots := make(map[int64]bool)
+	var pitcher *LineupSpot
+
+	for i := range spots {
+		spot := spots[i]
+		if !IsBaseballPosition(string(spot.Position)) {
+			return fmt.Errorf("%s is not a baseball position", spot.Position)
+		}
+		if players[spot.PlayerID] {
+			return fmt.Errorf("player %s is in the lineup twice", spot.PlayerID)
+		}
+		players[spot.PlayerID] = true
+		if positions[spot.Position] {
+			return fmt.Errorf("%s is filled twice", spot.Position)
+		}
+		positions[spot.Position] = true
+		if spot.BatPosition < 0 {
+			return fmt.Errorf("batting slot %d is not valid", spot.BatPosition)
+		}
+		if spot.BatPosition > 0 {
+			if slots[spot.BatPosition] {
+				return fmt.Errorf("batting slot %d is taken twice", spot.BatPosition)
+			}
+			slots[spot.BatPosition] = true
+		}
+		if spot.Position == Pitcher {
+			pitcher = &spots[i]
+		}
+	}
+
+	for slot := int64(1); slot <= int64(len(slots)); slot++ {
+		if !slots[slot] {
+			return fmt.Errorf("batting slot %d is empty", slot)
+		}
+	}
+	if pitcher == nil {
+		return fmt.Errorf("lineup needs a %s", Pitcher)
+	}
+
+	hasDH := positions[DesignatedHitter]
+	if hasDH && !rules.DesignatedHitter {
+		return fmt.Errorf("the game's rules do not allow a %s", DesignatedHitter)
+	}
+	for _, spot := range spots {
+		if spot.BatPosition > 0 {
+			continue
+		}
+		if !hasDH {
+			return fmt.Errorf("player %s must bat when there is no %s", spot.PlayerID, DesignatedHitter)
+		}
+		if spot.Position != Pitcher {
+			return fmt.Errorf("only the %s may skip batting", Pitcher)
+		}
+	}
+	if hasDH && pitcher.BatPosition > 0 {
+		return fmt.Errorf("the %s cannot bat when a %s bats for them", Pitcher, DesignatedHitter)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package util
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func nineSpots() []LineupSpot {
+	positions := []BaseballPosition{CenterField, SecondBase, FirstBase, ThirdBase, RightField, LeftField, ShortStop, Catcher, Pitcher}
+	spots := make([]LineupSpot, len(positions))
+	for i, position := range positions {
+		spots[i] = LineupSpot{PlayerID: fmt.Sprintf("p%d", i+1), BatPosition: int64(i + 1), Position: position}
+	}
+	return spots
+}
+
+func TestValidateLineup(t *testing.T) {
+	dh := RuleSet{DesignatedHitter: true}
+
+	testCases := []struct {
+		name   string
+		rules  RuleSet
+		modify func(spots []LineupSpot) []LineupSpot
+		valid  bool
+	}{
+		{
+			name:   "Nine",
+			modify: func(spots []LineupSpot) []LineupSpot { return spots },
+			valid:  true,
+		},
+		{
+			name:  "DesignatedHitter",
+			rules: dh,
+			modify: func(spots []LineupSpot) []LineupSpot {
+				spots[8].BatPosition = 0
+				return append(spots, LineupSpot{PlayerID: "dh", BatPosition: 9, Position: DesignatedHitter})
+			},
+			valid: true,
+		},
+		{
+			name: "DesignatedHitterNotInRules",
+			modify: func(spots []LineupSpot) []LineupSpot {
+				spots[8].BatPosition = 0
+				return append(spots, LineupSpot{PlayerID: "dh", BatPosition: 9, Position: DesignatedHitter})
+			},
+		},
+		{
+			name:  "PitcherBatsWithDH",
+			rules: dh,
+			modify: func(spots []LineupSpot) []LineupSpot {
+				return append(spots, LineupSpot{PlayerID: "dh", BatPosition: 10, Position: DesignatedHitter})
+			},
+		},
+		{
+			name: "PitcherSkipsWithoutDH",
+			modify: func(spots []LineupSpot) []LineupSpot {
+				spots[8].BatPosition = 0
+				return spots
+			},
+		},
+		{
+			name:  "FielderSkipsBatting",
+			rules: dh,
+			modify: func(spots []LineupSpot) []LineupSpot {
+				spots[8].BatPosition = 0
+				spots[7].BatPosition = 0
+				return append(spots, LineupSpot{PlayerID: "dh", BatPosition: 8, Position: DesignatedHitter})
+			},
+		},
+		{
+			name: "GapInOrder",
+			modify: func(spots []LineupSpot) []LineupSpot {
+				spots[8].BatPosition = 10
+				return spots
+			},
+		},
+		{
+			name: "DuplicateSlot",
+			modify: func(spots []LineupSpot) []LineupSpot {
+				spots[8].BatPosition = 1
+				return spots
+			},
+		},
+		{
+			name: "DuplicatePosition",
+			modify: func(spots []LineupSpot) []LineupSpot {
+				spots[0].Position = ShortStop
+				return spots
+			},
+		},
+		{
+			name: "DuplicatePlayer",
+			modify: func(spots []LineupSpot) []LineupSpot {
+				spots[1].PlayerID = spots[0].PlayerID
+				return spots
+			},
+		},
+		{
+			name: "NoPitcher",
+			modify: func(spots []LineupSpot) []LineupSpot {
+				return spots[:8]
+			},
+		},
+		{
+			name: "ExtraFielders",
+			modify: func(spots []LineupSpot) []LineupSpot {
+				return append(spots, LineupSpot{PlayerID: "rcf", BatPosition: 10, Position: RightCenterField})
+			},
+			valid: true,
+		},
+		{
+			name: "UnknownPosition",
+			modify: func(spots []LineupSpot) []LineupSpot {
+				spots[0].Position = "ROVER"
+				return spots
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateLineup(tc.rules, tc.modify(nineSpots()))
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
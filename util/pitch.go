@@ -0,0 +1,79 @@
+package util
+
+import "fmt"
+
+// PitchType is what happened on a single pitch
+type PitchType string
+
+// Constants representing pitch types
+const (
+	PitchBall           PitchType = "ball"
+	PitchCalledStrike   PitchType = "called_strike"
+	PitchSwingingStrike PitchType = "swinging_strike"
+	PitchFoul           PitchType = "foul"
+	PitchInPlay         PitchType = "in_play"
+	PitchHitByPitch     PitchType = "hit_by_pitch"
+)
+
+// AtBatResult is how an at-bat ended
+type AtBatResult string
+
+// Constants representing at-bat results. The outcome of a ball in play is scored separately.
+const (
+	AtBatWalk       AtBatResult = "walk"
+	AtBatStrikeout  AtBatResult = "strikeout"
+	AtBatHitByPitch AtBatResult = "hit_by_pitch"
+	AtBatInPlay     AtBatResult = "in_play"
+)
+
+// InningHalf is the top or bottom of an inning. The away team bats in the top.
+type InningHalf string
+
+// Constants representing inning halves
+const (
+	HalfTop    InningHalf = "top"
+	HalfBottom InningHalf = "bottom"
+)
+
+// Constants for the count that ends an at-bat
+const (
+	BallsForWalk        int64 = 4
+	StrikesForStrikeout int64 = 3
+)
+
+// ApplyPitch returns the count after a pitch and, if the pitch ended the at-bat, how it ended.
+// A foul is a strike except with two strikes, when the count stays the same.
+func ApplyPitch(balls, strikes int64, pitch PitchType) (int64, int64, AtBatResult, error) {
+	if balls < 0 || balls >= BallsForWalk || strikes < 0 || strikes >= StrikesForStrikeout {
+		return balls, strikes, "", fmt.Errorf("%d-%d is not a count", balls, strikes)
+	}
+
+	switch pitch {
+	case PitchBall:
+		balls++
+		if balls == BallsForWalk {
+			return balls, strikes, AtBatWalk, nil
+		}
+	case PitchCalledStrike, PitchSwingingStrike:
+		strikes++
+		if strikes == StrikesForStrikeout {
+			return balls, strikes, AtBatStrikeout, nil
+		}
+	case PitchFoul:
+		if strikes < StrikesForStrikeout-1 {
+			strikes++
+		}
+	case PitchInPlay:
+		return balls, strikes, AtBatInPlay, nil
+	case PitchHitByPitch:
+		return balls, strikes, AtBatHitByPitch, nil
+	default:
+		return balls, strikes, "", fmt.Errorf("%s is not a pitch type", pitch)
+	}
+	return balls, strikes, "", nil
+}
+
+// BattingSide reports whether the home team bats in half
+func BattingSide(half InningHalf) bool {
+	return half == HalfBottom
+}
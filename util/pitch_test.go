@@ -0,0 +1,49 @@
+package util
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestApplyPitch(t *testing.T) {
+	testCases := []struct {
+		name    string
+		balls   int64
+		strikes int64
+		pitch   PitchType
+		want    [2]int64
+		result  AtBatResult
+		valid   bool
+	}{
+		{name: "Ball", balls: 0, strikes: 0, pitch: PitchBall, want: [2]int64{1, 0}, valid: true},
+		{name: "Walk", balls: 3, strikes: 2, pitch: PitchBall, want: [2]int64{4, 2}, result: AtBatWalk, valid: true},
+		{name: "CalledStrike", balls: 1, strikes: 1, pitch: PitchCalledStrike, want: [2]int64{1, 2}, valid: true},
+		{name: "StrikeoutSwinging", balls: 3, strikes: 2, pitch: PitchSwingingStrike, want: [2]int64{3, 3}, result: AtBatStrikeout, valid: true},
+		{name: "StrikeoutLooking", balls: 0, strikes: 2, pitch: PitchCalledStrike, want: [2]int64{0, 3}, result: AtBatStrikeout, valid: true},
+		{name: "Foul", balls: 0, strikes: 1, pitch: PitchFoul, want: [2]int64{0, 2}, valid: true},
+		{name: "FoulWithTwoStrikes", balls: 2, strikes: 2, pitch: PitchFoul, want: [2]int64{2, 2}, valid: true},
+		{name: "InPlay", balls: 1, strikes: 2, pitch: PitchInPlay, want: [2]int64{1, 2}, result: AtBatInPlay, valid: true},
+		{name: "HitByPitch", balls: 0, strikes: 0, pitch: PitchHitByPitch, want: [2]int64{0, 0}, result: AtBatHitByPitch, valid: true},
+		{name: "UnknownPitch", balls: 0, strikes: 0, pitch: "balk"},
+		{name: "FinishedCount", balls: 4, strikes: 0, pitch: PitchBall},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			balls, strikes, result, err := ApplyPitch(tc.balls, tc.strikes, tc.pitch)
+			if !tc.valid {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, [2]int64{balls, strikes})
+			require.Equal(t, tc.result, result)
+		})
+	}
+}
+
+func TestBattingSide(t *testing.T) {
+	require.False(t, BattingSide(HalfTop))
+	require.True(t, BattingSide(HalfBottom))
+}
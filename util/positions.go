@@ -18,3 +18,16 @@ const (
 	RightCenterField BaseballPosition = "RIGHT_CENTER_FIELD"
 	LeftCenterField  BaseballPosition = "LEFT_CENTER_FIELD"
 )
+
+// BaseballPositions lists every valid baseball position
+var BaseballPositions = []BaseballPosition{Pitcher, Catcher, FirstBase, SecondBase, ThirdBase, ShortStop, LeftField, CenterField, RightField, DesignatedHitter, RightCenterField, LeftCenterField}
+
+// IsBaseballPosition reports whether position is one of the valid baseball positions
+func IsBaseballPosition(position string) bool {
+	for _, p := range BaseballPositions {
+		if string(p) == position {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,19 @@
+package util
+
+// Visibility is the audience a user shares part of their profile with
+type Visibility string
+
+// Constants representing visibility levels
+const (
+	VisibilityPublic    Visibility = "public"
+	VisibilityTeammates Visibility = "teammates"
+	VisibilityPrivate   Visibility = "private"
+)
+
+// AuditAction identifies the kind of event recorded in the audit log
+type AuditAction string
+
+// Constants representing audited actions
+const (
+	AuditPrivacyBypass AuditAction = "privacy_bypass"
+)
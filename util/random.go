@@ -33,6 +33,5 @@ func RandomEmail() string {
 
 // RandomBaseballPosition returns a random baseball position from a list of positions from positions.go
 func RandomBaseballPosition() BaseballPosition {
-	list := []BaseballPosition{Pitcher, Catcher, FirstBase, SecondBase, ThirdBase, ShortStop, LeftField, CenterField, RightField, DesignatedHitter, RightCenterField, LeftCenterField}
-	return list[rand.Intn(len(list))]
+	return BaseballPositions[rand.Intn(len(BaseballPositions))]
 }
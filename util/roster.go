@@ -0,0 +1,36 @@
+package util
+
+// RosterTransactionType is the kind of change recorded in a team's transaction log
+type RosterTransactionType string
+
+// Constants representing roster transaction types
+const (
+	RosterSigning      RosterTransactionType = "signing"
+	RosterRelease      RosterTransactionType = "release"
+	RosterTrade        RosterTransactionType = "trade"
+	RosterInjuredList  RosterTransactionType = "injured_list"
+	RosterInactiveList RosterTransactionType = "inactive_list"
+	RosterActivation   RosterTransactionType = "activation"
+	RosterMemberUpdate RosterTransactionType = "member_update"
+)
+
+// RosterStatus is the standing of a player during a stint on a team
+type RosterStatus string
+
+// Constants representing roster statuses
+const (
+	RosterStatusActive    RosterStatus = "active"
+	RosterStatusInjured   RosterStatus = "injured"
+	RosterStatusInactive  RosterStatus = "inactive"
+	RosterStatusSuspended RosterStatus = "suspended"
+)
+
+// TeamRole is the part a member plays on a team
+type TeamRole string
+
+// Constants representing team roles
+const (
+	TeamRolePlayer  TeamRole = "player"
+	TeamRoleCoach   TeamRole = "coach"
+	TeamRoleManager TeamRole = "manager"
+)
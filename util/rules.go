@@ -0,0 +1,191 @@
+package util
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// MaxRestDays is the most days of rest a rest table may require
+const MaxRestDays int64 = 30
+
+// RulePreset names a standard rule set
+type RulePreset string
+
+// Constants representing rule presets
+const (
+	RulesMLB          RulePreset = "mlb"
+	RulesNFHS         RulePreset = "nfhs"
+	RulesLittleLeague RulePreset = "little_league"
+	RulesSlowPitch    RulePreset = "slow_pitch"
+)
+
+// MercyRule ends a game when a team leads by at least Runs once Inning innings are complete, or once
+// the top of that inning is complete if the home team is the one ahead
+type MercyRule struct {
+	Inning int64 `json:"inning"`
+	Runs   int64 `json:"runs"`
+}
+
+// PitchRest is a row of a rest table: a pitcher who throws at least Pitches pitches in a game
+// must rest for Days calendar days before pitching again
+type PitchRest struct {
+	Pitches int64 `json:"pitches"`
+	Days    int64 `json:"days"`
+}
+
+// RuleSet is the rules a game is played under. The zero value has no run cap, mercy rule, tiebreaker
+// runner, designated hitter or rest table, as in games created before rule sets existed. The run cap, mercy
+// rules and tiebreaker runner are applied by the scoring engine, lineups may only use a designated hitter
+// when DesignatedHitter is set, and the rest table is checked when a pitcher is put in a lineup or brought
+// in to pitch. The courtesy runner and time limit are kept for scorers to go by.
+type RuleSet struct {
+	// Preset is the preset the rules came from, or "" for a league's own rules
+	Preset RulePreset `json:"preset,omitempty"`
+	// RegulationInnings is how many innings a game lasts before extra innings
+	RegulationInnings int64 `json:"regulation_innings"`
+	// MercyRules are checked in order of inning; the latest one that applies decides
+	MercyRules []MercyRule `json:"mercy_rules,omitempty"`
+	// MaxRunsPerInning ends a half inning once the batting team has scored this many runs, or never when 0
+	MaxRunsPerInning int64 `json:"max_runs_per_inning"`
+	// OpenLastInning lifts the run cap in the last regulation inning and in extra innings
+	OpenLastInning bool `json:"open_last_inning"`
+	// TiebreakerInning is the first inning in which each half starts with a runner on second, or 0 for none
+	TiebreakerInning int64 `json:"tiebreaker_inning"`
+	DesignatedHitter bool  `json:"designated_hitter"`
+	CourtesyRunner   bool  `json:"courtesy_runner"`
+	// TimeLimitMinutes is how long a game may run before no new inning starts, or 0 for no limit
+	TimeLimitMinutes int64 `json:"time_limit_minutes"`
+	// PitchRest is the rest table for pitchers who pitch in the game, in order of pitches
+	PitchRest []PitchRest `json:"pitch_rest,omitempty"`
+	// WarnOnRest lets a pitcher who has not rested enough into the game with a warning instead of blocking them
+	WarnOnRest bool `json:"warn_on_rest"`
+}
+
+// rulePresets are the standard rule sets
+var rulePresets = map[RulePreset]RuleSet{
+	RulesMLB: {
+		Preset:            RulesMLB,
+		RegulationInnings: 9,
+		TiebreakerInning:  10,
+		DesignatedHitter:  true,
+	},
+	RulesNFHS: {
+		Preset:            RulesNFHS,
+		RegulationInnings: 7,
+		MercyRules:        []MercyRule{{Inning: 5, Runs: 10}},
+		DesignatedHitter:  true,
+		CourtesyRunner:    true,
+		PitchRest:         []PitchRest{{Pitches: 31, Days: 1}, {Pitches: 46, Days: 2}, {Pitches: 61, Days: 3}, {Pitches: 76, Days: 4}},
+	},
+	RulesLittleLeague: {
+		Preset:            RulesLittleLeague,
+		RegulationInnings: 6,
+		MercyRules:        []MercyRule{{Inning: 4, Runs: 10}},
+		PitchRest:         []PitchRest{{Pitches: 21, Days: 1}, {Pitches: 36, Days: 2}, {Pitches: 51, Days: 3}, {Pitches: 66, Days: 4}},
+	},
+	RulesSlowPitch: {
+		Preset:            RulesSlowPitch,
+		RegulationInnings: 7,
+		MercyRules:        []MercyRule{{Inning: 3, Runs: 20}, {Inning: 4, Runs: 15}, {Inning: 5, Runs: 10}},
+		MaxRunsPerInning:  5,
+		OpenLastInning:    true,
+		TiebreakerInning:  8,
+		CourtesyRunner:    true,
+		TimeLimitMinutes:  60,
+	},
+}
+
+// RulePresets lists the presets in the order they are offered
+var RulePresets = []RulePreset{RulesMLB, RulesNFHS, RulesLittleLeague, RulesSlowPitch}
+
+// PresetRules returns the rules of a preset, and whether there is such a preset
+func PresetRules(preset RulePreset) (RuleSet, bool) {
+	rules, ok := rulePresets[preset]
+	rules.MercyRules = append([]MercyRule(nil), rules.MercyRules...)
+	rules.PitchRest = append([]PitchRest(nil), rules.PitchRest...)
+	return rules, ok
+}
+
+// Validate checks that a rule set makes sense and puts its mercy rules and rest table in order
+func (r *RuleSet) Validate() error {
+	if r.Preset != "" {
+		if _, ok := rulePresets[r.Preset]; !ok {
+			return fmt.Errorf("%s is not a rule preset", r.Preset)
+		}
+	}
+	if r.RegulationInnings < 0 || r.MaxRunsPerInning < 0 || r.TiebreakerInning < 0 || r.TimeLimitMinutes < 0 {
+		return fmt.Errorf("rules cannot have negative innings, runs or minutes")
+	}
+	if r.TiebreakerInning > 0 && r.TiebreakerInning <= r.RegulationInnings {
+		return fmt.Errorf("the tiebreaker runner can only be used in extra innings")
+	}
+	if r.OpenLastInning && r.RegulationInnings == 0 {
+		return fmt.Errorf("an open last inning needs a number of regulation innings")
+	}
+
+	sort.Slice(r.MercyRules, func(i, j int) bool { return r.MercyRules[i].Inning < r.MercyRules[j].Inning })
+	for i, mercy := range r.MercyRules {
+		if mercy.Inning < 1 || mercy.Runs < 1 {
+			return fmt.Errorf("a mercy rule needs an inning and a lead of at least 1")
+		}
+		if i > 0 && mercy.Inning == r.MercyRules[i-1].Inning {
+			return fmt.Errorf("there are two mercy rules for inning %d", mercy.Inning)
+		}
+	}
+
+	sort.Slice(r.PitchRest, func(i, j int) bool { return r.PitchRest[i].Pitches < r.PitchRest[j].Pitches })
+	for i, rest := range r.PitchRest {
+		if rest.Pitches < 1 || rest.Days < 0 || rest.Days > MaxRestDays {
+			return fmt.Errorf("a rest table row needs at least 1 pitch and 0 to %d days", MaxRestDays)
+		}
+		if i > 0 && rest.Pitches == r.PitchRest[i-1].Pitches {
+			return fmt.Errorf("there are two rest table rows for %d pitches", rest.Pitches)
+		}
+	}
+	return nil
+}
+
+// MercyLead returns the lead that ends a game once inning is complete, or 0 if no mercy rule applies yet
+func (r RuleSet) MercyLead(inning int64) int64 {
+	var lead int64
+	for _, mercy := range r.MercyRules {
+		if mercy.Inning <= inning {
+			lead = mercy.Runs
+		}
+	}
+	return lead
+}
+
+// RunCap returns how many runs end a half in the given inning, or 0 when there is no cap
+func (r RuleSet) RunCap(inning int64) int64 {
+	if r.OpenLastInning && inning >= r.RegulationInnings {
+		return 0
+	}
+	return r.MaxRunsPerInning
+}
+
+// Tiebreaker reports whether halves of the given inning start with a runner on second
+func (r RuleSet) Tiebreaker(inning int64) bool {
+	return r.TiebreakerInning > 0 && inning >= r.TiebreakerInning
+}
+
+// RestDays returns the days of rest the rest table requires after a game with the given pitches
+func (r RuleSet) RestDays(pitches int64) int64 {
+	var days int64
+	for _, rest := range r.PitchRest {
+		if pitches >= rest.Pitches {
+			days = rest.Days
+		}
+	}
+	return days
+}
+
+// EligibleToPitch returns the first date a pitcher who pitched on the given date may pitch again after
+// resting for restDays full days. A pitcher who needs no rest may pitch again the same day.
+func EligibleToPitch(pitched time.Time, restDays int64) time.Time {
+	if restDays == 0 {
+		return pitched
+	}
+	return pitched.AddDate(0, 0, int(restDays)+1)
+}
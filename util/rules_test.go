@@ -0,0 +1,91 @@
+package util
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestPresetRules(t *testing.T) {
+	for _, preset := range RulePresets {
+		rules, ok := PresetRules(preset)
+		require.True(t, ok)
+		require.Equal(t, preset, rules.Preset)
+		require.NoError(t, rules.Validate())
+	}
+
+	_, ok := PresetRules("cricket")
+	require.False(t, ok)
+
+	// changing a copy leaves the preset alone
+	rules, _ := PresetRules(RulesSlowPitch)
+	rules.MercyRules[0].Runs = 1
+	again, _ := PresetRules(RulesSlowPitch)
+	require.Equal(t, int64(20), again.MercyRules[0].Runs)
+}
+
+func TestRuleSet_Validate(t *testing.T) {
+	testCases := []struct {
+		name  string
+		rules RuleSet
+		err   string
+	}{
+		{name: "None", rules: RuleSet{}},
+		{name: "UnknownPreset", rules: RuleSet{Preset: "cricket"}, err: "cricket is not a rule preset"},
+		{name: "Negative", rules: RuleSet{MaxRunsPerInning: -1}, err: "rules cannot have negative innings, runs or minutes"},
+		{name: "TiebreakerInRegulation", rules: RuleSet{RegulationInnings: 7, TiebreakerInning: 7}, err: "the tiebreaker runner can only be used in extra innings"},
+		{name: "OpenLastInningWithoutInnings", rules: RuleSet{MaxRunsPerInning: 5, OpenLastInning: true}, err: "an open last inning needs a number of regulation innings"},
+		{name: "EmptyMercyRule", rules: RuleSet{MercyRules: []MercyRule{{Inning: 3}}}, err: "a mercy rule needs an inning and a lead of at least 1"},
+		{name: "RepeatedMercyRule", rules: RuleSet{MercyRules: []MercyRule{{Inning: 3, Runs: 15}, {Inning: 3, Runs: 10}}}, err: "there are two mercy rules for inning 3"},
+		{name: "TooMuchRest", rules: RuleSet{PitchRest: []PitchRest{{Pitches: 50, Days: 31}}}, err: "a rest table row needs at least 1 pitch and 0 to 30 days"},
+		{name: "RepeatedRestRow", rules: RuleSet{PitchRest: []PitchRest{{Pitches: 50, Days: 2}, {Pitches: 50, Days: 3}}}, err: "there are two rest table rows for 50 pitches"},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.rules.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.err)
+			}
+		})
+	}
+
+	rules := RuleSet{MercyRules: []MercyRule{{Inning: 5, Runs: 10}, {Inning: 3, Runs: 20}}}
+	require.NoError(t, rules.Validate())
+	require.Equal(t, []MercyRule{{Inning: 3, Runs: 20}, {Inning: 5, Runs: 10}}, rules.MercyRules)
+}
+
+func TestRuleSet_Innings(t *testing.T) {
+	rules, _ := PresetRules(RulesSlowPitch)
+
+	require.Equal(t, int64(0), rules.MercyLead(2))
+	require.Equal(t, int64(20), rules.MercyLead(3))
+	require.Equal(t, int64(15), rules.MercyLead(4))
+	require.Equal(t, int64(10), rules.MercyLead(9))
+
+	require.Equal(t, int64(5), rules.RunCap(6))
+	require.Equal(t, int64(0), rules.RunCap(7))
+	require.Equal(t, int64(0), rules.RunCap(8))
+
+	require.False(t, rules.Tiebreaker(7))
+	require.True(t, rules.Tiebreaker(8))
+	require.False(t, RuleSet{}.Tiebreaker(10))
+}
+
+func TestRuleSet_RestDays(t *testing.T) {
+	rules, _ := PresetRules(RulesNFHS)
+
+	require.Equal(t, int64(0), rules.RestDays(30))
+	require.Equal(t, int64(1), rules.RestDays(31))
+	require.Equal(t, int64(2), rules.RestDays(60))
+	require.Equal(t, int64(4), rules.RestDays(110))
+	require.Equal(t, int64(0), RuleSet{}.RestDays(110))
+
+	pitched := time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, pitched, EligibleToPitch(pitched, 0))
+	require.Equal(t, time.Date(2024, time.May, 3, 0, 0, 0, 0, time.UTC), EligibleToPitch(pitched, 1))
+	require.Equal(t, time.Date(2024, time.May, 6, 0, 0, 0, 0, time.UTC), EligibleToPitch(pitched, 4))
+}
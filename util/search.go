@@ -0,0 +1,64 @@
+package util
+
+import (
+	"html"
+	"strings"
+	"unicode/utf8"
+)
+
+// SearchResultType is the kind of entity a search result points at
+type SearchResultType string
+
+// Constants representing searchable entities
+const (
+	SearchResultUser SearchResultType = "user"
+	SearchResultTeam SearchResultType = "team"
+)
+
+// Highlight markers wrapped around the parts of a search result that match the query
+const (
+	HighlightStart = "<em>"
+	HighlightEnd   = "</em>"
+)
+
+// Highlight wraps every case-insensitive occurrence of the words in query found in text with
+// HighlightStart and HighlightEnd. Overlapping matches are merged into a single highlight.
+// The text is HTML-escaped, so only the markers are markup.
+func Highlight(text string, query string) string {
+	lower := strings.ToLower(text)
+	// lowercasing may change the byte length of some runes, in which case offsets no longer line up
+	if len(lower) != len(text) {
+		return html.EscapeString(text)
+	}
+
+	marked := make([]bool, len(text))
+	for _, word := range strings.Fields(strings.ToLower(query)) {
+		for start := 0; start < len(lower); {
+			i := strings.Index(lower[start:], word)
+			if i < 0 {
+				break
+			}
+			for j := start + i; j < start+i+len(word); j++ {
+				marked[j] = true
+			}
+			_, size := utf8.DecodeRuneInString(lower[start+i:])
+			start += i + size
+		}
+	}
+
+	// matching is done on the raw text, and each run of marked or unmarked text is escaped on its own
+	var b strings.Builder
+	start := 0
+	for i := 1; i <= len(text); i++ {
+		if i < len(text) && marked[i] == marked[start] {
+			continue
+		}
+		if marked[start] {
+			b.WriteString(HighlightStart + html.EscapeString(text[start:i]) + HighlightEnd)
+		} else {
+			b.WriteString(html.EscapeString(text[start:i]))
+		}
+		start = i
+	}
+	return b.String()
+}
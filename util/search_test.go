@@ -0,0 +1,31 @@
+package util
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestHighlight(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		query    string
+		expected string
+	}{
+		{"Single", "Mike Smith", "mike", "<em>Mike</em> Smith"},
+		{"MultipleWords", "Mike Smith", "mike s", "<em>Mike</em> <em>S</em>mith"},
+		{"Repeated", "Anna Banana", "ana", "Anna B<em>anana</em>"},
+		{"NoMatch", "Mike Smith", "zed", "Mike Smith"},
+		{"EmptyQuery", "Mike Smith", "", "Mike Smith"},
+		{"EscapesMarkup", "<script>alert(1)</script> Smith", "smith", "&lt;script&gt;alert(1)&lt;/script&gt; <em>Smith</em>"},
+		{"EscapesMatch", "<script>", "script", "&lt;<em>script</em>&gt;"},
+		{"EscapesUnmatched", "O'Brien & <b>Co</b>", "zed", "O&#39;Brien &amp; &lt;b&gt;Co&lt;/b&gt;"},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, Highlight(tc.text, tc.query))
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package util
+
+// GameStatType is a notable event credited or charged to one player, listed in a box score's notes
+type GameStatType string
+
+// Constants representing game stat types
+const (
+	StatDouble         GameStatType = "double"
+	StatTriple         GameStatType = "triple"
+	StatHomeRun        GameStatType = "home_run"
+	StatStolenBase     GameStatType = "stolen_base"
+	StatCaughtStealing GameStatType = "caught_stealing"
+	StatError          GameStatType = "error"
+)
+
+// GameStatTypes lists game stat types in the order a box score's notes give them
+var GameStatTypes = []GameStatType{StatDouble, StatTriple, StatHomeRun, StatStolenBase, StatCaughtStealing, StatError}
@@ -0,0 +1,198 @@
+package util
+
+import "fmt"
+
+// ReentryRule controls whether a player who has left a game may come back in
+type ReentryRule string
+
+// Constants representing re-entry rules
+const (
+	// ReentryNone never lets a player back in once they leave, as in MLB
+	ReentryNone ReentryRule = "none"
+	// ReentryStarterOnce lets a starter re-enter once, in their original batting slot, as in high school
+	ReentryStarterOnce ReentryRule = "starter_once"
+	// ReentryUnlimited lets anyone come and go, as in most rec leagues
+	ReentryUnlimited ReentryRule = "unlimited"
+)
+
+// IsReentryRule reports whether rule is one of the valid re-entry rules
+func IsReentryRule(rule string) bool {
+	switch ReentryRule(rule) {
+	case ReentryNone, ReentryStarterOnce, ReentryUnlimited:
+		return true
+	}
+	return false
+}
+
+// ParticipantEntry is how a player came to be in the game
+type ParticipantEntry string
+
+// Constants representing participant entries. Every entry other than EntryStarter is a kind of substitution.
+const (
+	EntryStarter        ParticipantEntry = "starter"
+	EntryPinchHitter    ParticipantEntry = "pinch_hitter"
+	EntryPinchRunner    ParticipantEntry = "pinch_runner"
+	EntryDefensive      ParticipantEntry = "defensive"
+	EntryPitchingChange ParticipantEntry = "pitching_change"
+	// EntryPositionChange moves a player already in the game to a new position
+	EntryPositionChange ParticipantEntry = "position_change"
+)
+
+// Participant is one stint by a player in a game, in a single batting slot and position
+type Participant struct {
+	ID          string
+	PlayerID    string
+	Entry       ParticipantEntry
+	BatPosition int64
+	Position    BaseballPosition
+	Active      bool
+}
+
+// Substitution is one requested change to a lineup. InPlayerID is empty for a position change.
+// An empty Position keeps the position of the player leaving, and a nil BatPosition keeps their batting slot.
+type Substitution struct {
+	Kind        ParticipantEntry
+	OutPlayerID string
+	InPlayerID  string
+	Position    BaseballPosition
+	BatPosition *int64
+}
+
+// SubstitutionStep is a planned change: the stint with ID Replaces ends and a new stint begins
+type SubstitutionStep struct {
+	Replaces    string
+	PlayerID    string
+	Entry       ParticipantEntry
+	BatPosition int64
+	Position    BaseballPosition
+}
+
+// PlanSubstitutions applies subs in order to a side's participants and returns the steps that carry them out.
+// Each substitution must replace a player who is in the game and has not entered in the same batch.
+// Players coming back in are checked against rule, and the lineup that results must pass ValidateLineup under rules.
+func PlanSubstitutions(rules RuleSet, rule ReentryRule, participants []Participant, subs []Substitution) ([]SubstitutionStep, error) {
+	active := make(map[string]Participant)
+	history := make(map[string][]Participant)
+	for _, participant := range participants {
+		history[participant.PlayerID] = append(history[participant.PlayerID], participant)
+		if participant.Active {
+			active[participant.PlayerID] = participant
+		}
+	}
+	if len(active) == 0 {
+		return nil, fmt.Errorf("there is no lineup to substitute into")
+	}
+
+	entered := make(map[string]bool)
+	steps := make([]SubstitutionStep, 0, len(subs))
+	for _, sub := range subs {
+		out, ok := active[sub.OutPlayerID]
+		if !ok {
+			return nil, fmt.Errorf("player %s is not in the game", sub.OutPlayerID)
+		}
+		if entered[sub.OutPlayerID] {
+			return nil, fmt.Errorf("player %s entered in this change and cannot be replaced in it", sub.OutPlayerID)
+		}
+
+		step := SubstitutionStep{
+			Replaces:    out.ID,
+			PlayerID:    sub.InPlayerID,
+			Entry:       sub.Kind,
+			BatPosition: out.BatPosition,
+			Position:    out.Position,
+		}
+		if sub.Position != "" {
+			step.Position = sub.Position
+		}
+		if sub.BatPosition != nil {
+			step.BatPosition = *sub.BatPosition
+		}
+
+		switch sub.Kind {
+		case EntryPositionChange:
+			if sub.Position == "" || sub.Position == out.Position {
+				return nil, fmt.Errorf("player %s needs a new position", sub.OutPlayerID)
+			}
+			step.PlayerID = sub.OutPlayerID
+		case EntryPinchHitter, EntryPinchRunner:
+			if out.BatPosition == 0 {
+				return nil, fmt.Errorf("player %s does not bat", sub.OutPlayerID)
+			}
+			if step.BatPosition != out.BatPosition {
+				return nil, fmt.Errorf("a %s takes the batting slot of the player they replace", sub.Kind)
+			}
+		case EntryPitchingChange:
+			if out.Position != Pitcher {
+				return nil, fmt.Errorf("player %s is not pitching", sub.OutPlayerID)
+			}
+			if step.Position != Pitcher {
+				return nil, fmt.Errorf("a %s brings in a %s", sub.Kind, Pitcher)
+			}
+		case EntryDefensive:
+		default:
+			return nil, fmt.Errorf("%s is not a kind of substitution", sub.Kind)
+		}
+		if !IsBaseballPosition(string(step.Position)) {
+			return nil, fmt.Errorf("%s is not a baseball position", step.Position)
+		}
+
+		if sub.Kind != EntryPositionChange {
+			if sub.InPlayerID == "" {
+				return nil, fmt.Errorf("a %s needs a player coming in", sub.Kind)
+			}
+			if _, ok := active[sub.InPlayerID]; ok {
+				return nil, fmt.Errorf("player %s is already in the game", sub.InPlayerID)
+			}
+			if err := checkReentry(rule, sub.InPlayerID, history[sub.InPlayerID], step.BatPosition); err != nil {
+				return nil, err
+			}
+			entered[sub.InPlayerID] = true
+		}
+
+		delete(active, out.PlayerID)
+
+		next := Participant{PlayerID: step.PlayerID, Entry: step.Entry, BatPosition: step.BatPosition, Position: step.Position, Active: true}
+		active[next.PlayerID] = next
+		history[next.PlayerID] = append(history[next.PlayerID], next)
+		steps = append(steps, step)
+	}
+
+	spots := make([]LineupSpot, 0, len(active))
+	for _, participant := range active {
+		spots = append(spots, LineupSpot{PlayerID: participant.PlayerID, BatPosition: participant.BatPosition, Position: participant.Position})
+	}
+	if err := ValidateLineup(rules, spots); err != nil {
+		return nil, err
+	}
+	return steps, nil
+}
+
+// checkReentry reports whether a player with the given earlier stints may come back into the game.
+func checkReentry(rule ReentryRule, playerID string, stints []Participant, batPosition int64) error {
+	if len(stints) == 0 || rule == ReentryUnlimited {
+		return nil
+	}
+	if rule != ReentryStarterOnce {
+		return fmt.Errorf("player %s has left the game and may not re-enter", playerID)
+	}
+
+	var starter *Participant
+	for i, stint := range stints {
+		switch stint.Entry {
+		case EntryStarter:
+			starter = &stints[i]
+		case EntryPositionChange:
+		default:
+			if starter != nil {
+				return fmt.Errorf("player %s has already re-entered once", playerID)
+			}
+		}
+	}
+	if starter == nil {
+		return fmt.Errorf("player %s did not start and may not re-enter", playerID)
+	}
+	if batPosition != starter.BatPosition {
+		return fmt.Errorf("player %s must re-enter in batting slot %d", playerID, starter.BatPosition)
+	}
+	return nil
+}
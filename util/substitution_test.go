@@ -0,0 +1,224 @@
+package util
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func startingParticipants() []Participant {
+	spots := nineSpots()
+	participants := make([]Participant, len(spots))
+	for i, spot := range spots {
+		participants[i] = Participant{ID: "gp-" + spot.PlayerID, PlayerID: spot.PlayerID, Entry: EntryStarter, BatPosition: spot.BatPosition, Position: spot.Position, Active: true}
+	}
+	return participants
+}
+
+// leftGame marks player p1, the center fielder batting first, as replaced by a pinch hitter
+func leftGame(participants []Participant) []Participant {
+	participants[0].Active = false
+	return append(participants, Participant{ID: "gp-ph", PlayerID: "ph", Entry: EntryPinchHitter, BatPosition: 1, Position: CenterField, Active: true})
+}
+
+func TestPlanSubstitutions(t *testing.T) {
+	slot := func(n int64) *int64 { return &n }
+
+	testCases := []struct {
+		name         string
+		rule         ReentryRule
+		participants []Participant
+		subs         []Substitution
+		check        func(steps []SubstitutionStep, err error)
+	}{
+		{
+			name:         "PinchHitter",
+			rule:         ReentryNone,
+			participants: startingParticipants(),
+			subs:         []Substitution{{Kind: EntryPinchHitter, OutPlayerID: "p4", InPlayerID: "ph"}},
+			check: func(steps []SubstitutionStep, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []SubstitutionStep{{Replaces: "gp-p4", PlayerID: "ph", Entry: EntryPinchHitter, BatPosition: 4, Position: ThirdBase}}, steps)
+			},
+		},
+		{
+			name:         "PitchingChange",
+			rule:         ReentryNone,
+			participants: startingParticipants(),
+			subs:         []Substitution{{Kind: EntryPitchingChange, OutPlayerID: "p9", InPlayerID: "rp"}},
+			check: func(steps []SubstitutionStep, err error) {
+				require.NoError(t, err)
+				require.Equal(t, Pitcher, steps[0].Position)
+				require.Equal(t, int64(9), steps[0].BatPosition)
+			},
+		},
+		{
+			name:         "PitchingChangeForFielder",
+			rule:         ReentryNone,
+			participants: startingParticipants(),
+			subs:         []Substitution{{Kind: EntryPitchingChange, OutPlayerID: "p1", InPlayerID: "rp"}},
+			check: func(steps []SubstitutionStep, err error) {
+				require.EqualError(t, err, "player p1 is not pitching")
+			},
+		},
+		{
+			name:         "DoubleSwitch",
+			rule:         ReentryNone,
+			participants: startingParticipants(),
+			subs: []Substitution{
+				{Kind: EntryPitchingChange, OutPlayerID: "p9", InPlayerID: "rp", BatPosition: slot(6)},
+				{Kind: EntryDefensive, OutPlayerID: "p6", InPlayerID: "lf", BatPosition: slot(9)},
+			},
+			check: func(steps []SubstitutionStep, err error) {
+				require.NoError(t, err)
+				require.Len(t, steps, 2)
+				require.Equal(t, int64(6), steps[0].BatPosition)
+				require.Equal(t, LeftField, steps[1].Position)
+			},
+		},
+		{
+			name:         "SwapPositions",
+			rule:         ReentryNone,
+			participants: startingParticipants(),
+			subs: []Substitution{
+				{Kind: EntryPositionChange, OutPlayerID: "p5", Position: LeftField},
+				{Kind: EntryPositionChange, OutPlayerID: "p6", Position: RightField},
+			},
+			check: func(steps []SubstitutionStep, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "p5", steps[0].PlayerID)
+				require.Equal(t, "gp-p5", steps[0].Replaces)
+			},
+		},
+		{
+			name:         "DuplicatePosition",
+			rule:         ReentryNone,
+			participants: startingParticipants(),
+			subs:         []Substitution{{Kind: EntryPositionChange, OutPlayerID: "p5", Position: LeftField}},
+			check: func(steps []SubstitutionStep, err error) {
+				require.EqualError(t, err, "LEFT_FIELD is filled twice")
+			},
+		},
+		{
+			name:         "AlreadyInGame",
+			rule:         ReentryUnlimited,
+			participants: startingParticipants(),
+			subs:         []Substitution{{Kind: EntryDefensive, OutPlayerID: "p1", InPlayerID: "p2"}},
+			check: func(steps []SubstitutionStep, err error) {
+				require.EqualError(t, err, "player p2 is already in the game")
+			},
+		},
+		{
+			name:         "NotInGame",
+			rule:         ReentryUnlimited,
+			participants: leftGame(startingParticipants()),
+			subs:         []Substitution{{Kind: EntryPinchRunner, OutPlayerID: "p1", InPlayerID: "pr"}},
+			check: func(steps []SubstitutionStep, err error) {
+				require.EqualError(t, err, "player p1 is not in the game")
+			},
+		},
+		{
+			name:         "ReplacedInSameChange",
+			rule:         ReentryUnlimited,
+			participants: startingParticipants(),
+			subs: []Substitution{
+				{Kind: EntryPinchHitter, OutPlayerID: "p2", InPlayerID: "ph"},
+				{Kind: EntryPinchRunner, OutPlayerID: "ph", InPlayerID: "pr"},
+			},
+			check: func(steps []SubstitutionStep, err error) {
+				require.EqualError(t, err, "player ph entered in this change and cannot be replaced in it")
+			},
+		},
+		{
+			name:         "NoReentry",
+			rule:         ReentryNone,
+			participants: leftGame(startingParticipants()),
+			subs:         []Substitution{{Kind: EntryDefensive, OutPlayerID: "ph", InPlayerID: "p1"}},
+			check: func(steps []SubstitutionStep, err error) {
+				require.EqualError(t, err, "player p1 has left the game and may not re-enter")
+			},
+		},
+		{
+			name:         "StarterReenters",
+			rule:         ReentryStarterOnce,
+			participants: leftGame(startingParticipants()),
+			subs:         []Substitution{{Kind: EntryDefensive, OutPlayerID: "ph", InPlayerID: "p1"}},
+			check: func(steps []SubstitutionStep, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "gp-ph", steps[0].Replaces)
+			},
+		},
+		{
+			name: "StarterReentersTwice",
+			rule: ReentryStarterOnce,
+			participants: func() []Participant {
+				participants := leftGame(startingParticipants())
+				participants[len(participants)-1].Active = false
+				participants = append(participants, Participant{ID: "gp-p1b", PlayerID: "p1", Entry: EntryDefensive, BatPosition: 1, Position: CenterField})
+				return append(participants, Participant{ID: "gp-cf", PlayerID: "cf", Entry: EntryDefensive, BatPosition: 1, Position: CenterField, Active: true})
+			}(),
+			subs: []Substitution{{Kind: EntryDefensive, OutPlayerID: "cf", InPlayerID: "p1"}},
+			check: func(steps []SubstitutionStep, err error) {
+				require.EqualError(t, err, "player p1 has already re-entered once")
+			},
+		},
+		{
+			name:         "StarterReentersInOtherSlot",
+			rule:         ReentryStarterOnce,
+			participants: leftGame(startingParticipants()),
+			subs: []Substitution{
+				{Kind: EntryDefensive, OutPlayerID: "p2", InPlayerID: "p1"},
+			},
+			check: func(steps []SubstitutionStep, err error) {
+				require.EqualError(t, err, "player p1 must re-enter in batting slot 1")
+			},
+		},
+		{
+			name:         "SubstituteReenters",
+			rule:         ReentryStarterOnce,
+			participants: leftGame(startingParticipants()),
+			subs: []Substitution{
+				{Kind: EntryDefensive, OutPlayerID: "ph", InPlayerID: "cf"},
+				{Kind: EntryDefensive, OutPlayerID: "p3", InPlayerID: "ph"},
+			},
+			check: func(steps []SubstitutionStep, err error) {
+				require.EqualError(t, err, "player ph did not start and may not re-enter")
+			},
+		},
+		{
+			name:         "UnlimitedReentry",
+			rule:         ReentryUnlimited,
+			participants: leftGame(startingParticipants()),
+			subs: []Substitution{
+				{Kind: EntryDefensive, OutPlayerID: "ph", InPlayerID: "cf"},
+				{Kind: EntryDefensive, OutPlayerID: "p3", InPlayerID: "ph"},
+			},
+			check: func(steps []SubstitutionStep, err error) {
+				require.NoError(t, err)
+				require.Len(t, steps, 2)
+			},
+		},
+		{
+			name:         "NoLineup",
+			rule:         ReentryNone,
+			participants: nil,
+			subs:         []Substitution{{Kind: EntryDefensive, OutPlayerID: "p1", InPlayerID: "cf"}},
+			check: func(steps []SubstitutionStep, err error) {
+				require.EqualError(t, err, "there is no lineup to substitute into")
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			tc.check(PlanSubstitutions(RuleSet{}, tc.rule, tc.participants, tc.subs))
+		})
+	}
+}
+
+func TestIsReentryRule(t *testing.T) {
+	require.True(t, IsReentryRule("none"))
+	require.True(t, IsReentryRule("starter_once"))
+	require.True(t, IsReentryRule("unlimited"))
+	require.False(t, IsReentryRule("sometimes"))
+}
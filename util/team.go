@@ -0,0 +1,22 @@
+package util
+
+import (
+	"regexp"
+	"strings"
+)
+
+var hexColorPattern = regexp.MustCompile(`^#?([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// NormalizeHexColor converts a #RGB or #RRGGBB color, with or without the leading #, to lowercase #rrggbb.
+// It reports false if the value is not a hex color.
+func NormalizeHexColor(color string) (string, bool) {
+	m := hexColorPattern.FindStringSubmatch(strings.TrimSpace(color))
+	if m == nil {
+		return "", false
+	}
+	hex := strings.ToLower(m[1])
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	return "#" + hex, true
+}
@@ -0,0 +1,33 @@
+package util
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNormalizeHexColor(t *testing.T) {
+	testCases := []struct {
+		name     string
+		color    string
+		expected string
+		ok       bool
+	}{
+		{"Long", "#1A2b3C", "#1a2b3c", true},
+		{"Short", "#fA0", "#ffaa00", true},
+		{"NoHash", "003366", "#003366", true},
+		{"Padded", " #003366 ", "#003366", true},
+		{"Named", "red", "", false},
+		{"TooLong", "#0033669", "", false},
+		{"NotHex", "#00336g", "", false},
+		{"Empty", "", "", false},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			color, ok := NormalizeHexColor(tc.color)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.expected, color)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package util
+
+import "math"
+
+// FieldSurface is the playing surface of a field
+type FieldSurface string
+
+// Constants representing field surfaces
+const (
+	SurfaceGrass FieldSurface = "grass"
+	SurfaceTurf  FieldSurface = "turf"
+	SurfaceDirt  FieldSurface = "dirt"
+)
+
+// earthRadiusKm is the mean radius of the earth
+const earthRadiusKm = 6371.0
+
+// DistanceKm returns the great-circle distance in kilometers between two points, using the haversine formula
+func DistanceKm(lat1, lng1, lat2, lng2 float64) float64 {
+	dLat := radians(lat2 - lat1)
+	dLng := radians(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(radians(lat1))*math.Cos(radians(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// BoundingBox returns the latitude and longitude ranges that contain every point within radiusKm of a point.
+// It is wider than the circle, so callers still filter with DistanceKm. Near the poles or the antimeridian
+// it spans every longitude.
+func BoundingBox(lat, lng, radiusKm float64) (minLat, maxLat, minLng, maxLng float64) {
+	dLat := degrees(radiusKm / earthRadiusKm)
+	minLat = math.Max(-90, lat-dLat)
+	maxLat = math.Min(90, lat+dLat)
+	if minLat == -90 || maxLat == 90 {
+		return minLat, maxLat, -180, 180
+	}
+
+	dLng := degrees(math.Asin(math.Min(1, math.Sin(radiusKm/earthRadiusKm)/math.Cos(radians(lat)))))
+	minLng = lng - dLng
+	maxLng = lng + dLng
+	if minLng < -180 || maxLng > 180 {
+		return minLat, maxLat, -180, 180
+	}
+	return minLat, maxLat, minLng, maxLng
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+func degrees(radians float64) float64 {
+	return radians * 180 / math.Pi
+}
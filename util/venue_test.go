@@ -0,0 +1,35 @@
+package util
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDistanceKm(t *testing.T) {
+	// Wrigley Field to Guaranteed Rate Field
+	require.InDelta(t, 13.2, DistanceKm(41.9484, -87.6553, 41.8299, -87.6338), 0.2)
+	require.InDelta(t, 0, DistanceKm(41.9484, -87.6553, 41.9484, -87.6553), 1e-9)
+	// London to New York
+	require.InDelta(t, 5570, DistanceKm(51.5074, -0.1278, 40.7128, -74.0060), 10)
+}
+
+func TestBoundingBox(t *testing.T) {
+	lat, lng := 41.9484, -87.6553
+	minLat, maxLat, minLng, maxLng := BoundingBox(lat, lng, 10)
+	require.Less(t, minLat, lat)
+	require.Greater(t, maxLat, lat)
+	require.Less(t, minLng, lng)
+	require.Greater(t, maxLng, lng)
+
+	// the edges of the box are at least the radius away
+	require.GreaterOrEqual(t, DistanceKm(lat, lng, maxLat, lng), 9.99)
+	require.GreaterOrEqual(t, DistanceKm(lat, lng, lat, maxLng), 9.99)
+
+	_, _, minLng, maxLng = BoundingBox(89.99, 0, 10)
+	require.Equal(t, -180.0, minLng)
+	require.Equal(t, 180.0, maxLng)
+
+	_, _, minLng, maxLng = BoundingBox(0, 179.99, 10)
+	require.Equal(t, -180.0, minLng)
+	require.Equal(t, 180.0, maxLng)
+}